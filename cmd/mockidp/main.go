@@ -0,0 +1,51 @@
+// Command mockidp menjalankan Identity Provider tiruan (package mockidp) untuk
+// menguji login SSO secara lokal tanpa akun Azure AD/Google/Okta. Semua
+// permintaan login langsung disetujui sebagai user yang di-set lewat flag
+// -email (atau parameter login_hint untuk OIDC).
+//
+// OIDC:
+//
+//	issuer        : http://localhost:9000
+//	client_id     : hris
+//	client_secret : secret
+//
+// SAML: daftarkan http://localhost:9000/saml/metadata sebagai
+// saml_idp_metadata_url. Metadata SP diambil otomatis dari entity ID
+// AuthnRequest (URL metadata SP milik API).
+//
+// JANGAN dipakai di production.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso/mockidp"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "alamat listen")
+	issuer := flag.String("issuer", "http://localhost:9000", "base URL publik mock IdP")
+	clientID := flag.String("client-id", "hris", "client_id OIDC")
+	clientSecret := flag.String("client-secret", "secret", "client_secret OIDC")
+	email := flag.String("email", "admin@example.com", "email user yang selalu login")
+	name := flag.String("name", "Mock User", "nama user yang selalu login")
+	flag.Parse()
+
+	idp, err := mockidp.New(mockidp.Config{
+		Issuer:       *issuer,
+		ClientID:     *clientID,
+		ClientSecret: *clientSecret,
+		Email:        *email,
+		Name:         *name,
+	})
+	if err != nil {
+		log.Fatalf("Gagal menyiapkan mock IdP: %v", err)
+	}
+
+	log.Printf("Mock IdP berjalan di %s (issuer %s, user %s)", *addr, idp.Issuer(), *email)
+	if err := http.ListenAndServe(*addr, idp.Handler()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -18,9 +18,13 @@ import (
 	// Import package internal kita
 
 	"github.com/maskholilaziz/hris-go/internal/config"
+	"github.com/maskholilaziz/hris-go/internal/entity"
 	inhttp "github.com/maskholilaziz/hris-go/internal/handler/http"
 	"github.com/maskholilaziz/hris-go/internal/infrastructure/database"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/mailer"
 	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/storage"
 	"github.com/maskholilaziz/hris-go/internal/usecase"
 	"github.com/maskholilaziz/hris-go/pkg/util"
 )
@@ -49,6 +53,10 @@ func main() {
 	// aplikasi (fungsi main) selesai.
 	defer dbPool.Close()
 
+	// Semua repository memakai TenantDB agar setiap transaksi membawa tenant
+	// aktif untuk policy Row Level Security.
+	db := database.NewTenantDB(dbPool, security.TenantIDFromContext)
+
 	// 3. Inisialisasi Router (Chi)
 	r := chi.NewRouter()
 	
@@ -65,17 +73,101 @@ func main() {
 	validate := util.NewValidator()
 
 	jwtService := security.NewJWTService(cfg.JWTSecret)
+	tokenSigner := security.NewTokenSigner(cfg.JWTSecret)
+
+	var mailService mailer.Mailer = mailer.NewLogMailer()
+	if cfg.SMTPHost != "" {
+		mailService = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
 
-	adminUserRepo := database.NewPostgresAdminUserRepo(dbPool)
-	tenantRepo := database.NewPostgresTenantRepo(dbPool)
+	adminUserRepo := database.NewPostgresAdminUserRepo(db)
+	tenantRepo := database.NewPostgresTenantRepo(db)
+	planRepo := database.NewPostgresPlanRepo(db)
+	subscriptionRepo := database.NewPostgresSubscriptionRepo(db)
+	featureRepo := database.NewPostgresFeatureRepo(db)
+	tenantAddonRepo := database.NewPostgresTenantAddonRepo(db)
+	employeeSeatRepo := database.NewPostgresEmployeeSeatRepo(db)
+	invoiceRepo := database.NewPostgresInvoiceRepo(db)
+	creditNoteRepo := database.NewPostgresCreditNoteRepo(db)
+	revenueMetricsRepo := database.NewPostgresRevenueMetricsRepo(db)
+	userRepo := database.NewPostgresUserRepo(db)
+	roleRepo := database.NewPostgresRoleRepo(db)
+	userInvitationRepo := database.NewPostgresUserInvitationRepo(db)
+	permissionRepo := database.NewPostgresPermissionRepo(db)
+	impersonationRepo := database.NewPostgresImpersonationRepo(db)
+	auditLogRepo := database.NewPostgresAuditLogRepo(db)
+	ssoRepo := database.NewPostgresSSORepo(db)
+	scimRepo := database.NewPostgresSCIMRepo(db)
+	apiKeyRepo := database.NewPostgresAPIKeyRepo(db)
+	passwordPolicyRepo := database.NewPostgresPasswordPolicyRepo(db)
+	employeeRepo := database.NewPostgresEmployeeRepo(db)
+	organizationRepo := database.NewPostgresOrganizationRepo(db)
+	jobHistoryRepo := database.NewPostgresJobHistoryRepo(db)
+	offboardingRepo := database.NewPostgresOffboardingRepo(db)
+	employeeImportRepo := database.NewPostgresEmployeeImportRepo(db)
+	employeeDocumentRepo := database.NewPostgresEmployeeDocumentRepo(db)
+	customFieldRepo := database.NewPostgresCustomFieldRepo(db)
+	employeeNumberRepo := database.NewPostgresEmployeeNumberRepo(db)
+
+	fileStorage := storage.NewLocalStorage(cfg.StoragePath)
 
 	adminAuthUsecase := usecase.NewAdminAuthUsecase(adminUserRepo, jwtService)
 	adminUserUsecase := usecase.NewAdminUserUsecase(adminUserRepo)
 	tenantUsecase := usecase.NewTenantUsecase(tenantRepo)
+	entitlementUsecase := usecase.NewEntitlementUsecase(featureRepo)
+	subscriptionUsecase := usecase.NewSubscriptionUsecase(tenantRepo, planRepo, subscriptionRepo, featureRepo, tenantAddonRepo, entitlementUsecase)
+	employeeLimitUsecase := usecase.NewEmployeeLimitUsecase(employeeSeatRepo)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceRepo, creditNoteRepo, tenantRepo)
+	creditNoteUsecase := usecase.NewCreditNoteUsecase(creditNoteRepo, invoiceRepo, tenantRepo)
+	revenueMetricsUsecase := usecase.NewRevenueMetricsUsecase(revenueMetricsRepo, adminUserRepo)
+	authorizationUsecase := usecase.NewAuthorizationUsecase(permissionRepo, userRepo)
+	passwordPolicyUsecase := usecase.NewPasswordPolicyUsecase(passwordPolicyRepo, userRepo)
+	tenantAuthUsecase := usecase.NewTenantAuthUsecase(userRepo, roleRepo, ssoRepo, jwtService, authorizationUsecase, passwordPolicyUsecase)
+	invitationUsecase := usecase.NewInvitationUsecase(userRepo, userInvitationRepo, tenantRepo, tokenSigner, mailService, passwordPolicyUsecase, cfg.AppURL)
+	ssoUsecase := usecase.NewSSOUsecase(ssoRepo, userRepo, roleRepo, jwtService, sso.NewOIDC(), cfg.APIURL)
+	scimUsecase := usecase.NewSCIMUsecase(scimRepo, roleRepo, employeeLimitUsecase, authorizationUsecase)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, permissionRepo)
+	impersonationUsecase := usecase.NewImpersonationUsecase(adminUserRepo, tenantRepo, userRepo, roleRepo, impersonationRepo, auditLogRepo, jwtService)
+	roleUsecase := usecase.NewRoleUsecase(roleRepo, permissionRepo, userRepo, invitationUsecase, authorizationUsecase)
+	customFieldUsecase := usecase.NewCustomFieldUsecase(customFieldRepo)
+	employeeNumberUsecase := usecase.NewEmployeeNumberUsecase(employeeNumberRepo, organizationRepo)
+	employeeUsecase := usecase.NewEmployeeUsecase(employeeRepo, employeeLimitUsecase, authorizationUsecase, customFieldUsecase, employeeNumberUsecase)
+	organizationUsecase := usecase.NewOrganizationUsecase(organizationRepo, authorizationUsecase)
+	jobHistoryUsecase := usecase.NewJobHistoryUsecase(jobHistoryRepo, employeeRepo, authorizationUsecase)
+	offboardingUsecase := usecase.NewOffboardingUsecase(offboardingRepo, employeeRepo, authorizationUsecase)
+	employeeImportUsecase := usecase.NewEmployeeImportUsecase(employeeImportRepo, employeeLimitUsecase, customFieldUsecase, employeeNumberUsecase)
+	employeeExportUsecase := usecase.NewEmployeeExportUsecase(employeeRepo, employeeImportRepo, customFieldUsecase)
+	employeeDocumentUsecase := usecase.NewEmployeeDocumentUsecase(employeeDocumentRepo, employeeRepo, permissionRepo, authorizationUsecase, fileStorage, mailService, cfg.AppURL, cfg.DocumentReminderDays)
 
 	adminAuthHandler := inhttp.NewAdminAuthHandler(adminAuthUsecase, validate)
 	adminUserHandler := inhttp.NewAdminUserHandler(adminUserUsecase)
 	tenantHandler := inhttp.NewTenantHandler(tenantUsecase, validate)
+	subscriptionHandler := inhttp.NewSubscriptionHandler(subscriptionUsecase, entitlementUsecase, validate)
+	employeeLimitHandler := inhttp.NewEmployeeLimitHandler(employeeLimitUsecase)
+	invoiceHandler := inhttp.NewInvoiceHandler(invoiceUsecase, validate)
+	creditNoteHandler := inhttp.NewCreditNoteHandler(creditNoteUsecase, validate)
+	revenueMetricsHandler := inhttp.NewRevenueMetricsHandler(revenueMetricsUsecase)
+	tenantAuthHandler := inhttp.NewTenantAuthHandler(tenantAuthUsecase, validate)
+	invitationHandler := inhttp.NewInvitationHandler(invitationUsecase, validate)
+	roleHandler := inhttp.NewRoleHandler(roleUsecase, validate)
+	ssoHandler := inhttp.NewSSOHandler(ssoUsecase, validate, cfg.AppURL, cfg.APIURL)
+	scimHandler := inhttp.NewSCIMHandler(scimUsecase, validate, cfg.APIURL)
+	apiKeyHandler := inhttp.NewAPIKeyHandler(apiKeyUsecase, validate)
+	passwordPolicyHandler := inhttp.NewPasswordPolicyHandler(passwordPolicyUsecase, validate)
+	impersonationHandler := inhttp.NewImpersonationHandler(impersonationUsecase, validate)
+	employeeHandler := inhttp.NewEmployeeHandler(employeeUsecase, validate)
+	organizationHandler := inhttp.NewOrganizationHandler(organizationUsecase, validate)
+	jobHistoryHandler := inhttp.NewJobHistoryHandler(jobHistoryUsecase, validate)
+	offboardingHandler := inhttp.NewOffboardingHandler(offboardingUsecase, validate)
+	employeeImportHandler := inhttp.NewEmployeeImportHandler(employeeImportUsecase)
+	employeeDocumentHandler := inhttp.NewEmployeeDocumentHandler(employeeDocumentUsecase, validate)
+	employeeExportHandler := inhttp.NewEmployeeExportHandler(employeeExportUsecase)
+	customFieldHandler := inhttp.NewCustomFieldHandler(customFieldUsecase, validate)
+	employeeNumberHandler := inhttp.NewEmployeeNumberHandler(employeeNumberUsecase, validate)
+	permissionMiddleware := inhttp.NewPermissionMiddleware(authorizationUsecase)
+	featureMiddleware := inhttp.NewFeatureMiddleware(entitlementUsecase)
+
+	tenantResolver := inhttp.NewTenantResolver(tenantUsecase)
 
 	// ------------------------------------------------------------------------
 	// Routes / Endpoints
@@ -97,6 +189,7 @@ func main() {
 
 		r.Group(func(r chi.Router) {
 			r.Use(jwtService.SuperadminAuthMiddleware)
+			r.Use(inhttp.PlatformAccess)
 			r.Get("/users", adminUserHandler.ListAdmins)
 
 			r.Post("/tenants", tenantHandler.Create)
@@ -104,6 +197,254 @@ func main() {
 			r.Get("/tenants/{id}", tenantHandler.GetByID)
 			r.Put("/tenants/{id}", tenantHandler.Update)
 			r.Delete("/tenants/{id}", tenantHandler.Delete)
+
+			r.Get("/tenants/{id}/subscription", subscriptionHandler.GetSubscription)
+			r.Put("/tenants/{id}/subscription", subscriptionHandler.Subscribe)
+			r.Delete("/tenants/{id}/subscription", subscriptionHandler.Cancel)
+			r.Post("/tenants/{id}/subscription/change/preview", subscriptionHandler.PreviewChangePlan)
+			r.Post("/tenants/{id}/subscription/change", subscriptionHandler.ChangePlan)
+			r.Get("/tenants/{id}/invoices", invoiceHandler.ListByTenant)
+			r.Get("/invoices/{invoiceID}", invoiceHandler.GetByID)
+			r.Get("/invoices/{invoiceID}/pdf", invoiceHandler.DownloadPDF)
+			r.Post("/invoices/{invoiceID}/payments", invoiceHandler.RecordPayment)
+			r.Post("/invoices/{invoiceID}/apply-credits", invoiceHandler.ApplyCredits)
+			r.Post("/invoices/{invoiceID}/credit-notes", creditNoteHandler.Issue)
+			r.Get("/invoices/{invoiceID}/credit-notes", creditNoteHandler.ListByInvoice)
+			r.Get("/credit-notes/{creditNoteID}", creditNoteHandler.GetByID)
+			r.Get("/credit-notes/{creditNoteID}/pdf", creditNoteHandler.DownloadPDF)
+			r.Post("/tenants/{id}/addons", subscriptionHandler.GrantAddon)
+			r.Delete("/tenants/{id}/addons/{feature}", subscriptionHandler.RevokeAddon)
+			r.Get("/tenants/{id}/entitlements", subscriptionHandler.ListTenantEntitlements)
+			r.Get("/tenants/{id}/employee-usage", employeeLimitHandler.GetTenantUsage)
+			r.Post("/tenants/{id}/administrator", roleHandler.InviteAdministrator)
+			r.Post("/tenants/{id}/impersonations", impersonationHandler.Start)
+			r.Delete("/impersonations/{sessionID}", impersonationHandler.End)
+			r.Get("/impersonations/{sessionID}/audit-logs", impersonationHandler.ListAuditLogs)
+
+			r.Get("/reports/employee-limits", employeeLimitHandler.Report)
+			r.Get("/reports/revenue", revenueMetricsHandler.GetMetrics)
+			r.Get("/reports/receivables-aging", revenueMetricsHandler.GetReceivablesAging)
+		})
+	})
+
+	// Single sign-on. Route ini dibuka browser lewat redirect dari/ke IdP,
+	// sehingga tenant diambil dari path, bukan header.
+	r.Route("/sso/{tenant}", func(r chi.Router) {
+		r.Use(tenantResolver.PathMiddleware)
+
+		r.Get("/login", ssoHandler.Login)
+		r.Get("/oidc/callback", ssoHandler.OIDCCallback)
+		r.Get("/saml/metadata", ssoHandler.SAMLMetadata)
+		r.Post("/saml/acs", ssoHandler.SAMLACS)
+	})
+
+	// Provisioning SCIM 2.0 dari Identity Provider tenant, diautentikasi
+	// dengan token SCIM (bukan JWT).
+	r.Route("/scim/v2/{tenant}", func(r chi.Router) {
+		r.Use(tenantResolver.PathMiddleware)
+		r.Use(scimHandler.Authenticate)
+
+		r.Get("/ServiceProviderConfig", scimHandler.ServiceProviderConfig)
+		r.Get("/ResourceTypes", scimHandler.ResourceTypes)
+
+		r.Get("/Users", scimHandler.ListUsers)
+		r.Post("/Users", scimHandler.CreateUser)
+		r.Get("/Users/{id}", scimHandler.GetUser)
+		r.Put("/Users/{id}", scimHandler.ReplaceUser)
+		r.Patch("/Users/{id}", scimHandler.PatchUser)
+		r.Delete("/Users/{id}", scimHandler.DeleteUser)
+
+		r.Get("/Groups", scimHandler.ListGroups)
+		r.Post("/Groups", scimHandler.CreateGroup)
+		r.Get("/Groups/{id}", scimHandler.GetGroup)
+		r.Put("/Groups/{id}", scimHandler.ReplaceGroup)
+		r.Patch("/Groups/{id}", scimHandler.PatchGroup)
+		r.Delete("/Groups/{id}", scimHandler.DeleteGroup)
+	})
+
+	// Route untuk aplikasi tenant. Tenant di-resolve dari header X-Tenant-Slug,
+	// staf login dengan token tenant (token superadmin ditolak) dan integrasi
+	// memakai API key sebagai pengganti token, lalu setiap
+	// modul dikunci dengan featureMiddleware.RequireFeature("...") sesuai paket
+	// langganan tenant.
+	r.Route("/api", func(r chi.Router) {
+		r.Use(tenantResolver.Middleware)
+
+		r.Post("/auth/login", tenantAuthHandler.Login)
+		r.Get("/auth/password-policy", passwordPolicyHandler.Get)
+		r.With(passwordPolicyHandler.Middleware).Post("/auth/password/expired", tenantAuthHandler.ChangeExpiredPassword)
+		r.Get("/invitations/verify", invitationHandler.Verify)
+		r.With(passwordPolicyHandler.Middleware).Post("/invitations/accept", invitationHandler.Accept)
+
+		r.Group(func(r chi.Router) {
+			r.Use(apiKeyHandler.Authenticate(jwtService.TenantAuthMiddleware))
+			r.Use(impersonationHandler.Middleware)
+
+			r.Get("/auth/me", tenantAuthHandler.Me)
+			r.With(passwordPolicyHandler.Middleware).Put("/auth/password", tenantAuthHandler.ChangePassword)
+			r.Get("/features", subscriptionHandler.ListMyEntitlements)
+
+			// Aksi sensitif hanya untuk user yang email-nya sudah terverifikasi.
+			r.Group(func(r chi.Router) {
+				r.Use(security.RequireVerifiedEmail)
+
+				r.Group(func(r chi.Router) {
+					r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageUsers))
+
+					r.Post("/invitations", invitationHandler.Invite)
+					r.Post("/invitations/bulk", invitationHandler.BulkInvite)
+					r.Post("/invitations/{id}/resend", invitationHandler.Resend)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageRoles))
+
+					r.Get("/permissions", roleHandler.ListPermissions)
+					r.Get("/roles", roleHandler.List)
+					r.Post("/roles", roleHandler.Create)
+					r.Get("/roles/{id}", roleHandler.GetByID)
+					r.Put("/roles/{id}", roleHandler.Update)
+					r.Delete("/roles/{id}", roleHandler.Delete)
+					r.Get("/users/{id}/access", roleHandler.GetUserAccess)
+					r.Put("/users/{id}/roles", roleHandler.SyncUserRoles)
+					r.Put("/users/{id}/permissions", roleHandler.SyncUserPermissions)
+				})
+
+				// Core HR: data karyawan dan struktur organisasi. Data master
+				// organisasi bisa dibaca semua staf (dipakai sebagai pilihan di
+				// form), perubahan butuh manage:organization.
+				r.Group(func(r chi.Router) {
+					r.Use(featureMiddleware.RequireFeature("core_hr"))
+
+					r.Route("/employees", func(r chi.Router) {
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/", employeeHandler.List)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/export", employeeExportHandler.Export)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}", employeeHandler.GetByID)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}/chain-of-command", employeeHandler.ChainOfCommand)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}/reports", employeeHandler.Reports)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}/job-histories", jobHistoryHandler.List)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}/employment", jobHistoryHandler.EmploymentAsOf)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Post("/{id}/job-histories", jobHistoryHandler.Create)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Delete("/{id}/job-histories/{historyId}", jobHistoryHandler.Cancel)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/{id}/termination", offboardingHandler.GetTermination)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Post("/{id}/termination", offboardingHandler.Terminate)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Delete("/{id}/termination", offboardingHandler.CancelTermination)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Put("/{id}/checklists/{checklistId}/tasks/{index}", offboardingHandler.UpdateChecklistTask)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageDocuments)).Get("/{id}/documents", employeeDocumentHandler.ListByEmployee)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageDocuments)).Post("/{id}/documents", employeeDocumentHandler.Upload)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Post("/", employeeHandler.Create)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Put("/{id}", employeeHandler.Update)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Delete("/{id}", employeeHandler.Delete)
+					})
+
+					r.With(permissionMiddleware.RequirePermission(entity.PermissionViewEmployees)).Get("/org-chart", employeeHandler.OrgChart)
+
+					// Impor karyawan massal dari CSV/XLSX, diproses di latar
+					// belakang; progres dan laporan per baris dibaca lewat GET.
+					r.Route("/employee-imports", func(r chi.Router) {
+						r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees))
+						r.Get("/fields", employeeImportHandler.Fields)
+						r.Get("/", employeeImportHandler.List)
+						r.Post("/", employeeImportHandler.Create)
+						r.Get("/{id}", employeeImportHandler.GetByID)
+						r.Get("/{id}/rows", employeeImportHandler.ListRows)
+					})
+
+					// Brankas dokumen karyawan. HR mengelola dokumen dalam data
+					// scope-nya; setiap karyawan bisa membaca dokumennya sendiri
+					// lewat /me/documents.
+					r.Route("/documents", func(r chi.Router) {
+						r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageDocuments))
+						r.Get("/", employeeDocumentHandler.List)
+						r.Get("/expiring", employeeDocumentHandler.Expiring)
+						r.Get("/{id}", employeeDocumentHandler.GetByID)
+						r.Put("/{id}", employeeDocumentHandler.Update)
+						r.Delete("/{id}", employeeDocumentHandler.Delete)
+						r.Get("/{id}/download", employeeDocumentHandler.Download)
+						r.Get("/{id}/versions", employeeDocumentHandler.ListVersions)
+						r.Post("/{id}/versions", employeeDocumentHandler.UploadVersion)
+					})
+					r.Get("/me/documents", employeeDocumentHandler.ListOwn)
+					r.Get("/me/documents/{id}/download", employeeDocumentHandler.DownloadOwn)
+
+					r.Route("/checklist-templates", func(r chi.Router) {
+						r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees))
+						r.Get("/", offboardingHandler.ListTemplates)
+						r.Get("/{id}", offboardingHandler.GetTemplate)
+						r.Post("/", offboardingHandler.CreateTemplate)
+						r.Put("/{id}", offboardingHandler.UpdateTemplate)
+						r.Delete("/{id}", offboardingHandler.DeleteTemplate)
+					})
+
+					// Komponen pembayaran akhir karyawan keluar, dibaca dan
+					// ditandai selesai oleh payroll.
+					r.With(permissionMiddleware.RequirePermission(entity.PermissionViewPayroll)).Get("/final-pay-items", offboardingHandler.ListFinalPayItems)
+					r.With(permissionMiddleware.RequirePermission(entity.PermissionManagePayroll)).Post("/final-pay-items/{id}/settle", offboardingHandler.SettleFinalPayItem)
+
+					manageOrganization := permissionMiddleware.RequirePermission(entity.PermissionManageOrganization)
+
+					r.Route("/branches", func(r chi.Router) {
+						r.Get("/", organizationHandler.ListBranches)
+						r.Get("/{id}", organizationHandler.GetBranch)
+						r.With(manageOrganization).Post("/", organizationHandler.CreateBranch)
+						r.With(manageOrganization).Put("/{id}", organizationHandler.UpdateBranch)
+						r.With(manageOrganization).Delete("/{id}", organizationHandler.DeleteBranch)
+					})
+
+					orgUnitRoutes := map[string]entity.OrgUnitKind{
+						"/departments":         entity.OrgUnitDepartment,
+						"/positions":           entity.OrgUnitPosition,
+						"/job-levels":          entity.OrgUnitJobLevel,
+						"/sbus":                entity.OrgUnitSBU,
+						"/employment-statuses": entity.OrgUnitEmploymentStatus,
+					}
+					manageSettings := permissionMiddleware.RequirePermission(entity.PermissionManageSettings)
+
+					r.Route("/employee-number-scheme", func(r chi.Router) {
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Get("/", employeeNumberHandler.Get)
+						r.With(permissionMiddleware.RequirePermission(entity.PermissionManageEmployees)).Post("/preview", employeeNumberHandler.Preview)
+						r.With(manageSettings).Put("/", employeeNumberHandler.Save)
+					})
+
+					r.Route("/custom-fields", func(r chi.Router) {
+						r.Get("/", customFieldHandler.List)
+						r.Get("/{id}", customFieldHandler.GetByID)
+						r.With(manageSettings).Post("/", customFieldHandler.Create)
+						r.With(manageSettings).Put("/{id}", customFieldHandler.Update)
+						r.With(manageSettings).Delete("/{id}", customFieldHandler.Delete)
+					})
+
+					for path, kind := range orgUnitRoutes {
+						r.Route(path, func(r chi.Router) {
+							r.Get("/", organizationHandler.ListUnits(kind))
+							r.Get("/{id}", organizationHandler.GetUnit(kind))
+							r.With(manageOrganization).Post("/", organizationHandler.CreateUnit(kind))
+							r.With(manageOrganization).Put("/{id}", organizationHandler.UpdateUnit(kind))
+							r.With(manageOrganization).Delete("/{id}", organizationHandler.DeleteUnit(kind))
+						})
+					}
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(permissionMiddleware.RequirePermission(entity.PermissionManageSettings))
+
+					r.Put("/password-policy", passwordPolicyHandler.Save)
+
+					r.Get("/sso", ssoHandler.GetConfig)
+					r.Put("/sso", ssoHandler.SaveConfig)
+
+					r.Get("/scim-tokens", scimHandler.ListTokens)
+					r.Post("/scim-tokens", scimHandler.CreateToken)
+					r.Delete("/scim-tokens/{id}", scimHandler.RevokeToken)
+
+					r.Get("/api-keys", apiKeyHandler.List)
+					r.Post("/api-keys", apiKeyHandler.Create)
+					r.Get("/api-keys/{id}", apiKeyHandler.GetByID)
+					r.Put("/api-keys/{id}", apiKeyHandler.Update)
+					r.Post("/api-keys/{id}/rotate", apiKeyHandler.Rotate)
+					r.Delete("/api-keys/{id}", apiKeyHandler.Revoke)
+				})
+			})
 		})
 	})
 
@@ -122,6 +463,19 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
+	// Job terjadwal: perubahan jabatan dan pengakhiran hubungan kerja yang
+	// tanggal berlakunya sudah tiba, serta impor karyawan yang terputus.
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go usecase.RunScheduler(schedulerCtx, time.Hour,
+		jobHistoryUsecase.RunDueJobChanges,
+		offboardingUsecase.RunDueTerminations,
+		employeeImportUsecase.ResumeImports,
+	)
+	// Pengingat dokumen yang akan kedaluwarsa cukup dicek sekali sehari.
+	go usecase.RunScheduler(schedulerCtx, 24*time.Hour,
+		employeeDocumentUsecase.RunExpiryReminders,
+	)
+
 	// Jalankan server di goroutine terpisah
 	go func()  {
 		log.Printf("Server berjalan di http://localhost:%s", cfg.AppPort)
@@ -134,6 +488,7 @@ func main() {
 	<- stop
 
 	log.Println("Server menerima sinyal untuk berhenti...")
+	stopScheduler()
 
 	// Beri waktu 5 detik untuk request yang sedang berjalan
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
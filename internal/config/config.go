@@ -13,6 +13,23 @@ type Config struct {
 	AppPort     string `mapstructure:"APP_PORT"`
 	DatabaseURL string `mapstructure:"DATABASE_URL"`
 	JWTSecret   string `mapstructure:"JWT_SECRET"`
+
+	// AppURL adalah base URL frontend, dipakai untuk link di email.
+	AppURL string `mapstructure:"APP_URL"`
+	// APIURL adalah base URL publik API ini, dipakai untuk callback SSO yang
+	// didaftarkan di Identity Provider.
+	APIURL       string `mapstructure:"API_URL"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     string `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+
+	// StoragePath adalah direktori penyimpanan file (dokumen karyawan).
+	StoragePath string `mapstructure:"STORAGE_PATH"`
+	// DocumentReminderDays adalah jumlah hari sebelum dokumen kedaluwarsa
+	// saat pengingat dikirim ke karyawan dan HR.
+	DocumentReminderDays int `mapstructure:"DOCUMENT_REMINDER_DAYS"`
 }
 
 // LoadConfig adalah fungsi yang akan mencari dan membaca file konfigurasi.
@@ -55,7 +72,22 @@ func LoadConfig(path string) (config Config, err error) {
 	if config.AppPort == "" {
 		config.AppPort = "8080"
 	}
+	if config.AppURL == "" {
+		config.AppURL = "http://localhost:" + config.AppPort
+	}
+	if config.APIURL == "" {
+		config.APIURL = "http://localhost:" + config.AppPort
+	}
+	if config.SMTPPort == "" {
+		config.SMTPPort = "587"
+	}
+	if config.StoragePath == "" {
+		config.StoragePath = "./storage"
+	}
+	if config.DocumentReminderDays <= 0 {
+		config.DocumentReminderDays = 30
+	}
 
 	log.Println("Konfigurasi berhasil dimuat.")
 	return
-}
\ No newline at end of file
+}
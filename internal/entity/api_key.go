@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey adalah kredensial integrasi mesin-ke-mesin milik tenant. Hak
+// aksesnya dipilih dari katalog tenant_permissions dan selalu berlaku untuk
+// seluruh tenant.
+type APIKey struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	Name        string
+	KeyPrefix   string
+	KeyHash     string
+	Permissions []string
+	// AllowedIPs berisi IP atau CIDR. Kosong berarti semua IP diizinkan.
+	AllowedIPs   []string
+	ExpiresAt    *time.Time
+	LastUsedAt   *time.Time
+	LastUsedIP   *string
+	RevokedAt    *time.Time
+	ReplacedByID *uuid.UUID
+	CreatedBy    *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+const (
+	APIKeyStatusActive  = "active"
+	APIKeyStatusExpired = "expired"
+	APIKeyStatusRevoked = "revoked"
+)
+
+func (k *APIKey) Status(now time.Time) string {
+	if k.RevokedAt != nil {
+		return APIKeyStatusRevoked
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return APIKeyStatusExpired
+	}
+	return APIKeyStatusActive
+}
+
+// AllowsIP memeriksa IP klien terhadap allowlist key.
+func (k *APIKey) AllowsIP(ip netip.Addr) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+
+	ip = ip.Unmap()
+	for _, allowed := range k.AllowedIPs {
+		prefix, err := netip.ParsePrefix(allowed)
+		if err != nil {
+			continue
+		}
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIKeyAssignable menentukan hak akses yang boleh diberikan ke API key.
+// Pengelolaan user, role, dan pengaturan (termasuk API key itu sendiri) hanya
+// untuk staf, agar key yang bocor tidak bisa dipakai memperluas aksesnya.
+func IsAPIKeyAssignable(permission string) bool {
+	switch permission {
+	case PermissionManageRoles, PermissionManageUsers, PermissionManageSettings:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLog struct {
+	ID                     uuid.UUID
+	TenantID               uuid.UUID
+	UserID                 *uuid.UUID
+	ImpersonatorAdminID    *uuid.UUID
+	ImpersonationSessionID *uuid.UUID
+	Method                 string
+	Path                   string
+	StatusCode             int
+	IPAddress              string
+	UserAgent              string
+	CreatedAt              time.Time
+}
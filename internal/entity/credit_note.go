@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreditNoteResolution string
+
+const (
+	// CreditNoteResolutionCredit menambah saldo kredit tenant untuk invoice berikutnya.
+	CreditNoteResolutionCredit CreditNoteResolution = "credit"
+	// CreditNoteResolutionRefund mengembalikan dana, dicatat sebagai pembayaran negatif.
+	CreditNoteResolutionRefund CreditNoteResolution = "refund"
+)
+
+type StatusCreditNote string
+
+const (
+	StatusCreditNoteIssued StatusCreditNote = "issued"
+	StatusCreditNoteVoid   StatusCreditNote = "void"
+)
+
+const CreditNoteNumberPrefix = "CN"
+
+type CreditNote struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	InvoiceID   uuid.UUID
+	Number      string
+	IssueDate   time.Time
+	Reason      string
+	Resolution  CreditNoteResolution
+	TotalAmount float64
+	Status      StatusCreditNote
+	CreatedBy   *uuid.UUID
+	Items       []CreditNoteItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CreditNoteItem struct {
+	ID           uuid.UUID
+	CreditNoteID uuid.UUID
+	Description  string
+	Quantity     int
+	UnitPrice    float64
+	TotalPrice   float64
+}
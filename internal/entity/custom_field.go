@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldType adalah tipe nilai custom field.
+type CustomFieldType string
+
+const (
+	CustomFieldText        CustomFieldType = "text"
+	CustomFieldNumber      CustomFieldType = "number"
+	CustomFieldDate        CustomFieldType = "date"
+	CustomFieldBoolean     CustomFieldType = "boolean"
+	CustomFieldSelect      CustomFieldType = "select"
+	CustomFieldMultiSelect CustomFieldType = "multi_select"
+)
+
+// HasOptions bernilai true untuk tipe yang nilainya dipilih dari Options.
+func (t CustomFieldType) HasOptions() bool {
+	return t == CustomFieldSelect || t == CustomFieldMultiSelect
+}
+
+// CustomFieldEntity adalah entitas yang bisa diberi custom field. Nilai custom
+// field disimpan di kolom JSONB custom_fields pada tabel entitas tersebut.
+type CustomFieldEntity string
+
+const (
+	CustomFieldEntityEmployee CustomFieldEntity = "employee"
+)
+
+// Table adalah tabel entitas yang menyimpan nilai custom field, atau string
+// kosong jika entitas belum mendukung custom field.
+func (e CustomFieldEntity) Table() string {
+	switch e {
+	case CustomFieldEntityEmployee:
+		return "employees"
+	}
+	return ""
+}
+
+// CustomField adalah definisi field tambahan yang dibuat tenant untuk sebuah
+// entitas.
+type CustomField struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	EntityType CustomFieldEntity
+	// Key adalah kunci nilai di JSON custom_fields, huruf kecil, angka, dan
+	// garis bawah.
+	Key     string
+	Name    string
+	Type    CustomFieldType
+	Options []string
+
+	Required bool
+	Unique   bool
+	// Min dan Max membatasi panjang teks (text), nilai (number), atau jumlah
+	// pilihan (multi_select).
+	Min *float64
+	Max *float64
+	// Pattern adalah regex yang harus cocok dengan nilai text.
+	Pattern   *string
+	SortOrder int
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// DataScope membatasi baris karyawan yang boleh diakses lewat sebuah hak akses.
+type DataScope string
+
+const (
+	DataScopeSelf          DataScope = "self"
+	DataScopeDirectReports DataScope = "direct_reports"
+	DataScopeSubordinates  DataScope = "subordinates"
+	DataScopeBranch        DataScope = "branch"
+	DataScopeDepartment    DataScope = "department"
+	DataScopeSBU           DataScope = "sbu"
+	DataScopeTenant        DataScope = "tenant"
+)
+
+func (s DataScope) IsValid() bool {
+	switch s {
+	case DataScopeSelf, DataScopeDirectReports, DataScopeSubordinates,
+		DataScopeBranch, DataScopeDepartment, DataScopeSBU, DataScopeTenant:
+		return true
+	}
+	return false
+}
+
+// PermissionGrant adalah hak akses yang diberikan ke role atau user beserta
+// data scope-nya.
+type PermissionGrant struct {
+	PermissionID uuid.UUID
+	Name         string
+	DataScope    DataScope
+}
+
+// DataScopeSubject adalah posisi user di organisasi, dipakai sebagai acuan
+// data scope. Field bernilai nil jika user belum terhubung ke data karyawan.
+type DataScopeSubject struct {
+	UserID       uuid.UUID
+	EmployeeID   *uuid.UUID
+	BranchID     *uuid.UUID
+	DepartmentID *uuid.UUID
+	SBUID        *uuid.UUID
+}
+
+// DataScopeRule adalah hasil resolusi data scope untuk satu hak akses. Jika
+// user mendapat hak akses yang sama dari beberapa role dengan scope berbeda,
+// semua scope digabung (union).
+type DataScopeRule struct {
+	TenantID   uuid.UUID
+	Permission string
+	Scopes     []DataScope
+	Subject    DataScopeSubject
+}
+
+func (r *DataScopeRule) IsTenantWide() bool {
+	for _, s := range r.Scopes {
+		if s == DataScopeTenant {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Employee adalah data induk karyawan. Setiap karyawan punya satu akun login
+// (users) serta satu profil dan satu data keuangan (relasi 1-1).
+type Employee struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	TenantID uuid.UUID
+	// Email diambil dari akun login karyawan.
+	Email string
+
+	BranchID           *uuid.UUID
+	DepartmentID       *uuid.UUID
+	PositionID         *uuid.UUID
+	JobLevelID         *uuid.UUID
+	EmploymentStatusID *uuid.UUID
+	SBUID              *uuid.UUID
+	ManagerID          *uuid.UUID
+
+	EmployeeIDNumber string
+	JoinDate         time.Time
+	ResignDate       *time.Time
+
+	Profile   EmployeeProfile
+	Financial EmployeeFinancial
+	// CustomFields adalah nilai custom field karyawan per kunci field.
+	CustomFields map[string]any
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// IsActive mengikuti aturan hitung kuota karyawan: belum dihapus dan belum
+// melewati tanggal resign.
+func (e *Employee) IsActive(now time.Time) bool {
+	if e.DeletedAt != nil {
+		return false
+	}
+	if e.ResignDate == nil {
+		return true
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, e.ResignDate.Location())
+	return !e.ResignDate.Before(today)
+}
+
+// Employment mengembalikan posisi karyawan saat ini dalam bentuk yang
+// disimpan di riwayat jabatan.
+func (e *Employee) Employment() Employment {
+	return Employment{
+		BranchID:           e.BranchID,
+		DepartmentID:       e.DepartmentID,
+		PositionID:         e.PositionID,
+		JobLevelID:         e.JobLevelID,
+		EmploymentStatusID: e.EmploymentStatusID,
+		SBUID:              e.SBUID,
+		ManagerID:          e.ManagerID,
+		BasicSalary:        e.Financial.BasicSalary,
+	}
+}
+
+// SetEmployment menerapkan posisi dari riwayat jabatan ke karyawan.
+func (e *Employee) SetEmployment(m Employment) {
+	e.BranchID = m.BranchID
+	e.DepartmentID = m.DepartmentID
+	e.PositionID = m.PositionID
+	e.JobLevelID = m.JobLevelID
+	e.EmploymentStatusID = m.EmploymentStatusID
+	e.SBUID = m.SBUID
+	e.ManagerID = m.ManagerID
+	e.Financial.BasicSalary = m.BasicSalary
+}
+
+type EmployeeProfile struct {
+	FullName              string
+	NIKKTP                *string
+	PlaceOfBirth          *string
+	DateOfBirth           *time.Time
+	Gender                *string
+	MaritalStatus         *string
+	Religion              *string
+	AddressKTP            *string
+	AddressDomicile       *string
+	EmergencyContactName  *string
+	EmergencyContactPhone *string
+}
+
+type EmployeeFinancial struct {
+	BankName              *string
+	BankAccountNumber     *string
+	BankAccountHolder     *string
+	NPWP                  *string
+	PTKPStatus            *string
+	BPJSKetenagakerjaanNo *string
+	BPJSKesehatanNo       *string
+	// BasicSalary adalah gaji pokok saat ini. Perubahannya dicatat di
+	// riwayat jabatan.
+	BasicSalary *float64
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmployeeDocument adalah dokumen karyawan (KTP, kontrak kerja, sertifikat,
+// dll.). File disimpan di storage; FilePath menunjuk versi terbaru.
+type EmployeeDocument struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	EmployeeID uuid.UUID
+	// EmployeeUserID, EmployeeName, dan EmployeeEmail hanya terisi saat
+	// dibaca dari repository.
+	EmployeeUserID uuid.UUID
+	EmployeeName   string
+	EmployeeEmail  string
+
+	DocumentType string
+	FilePath     string
+	FileName     string
+	ContentType  string
+	FileSize     int64
+	Version      int
+	ExpiryDate   *time.Time
+	// ReminderSentFor adalah tanggal kedaluwarsa yang sudah diingatkan.
+	ReminderSentFor *time.Time
+	UploadedBy      *uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// DaysUntilExpiry mengembalikan sisa hari sampai dokumen kedaluwarsa (negatif
+// jika sudah lewat), atau nil jika dokumen tidak punya masa berlaku.
+func (d *EmployeeDocument) DaysUntilExpiry(now time.Time) *int {
+	if d.ExpiryDate == nil {
+		return nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(d.ExpiryDate.Year(), d.ExpiryDate.Month(), d.ExpiryDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(expiry.Sub(today).Hours() / 24)
+	return &days
+}
+
+// EmployeeDocumentVersion adalah satu file yang pernah diunggah untuk sebuah
+// dokumen.
+type EmployeeDocumentVersion struct {
+	ID          uuid.UUID
+	DocumentID  uuid.UUID
+	Version     int
+	FilePath    string
+	FileName    string
+	ContentType string
+	FileSize    int64
+	// Checksum adalah SHA-256 isi file dalam hex.
+	Checksum   string
+	UploadedBy *uuid.UUID
+	CreatedAt  time.Time
+}
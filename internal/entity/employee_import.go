@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportFileFormat string
+
+const (
+	ImportFormatCSV  ImportFileFormat = "csv"
+	ImportFormatXLSX ImportFileFormat = "xlsx"
+)
+
+type ImportStatus string
+
+const (
+	ImportPending    ImportStatus = "pending"
+	ImportProcessing ImportStatus = "processing"
+	ImportCompleted  ImportStatus = "completed"
+	// ImportFailed berarti job berhenti sebelum semua baris diproses,
+	// misalnya karena kuota karyawan habis. Baris yang sudah diimpor tetap
+	// tersimpan.
+	ImportFailed ImportStatus = "failed"
+)
+
+// EmployeeImport adalah satu job impor karyawan dari file CSV atau XLSX.
+type EmployeeImport struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	FileName string
+	Format   ImportFileFormat
+	// Mapping memetakan judul kolom file ke field impor (lihat
+	// usecase.EmployeeImportFields).
+	Mapping map[string]string
+	DryRun  bool
+	Status  ImportStatus
+
+	TotalRows     int
+	ProcessedRows int
+	// SuccessRows adalah baris valid (dry run) atau berhasil diimpor.
+	SuccessRows int
+	FailedRows  int
+
+	ErrorMessage *string
+	CreatedBy    *uuid.UUID
+	StartedAt    *time.Time
+	FinishedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Progress mengembalikan persentase baris yang sudah diproses (0-100).
+func (i *EmployeeImport) Progress() float64 {
+	if i.TotalRows == 0 {
+		return 100
+	}
+	return float64(i.ProcessedRows) * 100 / float64(i.TotalRows)
+}
+
+type ImportRowStatus string
+
+const (
+	ImportRowPending ImportRowStatus = "pending"
+	// ImportRowValid hanya dipakai pada dry run.
+	ImportRowValid    ImportRowStatus = "valid"
+	ImportRowInvalid  ImportRowStatus = "invalid"
+	ImportRowImported ImportRowStatus = "imported"
+	// ImportRowFailed berarti baris valid tetapi gagal disimpan, misalnya
+	// email sudah dipakai tenant lain.
+	ImportRowFailed ImportRowStatus = "failed"
+)
+
+// EmployeeImportRow adalah satu baris file impor beserta hasil validasinya.
+type EmployeeImportRow struct {
+	ID        uuid.UUID
+	ImportID  uuid.UUID
+	RowNumber int
+	Status    ImportRowStatus
+	// Data berisi nilai baris yang sudah dipetakan ke field impor.
+	Data       map[string]string
+	Errors     []string
+	EmployeeID *uuid.UUID
+}
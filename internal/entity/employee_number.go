@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmployeeNumberScheme adalah format nomor induk karyawan otomatis sebuah
+// tenant. Template berisi teks biasa dan token:
+//   - {BRANCH}: kode cabang karyawan
+//   - {YYYY}, {YY}, {MM}: tahun dan bulan bergabung
+//   - {SEQ} atau {SEQ:n}: nomor urut, dengan {SEQ:n} diisi nol di depan
+//     sampai n digit
+type EmployeeNumberScheme struct {
+	TenantID uuid.UUID
+	Template string
+	// ResetYearly mengulang nomor urut dari 1 untuk setiap tahun bergabung.
+	ResetYearly bool
+	// ResetPerBranch menghitung nomor urut terpisah untuk setiap cabang.
+	ResetPerBranch bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// DefaultEmployeeNumberScheme berlaku untuk tenant yang belum mengatur format
+// nomor induk karyawan.
+func DefaultEmployeeNumberScheme(tenantID uuid.UUID) *EmployeeNumberScheme {
+	return &EmployeeNumberScheme{
+		TenantID:    tenantID,
+		Template:    "{YYYY}-{SEQ:4}",
+		ResetYearly: true,
+	}
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "github.com/google/uuid"
+
+// EmployeeSeatUsage merangkum pemakaian kuota karyawan sebuah tenant
+// terhadap paket langganan yang sedang berlaku.
+type EmployeeSeatUsage struct {
+	TenantID        uuid.UUID
+	TenantName      string
+	PlanID          *uuid.UUID
+	PlanName        *string
+	PlanLimit       int
+	AddonSeats      int
+	ActiveEmployees int
+}
+
+func (u *EmployeeSeatUsage) Capacity() int {
+	return u.PlanLimit + u.AddonSeats
+}
+
+func (u *EmployeeSeatUsage) Remaining() int {
+	return u.Capacity() - u.ActiveEmployees
+}
+
+// UsageRatio mengembalikan pemakaian dalam rentang 0..1 (bisa > 1 jika over limit).
+func (u *EmployeeSeatUsage) UsageRatio() float64 {
+	if u.Capacity() == 0 {
+		if u.ActiveEmployees == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(u.ActiveEmployees) / float64(u.Capacity())
+}
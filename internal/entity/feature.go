@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Feature struct {
+	ID          uuid.UUID
+	Name        string
+	Slug        string
+	Description *string
+	IsAddon     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type TenantAddon struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	FeatureID uuid.UUID
+	// ExtraEmployeeSeats menambah kapasitas karyawan di atas plans.employee_limit.
+	ExtraEmployeeSeats int
+	ExpiresAt          *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Entitlement adalah satu fitur yang sedang dimiliki tenant, entah dari paket
+// langganan atau dari addon. ExpiresAt nil berarti tidak ada batas waktu.
+type Entitlement struct {
+	FeatureSlug string
+	Source      string
+	ExpiresAt   *time.Time
+}
+
+const (
+	EntitlementSourcePlan  = "plan"
+	EntitlementSourceAddon = "addon"
+)
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminPermissionImpersonate adalah hak akses superadmin untuk masuk sebagai
+// user tenant (admin_permissions).
+const AdminPermissionImpersonate = "impersonate:tenant_users"
+
+// ImpersonationSession mencatat satu kali superadmin bertindak sebagai user
+// tenant. Token impersonasi hanya berlaku selama sesinya masih aktif.
+type ImpersonationSession struct {
+	ID          uuid.UUID
+	AdminUserID uuid.UUID
+	TenantID    uuid.UUID
+	UserID      uuid.UUID
+	Reason      string
+	// ReadOnly memblokir semua request selain GET/HEAD/OPTIONS.
+	ReadOnly  bool
+	ExpiresAt time.Time
+	EndedAt   *time.Time
+	CreatedAt time.Time
+}
+
+// IsActive bernilai true jika sesi belum diakhiri dan belum kedaluwarsa.
+func (s *ImpersonationSession) IsActive(at time.Time) bool {
+	return s.EndedAt == nil && at.Before(s.ExpiresAt)
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusInvoice string
+
+const (
+	StatusInvoiceUnpaid  StatusInvoice = "unpaid"
+	StatusInvoicePaid    StatusInvoice = "paid"
+	StatusInvoiceOverdue StatusInvoice = "overdue"
+	StatusInvoiceVoid    StatusInvoice = "void"
+)
+
+type Invoice struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	SubscriptionID uuid.UUID
+	IssueDate      time.Time
+	DueDate        time.Time
+	TotalAmount    float64
+	Status         StatusInvoice
+	Items          []InvoiceItem
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+type InvoiceItem struct {
+	ID          uuid.UUID
+	InvoiceID   uuid.UUID
+	Description string
+	Quantity    int
+	UnitPrice   float64
+	TotalPrice  float64
+}
+
+type InvoicePayment struct {
+	ID            uuid.UUID
+	InvoiceID     uuid.UUID
+	PaymentDate   time.Time
+	AmountPaid    float64
+	PaymentMethod *string
+	TransactionID *string
+	Notes         *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const (
+	BillingCreditSourceProration  = "proration"
+	BillingCreditSourceCreditNote = "credit_note"
+)
+
+// Metode pembayaran khusus yang tidak berasal dari payment gateway.
+const (
+	PaymentMethodCredit = "credit"
+	PaymentMethodRefund = "refund"
+)
+
+// BillingCredit adalah saldo kredit tenant yang bisa dipakai untuk invoice berikutnya.
+type BillingCredit struct {
+	ID              uuid.UUID
+	TenantID        uuid.UUID
+	Source          string
+	Description     string
+	CreditNoteID    *uuid.UUID
+	Amount          float64
+	RemainingAmount float64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobChangeType string
+
+const (
+	// JobChangeHire dicatat otomatis saat karyawan dibuat.
+	JobChangeHire             JobChangeType = "hire"
+	JobChangePromotion        JobChangeType = "promotion"
+	JobChangeDemotion         JobChangeType = "demotion"
+	JobChangeTransfer         JobChangeType = "transfer"
+	JobChangeMutation         JobChangeType = "mutation"
+	JobChangeEmploymentStatus JobChangeType = "status_change"
+	JobChangeSalaryAdjustment JobChangeType = "salary_adjustment"
+	// JobChangeCorrection dicatat otomatis saat data organisasi karyawan
+	// diubah langsung lewat update karyawan.
+	JobChangeCorrection JobChangeType = "correction"
+)
+
+type JobChangeStatus string
+
+const (
+	JobChangeScheduled JobChangeStatus = "scheduled"
+	JobChangeApplied   JobChangeStatus = "applied"
+	JobChangeCancelled JobChangeStatus = "cancelled"
+	JobChangeFailed    JobChangeStatus = "failed"
+)
+
+// Employment adalah posisi karyawan di organisasi beserta gaji pokoknya.
+type Employment struct {
+	BranchID           *uuid.UUID
+	DepartmentID       *uuid.UUID
+	PositionID         *uuid.UUID
+	JobLevelID         *uuid.UUID
+	EmploymentStatusID *uuid.UUID
+	SBUID              *uuid.UUID
+	ManagerID          *uuid.UUID
+	BasicSalary        *float64
+}
+
+// Equal membandingkan dua posisi berdasarkan nilainya.
+func (m Employment) Equal(o Employment) bool {
+	return sameUUIDPtr(m.BranchID, o.BranchID) &&
+		sameUUIDPtr(m.DepartmentID, o.DepartmentID) &&
+		sameUUIDPtr(m.PositionID, o.PositionID) &&
+		sameUUIDPtr(m.JobLevelID, o.JobLevelID) &&
+		sameUUIDPtr(m.EmploymentStatusID, o.EmploymentStatusID) &&
+		sameUUIDPtr(m.SBUID, o.SBUID) &&
+		sameUUIDPtr(m.ManagerID, o.ManagerID) &&
+		sameFloatPtr(m.BasicSalary, o.BasicSalary)
+}
+
+func sameUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// EmployeeJobHistory adalah satu perubahan jabatan karyawan. Employment berisi
+// posisi lengkap karyawan mulai EffectiveDate, bukan hanya bagian yang
+// berubah.
+type EmployeeJobHistory struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	EmployeeID    uuid.UUID
+	ChangeType    JobChangeType
+	EffectiveDate time.Time
+	Status        JobChangeStatus
+	Employment    Employment
+	Reason        *string
+	// DecreeNumber adalah nomor SK perubahan.
+	DecreeNumber  *string
+	FailureReason *string
+	CreatedBy     *uuid.UUID
+	AppliedAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
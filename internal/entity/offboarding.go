@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TerminationType string
+
+const (
+	TerminationResignation     TerminationType = "resignation"
+	TerminationDismissal       TerminationType = "dismissal"
+	TerminationLayoff          TerminationType = "layoff"
+	TerminationContractEnd     TerminationType = "contract_end"
+	TerminationRetirement      TerminationType = "retirement"
+	TerminationDeath           TerminationType = "death"
+	TerminationMutualAgreement TerminationType = "mutual_agreement"
+)
+
+type TerminationStatus string
+
+const (
+	// TerminationScheduled berarti login karyawan belum dinonaktifkan
+	// karena EffectiveDate belum tiba.
+	TerminationScheduled TerminationStatus = "scheduled"
+	TerminationCompleted TerminationStatus = "completed"
+	TerminationCancelled TerminationStatus = "cancelled"
+)
+
+// EmployeeTermination adalah pengakhiran hubungan kerja karyawan
+// (resign, PHK, kontrak selesai, dan sebagainya).
+type EmployeeTermination struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	EmployeeID     uuid.UUID
+	Type           TerminationType
+	Reason         string
+	LastWorkingDay time.Time
+	// EffectiveDate adalah tanggal login karyawan dinonaktifkan.
+	EffectiveDate     time.Time
+	EligibleForRehire bool
+	Status            TerminationStatus
+	ChecklistID       *uuid.UUID
+	CreatedBy         *uuid.UUID
+	CompletedAt       *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	// Checklist dan FinalPayItems hanya terisi saat dibaca lengkap dari
+	// repository.
+	Checklist     *EmployeeChecklist
+	FinalPayItems []*FinalPayItem
+}
+
+type ChecklistType string
+
+const (
+	ChecklistOnboarding  ChecklistType = "onboarding"
+	ChecklistOffboarding ChecklistType = "offboarding"
+)
+
+// ChecklistTemplate adalah daftar tugas standar onboarding atau offboarding
+// milik tenant.
+type ChecklistTemplate struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Name      string
+	Type      ChecklistType
+	Tasks     []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ChecklistStatus string
+
+const (
+	ChecklistPending    ChecklistStatus = "pending"
+	ChecklistInProgress ChecklistStatus = "in_progress"
+	ChecklistCompleted  ChecklistStatus = "completed"
+)
+
+// EmployeeChecklist adalah checklist yang dibuat dari template untuk satu
+// karyawan. Tugas disalin saat checklist dibuat sehingga perubahan template
+// tidak memengaruhi checklist yang sudah berjalan.
+type EmployeeChecklist struct {
+	ID         uuid.UUID
+	EmployeeID uuid.UUID
+	TemplateID uuid.UUID
+	Status     ChecklistStatus
+	Tasks      []ChecklistTask
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type ChecklistTask struct {
+	Title  string
+	Done   bool
+	DoneAt *time.Time
+	DoneBy *uuid.UUID
+}
+
+// RefreshStatus menghitung ulang status checklist dari status tugasnya.
+func (c *EmployeeChecklist) RefreshStatus() {
+	done := 0
+	for _, t := range c.Tasks {
+		if t.Done {
+			done++
+		}
+	}
+
+	switch {
+	case done == 0:
+		c.Status = ChecklistPending
+	case done == len(c.Tasks):
+		c.Status = ChecklistCompleted
+	default:
+		c.Status = ChecklistInProgress
+	}
+}
+
+type FinalPayItemType string
+
+const (
+	// FinalPayLeavePayout adalah uang pengganti sisa cuti. Nominalnya
+	// dihitung payroll dari Quantity (hari).
+	FinalPayLeavePayout FinalPayItemType = "leave_payout"
+	// FinalPayLoanDeduction adalah sisa pinjaman yang dipotong dari
+	// pembayaran akhir.
+	FinalPayLoanDeduction FinalPayItemType = "loan_deduction"
+	// FinalPayExpenseClaim adalah klaim yang belum diputuskan saat karyawan
+	// keluar.
+	FinalPayExpenseClaim FinalPayItemType = "expense_claim"
+)
+
+type FinalPayItemStatus string
+
+const (
+	FinalPayPending FinalPayItemStatus = "pending"
+	FinalPaySettled FinalPayItemStatus = "settled"
+)
+
+// FinalPayItem adalah komponen pembayaran akhir yang harus diperhitungkan
+// payroll berikutnya setelah karyawan keluar.
+type FinalPayItem struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	EmployeeID    uuid.UUID
+	TerminationID uuid.UUID
+	Type          FinalPayItemType
+	SourceID      *uuid.UUID
+	Description   string
+	Quantity      *float64
+	Amount        *float64
+	Status        FinalPayItemStatus
+	SettledAt     *time.Time
+	CreatedAt     time.Time
+}
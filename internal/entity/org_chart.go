@@ -0,0 +1,26 @@
+package entity
+
+import "github.com/google/uuid"
+
+// OrgChartNode adalah satu karyawan di garis pelaporan (employees.manager_id).
+type OrgChartNode struct {
+	EmployeeID       uuid.UUID
+	ManagerID        *uuid.UUID
+	FullName         string
+	EmployeeIDNumber string
+	PositionName     *string
+	DepartmentName   *string
+	// Level adalah jarak dari karyawan acuan: 1 untuk atasan atau bawahan
+	// langsung, 2 untuk satu tingkat di atas/bawahnya, dan seterusnya.
+	Level   int
+	Reports []*OrgChartNode
+}
+
+// CountDescendants menghitung seluruh bawahan node di dalam pohon.
+func (n *OrgChartNode) CountDescendants() int {
+	total := 0
+	for _, r := range n.Reports {
+		total += 1 + r.CountDescendants()
+	}
+	return total
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgUnitKind adalah jenis data master organisasi selain cabang. Semua jenis
+// memakai struktur yang sama (nama, dan deskripsi khusus SBU).
+type OrgUnitKind string
+
+const (
+	OrgUnitDepartment       OrgUnitKind = "department"
+	OrgUnitPosition         OrgUnitKind = "position"
+	OrgUnitJobLevel         OrgUnitKind = "job_level"
+	OrgUnitSBU              OrgUnitKind = "sbu"
+	OrgUnitEmploymentStatus OrgUnitKind = "employment_status"
+)
+
+// Table adalah nama tabel data master.
+func (k OrgUnitKind) Table() string {
+	switch k {
+	case OrgUnitDepartment:
+		return "departments"
+	case OrgUnitPosition:
+		return "positions"
+	case OrgUnitJobLevel:
+		return "job_levels"
+	case OrgUnitSBU:
+		return "sbus"
+	case OrgUnitEmploymentStatus:
+		return "employment_statuses"
+	}
+	return ""
+}
+
+// EmployeeColumn adalah kolom di tabel employees yang merujuk data master ini.
+func (k OrgUnitKind) EmployeeColumn() string {
+	return string(k) + "_id"
+}
+
+// Label adalah nama jenis data master untuk pesan error.
+func (k OrgUnitKind) Label() string {
+	switch k {
+	case OrgUnitDepartment:
+		return "departemen"
+	case OrgUnitPosition:
+		return "jabatan"
+	case OrgUnitJobLevel:
+		return "level jabatan"
+	case OrgUnitSBU:
+		return "SBU"
+	case OrgUnitEmploymentStatus:
+		return "status kepegawaian"
+	}
+	return string(k)
+}
+
+// HasDescription bernilai true untuk jenis yang punya kolom description.
+func (k OrgUnitKind) HasDescription() bool {
+	return k == OrgUnitSBU
+}
+
+func (k OrgUnitKind) IsValid() bool {
+	return k.Table() != ""
+}
+
+// OrgUnit adalah satu baris data master organisasi (departemen, jabatan,
+// level jabatan, SBU, atau status kepegawaian).
+type OrgUnit struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	Kind        OrgUnitKind
+	Name        string
+	Description *string
+	// ActiveEmployees hanya terisi saat dibaca dari repository.
+	ActiveEmployees int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Branch adalah kantor cabang. Koordinat dan radius dipakai untuk validasi
+// lokasi absensi.
+type Branch struct {
+	ID                     uuid.UUID
+	TenantID               uuid.UUID
+	Name                   string
+	// Code dipakai untuk token {BRANCH} pada nomor induk karyawan.
+	Code                   *string
+	Address                *string
+	Latitude               *float64
+	Longitude              *float64
+	AttendanceRadiusMeters int
+	// ActiveEmployees hanya terisi saat dibaca dari repository.
+	ActiveEmployees int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// HasLocation bernilai true jika koordinat cabang sudah diisi.
+func (b *Branch) HasLocation() bool {
+	return b.Latitude != nil && b.Longitude != nil
+}
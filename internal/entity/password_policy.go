@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordPolicy adalah kebijakan password staf sebuah tenant.
+type PasswordPolicy struct {
+	TenantID         uuid.UUID
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	// ExpiryDays 0 berarti password tidak pernah kedaluwarsa.
+	ExpiryDays int
+	// HistoryCount adalah jumlah password terakhir yang tidak boleh dipakai
+	// ulang (selain password saat ini).
+	HistoryCount int
+	// BlockCommon menolak password yang ada di daftar password umum/bocor.
+	BlockCommon bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DefaultPasswordPolicy berlaku untuk tenant yang belum mengatur kebijakan.
+// Nilainya sama dengan default kolom tenant_password_policies.
+func DefaultPasswordPolicy(tenantID uuid.UUID) *PasswordPolicy {
+	return &PasswordPolicy{
+		TenantID:    tenantID,
+		MinLength:   10,
+		BlockCommon: true,
+	}
+}
+
+// IsExpired memeriksa apakah password yang diganti pada changedAt sudah
+// melewati masa berlaku.
+func (p *PasswordPolicy) IsExpired(changedAt *time.Time, now time.Time) bool {
+	if p.ExpiryDays <= 0 || changedAt == nil {
+		return false
+	}
+	return now.After(changedAt.AddDate(0, 0, p.ExpiryDays))
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Katalog hak akses tenant. Harus sama dengan seed tenant_permissions di migrasi.
+const (
+	PermissionManageRoles        = "manage:roles"
+	PermissionManageUsers        = "manage:users"
+	PermissionViewEmployees      = "view:employees"
+	PermissionManageEmployees    = "manage:employees"
+	PermissionManageOrganization = "manage:organization"
+	PermissionViewAttendance     = "view:attendance"
+	PermissionManageAttendance   = "manage:attendance"
+	PermissionRequestLeave       = "request:leave"
+	PermissionApproveLeave       = "approve:leave"
+	PermissionViewPayroll        = "view:payroll"
+	PermissionManagePayroll      = "manage:payroll"
+	PermissionManageDocuments    = "manage:documents"
+	PermissionViewReports        = "view:reports"
+	PermissionManageSettings     = "manage:settings"
+)
+
+// AdministratorRoleName adalah role bawaan yang memiliki semua hak akses,
+// dibuat saat admin pertama tenant diundang.
+const AdministratorRoleName = "Administrator"
+
+type Permission struct {
+	ID        uuid.UUID
+	Name      string
+	GroupName string
+	// DataScope hanya terisi jika permission dimuat lewat role atau user.
+	DataScope DataScope
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
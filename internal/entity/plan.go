@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BillingCycle string
+
+const (
+	BillingCycleMonthly BillingCycle = "monthly"
+	BillingCycleYearly  BillingCycle = "yearly"
+)
+
+type Plan struct {
+	ID            uuid.UUID
+	Name          string
+	Slug          string
+	Description   *string
+	Price         float64
+	BillingCycle  BillingCycle
+	EmployeeLimit int
+	IsActive      bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// ProrationInput adalah data yang dibutuhkan untuk menghitung perpindahan paket
+// di tengah periode tagihan.
+type ProrationInput struct {
+	CurrentPlan *Plan
+	NewPlan     *Plan
+	// PeriodStart dan PeriodEnd adalah periode tagihan paket lama yang sedang berjalan.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	ChangeAt    time.Time
+	// IsTrial true jika langganan lama masih masa trial (belum pernah ditagih).
+	IsTrial bool
+}
+
+// Proration adalah hasil perhitungan. Net > 0 berarti tenant harus membayar
+// invoice penyesuaian, Net < 0 berarti tenant mendapat kredit sebesar -Net.
+type Proration struct {
+	UnusedRatio    float64
+	Credit         float64
+	Charge         float64
+	Net            float64
+	NewPeriodStart time.Time
+	NewPeriodEnd   time.Time
+}
+
+// CalculateProration menghitung kredit sisa waktu paket lama dan biaya paket baru.
+//
+//   - Siklus tagihan sama: periode tetap, paket baru ditagih hanya untuk sisa periode.
+//   - Siklus tagihan berbeda (monthly <-> yearly): periode baru dimulai saat ChangeAt
+//     dan paket baru ditagih penuh untuk satu siklus.
+//   - Masa trial: belum ada tagihan, jadi tidak ada kredit maupun biaya dan
+//     periode trial tetap berjalan. Paket baru ditagih saat trial berakhir.
+func CalculateProration(in ProrationInput) Proration {
+	if in.IsTrial {
+		return Proration{
+			NewPeriodStart: in.PeriodStart,
+			NewPeriodEnd:   in.PeriodEnd,
+		}
+	}
+
+	changeAt := in.ChangeAt
+	if changeAt.Before(in.PeriodStart) {
+		changeAt = in.PeriodStart
+	}
+	if changeAt.After(in.PeriodEnd) {
+		changeAt = in.PeriodEnd
+	}
+
+	var result Proration
+
+	total := in.PeriodEnd.Sub(in.PeriodStart)
+	if total > 0 {
+		result.UnusedRatio = float64(in.PeriodEnd.Sub(changeAt)) / float64(total)
+		result.Credit = RoundMoney(in.CurrentPlan.Price * result.UnusedRatio)
+	}
+
+	if in.CurrentPlan.BillingCycle != in.NewPlan.BillingCycle {
+		result.NewPeriodStart = changeAt
+		result.NewPeriodEnd = AddBillingCycle(changeAt, in.NewPlan.BillingCycle)
+		result.Charge = RoundMoney(in.NewPlan.Price)
+	} else {
+		result.NewPeriodStart = in.PeriodStart
+		result.NewPeriodEnd = in.PeriodEnd
+		result.Charge = RoundMoney(in.NewPlan.Price * result.UnusedRatio)
+	}
+
+	result.Net = RoundMoney(result.Charge - result.Credit)
+	return result
+}
+
+// AddBillingCycle menambah satu siklus tagihan. Tanggal di akhir bulan dijepit
+// ke hari terakhir bulan tujuan (31 Jan + 1 bulan = 28/29 Feb, bukan 2/3 Mar).
+func AddBillingCycle(t time.Time, cycle BillingCycle) time.Time {
+	months := 1
+	if cycle == BillingCycleYearly {
+		months = 12
+	}
+
+	year, month, day := t.Date()
+	firstOfTarget := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// RoundMoney membulatkan nominal ke 2 angka desimal (DECIMAL(15, 2)).
+func RoundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalculateProration(t *testing.T) {
+	basicMonthly := &Plan{Price: 300000, BillingCycle: BillingCycleMonthly}
+	proMonthly := &Plan{Price: 600000, BillingCycle: BillingCycleMonthly}
+	proYearly := &Plan{Price: 6000000, BillingCycle: BillingCycleYearly}
+
+	// Periode April 30 hari, sehingga tanggal 16 tepat di tengah periode.
+	periodStart := date(2025, time.April, 1)
+	periodEnd := date(2025, time.May, 1)
+	midPeriod := date(2025, time.April, 16)
+
+	tests := []struct {
+		name string
+		in   ProrationInput
+		want Proration
+	}{
+		{
+			name: "upgrade siklus sama di tengah periode",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proMonthly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: midPeriod,
+			},
+			want: Proration{
+				UnusedRatio: 0.5, Credit: 150000, Charge: 300000, Net: 150000,
+				NewPeriodStart: periodStart, NewPeriodEnd: periodEnd,
+			},
+		},
+		{
+			name: "downgrade siklus sama menghasilkan kredit",
+			in: ProrationInput{
+				CurrentPlan: proMonthly, NewPlan: basicMonthly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: midPeriod,
+			},
+			want: Proration{
+				UnusedRatio: 0.5, Credit: 300000, Charge: 150000, Net: -150000,
+				NewPeriodStart: periodStart, NewPeriodEnd: periodEnd,
+			},
+		},
+		{
+			name: "monthly ke yearly memulai periode baru",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proYearly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: midPeriod,
+			},
+			want: Proration{
+				UnusedRatio: 0.5, Credit: 150000, Charge: 6000000, Net: 5850000,
+				NewPeriodStart: midPeriod, NewPeriodEnd: date(2026, time.April, 16),
+			},
+		},
+		{
+			name: "trial tidak menghasilkan tagihan maupun kredit",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proYearly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: midPeriod,
+				IsTrial: true,
+			},
+			want: Proration{
+				NewPeriodStart: periodStart, NewPeriodEnd: periodEnd,
+			},
+		},
+		{
+			name: "perubahan sebelum periode dijepit ke awal periode",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proMonthly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: date(2025, time.March, 20),
+			},
+			want: Proration{
+				UnusedRatio: 1, Credit: 300000, Charge: 600000, Net: 300000,
+				NewPeriodStart: periodStart, NewPeriodEnd: periodEnd,
+			},
+		},
+		{
+			name: "perubahan setelah periode dijepit ke akhir periode",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proMonthly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: date(2025, time.May, 10),
+			},
+			want: Proration{
+				UnusedRatio: 0, Credit: 0, Charge: 0, Net: 0,
+				NewPeriodStart: periodStart, NewPeriodEnd: periodEnd,
+			},
+		},
+		{
+			name: "perubahan siklus setelah periode dimulai dari akhir periode",
+			in: ProrationInput{
+				CurrentPlan: basicMonthly, NewPlan: proYearly,
+				PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: date(2025, time.May, 10),
+			},
+			want: Proration{
+				UnusedRatio: 0, Credit: 0, Charge: 6000000, Net: 6000000,
+				NewPeriodStart: periodEnd, NewPeriodEnd: date(2026, time.May, 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateProration(tt.in)
+			if got.UnusedRatio != tt.want.UnusedRatio || got.Credit != tt.want.Credit ||
+				got.Charge != tt.want.Charge || got.Net != tt.want.Net {
+				t.Errorf("ratio/credit/charge/net = %v/%v/%v/%v, want %v/%v/%v/%v",
+					got.UnusedRatio, got.Credit, got.Charge, got.Net,
+					tt.want.UnusedRatio, tt.want.Credit, tt.want.Charge, tt.want.Net)
+			}
+			if !got.NewPeriodStart.Equal(tt.want.NewPeriodStart) || !got.NewPeriodEnd.Equal(tt.want.NewPeriodEnd) {
+				t.Errorf("periode = %v - %v, want %v - %v",
+					got.NewPeriodStart, got.NewPeriodEnd, tt.want.NewPeriodStart, tt.want.NewPeriodEnd)
+			}
+		})
+	}
+}
+
+func TestAddBillingCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		t     time.Time
+		cycle BillingCycle
+		want  time.Time
+	}{
+		{"bulanan biasa", date(2025, time.March, 15), BillingCycleMonthly, date(2025, time.April, 15)},
+		{"31 Januari ke Februari", date(2025, time.January, 31), BillingCycleMonthly, date(2025, time.February, 28)},
+		{"31 Januari ke Februari kabisat", date(2024, time.January, 31), BillingCycleMonthly, date(2024, time.February, 29)},
+		{"31 Maret ke April", date(2025, time.March, 31), BillingCycleMonthly, date(2025, time.April, 30)},
+		{"Desember ke tahun berikutnya", date(2025, time.December, 31), BillingCycleMonthly, date(2026, time.January, 31)},
+		{"tahunan biasa", date(2025, time.June, 10), BillingCycleYearly, date(2026, time.June, 10)},
+		{"tahunan dari 29 Februari", date(2024, time.February, 29), BillingCycleYearly, date(2025, time.February, 28)},
+		{
+			"jam dan zona waktu dipertahankan",
+			time.Date(2025, time.January, 31, 13, 45, 0, 0, time.FixedZone("WIB", 7*3600)),
+			BillingCycleMonthly,
+			time.Date(2025, time.February, 28, 13, 45, 0, 0, time.FixedZone("WIB", 7*3600)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddBillingCycle(tt.t, tt.cycle); !got.Equal(tt.want) {
+				t.Errorf("AddBillingCycle(%v, %s) = %v, want %v", tt.t, tt.cycle, got, tt.want)
+			}
+		})
+	}
+}
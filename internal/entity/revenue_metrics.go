@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminPermissionViewGlobalRevenue adalah hak akses superadmin untuk melihat
+// laporan revenue dan piutang seluruh tenant (admin_permissions).
+const AdminPermissionViewGlobalRevenue = "view:global_revenue"
+
+// RevenueMetrics adalah ringkasan metrik SaaS untuk rentang [From, To).
+// Semua nilai MRR sudah dinormalisasi ke bulanan (harga tahunan / 12).
+type RevenueMetrics struct {
+	From time.Time
+	To   time.Time
+
+	MRR          float64
+	ARR          float64
+	NewMRR       float64
+	ExpansionMRR float64
+	// ContractionMRR dan ChurnedMRR bernilai positif (besar penurunan).
+	ContractionMRR float64
+	ChurnedMRR     float64
+	NetNewMRR      float64
+
+	// CollectedRevenue adalah kas bersih yang diterima: pembayaran dikurangi
+	// refund, tanpa pemakaian saldo kredit.
+	CollectedRevenue float64
+	// CreditNotesIssued adalah total credit note yang diterbitkan, termasuk
+	// yang diselesaikan sebagai refund (Refunds).
+	CreditNotesIssued float64
+	Refunds           float64
+
+	TrialsStarted       int
+	TrialsConverted     int
+	TrialConversionRate float64
+
+	TenantsByStatus map[StatusTenant]int
+	TenantsByPlan   []PlanTenantCount
+}
+
+type PlanTenantCount struct {
+	PlanID       uuid.UUID
+	PlanName     string
+	Tenants      int
+	TrialTenants int
+	MRR          float64
+}
+
+// OutstandingInvoice adalah invoice yang belum lunas beserta sisa tagihannya.
+type OutstandingInvoice struct {
+	InvoiceID   uuid.UUID
+	TenantID    uuid.UUID
+	TenantName  string
+	IssueDate   time.Time
+	DueDate     time.Time
+	TotalAmount float64
+	Outstanding float64
+	DaysPastDue int
+}
+
+const (
+	AgingBucketCurrent = "current"
+	AgingBucket1To30   = "1-30"
+	AgingBucket31To60  = "31-60"
+	AgingBucket61To90  = "61-90"
+	AgingBucketOver90  = "90+"
+)
+
+// AgingBuckets adalah urutan bucket piutang untuk laporan.
+var AgingBuckets = []string{AgingBucketCurrent, AgingBucket1To30, AgingBucket31To60, AgingBucket61To90, AgingBucketOver90}
+
+// AgingBucket mengelompokkan jumlah hari keterlambatan ke bucket 30/60/90 hari.
+func AgingBucket(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return AgingBucketCurrent
+	case daysPastDue <= 30:
+		return AgingBucket1To30
+	case daysPastDue <= 60:
+		return AgingBucket31To60
+	case daysPastDue <= 90:
+		return AgingBucket61To90
+	default:
+		return AgingBucketOver90
+	}
+}
+
+type ReceivablesAging struct {
+	AsOf     time.Time
+	Totals   map[string]float64
+	Invoices []OutstandingInvoice
+}
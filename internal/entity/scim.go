@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SCIMToken adalah bearer token yang dipakai Identity Provider tenant untuk
+// mengakses endpoint SCIM.
+type SCIMToken struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	Name        string
+	TokenPrefix string
+	TokenHash   string
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// SCIMUser adalah resource User SCIM: akun login (users) beserta data
+// karyawannya (employees) dan role tenant sebagai group.
+type SCIMUser struct {
+	User           User
+	EmployeeID     *uuid.UUID
+	EmployeeNumber *string
+	JoinDate       *time.Time
+	ResignDate     *time.Time
+	Groups         []SCIMGroupRef
+}
+
+// Active bernilai false jika login user sudah dinonaktifkan.
+func (u *SCIMUser) Active() bool {
+	return u.User.LoginDisabledAt == nil
+}
+
+// SCIMGroup adalah resource Group SCIM yang dipetakan ke role tenant.
+type SCIMGroup struct {
+	Role    Role
+	Members []SCIMGroupRef
+}
+
+// SCIMGroupRef adalah referensi singkat (user atau group) di dalam resource SCIM.
+type SCIMGroupRef struct {
+	ID      uuid.UUID
+	Display string
+}
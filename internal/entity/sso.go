@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SSOProtocol string
+
+const (
+	SSOProtocolOIDC SSOProtocol = "oidc"
+	SSOProtocolSAML SSOProtocol = "saml"
+)
+
+// SSOConfig adalah konfigurasi Identity Provider milik satu tenant.
+type SSOConfig struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Protocol SSOProtocol
+	Enabled  bool
+	// JITProvisioning membuat akun user baru saat email dari IdP belum
+	// terdaftar di tenant.
+	JITProvisioning       bool
+	PasswordLoginDisabled bool
+
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+
+	SAMLIDPMetadataURL string
+	SAMLIDPMetadataXML string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BlocksPasswordLogin bernilai true jika staf tenant wajib login lewat SSO.
+func (c *SSOConfig) BlocksPasswordLogin() bool {
+	return c.Enabled && c.PasswordLoginDisabled
+}
+
+// SSOLoginRequest menyimpan data sementara antara redirect ke IdP dan
+// callback-nya (PKCE verifier, nonce, atau ID AuthnRequest SAML).
+type SSOLoginRequest struct {
+	ID            string
+	TenantID      uuid.UUID
+	Protocol      SSOProtocol
+	CodeVerifier  string
+	Nonce         string
+	SAMLRequestID string
+	ExpiresAt     time.Time
+	ConsumedAt    *time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusSubscription string
+
+const (
+	StatusSubscriptionActive   StatusSubscription = "active"
+	StatusSubscriptionTrialing StatusSubscription = "trialing"
+	StatusSubscriptionPastDue  StatusSubscription = "past_due"
+	StatusSubscriptionCanceled StatusSubscription = "canceled"
+)
+
+type Subscription struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	PlanID      uuid.UUID
+	Status      StatusSubscription
+	StartedAt   time.Time
+	EndsAt      *time.Time
+	TrialEndsAt *time.Time
+	// CurrentPeriodStart/End adalah periode tagihan yang sedang berjalan,
+	// dipakai sebagai dasar perhitungan prorata.
+	CurrentPeriodStart *time.Time
+	CurrentPeriodEnd   *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsEntitled menentukan apakah langganan ini memberi akses fitur pada waktu 'at'.
+// Status past_due tetap dianggap aktif sebagai masa tenggang pembayaran.
+func (s *Subscription) IsEntitled(at time.Time) bool {
+	if s.Status == StatusSubscriptionCanceled {
+		return false
+	}
+	if s.StartedAt.After(at) {
+		return false
+	}
+	if s.EndsAt != nil && !s.EndsAt.After(at) {
+		return false
+	}
+	if s.Status == StatusSubscriptionTrialing && s.TrialEndsAt != nil && !s.TrialEndsAt.After(at) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHashCost adalah cost bcrypt untuk password staf. Hash lama dengan
+// cost lebih rendah di-hash ulang saat user berhasil login.
+const PasswordHashCost = 12
+
+// User adalah akun login staf tenant (berbeda dengan AdminUser milik superadmin).
+type User struct {
+	ID              uuid.UUID
+	TenantID        uuid.UUID
+	Name            string
+	Email           string
+	EmailVerifiedAt *time.Time
+	Password        string
+	// PasswordChangedAt dipakai untuk menghitung masa berlaku password.
+	PasswordChangedAt *time.Time
+	Timezone          string
+	// LoginDisabledAt terisi jika user tidak boleh login lagi (mis. dinonaktifkan
+	// lewat SCIM atau sudah resign).
+	LoginDisabledAt *time.Time
+	// SCIMExternalID adalah ID user di Identity Provider yang mem-provisioning-nya.
+	SCIMExternalID *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+func (u *User) HashPassword(plainPassword string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(plainPassword), PasswordHashCost)
+	if err != nil {
+		return err
+	}
+	u.Password = string(hashedPassword)
+	return nil
+}
+
+func (u *User) CheckPassword(plainPassword string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(plainPassword))
+	return err == nil
+}
+
+// NeedsRehash bernilai true jika hash password dibuat dengan cost bcrypt yang
+// lebih rendah dari PasswordHashCost.
+func (u *User) NeedsRehash() bool {
+	cost, err := bcrypt.Cost([]byte(u.Password))
+	return err == nil && cost < PasswordHashCost
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsLoginDisabled() bool {
+	return u.LoginDisabledAt != nil
+}
@@ -36,7 +36,7 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	Name     string `json:"name" validate:"required,min=2"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=10,no_consecutive_spaces"`
+	Password string `json:"password" validate:"required,password_policy,no_consecutive_spaces"`
 }
 
 type RegisterResponse struct {
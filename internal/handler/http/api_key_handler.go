@@ -0,0 +1,320 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type APIKeyRequest struct {
+	Name        string     `json:"name" validate:"required,min=2,max=255"`
+	Permissions []string   `json:"permissions" validate:"required,min=1,dive,required"`
+	AllowedIPs  []string   `json:"allowed_ips" validate:"dive,cidr|ip"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// RotateAPIKeyRequest boleh dikosongkan. GracePeriodHours 0 berarti key lama
+// langsung dicabut.
+type RotateAPIKeyRequest struct {
+	GracePeriodHours int `json:"grace_period_hours" validate:"min=0,max=168"`
+}
+
+type APIKeyResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	KeyPrefix    string     `json:"key_prefix"`
+	Status       string     `json:"status"`
+	Permissions  []string   `json:"permissions"`
+	AllowedIPs   []string   `json:"allowed_ips"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	LastUsedIP   *string    `json:"last_used_ip"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uuid.UUID `json:"replaced_by_id"`
+	CreatedBy    *uuid.UUID `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// APIKeyCreatedResponse memuat plaintext key yang hanya ditampilkan sekali.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func newAPIKeyResponse(k *entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:           k.ID,
+		Name:         k.Name,
+		KeyPrefix:    k.KeyPrefix,
+		Status:       k.Status(time.Now()),
+		Permissions:  k.Permissions,
+		AllowedIPs:   k.AllowedIPs,
+		ExpiresAt:    k.ExpiresAt,
+		LastUsedAt:   k.LastUsedAt,
+		LastUsedIP:   k.LastUsedIP,
+		RevokedAt:    k.RevokedAt,
+		ReplacedByID: k.ReplacedByID,
+		CreatedBy:    k.CreatedBy,
+		CreatedAt:    k.CreatedAt,
+		UpdatedAt:    k.UpdatedAt,
+	}
+}
+
+// APIKeyHandler mengelola API key tenant dan menyediakan middleware yang
+// menerima API key sebagai pengganti JWT.
+type APIKeyHandler struct {
+	usecase  *usecase.APIKeyUsecase
+	validate *validator.Validate
+}
+
+func NewAPIKeyHandler(uc *usecase.APIKeyUsecase, v *validator.Validate) *APIKeyHandler {
+	return &APIKeyHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// Authenticate membungkus middleware JWT tenant: bearer token berprefix
+// "hris_" divalidasi sebagai API key, token lain diteruskan ke jwtAuth.
+// Harus dipasang setelah TenantResolver.
+//
+// IP klien diambil dari RemoteAddr. Jika server berada di belakang reverse
+// proxy, pasang middleware.RealIP hanya bila proxy tersebut tepercaya.
+//
+//	r.Use(apiKeyHandler.Authenticate(jwtService.TenantAuthMiddleware))
+func (h *APIKeyHandler) Authenticate(jwtAuth func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		jwtNext := jwtAuth(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plaintext, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || !strings.HasPrefix(plaintext, usecase.APIKeyKind+"_") {
+				jwtNext.ServeHTTP(w, r)
+				return
+			}
+
+			tenantID, ok := security.TenantIDFromContext(r.Context())
+			if !ok {
+				util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+				return
+			}
+
+			key, err := h.usecase.Authenticate(r.Context(), tenantID, strings.TrimSpace(plaintext), clientIP(r))
+			if errors.Is(err, usecase.ErrAPIKeyIPNotAllowed) {
+				util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+				return
+			}
+			if err != nil {
+				util.ErrorResponse(w, http.StatusUnauthorized, "API key tidak valid", err.Error())
+				return
+			}
+
+			ctx := security.WithAPIKey(r.Context(), &security.APIKeyPrincipal{
+				KeyID:       key.ID,
+				TenantID:    key.TenantID,
+				Permissions: key.Permissions,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func clientIP(r *http.Request) netip.Addr {
+	if addrPort, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		return addrPort.Addr()
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, _ := netip.ParseAddr(host)
+	return addr
+}
+
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	keys, err := h.usecase.ListKeys(r.Context(), claims.TenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil API key", err.Error())
+		return
+	}
+
+	res := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		res[i] = newAPIKeyResponse(k)
+	}
+	util.SuccessResponse(w, "API key berhasil diambil", res)
+}
+
+func (h *APIKeyHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID API key tidak valid", err.Error())
+		return
+	}
+
+	key, err := h.usecase.GetKey(r.Context(), claims.TenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "API key tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "API key berhasil diambil", newAPIKeyResponse(key))
+}
+
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	input, ok := h.decodeAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	key, plaintext, err := h.usecase.CreateKey(r.Context(), claims.TenantID, &claims.UserID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat API key", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "API key berhasil dibuat. Simpan key ini, key tidak akan ditampilkan lagi", APIKeyCreatedResponse{
+		APIKeyResponse: newAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+func (h *APIKeyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID API key tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	key, err := h.usecase.UpdateKey(r.Context(), claims.TenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui API key", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "API key berhasil diperbarui", newAPIKeyResponse(key))
+}
+
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID API key tidak valid", err.Error())
+		return
+	}
+
+	var req RotateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	grace := time.Duration(req.GracePeriodHours) * time.Hour
+	key, plaintext, err := h.usecase.RotateKey(r.Context(), claims.TenantID, id, &claims.UserID, grace)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal merotasi API key", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "API key berhasil dirotasi. Simpan key ini, key tidak akan ditampilkan lagi", APIKeyCreatedResponse{
+		APIKeyResponse: newAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID API key tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.RevokeKey(r.Context(), claims.TenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "API key tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "API key berhasil dicabut", nil)
+}
+
+func (h *APIKeyHandler) decodeAPIKey(w http.ResponseWriter, r *http.Request) (usecase.APIKeyInput, bool) {
+	var req APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return usecase.APIKeyInput{}, false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.APIKeyInput{}, false
+	}
+
+	return usecase.APIKeyInput{
+		Name:        strings.TrimSpace(req.Name),
+		Permissions: req.Permissions,
+		AllowedIPs:  req.AllowedIPs,
+		ExpiresAt:   req.ExpiresAt,
+	}, true
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type CreditNoteItemRequest struct {
+	Description string  `json:"description" validate:"required,max=255"`
+	Quantity    int     `json:"quantity" validate:"required,gt=0"`
+	UnitPrice   float64 `json:"unit_price" validate:"required,gt=0"`
+}
+
+type IssueCreditNoteRequest struct {
+	Reason     string                  `json:"reason" validate:"required"`
+	Resolution string                  `json:"resolution" validate:"required,oneof=credit refund"`
+	Items      []CreditNoteItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type CreditNoteHandler struct {
+	usecase  *usecase.CreditNoteUsecase
+	validate *validator.Validate
+}
+
+func NewCreditNoteHandler(uc *usecase.CreditNoteUsecase, v *validator.Validate) *CreditNoteHandler {
+	return &CreditNoteHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+type CreditNoteItemResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	TotalPrice  float64   `json:"total_price"`
+}
+
+type CreditNoteResponse struct {
+	ID          uuid.UUID                   `json:"id"`
+	Number      string                      `json:"number"`
+	TenantID    uuid.UUID                   `json:"tenant_id"`
+	InvoiceID   uuid.UUID                   `json:"invoice_id"`
+	IssueDate   string                      `json:"issue_date"`
+	Reason      string                      `json:"reason"`
+	Resolution  entity.CreditNoteResolution `json:"resolution"`
+	TotalAmount float64                     `json:"total_amount"`
+	Status      entity.StatusCreditNote     `json:"status"`
+	CreatedBy   *uuid.UUID                  `json:"created_by"`
+	Items       []CreditNoteItemResponse    `json:"items"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+func newCreditNoteResponse(cn *entity.CreditNote) CreditNoteResponse {
+	items := make([]CreditNoteItemResponse, len(cn.Items))
+	for i, item := range cn.Items {
+		items[i] = CreditNoteItemResponse{
+			ID:          item.ID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		}
+	}
+
+	return CreditNoteResponse{
+		ID:          cn.ID,
+		Number:      cn.Number,
+		TenantID:    cn.TenantID,
+		InvoiceID:   cn.InvoiceID,
+		IssueDate:   cn.IssueDate.Format(dateLayout),
+		Reason:      cn.Reason,
+		Resolution:  cn.Resolution,
+		TotalAmount: cn.TotalAmount,
+		Status:      cn.Status,
+		CreatedBy:   cn.CreatedBy,
+		Items:       items,
+		CreatedAt:   cn.CreatedAt,
+	}
+}
+
+func (h *CreditNoteHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	var req IssueCreditNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.IssueCreditNoteInput{
+		Reason:     req.Reason,
+		Resolution: entity.CreditNoteResolution(req.Resolution),
+		Items:      make([]usecase.CreditNoteItemInput, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		input.Items[i] = usecase.CreditNoteItemInput{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+		}
+	}
+	if adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID); ok {
+		input.IssuedBy = adminID
+	}
+
+	creditNote, err := h.usecase.IssueCreditNote(r.Context(), invoiceID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menerbitkan credit note", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Credit note berhasil diterbitkan", newCreditNoteResponse(creditNote))
+}
+
+func (h *CreditNoteHandler) ListByInvoice(w http.ResponseWriter, r *http.Request) {
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	creditNotes, err := h.usecase.ListInvoiceCreditNotes(r.Context(), invoiceID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Invoice tidak ditemukan", err.Error())
+		return
+	}
+
+	responses := make([]CreditNoteResponse, len(creditNotes))
+	for i, cn := range creditNotes {
+		responses[i] = newCreditNoteResponse(cn)
+	}
+
+	util.SuccessResponse(w, "Data credit note berhasil diambil", responses)
+}
+
+func (h *CreditNoteHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "creditNoteID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID credit note tidak valid", err.Error())
+		return
+	}
+
+	creditNote, err := h.usecase.GetCreditNote(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Credit note tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Credit note berhasil diambil", newCreditNoteResponse(creditNote))
+}
+
+func (h *CreditNoteHandler) DownloadPDF(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "creditNoteID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID credit note tidak valid", err.Error())
+		return
+	}
+
+	creditNote, pdf, err := h.usecase.GetCreditNotePDF(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Credit note tidak ditemukan", err.Error())
+		return
+	}
+
+	writePDF(w, creditNote.Number+".pdf", pdf)
+}
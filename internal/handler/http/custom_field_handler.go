@@ -0,0 +1,233 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type CustomFieldRequest struct {
+	Name     string   `json:"name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	Options  []string `json:"options" validate:"omitempty,max=200,dive,required,max=255"`
+	Required bool     `json:"required"`
+	Unique   bool     `json:"unique"`
+	// Min dan Max adalah batas panjang (text), nilai (number), atau jumlah
+	// pilihan (multi_select).
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	Pattern   *string  `json:"pattern" validate:"omitempty,max=500"`
+	SortOrder int      `json:"sort_order"`
+}
+
+// CreateCustomFieldRequest menambahkan entitas, kunci, dan tipe yang hanya
+// diisi saat field dibuat.
+type CreateCustomFieldRequest struct {
+	EntityType string `json:"entity_type" validate:"omitempty,max=50"`
+	Key        string `json:"key" validate:"required,max=100"`
+	Type       string `json:"type" validate:"required,oneof=text number date boolean select multi_select"`
+	CustomFieldRequest
+}
+
+type CustomFieldResponse struct {
+	ID         uuid.UUID `json:"id"`
+	EntityType string    `json:"entity_type"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Options    []string  `json:"options"`
+	Required   bool      `json:"required"`
+	Unique     bool      `json:"unique"`
+	Min        *float64  `json:"min"`
+	Max        *float64  `json:"max"`
+	Pattern    *string   `json:"pattern"`
+	SortOrder  int       `json:"sort_order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func newCustomFieldResponse(f *entity.CustomField) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:         f.ID,
+		EntityType: string(f.EntityType),
+		Key:        f.Key,
+		Name:       f.Name,
+		Type:       string(f.Type),
+		Options:    f.Options,
+		Required:   f.Required,
+		Unique:     f.Unique,
+		Min:        f.Min,
+		Max:        f.Max,
+		Pattern:    f.Pattern,
+		SortOrder:  f.SortOrder,
+		CreatedAt:  f.CreatedAt,
+		UpdatedAt:  f.UpdatedAt,
+	}
+}
+
+type CustomFieldHandler struct {
+	usecase  *usecase.CustomFieldUsecase
+	validate *validator.Validate
+}
+
+func NewCustomFieldHandler(uc *usecase.CustomFieldUsecase, v *validator.Validate) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// List mengembalikan definisi custom field untuk ?entity_type= (default
+// employee), diurutkan berdasarkan sort_order.
+func (h *CustomFieldHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	entityType := entity.CustomFieldEntity(r.URL.Query().Get("entity_type"))
+	if entityType == "" {
+		entityType = entity.CustomFieldEntityEmployee
+	}
+
+	fields, err := h.usecase.ListFields(r.Context(), tenantID, entityType)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengambil data custom field", err.Error())
+		return
+	}
+
+	responses := make([]CustomFieldResponse, len(fields))
+	for i, f := range fields {
+		responses[i] = newCustomFieldResponse(f)
+	}
+
+	util.SuccessResponse(w, "Data custom field berhasil diambil", responses)
+}
+
+func (h *CustomFieldHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID custom field tidak valid", err.Error())
+		return
+	}
+
+	field, err := h.usecase.GetField(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Custom field tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data custom field berhasil diambil", newCustomFieldResponse(field))
+}
+
+func (h *CustomFieldHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.EntityType = strings.TrimSpace(req.EntityType)
+	if req.EntityType == "" {
+		req.EntityType = string(entity.CustomFieldEntityEmployee)
+	}
+	req.Key = strings.TrimSpace(req.Key)
+
+	input, ok := h.decodeCustomField(w, &req, &req.CustomFieldRequest)
+	if !ok {
+		return
+	}
+	input.EntityType = entity.CustomFieldEntity(req.EntityType)
+	input.Key = req.Key
+	input.Type = entity.CustomFieldType(req.Type)
+
+	field, err := h.usecase.CreateField(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat custom field", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Custom field berhasil dibuat", newCustomFieldResponse(field))
+}
+
+// Update mengubah nama, pilihan, dan aturan field. Kunci dan tipe tidak bisa
+// diubah.
+func (h *CustomFieldHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID custom field tidak valid", err.Error())
+		return
+	}
+
+	var req CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeCustomField(w, &req, &req)
+	if !ok {
+		return
+	}
+
+	field, err := h.usecase.UpdateField(r.Context(), tenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui custom field", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Custom field berhasil diperbarui", newCustomFieldResponse(field))
+}
+
+// Delete menghapus definisi field beserta nilainya di semua record.
+func (h *CustomFieldHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID custom field tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.DeleteField(r.Context(), tenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Custom field tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Custom field berhasil dihapus", nil)
+}
+
+// decodeCustomField memvalidasi 'v' (request lengkap) lalu mengubah 'req'
+// menjadi input usecase.
+func (h *CustomFieldHandler) decodeCustomField(w http.ResponseWriter, v any, req *CustomFieldRequest) (usecase.CustomFieldInput, bool) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if err := h.validate.Struct(v); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.CustomFieldInput{}, false
+	}
+
+	return usecase.CustomFieldInput{
+		Name:      req.Name,
+		Options:   req.Options,
+		Required:  req.Required,
+		Unique:    req.Unique,
+		Min:       req.Min,
+		Max:       req.Max,
+		Pattern:   trimOptional(req.Pattern),
+		SortOrder: req.SortOrder,
+	}, true
+}
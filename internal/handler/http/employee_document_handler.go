@@ -0,0 +1,495 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type UpdateEmployeeDocumentRequest struct {
+	DocumentType string  `json:"document_type" validate:"required,max=255"`
+	ExpiryDate   *string `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type EmployeeDocumentResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	EmployeeID      uuid.UUID  `json:"employee_id"`
+	EmployeeName    string     `json:"employee_name"`
+	DocumentType    string     `json:"document_type"`
+	FileName        string     `json:"file_name"`
+	ContentType     string     `json:"content_type"`
+	FileSize        int64      `json:"file_size"`
+	Version         int        `json:"version"`
+	ExpiryDate      *string    `json:"expiry_date"`
+	DaysUntilExpiry *int       `json:"days_until_expiry"`
+	UploadedBy      *uuid.UUID `json:"uploaded_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type EmployeeDocumentVersionResponse struct {
+	Version     int        `json:"version"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	FileSize    int64      `json:"file_size"`
+	Checksum    string     `json:"checksum"`
+	UploadedBy  *uuid.UUID `json:"uploaded_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ListEmployeeDocumentsResponse struct {
+	Data       []EmployeeDocumentResponse `json:"data"`
+	Pagination util.Pagination            `json:"pagination"`
+}
+
+func newEmployeeDocumentResponse(d *entity.EmployeeDocument, now time.Time) EmployeeDocumentResponse {
+	resp := EmployeeDocumentResponse{
+		ID:              d.ID,
+		EmployeeID:      d.EmployeeID,
+		EmployeeName:    d.EmployeeName,
+		DocumentType:    d.DocumentType,
+		FileName:        d.FileName,
+		ContentType:     d.ContentType,
+		FileSize:        d.FileSize,
+		Version:         d.Version,
+		DaysUntilExpiry: d.DaysUntilExpiry(now),
+		UploadedBy:      d.UploadedBy,
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+	}
+	if d.ExpiryDate != nil {
+		expiry := d.ExpiryDate.Format(dateLayout)
+		resp.ExpiryDate = &expiry
+	}
+	return resp
+}
+
+func newEmployeeDocumentResponses(documents []*entity.EmployeeDocument) []EmployeeDocumentResponse {
+	now := time.Now()
+	responses := make([]EmployeeDocumentResponse, len(documents))
+	for i, d := range documents {
+		responses[i] = newEmployeeDocumentResponse(d, now)
+	}
+	return responses
+}
+
+type EmployeeDocumentHandler struct {
+	usecase  *usecase.EmployeeDocumentUsecase
+	validate *validator.Validate
+}
+
+func NewEmployeeDocumentHandler(uc *usecase.EmployeeDocumentUsecase, v *validator.Validate) *EmployeeDocumentHandler {
+	return &EmployeeDocumentHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// parseDocumentForm membaca multipart/form-data unggahan dokumen. Mengembalikan
+// false jika response error sudah ditulis.
+func parseDocumentForm(w http.ResponseWriter, r *http.Request) (usecase.DocumentFileInput, bool) {
+	// Sisa 1 MB untuk field form selain file.
+	r.Body = http.MaxBytesReader(w, r.Body, usecase.EmployeeDocumentMaxFileSize+1<<20)
+	if err := r.ParseMultipartForm(usecase.EmployeeDocumentMaxFileSize); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			util.ErrorResponse(w, http.StatusRequestEntityTooLarge, "File terlalu besar", "ukuran file maksimal 20 MB")
+			return usecase.DocumentFileInput{}, false
+		}
+		util.ErrorResponse(w, http.StatusBadRequest, "Request tidak valid", err.Error())
+		return usecase.DocumentFileInput{}, false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "File wajib diunggah", err.Error())
+		return usecase.DocumentFileInput{}, false
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membaca file", err.Error())
+		return usecase.DocumentFileInput{}, false
+	}
+
+	return usecase.DocumentFileInput{
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Content:     content,
+	}, true
+}
+
+// parseExpiryDate membaca field form expiry_date (YYYY-MM-DD, opsional).
+func parseExpiryDate(w http.ResponseWriter, raw string) (*time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, true
+	}
+	date, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tanggal kedaluwarsa tidak valid", "format tanggal harus YYYY-MM-DD")
+		return nil, false
+	}
+	return &date, true
+}
+
+func uploaderFromContext(r *http.Request) *uuid.UUID {
+	if claims, ok := security.TenantClaimsFromContext(r.Context()); ok {
+		return &claims.UserID
+	}
+	return nil
+}
+
+// Upload menerima multipart/form-data dengan field:
+//   - file: file dokumen, maksimal 20 MB
+//   - document_type: jenis dokumen, mis. KTP, Kontrak Kerja, Sertifikat
+//   - expiry_date (opsional): tanggal kedaluwarsa YYYY-MM-DD
+func (h *EmployeeDocumentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	file, ok := parseDocumentForm(w, r)
+	if !ok {
+		return
+	}
+
+	documentType := strings.TrimSpace(r.FormValue("document_type"))
+	if documentType == "" || len(documentType) > 255 {
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", "document_type wajib diisi, maksimal 255 karakter")
+		return
+	}
+	expiryDate, ok := parseExpiryDate(w, r.FormValue("expiry_date"))
+	if !ok {
+		return
+	}
+
+	document, err := h.usecase.Upload(r.Context(), tenantID, employeeID, uploaderFromContext(r), usecase.DocumentInput{
+		DocumentType: documentType,
+		ExpiryDate:   expiryDate,
+	}, file)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengunggah dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Dokumen berhasil diunggah", newEmployeeDocumentResponse(document, time.Now()))
+}
+
+// UploadVersion mengunggah versi baru sebuah dokumen. Field form sama dengan
+// Upload tanpa document_type; expiry_date kosong berarti tidak berubah.
+func (h *EmployeeDocumentHandler) UploadVersion(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return
+	}
+
+	file, ok := parseDocumentForm(w, r)
+	if !ok {
+		return
+	}
+	expiryDate, ok := parseExpiryDate(w, r.FormValue("expiry_date"))
+	if !ok {
+		return
+	}
+
+	document, err := h.usecase.UploadVersion(r.Context(), tenantID, id, uploaderFromContext(r), expiryDate, file)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengunggah versi dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Versi dokumen berhasil diunggah", newEmployeeDocumentResponse(document, time.Now()))
+}
+
+func (h *EmployeeDocumentHandler) ListByEmployee(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	h.list(w, r, tenantID, repository.EmployeeDocumentFilter{
+		EmployeeID:   &employeeID,
+		DocumentType: r.URL.Query().Get("document_type"),
+	})
+}
+
+// List mengembalikan semua dokumen dalam data scope, difilter dengan
+// ?employee_id= dan ?document_type=.
+func (h *EmployeeDocumentHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	filter := repository.EmployeeDocumentFilter{
+		DocumentType: r.URL.Query().Get("document_type"),
+	}
+	if raw := r.URL.Query().Get("employee_id"); raw != "" {
+		employeeID, err := uuid.Parse(raw)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter employee_id tidak valid", err.Error())
+			return
+		}
+		filter.EmployeeID = &employeeID
+	}
+
+	h.list(w, r, tenantID, filter)
+}
+
+func (h *EmployeeDocumentHandler) list(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, filter repository.EmployeeDocumentFilter) {
+	documents, pagination, err := h.usecase.ListDocuments(r.Context(), tenantID, filter, util.GetPaginationQuery(r))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data dokumen berhasil diambil", ListEmployeeDocumentsResponse{
+		Data:       newEmployeeDocumentResponses(documents),
+		Pagination: pagination,
+	})
+}
+
+// Expiring adalah dashboard dokumen yang sudah atau akan kedaluwarsa dalam
+// ?days= hari (default sesuai DOCUMENT_REMINDER_DAYS).
+func (h *EmployeeDocumentHandler) Expiring(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	days := 0
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		var err error
+		days, err = strconv.Atoi(raw)
+		if err != nil || days < 1 || days > 366 {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter days tidak valid", "days harus angka 1 sampai 366")
+			return
+		}
+	}
+
+	documents, pagination, err := h.usecase.ListExpiring(r.Context(), tenantID, days, util.GetPaginationQuery(r))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data dokumen kedaluwarsa berhasil diambil", ListEmployeeDocumentsResponse{
+		Data:       newEmployeeDocumentResponses(documents),
+		Pagination: pagination,
+	})
+}
+
+func (h *EmployeeDocumentHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return
+	}
+
+	document, err := h.usecase.GetDocument(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Dokumen tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data dokumen berhasil diambil", newEmployeeDocumentResponse(document, time.Now()))
+}
+
+// Update mengubah jenis dan tanggal kedaluwarsa dokumen tanpa mengganti file.
+func (h *EmployeeDocumentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return
+	}
+
+	var req UpdateEmployeeDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak valid", err.Error())
+		return
+	}
+
+	req.DocumentType = strings.TrimSpace(req.DocumentType)
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.DocumentInput{DocumentType: req.DocumentType}
+	if req.ExpiryDate != nil {
+		expiryDate, _ := time.Parse(dateLayout, *req.ExpiryDate)
+		input.ExpiryDate = &expiryDate
+	}
+
+	document, err := h.usecase.UpdateDocument(r.Context(), tenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Dokumen berhasil diperbarui", newEmployeeDocumentResponse(document, time.Now()))
+}
+
+func (h *EmployeeDocumentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.DeleteDocument(r.Context(), tenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Dokumen tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Dokumen berhasil dihapus", nil)
+}
+
+func (h *EmployeeDocumentHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return
+	}
+
+	versions, err := h.usecase.ListVersions(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Dokumen tidak ditemukan", err.Error())
+		return
+	}
+
+	resp := make([]EmployeeDocumentVersionResponse, len(versions))
+	for i, v := range versions {
+		resp[i] = EmployeeDocumentVersionResponse{
+			Version:     v.Version,
+			FileName:    v.FileName,
+			ContentType: v.ContentType,
+			FileSize:    v.FileSize,
+			Checksum:    v.Checksum,
+			UploadedBy:  v.UploadedBy,
+			CreatedAt:   v.CreatedAt,
+		}
+	}
+
+	util.SuccessResponse(w, "Riwayat versi dokumen berhasil diambil", resp)
+}
+
+// Download mengirim isi file dokumen; ?version= untuk versi tertentu
+// (default versi terbaru).
+func (h *EmployeeDocumentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, version, ok := parseDownloadParams(w, r)
+	if !ok {
+		return
+	}
+
+	v, rc, err := h.usecase.OpenDocument(r.Context(), tenantID, id, version)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Dokumen tidak ditemukan", err.Error())
+		return
+	}
+	serveDocument(w, v, rc)
+}
+
+// ListOwn mengembalikan dokumen milik user yang sedang login.
+func (h *EmployeeDocumentHandler) ListOwn(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	documents, pagination, err := h.usecase.ListOwnDocuments(r.Context(), claims.TenantID, claims.UserID, util.GetPaginationQuery(r))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data dokumen", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data dokumen berhasil diambil", ListEmployeeDocumentsResponse{
+		Data:       newEmployeeDocumentResponses(documents),
+		Pagination: pagination,
+	})
+}
+
+// DownloadOwn sama dengan Download, tetapi hanya untuk dokumen milik user
+// yang sedang login.
+func (h *EmployeeDocumentHandler) DownloadOwn(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, version, ok := parseDownloadParams(w, r)
+	if !ok {
+		return
+	}
+
+	v, rc, err := h.usecase.OpenOwnDocument(r.Context(), claims.TenantID, claims.UserID, id, version)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Dokumen tidak ditemukan", err.Error())
+		return
+	}
+	serveDocument(w, v, rc)
+}
+
+func parseDownloadParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, int, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID dokumen tidak valid", err.Error())
+		return uuid.Nil, 0, false
+	}
+
+	version := 0
+	if raw := r.URL.Query().Get("version"); raw != "" {
+		version, err = strconv.Atoi(raw)
+		if err != nil || version < 1 {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter version tidak valid", "version harus angka positif")
+			return uuid.Nil, 0, false
+		}
+	}
+	return id, version, true
+}
+
+func serveDocument(w http.ResponseWriter, v *entity.EmployeeDocumentVersion, rc io.ReadCloser) {
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", v.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(v.FileSize, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": v.FileName}))
+	// Cegah browser menjalankan file unggahan sebagai HTML/script.
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, rc)
+}
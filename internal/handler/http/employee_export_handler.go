@@ -0,0 +1,65 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeExportHandler struct {
+	usecase *usecase.EmployeeExportUsecase
+}
+
+func NewEmployeeExportHandler(uc *usecase.EmployeeExportUsecase) *EmployeeExportHandler {
+	return &EmployeeExportHandler{
+		usecase: uc,
+	}
+}
+
+// Export mengunduh data karyawan dalam format ?format=csv (default) atau xlsx.
+// Filter, pencarian, dan sorting sama dengan GET /employees. Kolom file sama
+// dengan kolom impor sehingga file bisa diimpor kembali.
+func (h *EmployeeExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	format := entity.ImportFileFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = entity.ImportFormatCSV
+	}
+	if format != entity.ImportFormatCSV && format != entity.ImportFormatXLSX {
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter format tidak valid", "format harus csv atau xlsx")
+		return
+	}
+
+	paginationQuery := util.GetPaginationQuery(r)
+	if !validateEmployeeFilters(w, paginationQuery) {
+		return
+	}
+
+	content, err := h.usecase.ExportEmployees(r.Context(), tenantID, paginationQuery, format)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCustomFieldQuery) {
+			util.ErrorResponse(w, http.StatusBadRequest, "Filter tidak valid", err.Error())
+			return
+		}
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengekspor data karyawan", err.Error())
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == entity.ImportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	filename := fmt.Sprintf("employees-%s.%s", time.Now().Format("20060102"), format)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
@@ -0,0 +1,435 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeProfileRequest struct {
+	FullName              string  `json:"full_name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	NIKKTP                *string `json:"nik_ktp" validate:"omitempty,nik,nik_birth=DateOfBirth Gender"`
+	PlaceOfBirth          *string `json:"place_of_birth" validate:"omitempty,max=255"`
+	DateOfBirth           *string `json:"date_of_birth" validate:"omitempty,datetime=2006-01-02"`
+	Gender                *string `json:"gender" validate:"omitempty,oneof=male female"`
+	MaritalStatus         *string `json:"marital_status" validate:"omitempty,max=50"`
+	Religion              *string `json:"religion" validate:"omitempty,max=50"`
+	AddressKTP            *string `json:"address_ktp"`
+	AddressDomicile       *string `json:"address_domicile"`
+	EmergencyContactName  *string `json:"emergency_contact_name" validate:"omitempty,max=255"`
+	EmergencyContactPhone *string `json:"emergency_contact_phone" validate:"omitempty,max=50"`
+}
+
+type EmployeeFinancialRequest struct {
+	BankName              *string `json:"bank_name" validate:"omitempty,max=100"`
+	BankAccountNumber     *string `json:"bank_account_number" validate:"omitempty,max=100"`
+	BankAccountHolder     *string `json:"bank_account_holder" validate:"omitempty,max=255"`
+	NPWP                  *string `json:"npwp" validate:"omitempty,npwp"`
+	PTKPStatus            *string `json:"ptkp_status" validate:"omitempty,max=10"`
+	BPJSKetenagakerjaanNo *string `json:"bpjs_ketenagakerjaan_no" validate:"omitempty,bpjs_ketenagakerjaan"`
+	BPJSKesehatanNo       *string `json:"bpjs_kesehatan_no" validate:"omitempty,bpjs_kesehatan"`
+	// BasicSalary yang diubah di sini dicatat sebagai koreksi di riwayat
+	// jabatan. Kenaikan gaji memakai POST /employees/{id}/job-histories.
+	BasicSalary *float64 `json:"basic_salary" validate:"omitempty,min=0"`
+}
+
+type EmployeeRequest struct {
+	// EmployeeIDNumber kosong berarti dibuat otomatis dari format nomor induk
+	// tenant (saat create) atau tidak diubah (saat update).
+	EmployeeIDNumber   string                   `json:"employee_id_number" validate:"omitempty,max=255"`
+	JoinDate           string                   `json:"join_date" validate:"required,datetime=2006-01-02"`
+	BranchID           *string                  `json:"branch_id" validate:"omitempty,uuid"`
+	DepartmentID       *string                  `json:"department_id" validate:"omitempty,uuid"`
+	PositionID         *string                  `json:"position_id" validate:"omitempty,uuid"`
+	JobLevelID         *string                  `json:"job_level_id" validate:"omitempty,uuid"`
+	EmploymentStatusID *string                  `json:"employment_status_id" validate:"omitempty,uuid"`
+	SBUID              *string                  `json:"sbu_id" validate:"omitempty,uuid"`
+	ManagerID          *string                  `json:"manager_id" validate:"omitempty,uuid"`
+	Profile            EmployeeProfileRequest   `json:"profile"`
+	Financial          EmployeeFinancialRequest `json:"financial"`
+	// CustomFields berisi nilai custom field karyawan per kunci. Saat update,
+	// tanpa custom_fields nilai yang tersimpan tidak diubah.
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// CreateEmployeeRequest menambahkan email akun login yang hanya diisi saat
+// karyawan dibuat.
+type CreateEmployeeRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	EmployeeRequest
+}
+
+type EmployeeProfileResponse struct {
+	FullName              string  `json:"full_name"`
+	NIKKTP                *string `json:"nik_ktp"`
+	PlaceOfBirth          *string `json:"place_of_birth"`
+	DateOfBirth           *string `json:"date_of_birth"`
+	Gender                *string `json:"gender"`
+	MaritalStatus         *string `json:"marital_status"`
+	Religion              *string `json:"religion"`
+	AddressKTP            *string `json:"address_ktp"`
+	AddressDomicile       *string `json:"address_domicile"`
+	EmergencyContactName  *string `json:"emergency_contact_name"`
+	EmergencyContactPhone *string `json:"emergency_contact_phone"`
+}
+
+type EmployeeFinancialResponse struct {
+	BankName              *string  `json:"bank_name"`
+	BankAccountNumber     *string  `json:"bank_account_number"`
+	BankAccountHolder     *string  `json:"bank_account_holder"`
+	NPWP                  *string  `json:"npwp"`
+	PTKPStatus            *string  `json:"ptkp_status"`
+	BPJSKetenagakerjaanNo *string  `json:"bpjs_ketenagakerjaan_no"`
+	BPJSKesehatanNo       *string  `json:"bpjs_kesehatan_no"`
+	BasicSalary           *float64 `json:"basic_salary"`
+}
+
+type EmployeeResponse struct {
+	ID                 uuid.UUID                 `json:"id"`
+	UserID             uuid.UUID                 `json:"user_id"`
+	Email              string                    `json:"email"`
+	EmployeeIDNumber   string                    `json:"employee_id_number"`
+	JoinDate           string                    `json:"join_date"`
+	ResignDate         *string                   `json:"resign_date"`
+	Active             bool                      `json:"active"`
+	BranchID           *uuid.UUID                `json:"branch_id"`
+	DepartmentID       *uuid.UUID                `json:"department_id"`
+	PositionID         *uuid.UUID                `json:"position_id"`
+	JobLevelID         *uuid.UUID                `json:"job_level_id"`
+	EmploymentStatusID *uuid.UUID                `json:"employment_status_id"`
+	SBUID              *uuid.UUID                `json:"sbu_id"`
+	ManagerID          *uuid.UUID                `json:"manager_id"`
+	Profile            EmployeeProfileResponse   `json:"profile"`
+	Financial          EmployeeFinancialResponse `json:"financial"`
+	CustomFields       map[string]any            `json:"custom_fields"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	UpdatedAt          time.Time                 `json:"updated_at"`
+}
+
+type ListEmployeesResponse struct {
+	Data       []EmployeeResponse `json:"data"`
+	Pagination util.Pagination    `json:"pagination"`
+}
+
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(dateLayout)
+	return &s
+}
+
+func newEmployeeResponse(e *entity.Employee) EmployeeResponse {
+	return EmployeeResponse{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		Email:              e.Email,
+		EmployeeIDNumber:   e.EmployeeIDNumber,
+		JoinDate:           e.JoinDate.Format(dateLayout),
+		ResignDate:         formatOptionalDate(e.ResignDate),
+		Active:             e.IsActive(time.Now()),
+		BranchID:           e.BranchID,
+		DepartmentID:       e.DepartmentID,
+		PositionID:         e.PositionID,
+		JobLevelID:         e.JobLevelID,
+		EmploymentStatusID: e.EmploymentStatusID,
+		SBUID:              e.SBUID,
+		ManagerID:          e.ManagerID,
+		Profile: EmployeeProfileResponse{
+			FullName:              e.Profile.FullName,
+			NIKKTP:                e.Profile.NIKKTP,
+			PlaceOfBirth:          e.Profile.PlaceOfBirth,
+			DateOfBirth:           formatOptionalDate(e.Profile.DateOfBirth),
+			Gender:                e.Profile.Gender,
+			MaritalStatus:         e.Profile.MaritalStatus,
+			Religion:              e.Profile.Religion,
+			AddressKTP:            e.Profile.AddressKTP,
+			AddressDomicile:       e.Profile.AddressDomicile,
+			EmergencyContactName:  e.Profile.EmergencyContactName,
+			EmergencyContactPhone: e.Profile.EmergencyContactPhone,
+		},
+		Financial: EmployeeFinancialResponse{
+			BankName:              e.Financial.BankName,
+			BankAccountNumber:     e.Financial.BankAccountNumber,
+			BankAccountHolder:     e.Financial.BankAccountHolder,
+			NPWP:                  e.Financial.NPWP,
+			PTKPStatus:            e.Financial.PTKPStatus,
+			BPJSKetenagakerjaanNo: e.Financial.BPJSKetenagakerjaanNo,
+			BPJSKesehatanNo:       e.Financial.BPJSKesehatanNo,
+			BasicSalary:           e.Financial.BasicSalary,
+		},
+		CustomFields: e.CustomFields,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+type EmployeeHandler struct {
+	usecase  *usecase.EmployeeUsecase
+	validate *validator.Validate
+}
+
+func NewEmployeeHandler(uc *usecase.EmployeeUsecase, v *validator.Validate) *EmployeeHandler {
+	return &EmployeeHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// List mendukung filter branch_id, department_id, position_id, job_level_id,
+// employment_status_id, sbu_id, manager_id, dan status (active/resigned).
+// Custom field bisa difilter dengan custom.<key>=nilai (number dan date juga
+// custom.<key>.min dan custom.<key>.max) dan diurutkan dengan
+// sort=custom.<key>:asc. Hasilnya mengikuti data scope hak akses
+// view:employees.
+func (h *EmployeeHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	paginationQuery := util.GetPaginationQuery(r)
+	if !validateEmployeeFilters(w, paginationQuery) {
+		return
+	}
+
+	employees, pagination, err := h.usecase.ListEmployees(r.Context(), tenantID, paginationQuery)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCustomFieldQuery) {
+			util.ErrorResponse(w, http.StatusBadRequest, "Filter tidak valid", err.Error())
+			return
+		}
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data karyawan", err.Error())
+		return
+	}
+
+	responses := make([]EmployeeResponse, len(employees))
+	for i, e := range employees {
+		responses[i] = newEmployeeResponse(e)
+	}
+
+	util.SuccessResponse(w, "Data karyawan berhasil diambil", ListEmployeesResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
+
+// validateEmployeeFilters memeriksa filter bawaan list karyawan. Filter
+// custom field divalidasi oleh usecase.
+func validateEmployeeFilters(w http.ResponseWriter, query util.PaginationQuery) bool {
+	for _, key := range repository.EmployeeFilterKeys {
+		if value, ok := query.Filters[key].(string); ok && value != "" {
+			if _, err := uuid.Parse(value); err != nil {
+				util.ErrorResponse(w, http.StatusBadRequest, "Filter tidak valid", key+" harus berupa UUID")
+				return false
+			}
+		}
+	}
+	if status, ok := query.Filters["status"].(string); ok && status != "" && status != "active" && status != "resigned" {
+		util.ErrorResponse(w, http.StatusBadRequest, "Filter tidak valid", "status harus active atau resigned")
+		return false
+	}
+	return true
+}
+
+func (h *EmployeeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	employee, err := h.usecase.GetEmployee(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data karyawan berhasil diambil", newEmployeeResponse(employee))
+}
+
+func (h *EmployeeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req CreateEmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+
+	input, ok := h.decodeEmployee(w, &req, &req.EmployeeRequest)
+	if !ok {
+		return
+	}
+	input.Email = req.Email
+
+	employee, err := h.usecase.CreateEmployee(r.Context(), tenantID, input)
+	if err != nil {
+		if writeEmployeeLimitError(w, err) {
+			return
+		}
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Karyawan berhasil dibuat", newEmployeeResponse(employee))
+}
+
+func (h *EmployeeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	var req EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeEmployee(w, &req, &req)
+	if !ok {
+		return
+	}
+
+	employee, err := h.usecase.UpdateEmployee(r.Context(), tenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Karyawan berhasil diperbarui", newEmployeeResponse(employee))
+}
+
+func (h *EmployeeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.DeleteEmployee(r.Context(), tenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Karyawan berhasil dihapus", nil)
+}
+
+// decodeEmployee memvalidasi 'v' (request lengkap) lalu mengubah 'req' menjadi
+// input usecase. String kosong pada field opsional disimpan sebagai NULL.
+// NIK, NPWP, dan nomor BPJS disimpan tanpa titik, tanda hubung, dan spasi.
+func (h *EmployeeHandler) decodeEmployee(w http.ResponseWriter, v any, req *EmployeeRequest) (usecase.EmployeeInput, bool) {
+	req.EmployeeIDNumber = strings.TrimSpace(req.EmployeeIDNumber)
+	req.Profile.FullName = strings.TrimSpace(req.Profile.FullName)
+	req.Profile.NIKKTP = normalizeIDNumber(req.Profile.NIKKTP)
+	req.Financial.NPWP = normalizeIDNumber(req.Financial.NPWP)
+	req.Financial.BPJSKetenagakerjaanNo = normalizeIDNumber(req.Financial.BPJSKetenagakerjaanNo)
+	req.Financial.BPJSKesehatanNo = normalizeIDNumber(req.Financial.BPJSKesehatanNo)
+
+	if err := h.validate.Struct(v); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.EmployeeInput{}, false
+	}
+
+	joinDate, _ := time.Parse(dateLayout, req.JoinDate)
+
+	var dateOfBirth *time.Time
+	if req.Profile.DateOfBirth != nil && *req.Profile.DateOfBirth != "" {
+		t, _ := time.Parse(dateLayout, *req.Profile.DateOfBirth)
+		if !t.Before(joinDate) {
+			util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", "tanggal lahir harus sebelum tanggal bergabung")
+			return usecase.EmployeeInput{}, false
+		}
+		dateOfBirth = &t
+	}
+
+	return usecase.EmployeeInput{
+		EmployeeIDNumber:   req.EmployeeIDNumber,
+		JoinDate:           joinDate,
+		BranchID:           optionalUUID(req.BranchID),
+		DepartmentID:       optionalUUID(req.DepartmentID),
+		PositionID:         optionalUUID(req.PositionID),
+		JobLevelID:         optionalUUID(req.JobLevelID),
+		EmploymentStatusID: optionalUUID(req.EmploymentStatusID),
+		SBUID:              optionalUUID(req.SBUID),
+		ManagerID:          optionalUUID(req.ManagerID),
+		Profile: entity.EmployeeProfile{
+			FullName:              req.Profile.FullName,
+			NIKKTP:                trimOptional(req.Profile.NIKKTP),
+			PlaceOfBirth:          trimOptional(req.Profile.PlaceOfBirth),
+			DateOfBirth:           dateOfBirth,
+			Gender:                trimOptional(req.Profile.Gender),
+			MaritalStatus:         trimOptional(req.Profile.MaritalStatus),
+			Religion:              trimOptional(req.Profile.Religion),
+			AddressKTP:            trimOptional(req.Profile.AddressKTP),
+			AddressDomicile:       trimOptional(req.Profile.AddressDomicile),
+			EmergencyContactName:  trimOptional(req.Profile.EmergencyContactName),
+			EmergencyContactPhone: trimOptional(req.Profile.EmergencyContactPhone),
+		},
+		Financial: entity.EmployeeFinancial{
+			BankName:              trimOptional(req.Financial.BankName),
+			BankAccountNumber:     trimOptional(req.Financial.BankAccountNumber),
+			BankAccountHolder:     trimOptional(req.Financial.BankAccountHolder),
+			NPWP:                  trimOptional(req.Financial.NPWP),
+			PTKPStatus:            trimOptional(req.Financial.PTKPStatus),
+			BPJSKetenagakerjaanNo: trimOptional(req.Financial.BPJSKetenagakerjaanNo),
+			BPJSKesehatanNo:       trimOptional(req.Financial.BPJSKesehatanNo),
+			BasicSalary:           req.Financial.BasicSalary,
+		},
+		CustomFields: req.CustomFields,
+	}, true
+}
+
+func trimOptional(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func normalizeIDNumber(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	normalized := util.NormalizeIDNumber(*s)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// optionalUUID mengubah ID opsional yang sudah lolos validasi "uuid".
+func optionalUUID(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id, err := uuid.Parse(*s)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
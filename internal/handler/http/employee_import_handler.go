@@ -0,0 +1,249 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeImportResponse struct {
+	ID            uuid.UUID         `json:"id"`
+	FileName      string            `json:"file_name"`
+	FileFormat    string            `json:"file_format"`
+	Mapping       map[string]string `json:"mapping"`
+	DryRun        bool              `json:"dry_run"`
+	Status        string            `json:"status"`
+	TotalRows     int               `json:"total_rows"`
+	ProcessedRows int               `json:"processed_rows"`
+	SuccessRows   int               `json:"success_rows"`
+	FailedRows    int               `json:"failed_rows"`
+	Progress      float64           `json:"progress"`
+	ErrorMessage  *string           `json:"error_message"`
+	CreatedBy     *uuid.UUID        `json:"created_by"`
+	StartedAt     *time.Time        `json:"started_at"`
+	FinishedAt    *time.Time        `json:"finished_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type EmployeeImportRowResponse struct {
+	RowNumber  int               `json:"row_number"`
+	Status     string            `json:"status"`
+	Data       map[string]string `json:"data"`
+	Errors     []string          `json:"errors"`
+	EmployeeID *uuid.UUID        `json:"employee_id"`
+}
+
+type ListEmployeeImportsResponse struct {
+	Data       []EmployeeImportResponse `json:"data"`
+	Pagination util.Pagination          `json:"pagination"`
+}
+
+type ListEmployeeImportRowsResponse struct {
+	Data       []EmployeeImportRowResponse `json:"data"`
+	Pagination util.Pagination             `json:"pagination"`
+}
+
+func newEmployeeImportResponse(i *entity.EmployeeImport) EmployeeImportResponse {
+	return EmployeeImportResponse{
+		ID:            i.ID,
+		FileName:      i.FileName,
+		FileFormat:    string(i.Format),
+		Mapping:       i.Mapping,
+		DryRun:        i.DryRun,
+		Status:        string(i.Status),
+		TotalRows:     i.TotalRows,
+		ProcessedRows: i.ProcessedRows,
+		SuccessRows:   i.SuccessRows,
+		FailedRows:    i.FailedRows,
+		Progress:      i.Progress(),
+		ErrorMessage:  i.ErrorMessage,
+		CreatedBy:     i.CreatedBy,
+		StartedAt:     i.StartedAt,
+		FinishedAt:    i.FinishedAt,
+		CreatedAt:     i.CreatedAt,
+		UpdatedAt:     i.UpdatedAt,
+	}
+}
+
+type EmployeeImportHandler struct {
+	usecase *usecase.EmployeeImportUsecase
+}
+
+func NewEmployeeImportHandler(uc *usecase.EmployeeImportUsecase) *EmployeeImportHandler {
+	return &EmployeeImportHandler{
+		usecase: uc,
+	}
+}
+
+// Fields mengembalikan daftar field yang bisa dipakai sebagai tujuan mapping,
+// termasuk custom field karyawan ("custom.<key>").
+func (h *EmployeeImportHandler) Fields(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	fields, _, err := h.usecase.ImportFields(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil field impor karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Field impor karyawan berhasil diambil", fields)
+}
+
+// Create menerima multipart/form-data dengan field:
+//   - file: file CSV atau XLSX, baris pertama adalah judul kolom
+//   - mapping (opsional): JSON {"Judul Kolom": "field_impor"}; tanpa mapping,
+//     kolom dicocokkan otomatis dari judulnya
+//   - dry_run (opsional): "true" untuk validasi saja tanpa menyimpan
+func (h *EmployeeImportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	// Sisa 1 MB untuk field form selain file.
+	r.Body = http.MaxBytesReader(w, r.Body, usecase.EmployeeImportMaxFileSize+1<<20)
+	if err := r.ParseMultipartForm(usecase.EmployeeImportMaxFileSize); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			util.ErrorResponse(w, http.StatusRequestEntityTooLarge, "File terlalu besar", "ukuran file maksimal 10 MB")
+			return
+		}
+		util.ErrorResponse(w, http.StatusBadRequest, "Request tidak valid", err.Error())
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "File wajib diunggah", err.Error())
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membaca file", err.Error())
+		return
+	}
+
+	input := usecase.EmployeeImportInput{
+		FileName: header.Filename,
+		Content:  content,
+	}
+
+	if raw := r.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &input.Mapping); err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Mapping tidak valid", "mapping harus berupa JSON {\"Judul Kolom\": \"field\"}")
+			return
+		}
+	}
+
+	if raw := r.FormValue("dry_run"); raw != "" {
+		if input.DryRun, err = strconv.ParseBool(raw); err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter dry_run tidak valid", "dry_run harus true atau false")
+			return
+		}
+	}
+
+	if claims, ok := security.TenantClaimsFromContext(r.Context()); ok {
+		input.CreatedBy = &claims.UserID
+	}
+
+	job, err := h.usecase.CreateImport(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memproses file impor", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "File impor diterima dan sedang diproses", newEmployeeImportResponse(job))
+}
+
+func (h *EmployeeImportHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	paginationQuery := util.GetPaginationQuery(r)
+
+	jobs, pagination, err := h.usecase.ListImports(r.Context(), tenantID, paginationQuery)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data impor karyawan", err.Error())
+		return
+	}
+
+	responses := make([]EmployeeImportResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = newEmployeeImportResponse(job)
+	}
+
+	util.SuccessResponse(w, "Data impor karyawan berhasil diambil", ListEmployeeImportsResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
+
+// GetByID dipakai untuk memantau progres impor.
+func (h *EmployeeImportHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID impor tidak valid", err.Error())
+		return
+	}
+
+	job, err := h.usecase.GetImport(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Impor karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data impor karyawan berhasil diambil", newEmployeeImportResponse(job))
+}
+
+// ListRows mengembalikan laporan validasi per baris, difilter dengan
+// ?status=pending|valid|invalid|imported|failed.
+func (h *EmployeeImportHandler) ListRows(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID impor tidak valid", err.Error())
+		return
+	}
+
+	status := entity.ImportRowStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", entity.ImportRowPending, entity.ImportRowValid, entity.ImportRowInvalid, entity.ImportRowImported, entity.ImportRowFailed:
+	default:
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter status tidak valid", "status harus pending, valid, invalid, imported, atau failed")
+		return
+	}
+
+	rows, pagination, err := h.usecase.ListImportRows(r.Context(), tenantID, id, status, util.GetPaginationQuery(r))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Impor karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	responses := make([]EmployeeImportRowResponse, len(rows))
+	for i, row := range rows {
+		responses[i] = EmployeeImportRowResponse{
+			RowNumber:  row.RowNumber,
+			Status:     string(row.Status),
+			Data:       row.Data,
+			Errors:     row.Errors,
+			EmployeeID: row.EmployeeID,
+		}
+	}
+
+	util.SuccessResponse(w, "Laporan impor karyawan berhasil diambil", ListEmployeeImportRowsResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeLimitHandler struct {
+	usecase *usecase.EmployeeLimitUsecase
+}
+
+func NewEmployeeLimitHandler(uc *usecase.EmployeeLimitUsecase) *EmployeeLimitHandler {
+	return &EmployeeLimitHandler{
+		usecase: uc,
+	}
+}
+
+type EmployeeSeatUsageResponse struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	TenantName      string     `json:"tenant_name"`
+	PlanID          *uuid.UUID `json:"plan_id"`
+	PlanName        *string    `json:"plan_name"`
+	PlanLimit       int        `json:"plan_limit"`
+	AddonSeats      int        `json:"addon_seats"`
+	Capacity        int        `json:"capacity"`
+	ActiveEmployees int        `json:"active_employees"`
+	Remaining       int        `json:"remaining"`
+	UsagePercent    float64    `json:"usage_percent"`
+	OverLimit       bool       `json:"over_limit"`
+}
+
+type EmployeeLimitErrorResponse struct {
+	Capacity        int    `json:"capacity"`
+	ActiveEmployees int    `json:"active_employees"`
+	Requested       int    `json:"requested"`
+	UpgradeHint     string `json:"upgrade_hint"`
+}
+
+func newEmployeeSeatUsageResponse(u *entity.EmployeeSeatUsage) EmployeeSeatUsageResponse {
+	return EmployeeSeatUsageResponse{
+		TenantID:        u.TenantID,
+		TenantName:      u.TenantName,
+		PlanID:          u.PlanID,
+		PlanName:        u.PlanName,
+		PlanLimit:       u.PlanLimit,
+		AddonSeats:      u.AddonSeats,
+		Capacity:        u.Capacity(),
+		ActiveEmployees: u.ActiveEmployees,
+		Remaining:       u.Remaining(),
+		UsagePercent:    float64(int(u.UsageRatio()*10000)) / 100,
+		OverLimit:       u.Remaining() < 0,
+	}
+}
+
+// writeEmployeeLimitError menulis respons 402 jika err adalah EmployeeLimitError.
+// Dipakai oleh handler yang menambah karyawan (create, import, reaktivasi).
+func writeEmployeeLimitError(w http.ResponseWriter, err error) bool {
+	var limitErr *usecase.EmployeeLimitError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	util.ErrorResponseWithData(w, http.StatusPaymentRequired, "Batas karyawan paket tercapai", limitErr.Error(), EmployeeLimitErrorResponse{
+		Capacity:        limitErr.Capacity,
+		ActiveEmployees: limitErr.ActiveEmployees,
+		Requested:       limitErr.Requested,
+		UpgradeHint:     limitErr.UpgradeHint,
+	})
+	return true
+}
+
+// Report menampilkan tenant yang mendekati atau melewati kuota karyawan.
+// Query param 'threshold' dalam persen (default 80).
+func (h *EmployeeLimitHandler) Report(w http.ResponseWriter, r *http.Request) {
+	threshold := 80.0
+	if v := r.URL.Query().Get("threshold"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			util.ErrorResponse(w, http.StatusBadRequest, "Threshold tidak valid", "threshold harus angka persen >= 0")
+			return
+		}
+		threshold = parsed
+	}
+
+	usages, err := h.usecase.ReportNearLimit(r.Context(), threshold/100)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil laporan kuota karyawan", err.Error())
+		return
+	}
+
+	responses := make([]EmployeeSeatUsageResponse, len(usages))
+	for i, u := range usages {
+		responses[i] = newEmployeeSeatUsageResponse(u)
+	}
+
+	util.SuccessResponse(w, "Laporan kuota karyawan berhasil diambil", responses)
+}
+
+// GetTenantUsage menampilkan pemakaian kuota satu tenant (superadmin).
+func (h *EmployeeLimitHandler) GetTenantUsage(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	usage, err := h.usecase.GetUsage(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Tenant tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Kuota karyawan berhasil diambil", newEmployeeSeatUsageResponse(usage))
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeNumberSchemeRequest struct {
+	// Template e.g., "{BRANCH}-{YYYY}-{SEQ:4}".
+	Template       string `json:"template" validate:"required,max=100"`
+	ResetYearly    bool   `json:"reset_yearly"`
+	ResetPerBranch bool   `json:"reset_per_branch"`
+}
+
+// EmployeeNumberPreviewRequest memakai format tersimpan jika template
+// kosong, atau format di request untuk mencoba format baru.
+type EmployeeNumberPreviewRequest struct {
+	Template       string  `json:"template" validate:"omitempty,max=100"`
+	ResetYearly    bool    `json:"reset_yearly"`
+	ResetPerBranch bool    `json:"reset_per_branch"`
+	BranchID       *string `json:"branch_id" validate:"omitempty,uuid"`
+	JoinDate       *string `json:"join_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type EmployeeNumberSchemeResponse struct {
+	Template       string    `json:"template"`
+	ResetYearly    bool      `json:"reset_yearly"`
+	ResetPerBranch bool      `json:"reset_per_branch"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type EmployeeNumberPreviewResponse struct {
+	EmployeeIDNumber string `json:"employee_id_number"`
+	Sequence         int64  `json:"sequence"`
+}
+
+func newEmployeeNumberSchemeResponse(s *entity.EmployeeNumberScheme) EmployeeNumberSchemeResponse {
+	return EmployeeNumberSchemeResponse{
+		Template:       s.Template,
+		ResetYearly:    s.ResetYearly,
+		ResetPerBranch: s.ResetPerBranch,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+type EmployeeNumberHandler struct {
+	usecase  *usecase.EmployeeNumberUsecase
+	validate *validator.Validate
+}
+
+func NewEmployeeNumberHandler(uc *usecase.EmployeeNumberUsecase, v *validator.Validate) *EmployeeNumberHandler {
+	return &EmployeeNumberHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+func (h *EmployeeNumberHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	scheme, err := h.usecase.GetScheme(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil format nomor induk karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Format nomor induk karyawan berhasil diambil", newEmployeeNumberSchemeResponse(scheme))
+}
+
+func (h *EmployeeNumberHandler) Save(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req EmployeeNumberSchemeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Template = strings.TrimSpace(req.Template)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	scheme, err := h.usecase.SaveScheme(r.Context(), tenantID, usecase.EmployeeNumberSchemeInput{
+		Template:       req.Template,
+		ResetYearly:    req.ResetYearly,
+		ResetPerBranch: req.ResetPerBranch,
+	})
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menyimpan format nomor induk karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Format nomor induk karyawan berhasil disimpan", newEmployeeNumberSchemeResponse(scheme))
+}
+
+// Preview menampilkan nomor induk berikutnya untuk cabang dan tanggal
+// bergabung di request, tanpa memakai nomor urutnya.
+func (h *EmployeeNumberHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req EmployeeNumberPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Template = strings.TrimSpace(req.Template)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.EmployeeNumberPreviewInput{
+		BranchID: optionalUUID(req.BranchID),
+	}
+	if req.Template != "" {
+		input.Scheme = &usecase.EmployeeNumberSchemeInput{
+			Template:       req.Template,
+			ResetYearly:    req.ResetYearly,
+			ResetPerBranch: req.ResetPerBranch,
+		}
+	}
+	if req.JoinDate != nil && *req.JoinDate != "" {
+		input.JoinDate, _ = time.Parse(dateLayout, *req.JoinDate)
+	}
+
+	preview, err := h.usecase.Preview(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat contoh nomor induk karyawan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Contoh nomor induk karyawan berhasil dibuat", EmployeeNumberPreviewResponse{
+		EmployeeIDNumber: preview.Number,
+		Sequence:         preview.Sequence,
+	})
+}
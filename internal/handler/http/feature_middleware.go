@@ -0,0 +1,49 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type FeatureMiddleware struct {
+	entitlements *usecase.EntitlementUsecase
+}
+
+func NewFeatureMiddleware(uc *usecase.EntitlementUsecase) *FeatureMiddleware {
+	return &FeatureMiddleware{
+		entitlements: uc,
+	}
+}
+
+// RequireFeature mengunci satu grup route agar hanya bisa diakses tenant yang
+// paketnya (atau addon-nya) mencakup fitur 'featureSlug'.
+// Harus dipasang setelah TenantResolver.
+//
+//	r.With(featureMW.RequireFeature("payroll")).Route("/payroll", ...)
+func (m *FeatureMiddleware) RequireFeature(featureSlug string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID, ok := security.TenantIDFromContext(r.Context())
+			if !ok {
+				util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+				return
+			}
+
+			allowed, err := m.entitlements.HasFeature(r.Context(), tenantID, featureSlug)
+			if err != nil {
+				util.ErrorResponse(w, http.StatusInternalServerError, "Gagal memeriksa fitur", err.Error())
+				return
+			}
+
+			if !allowed {
+				util.ErrorResponse(w, http.StatusForbidden, "Fitur tidak tersedia", "Paket langganan Anda tidak mencakup fitur '"+featureSlug+"'")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
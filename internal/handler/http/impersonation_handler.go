@@ -0,0 +1,246 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type StartImpersonationRequest struct {
+	UserID          string `json:"user_id" validate:"required,uuid"`
+	Reason          string `json:"reason" validate:"required,min=5"`
+	DurationMinutes int    `json:"duration_minutes" validate:"omitempty,min=1,max=60"`
+	// ReadOnly default true: request tulis (POST/PUT/PATCH/DELETE) diblokir.
+	ReadOnly *bool `json:"read_only"`
+}
+
+type ImpersonationHandler struct {
+	usecase  *usecase.ImpersonationUsecase
+	validate *validator.Validate
+}
+
+func NewImpersonationHandler(uc *usecase.ImpersonationUsecase, v *validator.Validate) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+type ImpersonationSessionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	AdminUserID uuid.UUID  `json:"admin_user_id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Reason      string     `json:"reason"`
+	ReadOnly    bool       `json:"read_only"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	EndedAt     *time.Time `json:"ended_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type StartImpersonationResponse struct {
+	Token   string                       `json:"token"`
+	Session ImpersonationSessionResponse `json:"session"`
+	User    TenantUserResponse           `json:"user"`
+}
+
+type AuditLogResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     *uuid.UUID `json:"user_id"`
+	Method     string     `json:"method"`
+	Path       string     `json:"path"`
+	StatusCode int        `json:"status_code"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func newImpersonationSessionResponse(s *entity.ImpersonationSession) ImpersonationSessionResponse {
+	return ImpersonationSessionResponse{
+		ID:          s.ID,
+		AdminUserID: s.AdminUserID,
+		TenantID:    s.TenantID,
+		UserID:      s.UserID,
+		Reason:      s.Reason,
+		ReadOnly:    s.ReadOnly,
+		ExpiresAt:   s.ExpiresAt,
+		EndedAt:     s.EndedAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func (h *ImpersonationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID)
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Admin tidak ditemukan di context")
+		return
+	}
+
+	var req StartImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	readOnly := true
+	if req.ReadOnly != nil {
+		readOnly = *req.ReadOnly
+	}
+
+	result, err := h.usecase.Start(r.Context(), adminID, tenantID, usecase.StartImpersonationInput{
+		UserID:   uuid.MustParse(req.UserID),
+		Reason:   req.Reason,
+		Duration: time.Duration(req.DurationMinutes) * time.Minute,
+		ReadOnly: readOnly,
+	})
+	if errors.Is(err, usecase.ErrImpersonationForbidden) {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memulai impersonasi", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Sesi impersonasi dimulai", StartImpersonationResponse{
+		Token:   result.Token,
+		Session: newImpersonationSessionResponse(result.Session),
+		User:    newTenantUserResponse(result.User, nil, nil),
+	})
+}
+
+func (h *ImpersonationHandler) End(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID sesi tidak valid", err.Error())
+		return
+	}
+
+	adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID)
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Admin tidak ditemukan di context")
+		return
+	}
+
+	session, err := h.usecase.End(r.Context(), adminID, sessionID)
+	if errors.Is(err, usecase.ErrImpersonationForbidden) {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Sesi impersonasi tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Sesi impersonasi diakhiri", newImpersonationSessionResponse(session))
+}
+
+func (h *ImpersonationHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID sesi tidak valid", err.Error())
+		return
+	}
+
+	adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID)
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Admin tidak ditemukan di context")
+		return
+	}
+
+	logs, err := h.usecase.ListAuditLogs(r.Context(), adminID, sessionID)
+	if errors.Is(err, usecase.ErrImpersonationForbidden) {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Sesi impersonasi tidak ditemukan", err.Error())
+		return
+	}
+
+	responses := make([]AuditLogResponse, len(logs))
+	for i, l := range logs {
+		responses[i] = AuditLogResponse{
+			ID:         l.ID,
+			UserID:     l.UserID,
+			Method:     l.Method,
+			Path:       l.Path,
+			StatusCode: l.StatusCode,
+			IPAddress:  l.IPAddress,
+			UserAgent:  l.UserAgent,
+			CreatedAt:  l.CreatedAt,
+		}
+	}
+
+	util.SuccessResponse(w, "Audit log impersonasi berhasil diambil", responses)
+}
+
+// Middleware memeriksa token impersonasi di route tenant. Token biasa
+// diteruskan apa adanya. Untuk token impersonasi: sesi harus masih aktif,
+// respons diberi header X-Impersonation, request tulis diblokir pada sesi
+// read-only, dan setiap request dicatat ke audit log.
+// Harus dipasang tepat setelah TenantAuthMiddleware.
+func (h *ImpersonationHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := security.TenantClaimsFromContext(r.Context())
+		if !ok || !claims.IsImpersonated() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		session, err := h.usecase.Authorize(r.Context(), claims)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", err.Error())
+			return
+		}
+
+		w.Header().Set("X-Impersonation", "true")
+		w.Header().Set("X-Impersonator-ID", session.AdminUserID.String())
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if err := h.usecase.RecordAction(r.Context(), session, r.Method, r.URL.RequestURI(), status, r.RemoteAddr, r.UserAgent()); err != nil {
+				log.Printf("gagal mencatat audit log impersonasi %s: %v", session.ID, err)
+			}
+		}()
+
+		if session.ReadOnly && !isSafeMethod(r.Method) {
+			util.ErrorResponse(ww, http.StatusForbidden, "Akses ditolak", "Sesi impersonasi hanya boleh membaca data")
+			return
+		}
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type InviteUserRequest struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type BulkInviteRequest struct {
+	// UserIDs kosong berarti undang semua user yang belum terverifikasi.
+	UserIDs []string `json:"user_ids" validate:"omitempty,max=500,dive,uuid"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,password_policy,no_consecutive_spaces"`
+}
+
+type InvitationHandler struct {
+	usecase  *usecase.InvitationUsecase
+	validate *validator.Validate
+}
+
+func NewInvitationHandler(uc *usecase.InvitationUsecase, v *validator.Validate) *InvitationHandler {
+	return &InvitationHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+type InvitationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BulkInviteResultResponse struct {
+	UserID     uuid.UUID           `json:"user_id"`
+	Email      string              `json:"email,omitempty"`
+	Invitation *InvitationResponse `json:"invitation,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+type BulkInviteResponse struct {
+	Sent    int                        `json:"sent"`
+	Failed  int                        `json:"failed"`
+	Results []BulkInviteResultResponse `json:"results"`
+}
+
+func newInvitationResponse(inv *entity.UserInvitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        inv.ID,
+		UserID:    inv.UserID,
+		Email:     inv.Email,
+		ExpiresAt: inv.ExpiresAt,
+		CreatedAt: inv.CreatedAt,
+	}
+}
+
+// writeInvitationResult menangani kasus undangan tersimpan tetapi email gagal
+// terkirim: data undangan tetap dikembalikan supaya bisa dikirim ulang.
+func writeInvitationResult(w http.ResponseWriter, message string, invitation *entity.UserInvitation, err error) {
+	if err != nil && invitation != nil {
+		util.ErrorResponseWithData(w, http.StatusBadGateway, "Email undangan gagal dikirim", err.Error(), newInvitationResponse(invitation))
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengirim undangan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, message, newInvitationResponse(invitation))
+}
+
+func (h *InvitationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	var req InviteUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	invitation, err := h.usecase.Invite(r.Context(), claims.TenantID, usecase.InviteUserInput{
+		Name:      req.Name,
+		Email:     req.Email,
+		InvitedBy: claims.UserID,
+	})
+
+	writeInvitationResult(w, "Undangan berhasil dikirim", invitation, err)
+}
+
+func (h *InvitationHandler) BulkInvite(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	var req BulkInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(req.UserIDs))
+	for i, id := range req.UserIDs {
+		userIDs[i] = uuid.MustParse(id)
+	}
+
+	results, err := h.usecase.InviteUsers(r.Context(), claims.TenantID, claims.UserID, userIDs)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengirim undangan", err.Error())
+		return
+	}
+
+	response := BulkInviteResponse{Results: make([]BulkInviteResultResponse, len(results))}
+	for i, result := range results {
+		item := BulkInviteResultResponse{
+			UserID: result.UserID,
+			Email:  result.Email,
+		}
+		if result.Invitation != nil {
+			invitation := newInvitationResponse(result.Invitation)
+			item.Invitation = &invitation
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+			response.Failed++
+		} else {
+			response.Sent++
+		}
+		response.Results[i] = item
+	}
+
+	util.SuccessResponse(w, "Undangan massal diproses", response)
+}
+
+func (h *InvitationHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID undangan tidak valid", err.Error())
+		return
+	}
+
+	invitation, err := h.usecase.Resend(r.Context(), claims.TenantID, id, claims.UserID)
+
+	writeInvitationResult(w, "Undangan berhasil dikirim ulang", invitation, err)
+}
+
+// Verify dipanggil dari halaman undangan (tanpa login) untuk memeriksa token.
+func (h *InvitationHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	invitation, err := h.usecase.GetPendingInvitation(r.Context(), tenantID, r.URL.Query().Get("token"))
+	if err != nil {
+		writeInvitationTokenError(w, err)
+		return
+	}
+
+	util.SuccessResponse(w, "Undangan valid", newInvitationResponse(invitation))
+}
+
+// Accept menyimpan password sekaligus memverifikasi email user.
+func (h *InvitationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	if err := h.usecase.Accept(r.Context(), tenantID, req.Token, req.Password); err != nil {
+		writeInvitationTokenError(w, err)
+		return
+	}
+
+	util.SuccessResponse(w, "Undangan diterima, silakan login", nil)
+}
+
+func writeInvitationTokenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, security.ErrSignedTokenExpired) {
+		util.ErrorResponse(w, http.StatusGone, "Undangan sudah kedaluwarsa", err.Error())
+		return
+	}
+	util.ErrorResponse(w, http.StatusBadRequest, "Undangan tidak valid", err.Error())
+}
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type RecordPaymentRequest struct {
+	PaymentDate   *time.Time `json:"payment_date"`
+	AmountPaid    float64    `json:"amount_paid" validate:"required,gt=0"`
+	PaymentMethod string     `json:"payment_method" validate:"required,max=50"`
+	TransactionID string     `json:"transaction_id" validate:"max=255"`
+	Notes         string     `json:"notes"`
+}
+
+type InvoiceHandler struct {
+	usecase  *usecase.InvoiceUsecase
+	validate *validator.Validate
+}
+
+func NewInvoiceHandler(uc *usecase.InvoiceUsecase, v *validator.Validate) *InvoiceHandler {
+	return &InvoiceHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+type InvoiceItemResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	TotalPrice  float64   `json:"total_price"`
+}
+
+type InvoiceResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	TenantID       uuid.UUID             `json:"tenant_id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	IssueDate      string                `json:"issue_date"`
+	DueDate        string                `json:"due_date"`
+	TotalAmount    float64               `json:"total_amount"`
+	Status         entity.StatusInvoice  `json:"status"`
+	Items          []InvoiceItemResponse `json:"items,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+type InvoicePaymentResponse struct {
+	ID            uuid.UUID `json:"id"`
+	InvoiceID     uuid.UUID `json:"invoice_id"`
+	PaymentDate   time.Time `json:"payment_date"`
+	AmountPaid    float64   `json:"amount_paid"`
+	PaymentMethod *string   `json:"payment_method"`
+	TransactionID *string   `json:"transaction_id"`
+	Notes         *string   `json:"notes"`
+}
+
+type ApplyCreditsResponse struct {
+	InvoiceID       uuid.UUID `json:"invoice_id"`
+	AppliedAmount   float64   `json:"applied_amount"`
+	RemainingCredit float64   `json:"remaining_credit"`
+}
+
+type ListInvoicesResponse struct {
+	Data       []InvoiceResponse `json:"data"`
+	Pagination util.Pagination   `json:"pagination"`
+}
+
+func newInvoiceResponse(invoice *entity.Invoice) InvoiceResponse {
+	items := make([]InvoiceItemResponse, len(invoice.Items))
+	for i, item := range invoice.Items {
+		items[i] = InvoiceItemResponse{
+			ID:          item.ID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		}
+	}
+
+	return InvoiceResponse{
+		ID:             invoice.ID,
+		TenantID:       invoice.TenantID,
+		SubscriptionID: invoice.SubscriptionID,
+		IssueDate:      invoice.IssueDate.Format("2006-01-02"),
+		DueDate:        invoice.DueDate.Format("2006-01-02"),
+		TotalAmount:    invoice.TotalAmount,
+		Status:         invoice.Status,
+		Items:          items,
+		CreatedAt:      invoice.CreatedAt,
+	}
+}
+
+func newInvoiceListResponse(invoices []*entity.Invoice) []InvoiceResponse {
+	responses := make([]InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		responses[i] = newInvoiceResponse(invoice)
+	}
+	return responses
+}
+
+func (h *InvoiceHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	paginationQuery := util.GetPaginationQuery(r)
+
+	invoices, pagination, err := h.usecase.ListTenantInvoices(r.Context(), tenantID, paginationQuery)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data invoice", err.Error())
+		return
+	}
+
+	response := ListInvoicesResponse{
+		Data:       newInvoiceListResponse(invoices),
+		Pagination: pagination,
+	}
+
+	util.SuccessResponse(w, "Data invoice berhasil diambil", response)
+}
+
+func (h *InvoiceHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	invoice, err := h.usecase.GetInvoice(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Invoice tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Invoice berhasil diambil", newInvoiceResponse(invoice))
+}
+
+func (h *InvoiceHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	var req RecordPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.RecordPaymentInput{
+		AmountPaid:    req.AmountPaid,
+		PaymentMethod: req.PaymentMethod,
+		TransactionID: req.TransactionID,
+		Notes:         req.Notes,
+	}
+	if req.PaymentDate != nil {
+		input.PaymentDate = *req.PaymentDate
+	}
+
+	payment, err := h.usecase.RecordPayment(r.Context(), id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mencatat pembayaran", err.Error())
+		return
+	}
+
+	response := InvoicePaymentResponse{
+		ID:            payment.ID,
+		InvoiceID:     payment.InvoiceID,
+		PaymentDate:   payment.PaymentDate,
+		AmountPaid:    payment.AmountPaid,
+		PaymentMethod: payment.PaymentMethod,
+		TransactionID: payment.TransactionID,
+		Notes:         payment.Notes,
+	}
+
+	util.SuccessResponse(w, "Pembayaran berhasil dicatat", response)
+}
+
+// ApplyCredits memakai saldo kredit tenant (dari prorata atau credit note)
+// untuk melunasi invoice.
+func (h *InvoiceHandler) ApplyCredits(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	applied, err := h.usecase.ApplyCredits(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memakai saldo kredit", err.Error())
+		return
+	}
+
+	invoice, err := h.usecase.GetInvoice(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil invoice", err.Error())
+		return
+	}
+
+	remaining, err := h.usecase.GetAvailableCredits(r.Context(), invoice.TenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil saldo kredit", err.Error())
+		return
+	}
+
+	response := ApplyCreditsResponse{
+		InvoiceID:       id,
+		AppliedAmount:   applied,
+		RemainingCredit: remaining,
+	}
+
+	util.SuccessResponse(w, "Saldo kredit berhasil dipakai", response)
+}
+
+func (h *InvoiceHandler) DownloadPDF(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "invoiceID"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID invoice tidak valid", err.Error())
+		return
+	}
+
+	invoice, pdf, err := h.usecase.GetInvoicePDF(r.Context(), id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Invoice tidak ditemukan", err.Error())
+		return
+	}
+
+	writePDF(w, fmt.Sprintf("invoice-%s.pdf", invoice.ID), pdf)
+}
+
+func writePDF(w http.ResponseWriter, filename string, content []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
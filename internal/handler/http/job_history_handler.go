@@ -0,0 +1,245 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// JobChangeRequest hanya perlu mengisi bagian yang berubah; field yang
+// kosong mengikuti posisi sebelum tanggal berlaku.
+type JobChangeRequest struct {
+	ChangeType         string   `json:"change_type" validate:"required,oneof=promotion demotion transfer mutation status_change salary_adjustment correction"`
+	EffectiveDate      string   `json:"effective_date" validate:"required,datetime=2006-01-02"`
+	Reason             string   `json:"reason" validate:"required,max=1000"`
+	DecreeNumber       *string  `json:"decree_number" validate:"omitempty,max=100"`
+	BranchID           *string  `json:"branch_id" validate:"omitempty,uuid"`
+	DepartmentID       *string  `json:"department_id" validate:"omitempty,uuid"`
+	PositionID         *string  `json:"position_id" validate:"omitempty,uuid"`
+	JobLevelID         *string  `json:"job_level_id" validate:"omitempty,uuid"`
+	EmploymentStatusID *string  `json:"employment_status_id" validate:"omitempty,uuid"`
+	SBUID              *string  `json:"sbu_id" validate:"omitempty,uuid"`
+	ManagerID          *string  `json:"manager_id" validate:"omitempty,uuid"`
+	BasicSalary        *float64 `json:"basic_salary" validate:"omitempty,min=0"`
+}
+
+type EmploymentResponse struct {
+	BranchID           *uuid.UUID `json:"branch_id"`
+	DepartmentID       *uuid.UUID `json:"department_id"`
+	PositionID         *uuid.UUID `json:"position_id"`
+	JobLevelID         *uuid.UUID `json:"job_level_id"`
+	EmploymentStatusID *uuid.UUID `json:"employment_status_id"`
+	SBUID              *uuid.UUID `json:"sbu_id"`
+	ManagerID          *uuid.UUID `json:"manager_id"`
+	BasicSalary        *float64   `json:"basic_salary"`
+}
+
+type JobHistoryResponse struct {
+	ID            uuid.UUID          `json:"id"`
+	EmployeeID    uuid.UUID          `json:"employee_id"`
+	ChangeType    string             `json:"change_type"`
+	EffectiveDate string             `json:"effective_date"`
+	Status        string             `json:"status"`
+	Employment    EmploymentResponse `json:"employment"`
+	Reason        *string            `json:"reason"`
+	DecreeNumber  *string            `json:"decree_number"`
+	FailureReason *string            `json:"failure_reason"`
+	CreatedBy     *uuid.UUID         `json:"created_by"`
+	AppliedAt     *time.Time         `json:"applied_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type EmploymentAsOfResponse struct {
+	Date       string             `json:"date"`
+	Employment EmploymentResponse `json:"employment"`
+	// Source adalah baris riwayat yang berlaku pada tanggal tersebut.
+	Source JobHistoryResponse `json:"source"`
+}
+
+func newEmploymentResponse(m entity.Employment) EmploymentResponse {
+	return EmploymentResponse{
+		BranchID:           m.BranchID,
+		DepartmentID:       m.DepartmentID,
+		PositionID:         m.PositionID,
+		JobLevelID:         m.JobLevelID,
+		EmploymentStatusID: m.EmploymentStatusID,
+		SBUID:              m.SBUID,
+		ManagerID:          m.ManagerID,
+		BasicSalary:        m.BasicSalary,
+	}
+}
+
+func newJobHistoryResponse(h *entity.EmployeeJobHistory) JobHistoryResponse {
+	return JobHistoryResponse{
+		ID:            h.ID,
+		EmployeeID:    h.EmployeeID,
+		ChangeType:    string(h.ChangeType),
+		EffectiveDate: h.EffectiveDate.Format(dateLayout),
+		Status:        string(h.Status),
+		Employment:    newEmploymentResponse(h.Employment),
+		Reason:        h.Reason,
+		DecreeNumber:  h.DecreeNumber,
+		FailureReason: h.FailureReason,
+		CreatedBy:     h.CreatedBy,
+		AppliedAt:     h.AppliedAt,
+		CreatedAt:     h.CreatedAt,
+	}
+}
+
+type JobHistoryHandler struct {
+	usecase  *usecase.JobHistoryUsecase
+	validate *validator.Validate
+}
+
+func NewJobHistoryHandler(uc *usecase.JobHistoryUsecase, v *validator.Validate) *JobHistoryHandler {
+	return &JobHistoryHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+func (h *JobHistoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	histories, err := h.usecase.ListJobHistories(r.Context(), tenantID, employeeID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	resp := make([]JobHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		resp = append(resp, newJobHistoryResponse(history))
+	}
+
+	util.SuccessResponse(w, "Riwayat jabatan berhasil diambil", resp)
+}
+
+// Create mencatat mutasi, promosi, demosi, atau perubahan status/gaji.
+// Perubahan dengan tanggal berlaku di masa depan berstatus scheduled.
+func (h *JobHistoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	var req JobChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak valid", err.Error())
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	effectiveDate, _ := time.Parse(dateLayout, req.EffectiveDate)
+
+	var createdBy *uuid.UUID
+	if claims, ok := security.TenantClaimsFromContext(r.Context()); ok {
+		createdBy = &claims.UserID
+	}
+
+	history, err := h.usecase.CreateJobChange(r.Context(), tenantID, employeeID, createdBy, usecase.JobChangeInput{
+		ChangeType:         entity.JobChangeType(req.ChangeType),
+		EffectiveDate:      effectiveDate,
+		Reason:             req.Reason,
+		DecreeNumber:       trimOptional(req.DecreeNumber),
+		BranchID:           optionalUUID(req.BranchID),
+		DepartmentID:       optionalUUID(req.DepartmentID),
+		PositionID:         optionalUUID(req.PositionID),
+		JobLevelID:         optionalUUID(req.JobLevelID),
+		EmploymentStatusID: optionalUUID(req.EmploymentStatusID),
+		SBUID:              optionalUUID(req.SBUID),
+		ManagerID:          optionalUUID(req.ManagerID),
+		BasicSalary:        req.BasicSalary,
+	})
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mencatat perubahan jabatan", err.Error())
+		return
+	}
+
+	message := "Perubahan jabatan berhasil diterapkan"
+	if history.Status == entity.JobChangeScheduled {
+		message = "Perubahan jabatan berhasil dijadwalkan"
+	}
+	util.SuccessResponse(w, message, newJobHistoryResponse(history))
+}
+
+// Cancel membatalkan perubahan jabatan yang masih terjadwal.
+func (h *JobHistoryHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+	historyID, err := uuid.Parse(chi.URLParam(r, "historyId"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID riwayat jabatan tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.CancelJobChange(r.Context(), tenantID, employeeID, historyID); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membatalkan perubahan jabatan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Perubahan jabatan berhasil dibatalkan", nil)
+}
+
+// EmploymentAsOf mengembalikan posisi karyawan pada ?date=YYYY-MM-DD
+// (default hari ini).
+func (h *JobHistoryHandler) EmploymentAsOf(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	date := time.Now()
+	if raw := r.URL.Query().Get("date"); raw != "" {
+		date, err = time.Parse(dateLayout, raw)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter date tidak valid", "format tanggal harus YYYY-MM-DD")
+			return
+		}
+	}
+
+	asOf, err := h.usecase.GetEmploymentAsOf(r.Context(), tenantID, employeeID, date)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Data jabatan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data jabatan berhasil diambil", EmploymentAsOfResponse{
+		Date:       asOf.Date.Format(dateLayout),
+		Employment: newEmploymentResponse(asOf.History.Employment),
+		Source:     newJobHistoryResponse(asOf.History),
+	})
+}
@@ -0,0 +1,489 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type ChecklistTemplateRequest struct {
+	Name  string   `json:"name" validate:"required,max=255"`
+	Type  string   `json:"type" validate:"required,oneof=onboarding offboarding"`
+	Tasks []string `json:"tasks" validate:"required,min=1,max=100,dive,max=255"`
+}
+
+type TerminationRequest struct {
+	TerminationType string `json:"termination_type" validate:"required,oneof=resignation dismissal layoff contract_end retirement death mutual_agreement"`
+	Reason          string `json:"reason" validate:"required,max=1000"`
+	LastWorkingDay  string `json:"last_working_day" validate:"required,datetime=2006-01-02"`
+	// EffectiveDate adalah tanggal login dinonaktifkan; default sehari
+	// setelah last_working_day.
+	EffectiveDate     *string `json:"effective_date" validate:"omitempty,datetime=2006-01-02"`
+	EligibleForRehire *bool   `json:"eligible_for_rehire"`
+	TemplateID        *string `json:"template_id" validate:"omitempty,uuid"`
+}
+
+type ChecklistTaskRequest struct {
+	Done *bool `json:"done" validate:"required"`
+}
+
+type ChecklistTemplateResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Tasks     []string  `json:"tasks"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ChecklistTaskResponse struct {
+	Title  string     `json:"title"`
+	Done   bool       `json:"done"`
+	DoneAt *time.Time `json:"done_at"`
+	DoneBy *uuid.UUID `json:"done_by"`
+}
+
+type EmployeeChecklistResponse struct {
+	ID         uuid.UUID               `json:"id"`
+	EmployeeID uuid.UUID               `json:"employee_id"`
+	TemplateID uuid.UUID               `json:"template_id"`
+	Status     string                  `json:"status"`
+	Tasks      []ChecklistTaskResponse `json:"tasks"`
+	UpdatedAt  time.Time               `json:"updated_at"`
+}
+
+type FinalPayItemResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	EmployeeID    uuid.UUID  `json:"employee_id"`
+	TerminationID uuid.UUID  `json:"termination_id"`
+	ItemType      string     `json:"item_type"`
+	SourceID      *uuid.UUID `json:"source_id"`
+	Description   string     `json:"description"`
+	Quantity      *float64   `json:"quantity"`
+	Amount        *float64   `json:"amount"`
+	Status        string     `json:"status"`
+	SettledAt     *time.Time `json:"settled_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type TerminationResponse struct {
+	ID                uuid.UUID                  `json:"id"`
+	EmployeeID        uuid.UUID                  `json:"employee_id"`
+	TerminationType   string                     `json:"termination_type"`
+	Reason            string                     `json:"reason"`
+	LastWorkingDay    string                     `json:"last_working_day"`
+	EffectiveDate     string                     `json:"effective_date"`
+	EligibleForRehire bool                       `json:"eligible_for_rehire"`
+	Status            string                     `json:"status"`
+	CreatedBy         *uuid.UUID                 `json:"created_by"`
+	CompletedAt       *time.Time                 `json:"completed_at"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	Checklist         *EmployeeChecklistResponse `json:"checklist"`
+	FinalPayItems     []FinalPayItemResponse     `json:"final_pay_items"`
+}
+
+func newChecklistTemplateResponse(t *entity.ChecklistTemplate) ChecklistTemplateResponse {
+	return ChecklistTemplateResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Type:      string(t.Type),
+		Tasks:     t.Tasks,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func newEmployeeChecklistResponse(c *entity.EmployeeChecklist) *EmployeeChecklistResponse {
+	if c == nil {
+		return nil
+	}
+
+	tasks := make([]ChecklistTaskResponse, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		tasks = append(tasks, ChecklistTaskResponse{Title: t.Title, Done: t.Done, DoneAt: t.DoneAt, DoneBy: t.DoneBy})
+	}
+
+	return &EmployeeChecklistResponse{
+		ID:         c.ID,
+		EmployeeID: c.EmployeeID,
+		TemplateID: c.TemplateID,
+		Status:     string(c.Status),
+		Tasks:      tasks,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func newFinalPayItemResponses(items []*entity.FinalPayItem) []FinalPayItemResponse {
+	resp := make([]FinalPayItemResponse, 0, len(items))
+	for _, i := range items {
+		resp = append(resp, FinalPayItemResponse{
+			ID:            i.ID,
+			EmployeeID:    i.EmployeeID,
+			TerminationID: i.TerminationID,
+			ItemType:      string(i.Type),
+			SourceID:      i.SourceID,
+			Description:   i.Description,
+			Quantity:      i.Quantity,
+			Amount:        i.Amount,
+			Status:        string(i.Status),
+			SettledAt:     i.SettledAt,
+			CreatedAt:     i.CreatedAt,
+		})
+	}
+	return resp
+}
+
+func newTerminationResponse(t *entity.EmployeeTermination) TerminationResponse {
+	return TerminationResponse{
+		ID:                t.ID,
+		EmployeeID:        t.EmployeeID,
+		TerminationType:   string(t.Type),
+		Reason:            t.Reason,
+		LastWorkingDay:    t.LastWorkingDay.Format(dateLayout),
+		EffectiveDate:     t.EffectiveDate.Format(dateLayout),
+		EligibleForRehire: t.EligibleForRehire,
+		Status:            string(t.Status),
+		CreatedBy:         t.CreatedBy,
+		CompletedAt:       t.CompletedAt,
+		CreatedAt:         t.CreatedAt,
+		Checklist:         newEmployeeChecklistResponse(t.Checklist),
+		FinalPayItems:     newFinalPayItemResponses(t.FinalPayItems),
+	}
+}
+
+type OffboardingHandler struct {
+	usecase  *usecase.OffboardingUsecase
+	validate *validator.Validate
+}
+
+func NewOffboardingHandler(uc *usecase.OffboardingUsecase, v *validator.Validate) *OffboardingHandler {
+	return &OffboardingHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// ------------------------------------------------------------------------
+// Template checklist
+
+func (h *OffboardingHandler) decodeTemplateRequest(w http.ResponseWriter, r *http.Request) (usecase.ChecklistTemplateInput, bool) {
+	var req ChecklistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak valid", err.Error())
+		return usecase.ChecklistTemplateInput{}, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.ChecklistTemplateInput{}, false
+	}
+
+	return usecase.ChecklistTemplateInput{
+		Name:  req.Name,
+		Type:  entity.ChecklistType(req.Type),
+		Tasks: req.Tasks,
+	}, true
+}
+
+// ListTemplates mengembalikan template checklist, difilter dengan
+// ?type=onboarding|offboarding.
+func (h *OffboardingHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	checklistType := entity.ChecklistType(r.URL.Query().Get("type"))
+	if checklistType != "" && checklistType != entity.ChecklistOnboarding && checklistType != entity.ChecklistOffboarding {
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter type tidak valid", "type harus onboarding atau offboarding")
+		return
+	}
+
+	templates, err := h.usecase.ListTemplates(r.Context(), tenantID, checklistType)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil template checklist", err.Error())
+		return
+	}
+
+	resp := make([]ChecklistTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, newChecklistTemplateResponse(t))
+	}
+
+	util.SuccessResponse(w, "Template checklist berhasil diambil", resp)
+}
+
+func (h *OffboardingHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID template tidak valid", err.Error())
+		return
+	}
+
+	template, err := h.usecase.GetTemplate(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Template checklist tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Template checklist berhasil diambil", newChecklistTemplateResponse(template))
+}
+
+func (h *OffboardingHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	input, ok := h.decodeTemplateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := h.usecase.CreateTemplate(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat template checklist", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Template checklist berhasil dibuat", newChecklistTemplateResponse(template))
+}
+
+// UpdateTemplate mengubah template. Checklist karyawan yang sudah dibuat
+// tidak ikut berubah.
+func (h *OffboardingHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID template tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeTemplateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := h.usecase.UpdateTemplate(r.Context(), tenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengubah template checklist", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Template checklist berhasil diubah", newChecklistTemplateResponse(template))
+}
+
+func (h *OffboardingHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID template tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.DeleteTemplate(r.Context(), tenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Template checklist tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Template checklist berhasil dihapus", nil)
+}
+
+// ------------------------------------------------------------------------
+// Pengakhiran hubungan kerja
+
+// Terminate mencatat pengakhiran hubungan kerja karyawan. Login dinonaktifkan
+// langsung jika effective_date sudah tiba, atau oleh job terjadwal.
+func (h *OffboardingHandler) Terminate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	var req TerminationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak valid", err.Error())
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	lastWorkingDay, _ := time.Parse(dateLayout, req.LastWorkingDay)
+
+	var effectiveDate *time.Time
+	if req.EffectiveDate != nil {
+		date, _ := time.Parse(dateLayout, *req.EffectiveDate)
+		effectiveDate = &date
+	}
+
+	eligibleForRehire := true
+	if req.EligibleForRehire != nil {
+		eligibleForRehire = *req.EligibleForRehire
+	}
+
+	var createdBy *uuid.UUID
+	if claims, ok := security.TenantClaimsFromContext(r.Context()); ok {
+		createdBy = &claims.UserID
+	}
+
+	termination, err := h.usecase.Terminate(r.Context(), tenantID, employeeID, createdBy, usecase.TerminationInput{
+		Type:              entity.TerminationType(req.TerminationType),
+		Reason:            req.Reason,
+		LastWorkingDay:    lastWorkingDay,
+		EffectiveDate:     effectiveDate,
+		EligibleForRehire: eligibleForRehire,
+		TemplateID:        optionalUUID(req.TemplateID),
+	})
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mencatat pengakhiran hubungan kerja", err.Error())
+		return
+	}
+
+	message := "Pengakhiran hubungan kerja berhasil dicatat dan login karyawan dinonaktifkan"
+	if termination.Status == entity.TerminationScheduled {
+		message = "Pengakhiran hubungan kerja berhasil dijadwalkan"
+	}
+	util.SuccessResponse(w, message, newTerminationResponse(termination))
+}
+
+func (h *OffboardingHandler) GetTermination(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	termination, err := h.usecase.GetTermination(r.Context(), tenantID, employeeID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Pengakhiran hubungan kerja tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Pengakhiran hubungan kerja berhasil diambil", newTerminationResponse(termination))
+}
+
+// CancelTermination membatalkan pengakhiran yang login karyawannya belum
+// dinonaktifkan.
+func (h *OffboardingHandler) CancelTermination(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.CancelTermination(r.Context(), tenantID, employeeID); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membatalkan pengakhiran hubungan kerja", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Pengakhiran hubungan kerja berhasil dibatalkan", nil)
+}
+
+// UpdateChecklistTask menandai tugas checklist ke-{index} (mulai dari 0)
+// selesai atau belum.
+func (h *OffboardingHandler) UpdateChecklistTask(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	employeeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+	checklistID, err := uuid.Parse(chi.URLParam(r, "checklistId"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID checklist tidak valid", err.Error())
+		return
+	}
+	index, err := strconv.Atoi(chi.URLParam(r, "index"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Nomor tugas tidak valid", err.Error())
+		return
+	}
+
+	var req ChecklistTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak valid", err.Error())
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	var doneBy *uuid.UUID
+	if claims, ok := security.TenantClaimsFromContext(r.Context()); ok {
+		doneBy = &claims.UserID
+	}
+
+	checklist, err := h.usecase.UpdateChecklistTask(r.Context(), tenantID, employeeID, checklistID, index, *req.Done, doneBy)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengubah tugas checklist", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Tugas checklist berhasil diubah", newEmployeeChecklistResponse(checklist))
+}
+
+// ------------------------------------------------------------------------
+// Komponen pembayaran akhir
+
+// ListFinalPayItems mengembalikan komponen pembayaran akhir untuk payroll,
+// difilter dengan ?status=pending|settled.
+func (h *OffboardingHandler) ListFinalPayItems(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	status := entity.FinalPayItemStatus(r.URL.Query().Get("status"))
+	if status != "" && status != entity.FinalPayPending && status != entity.FinalPaySettled {
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter status tidak valid", "status harus pending atau settled")
+		return
+	}
+
+	items, err := h.usecase.ListFinalPayItems(r.Context(), tenantID, status)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil komponen pembayaran akhir", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Komponen pembayaran akhir berhasil diambil", newFinalPayItemResponses(items))
+}
+
+func (h *OffboardingHandler) SettleFinalPayItem(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID komponen tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.SettleFinalPayItem(r.Context(), tenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menandai komponen pembayaran akhir", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Komponen pembayaran akhir berhasil ditandai selesai", nil)
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type OrgChartNodeResponse struct {
+	EmployeeID       uuid.UUID  `json:"employee_id"`
+	ManagerID        *uuid.UUID `json:"manager_id"`
+	FullName         string     `json:"full_name"`
+	EmployeeIDNumber string     `json:"employee_id_number"`
+	PositionName     *string    `json:"position_name"`
+	DepartmentName   *string    `json:"department_name"`
+	Level            int        `json:"level"`
+}
+
+// OrgChartTreeResponse adalah node bagan organisasi beserta bawahannya.
+type OrgChartTreeResponse struct {
+	OrgChartNodeResponse
+	TotalReports int                    `json:"total_reports"`
+	Reports      []OrgChartTreeResponse `json:"reports"`
+}
+
+func newOrgChartNodeResponse(n *entity.OrgChartNode) OrgChartNodeResponse {
+	return OrgChartNodeResponse{
+		EmployeeID:       n.EmployeeID,
+		ManagerID:        n.ManagerID,
+		FullName:         n.FullName,
+		EmployeeIDNumber: n.EmployeeIDNumber,
+		PositionName:     n.PositionName,
+		DepartmentName:   n.DepartmentName,
+		Level:            n.Level,
+	}
+}
+
+func newOrgChartNodeResponses(nodes []*entity.OrgChartNode) []OrgChartNodeResponse {
+	resp := make([]OrgChartNodeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		resp = append(resp, newOrgChartNodeResponse(n))
+	}
+	return resp
+}
+
+func newOrgChartTreeResponses(nodes []*entity.OrgChartNode) []OrgChartTreeResponse {
+	resp := make([]OrgChartTreeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		resp = append(resp, OrgChartTreeResponse{
+			OrgChartNodeResponse: newOrgChartNodeResponse(n),
+			TotalReports:         n.CountDescendants(),
+			Reports:              newOrgChartTreeResponses(n.Reports),
+		})
+	}
+	return resp
+}
+
+// ChainOfCommand mengembalikan atasan karyawan dari atasan langsung sampai
+// puncak garis pelaporan.
+func (h *EmployeeHandler) ChainOfCommand(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	nodes, err := h.usecase.GetChainOfCommand(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Garis atasan karyawan berhasil diambil", newOrgChartNodeResponses(nodes))
+}
+
+// Reports mengembalikan bawahan karyawan. ?type=direct (default) hanya
+// bawahan langsung, ?type=all termasuk bawahan tidak langsung.
+func (h *EmployeeHandler) Reports(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID karyawan tidak valid", err.Error())
+		return
+	}
+
+	reportType := r.URL.Query().Get("type")
+	if reportType != "" && reportType != "direct" && reportType != "all" {
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter type tidak valid", "type harus direct atau all")
+		return
+	}
+
+	nodes, err := h.usecase.GetReports(r.Context(), tenantID, id, reportType != "all")
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Bawahan karyawan berhasil diambil", newOrgChartNodeResponses(nodes))
+}
+
+// OrgChart mengembalikan bagan organisasi sebagai pohon JSON, atau sebagai
+// file Graphviz DOT / SVG dengan ?format=dot|svg. ?root= membatasi bagan
+// pada satu karyawan dan seluruh bawahannya.
+func (h *EmployeeHandler) OrgChart(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var root *uuid.UUID
+	if raw := r.URL.Query().Get("root"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Parameter root tidak valid", err.Error())
+			return
+		}
+		root = &id
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "json":
+		roots, err := h.usecase.GetOrgChart(r.Context(), tenantID, root)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+			return
+		}
+		util.SuccessResponse(w, "Bagan organisasi berhasil diambil", newOrgChartTreeResponses(roots))
+
+	case string(usecase.OrgChartFormatDOT), string(usecase.OrgChartFormatSVG):
+		content, err := h.usecase.ExportOrgChart(r.Context(), tenantID, root, usecase.OrgChartFormat(format))
+		if err != nil {
+			util.ErrorResponse(w, http.StatusNotFound, "Karyawan tidak ditemukan", err.Error())
+			return
+		}
+		contentType := "text/vnd.graphviz; charset=utf-8"
+		if format == string(usecase.OrgChartFormatSVG) {
+			contentType = "image/svg+xml"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "org-chart."+format))
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+
+	default:
+		util.ErrorResponse(w, http.StatusBadRequest, "Parameter format tidak valid", "format harus json, dot, atau svg")
+	}
+}
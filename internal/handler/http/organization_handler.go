@@ -0,0 +1,415 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type BranchRequest struct {
+	Name                   string   `json:"name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	Code                   *string  `json:"code" validate:"omitempty,max=20,alphanum"`
+	Address                *string  `json:"address"`
+	Latitude               *float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude              *float64 `json:"longitude" validate:"omitempty,longitude"`
+	AttendanceRadiusMeters *int     `json:"attendance_radius_meters" validate:"omitempty,min=0,max=10000"`
+}
+
+type OrgUnitRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	// Description hanya disimpan untuk SBU.
+	Description *string `json:"description"`
+}
+
+type BranchResponse struct {
+	ID                     uuid.UUID `json:"id"`
+	Name                   string    `json:"name"`
+	Code                   *string   `json:"code"`
+	Address                *string   `json:"address"`
+	Latitude               *float64  `json:"latitude"`
+	Longitude              *float64  `json:"longitude"`
+	AttendanceRadiusMeters int       `json:"attendance_radius_meters"`
+	ActiveEmployees        int       `json:"active_employees"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+type OrgUnitResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     *string   `json:"description,omitempty"`
+	ActiveEmployees int       `json:"active_employees"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ListBranchesResponse struct {
+	Data       []BranchResponse `json:"data"`
+	Pagination util.Pagination  `json:"pagination"`
+}
+
+type ListOrgUnitsResponse struct {
+	Data       []OrgUnitResponse `json:"data"`
+	Pagination util.Pagination   `json:"pagination"`
+}
+
+type OrgDeleteResponse struct {
+	ReassignedEmployees int64 `json:"reassigned_employees"`
+}
+
+type OrgUnitInUseResponse struct {
+	ActiveEmployees int64 `json:"active_employees"`
+}
+
+func newBranchResponse(b *entity.Branch) BranchResponse {
+	return BranchResponse{
+		ID:                     b.ID,
+		Name:                   b.Name,
+		Code:                   b.Code,
+		Address:                b.Address,
+		Latitude:               b.Latitude,
+		Longitude:              b.Longitude,
+		AttendanceRadiusMeters: b.AttendanceRadiusMeters,
+		ActiveEmployees:        b.ActiveEmployees,
+		CreatedAt:              b.CreatedAt,
+		UpdatedAt:              b.UpdatedAt,
+	}
+}
+
+func newOrgUnitResponse(u *entity.OrgUnit) OrgUnitResponse {
+	return OrgUnitResponse{
+		ID:              u.ID,
+		Name:            u.Name,
+		Description:     u.Description,
+		ActiveEmployees: u.ActiveEmployees,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+}
+
+// OrganizationHandler menangani cabang dan data master organisasi lain.
+// Handler OrgUnit dibuat per jenis, misalnya:
+//
+//	r.Get("/departments", orgHandler.ListUnits(entity.OrgUnitDepartment))
+type OrganizationHandler struct {
+	usecase  *usecase.OrganizationUsecase
+	validate *validator.Validate
+}
+
+func NewOrganizationHandler(uc *usecase.OrganizationUsecase, v *validator.Validate) *OrganizationHandler {
+	return &OrganizationHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// ------------------------------------------------------------------------
+// Branches
+// ------------------------------------------------------------------------
+
+func (h *OrganizationHandler) ListBranches(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	paginationQuery := util.GetPaginationQuery(r)
+
+	branches, pagination, err := h.usecase.ListBranches(r.Context(), tenantID, paginationQuery)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data cabang", err.Error())
+		return
+	}
+
+	responses := make([]BranchResponse, len(branches))
+	for i, b := range branches {
+		responses[i] = newBranchResponse(b)
+	}
+
+	util.SuccessResponse(w, "Data cabang berhasil diambil", ListBranchesResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
+
+func (h *OrganizationHandler) GetBranch(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID cabang tidak valid", err.Error())
+		return
+	}
+
+	branch, err := h.usecase.GetBranch(r.Context(), tenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Cabang tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data cabang berhasil diambil", newBranchResponse(branch))
+}
+
+func (h *OrganizationHandler) CreateBranch(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	input, ok := h.decodeBranch(w, r)
+	if !ok {
+		return
+	}
+
+	branch, err := h.usecase.CreateBranch(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat cabang", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Cabang berhasil dibuat", newBranchResponse(branch))
+}
+
+func (h *OrganizationHandler) UpdateBranch(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID cabang tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeBranch(w, r)
+	if !ok {
+		return
+	}
+
+	branch, err := h.usecase.UpdateBranch(r.Context(), tenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui cabang", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Cabang berhasil diperbarui", newBranchResponse(branch))
+}
+
+// DeleteBranch menerima query param opsional 'reassign_to' berisi ID cabang
+// tujuan untuk karyawan aktif di cabang yang dihapus.
+func (h *OrganizationHandler) DeleteBranch(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	id, reassignTo, ok := parseOrgDeleteParams(w, r)
+	if !ok {
+		return
+	}
+
+	moved, err := h.usecase.DeleteBranch(r.Context(), tenantID, id, reassignTo)
+	if err != nil {
+		writeOrgDeleteError(w, "Gagal menghapus cabang", err)
+		return
+	}
+
+	util.SuccessResponse(w, "Cabang berhasil dihapus", OrgDeleteResponse{ReassignedEmployees: moved})
+}
+
+func (h *OrganizationHandler) decodeBranch(w http.ResponseWriter, r *http.Request) (usecase.BranchInput, bool) {
+	var req BranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return usecase.BranchInput{}, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Code = trimOptional(req.Code); req.Code != nil {
+		// Kode cabang disimpan dalam huruf besar.
+		code := strings.ToUpper(*req.Code)
+		req.Code = &code
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.BranchInput{}, false
+	}
+
+	return usecase.BranchInput{
+		Name:                   req.Name,
+		Code:                   req.Code,
+		Address:                trimOptional(req.Address),
+		Latitude:               req.Latitude,
+		Longitude:              req.Longitude,
+		AttendanceRadiusMeters: req.AttendanceRadiusMeters,
+	}, true
+}
+
+// ------------------------------------------------------------------------
+// Org units
+// ------------------------------------------------------------------------
+
+func (h *OrganizationHandler) ListUnits(kind entity.OrgUnitKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := security.TenantIDFromContext(r.Context())
+
+		paginationQuery := util.GetPaginationQuery(r)
+
+		units, pagination, err := h.usecase.ListUnits(r.Context(), kind, tenantID, paginationQuery)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data "+kind.Label(), err.Error())
+			return
+		}
+
+		responses := make([]OrgUnitResponse, len(units))
+		for i, u := range units {
+			responses[i] = newOrgUnitResponse(u)
+		}
+
+		util.SuccessResponse(w, "Data "+kind.Label()+" berhasil diambil", ListOrgUnitsResponse{
+			Data:       responses,
+			Pagination: pagination,
+		})
+	}
+}
+
+func (h *OrganizationHandler) GetUnit(kind entity.OrgUnitKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := security.TenantIDFromContext(r.Context())
+
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "ID "+kind.Label()+" tidak valid", err.Error())
+			return
+		}
+
+		unit, err := h.usecase.GetUnit(r.Context(), kind, tenantID, id)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusNotFound, "Data "+kind.Label()+" tidak ditemukan", err.Error())
+			return
+		}
+
+		util.SuccessResponse(w, "Data "+kind.Label()+" berhasil diambil", newOrgUnitResponse(unit))
+	}
+}
+
+func (h *OrganizationHandler) CreateUnit(kind entity.OrgUnitKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := security.TenantIDFromContext(r.Context())
+
+		input, ok := h.decodeUnit(w, r)
+		if !ok {
+			return
+		}
+
+		unit, err := h.usecase.CreateUnit(r.Context(), kind, tenantID, input)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat "+kind.Label(), err.Error())
+			return
+		}
+
+		util.SuccessResponse(w, "Data "+kind.Label()+" berhasil dibuat", newOrgUnitResponse(unit))
+	}
+}
+
+func (h *OrganizationHandler) UpdateUnit(kind entity.OrgUnitKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := security.TenantIDFromContext(r.Context())
+
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "ID "+kind.Label()+" tidak valid", err.Error())
+			return
+		}
+
+		input, ok := h.decodeUnit(w, r)
+		if !ok {
+			return
+		}
+
+		unit, err := h.usecase.UpdateUnit(r.Context(), kind, tenantID, id, input)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui "+kind.Label(), err.Error())
+			return
+		}
+
+		util.SuccessResponse(w, "Data "+kind.Label()+" berhasil diperbarui", newOrgUnitResponse(unit))
+	}
+}
+
+// DeleteUnit menerima query param opsional 'reassign_to', sama dengan
+// DeleteBranch.
+func (h *OrganizationHandler) DeleteUnit(kind entity.OrgUnitKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := security.TenantIDFromContext(r.Context())
+
+		id, reassignTo, ok := parseOrgDeleteParams(w, r)
+		if !ok {
+			return
+		}
+
+		moved, err := h.usecase.DeleteUnit(r.Context(), kind, tenantID, id, reassignTo)
+		if err != nil {
+			writeOrgDeleteError(w, "Gagal menghapus "+kind.Label(), err)
+			return
+		}
+
+		util.SuccessResponse(w, "Data "+kind.Label()+" berhasil dihapus", OrgDeleteResponse{ReassignedEmployees: moved})
+	}
+}
+
+func (h *OrganizationHandler) decodeUnit(w http.ResponseWriter, r *http.Request) (usecase.OrgUnitInput, bool) {
+	var req OrgUnitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return usecase.OrgUnitInput{}, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.OrgUnitInput{}, false
+	}
+
+	return usecase.OrgUnitInput{
+		Name:        req.Name,
+		Description: trimOptional(req.Description),
+	}, true
+}
+
+func parseOrgDeleteParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, *uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tidak valid", err.Error())
+		return uuid.Nil, nil, false
+	}
+
+	var reassignTo *uuid.UUID
+	if v := r.URL.Query().Get("reassign_to"); v != "" {
+		target, err := uuid.Parse(v)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "reassign_to tidak valid", err.Error())
+			return uuid.Nil, nil, false
+		}
+		reassignTo = &target
+	}
+	return id, reassignTo, true
+}
+
+// writeOrgDeleteError menulis 409 jika data master masih dipakai karyawan
+// aktif, selain itu 400.
+func writeOrgDeleteError(w http.ResponseWriter, message string, err error) {
+	var inUse *repository.OrgUnitInUseError
+	if errors.As(err, &inUse) {
+		util.ErrorResponseWithData(w, http.StatusConflict, message, err.Error(), OrgUnitInUseResponse{
+			ActiveEmployees: inUse.ActiveEmployees,
+		})
+		return
+	}
+	util.ErrorResponse(w, http.StatusBadRequest, message, err.Error())
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type PasswordPolicyRequest struct {
+	MinLength        int  `json:"min_length" validate:"required,min=8,max=72"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+	ExpiryDays       int  `json:"expiry_days" validate:"min=0,max=365"`
+	HistoryCount     int  `json:"history_count" validate:"min=0,max=24"`
+	BlockCommon      bool `json:"block_common"`
+}
+
+type PasswordPolicyResponse struct {
+	MinLength        int       `json:"min_length"`
+	RequireUppercase bool      `json:"require_uppercase"`
+	RequireLowercase bool      `json:"require_lowercase"`
+	RequireDigit     bool      `json:"require_digit"`
+	RequireSymbol    bool      `json:"require_symbol"`
+	ExpiryDays       int       `json:"expiry_days"`
+	HistoryCount     int       `json:"history_count"`
+	BlockCommon      bool      `json:"block_common"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func newPasswordPolicyResponse(p *entity.PasswordPolicy) PasswordPolicyResponse {
+	return PasswordPolicyResponse{
+		MinLength:        p.MinLength,
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireDigit:     p.RequireDigit,
+		RequireSymbol:    p.RequireSymbol,
+		ExpiryDays:       p.ExpiryDays,
+		HistoryCount:     p.HistoryCount,
+		BlockCommon:      p.BlockCommon,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+type PasswordPolicyHandler struct {
+	usecase  *usecase.PasswordPolicyUsecase
+	validate *validator.Validate
+}
+
+func NewPasswordPolicyHandler(uc *usecase.PasswordPolicyUsecase, v *validator.Validate) *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+// Middleware menaruh aturan password tenant di context agar validator
+// "password_policy" memakai kebijakan tenant. Dipasang hanya pada route yang
+// menerima password baru, setelah TenantResolver.
+//
+//	r.With(passwordPolicyHandler.Middleware).Post("/invitations/accept", ...)
+func (h *PasswordPolicyHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := security.TenantIDFromContext(r.Context())
+		if !ok {
+			util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+			return
+		}
+
+		rules, err := h.usecase.GetRules(r.Context(), tenantID)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil kebijakan password", err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(util.WithPasswordRules(r.Context(), rules)))
+	})
+}
+
+// Get bisa diakses tanpa login supaya halaman terima undangan dan ganti
+// password bisa menampilkan syarat password.
+func (h *PasswordPolicyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := security.TenantIDFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	policy, err := h.usecase.GetPolicy(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil kebijakan password", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Kebijakan password berhasil diambil", newPasswordPolicyResponse(policy))
+}
+
+func (h *PasswordPolicyHandler) Save(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := security.TenantIDFromContext(r.Context())
+
+	var req PasswordPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	policy, err := h.usecase.SavePolicy(r.Context(), tenantID, usecase.PasswordPolicyInput{
+		MinLength:        req.MinLength,
+		RequireUppercase: req.RequireUppercase,
+		RequireLowercase: req.RequireLowercase,
+		RequireDigit:     req.RequireDigit,
+		RequireSymbol:    req.RequireSymbol,
+		ExpiryDays:       req.ExpiryDays,
+		HistoryCount:     req.HistoryCount,
+		BlockCommon:      req.BlockCommon,
+	})
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal menyimpan kebijakan password", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Kebijakan password berhasil disimpan", newPasswordPolicyResponse(policy))
+}
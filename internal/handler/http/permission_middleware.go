@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type PermissionMiddleware struct {
+	authorization *usecase.AuthorizationUsecase
+}
+
+func NewPermissionMiddleware(uc *usecase.AuthorizationUsecase) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		authorization: uc,
+	}
+}
+
+// RequirePermission mengunci route agar hanya bisa diakses user tenant yang
+// memiliki hak akses 'permission', baik lewat role maupun diberikan langsung.
+// Data scope user untuk hak akses tersebut ikut ditaruh di context request
+// sehingga query repository (misalnya daftar karyawan) otomatis dibatasi.
+// Harus dipasang setelah TenantAuthMiddleware. Untuk request dengan API key,
+// hak akses diambil dari key dan selalu berlaku untuk seluruh tenant.
+//
+//	r.With(permMW.RequirePermission(entity.PermissionApproveLeave)).Post(...)
+func (m *PermissionMiddleware) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key, ok := security.APIKeyFromContext(r.Context()); ok {
+				if !key.HasPermission(permission) {
+					util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", "API key tidak memiliki hak akses '"+permission+"'")
+					return
+				}
+
+				ctx := repository.WithDataScope(r.Context(), &entity.DataScopeRule{
+					TenantID:   key.TenantID,
+					Permission: permission,
+					Scopes:     []entity.DataScope{entity.DataScopeTenant},
+				})
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			claims, ok := security.TenantClaimsFromContext(r.Context())
+			if !ok {
+				util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+				return
+			}
+
+			rule, err := m.authorization.DataScopeFor(r.Context(), claims.TenantID, claims.UserID, permission)
+			if err != nil {
+				util.ErrorResponse(w, http.StatusInternalServerError, "Gagal memeriksa hak akses", err.Error())
+				return
+			}
+
+			if rule == nil {
+				util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", "Anda tidak memiliki hak akses '"+permission+"'")
+				return
+			}
+
+			ctx := repository.WithDataScope(r.Context(), rule)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
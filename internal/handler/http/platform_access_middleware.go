@@ -0,0 +1,17 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// PlatformAccess menandai request superadmin sebagai akses platform sehingga
+// query boleh membaca data lintas tenant (Row Level Security di-bypass).
+// Harus dipasang setelah SuperadminAuthMiddleware.
+func PlatformAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := repository.WithPlatformAccess(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,289 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const dateLayout = "2006-01-02"
+
+type RevenueMetricsHandler struct {
+	usecase *usecase.RevenueMetricsUsecase
+}
+
+func NewRevenueMetricsHandler(uc *usecase.RevenueMetricsUsecase) *RevenueMetricsHandler {
+	return &RevenueMetricsHandler{
+		usecase: uc,
+	}
+}
+
+type PlanTenantCountResponse struct {
+	PlanID       uuid.UUID `json:"plan_id"`
+	PlanName     string    `json:"plan_name"`
+	Tenants      int       `json:"tenants"`
+	TrialTenants int       `json:"trial_tenants"`
+	MRR          float64   `json:"mrr"`
+}
+
+type RevenueMetricsResponse struct {
+	From                string                      `json:"from"`
+	To                  string                      `json:"to"`
+	MRR                 float64                     `json:"mrr"`
+	ARR                 float64                     `json:"arr"`
+	NewMRR              float64                     `json:"new_mrr"`
+	ExpansionMRR        float64                     `json:"expansion_mrr"`
+	ContractionMRR      float64                     `json:"contraction_mrr"`
+	ChurnedMRR          float64                     `json:"churned_mrr"`
+	NetNewMRR           float64                     `json:"net_new_mrr"`
+	CollectedRevenue    float64                     `json:"collected_revenue"`
+	CreditNotesIssued   float64                     `json:"credit_notes_issued"`
+	Refunds             float64                     `json:"refunds"`
+	TrialsStarted       int                         `json:"trials_started"`
+	TrialsConverted     int                         `json:"trials_converted"`
+	TrialConversionRate float64                     `json:"trial_conversion_rate"`
+	TenantsByStatus     map[entity.StatusTenant]int `json:"tenants_by_status"`
+	TenantsByPlan       []PlanTenantCountResponse   `json:"tenants_by_plan"`
+}
+
+type OutstandingInvoiceResponse struct {
+	InvoiceID   uuid.UUID `json:"invoice_id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	TenantName  string    `json:"tenant_name"`
+	IssueDate   string    `json:"issue_date"`
+	DueDate     string    `json:"due_date"`
+	TotalAmount float64   `json:"total_amount"`
+	Outstanding float64   `json:"outstanding"`
+	DaysPastDue int       `json:"days_past_due"`
+	Bucket      string    `json:"bucket"`
+}
+
+type ReceivablesAgingResponse struct {
+	AsOf     string                       `json:"as_of"`
+	Totals   map[string]float64           `json:"totals"`
+	Invoices []OutstandingInvoiceResponse `json:"invoices"`
+}
+
+func newRevenueMetricsResponse(m *entity.RevenueMetrics) RevenueMetricsResponse {
+	plans := make([]PlanTenantCountResponse, len(m.TenantsByPlan))
+	for i, p := range m.TenantsByPlan {
+		plans[i] = PlanTenantCountResponse{
+			PlanID:       p.PlanID,
+			PlanName:     p.PlanName,
+			Tenants:      p.Tenants,
+			TrialTenants: p.TrialTenants,
+			MRR:          p.MRR,
+		}
+	}
+
+	return RevenueMetricsResponse{
+		From:                m.From.Format(dateLayout),
+		To:                  m.To.AddDate(0, 0, -1).Format(dateLayout),
+		MRR:                 m.MRR,
+		ARR:                 m.ARR,
+		NewMRR:              m.NewMRR,
+		ExpansionMRR:        m.ExpansionMRR,
+		ContractionMRR:      m.ContractionMRR,
+		ChurnedMRR:          m.ChurnedMRR,
+		NetNewMRR:           m.NetNewMRR,
+		CollectedRevenue:    m.CollectedRevenue,
+		CreditNotesIssued:   m.CreditNotesIssued,
+		Refunds:             m.Refunds,
+		TrialsStarted:       m.TrialsStarted,
+		TrialsConverted:     m.TrialsConverted,
+		TrialConversionRate: m.TrialConversionRate,
+		TenantsByStatus:     m.TenantsByStatus,
+		TenantsByPlan:       plans,
+	}
+}
+
+func newReceivablesAgingResponse(a *entity.ReceivablesAging) ReceivablesAgingResponse {
+	invoices := make([]OutstandingInvoiceResponse, len(a.Invoices))
+	for i, o := range a.Invoices {
+		invoices[i] = OutstandingInvoiceResponse{
+			InvoiceID:   o.InvoiceID,
+			TenantID:    o.TenantID,
+			TenantName:  o.TenantName,
+			IssueDate:   o.IssueDate.Format(dateLayout),
+			DueDate:     o.DueDate.Format(dateLayout),
+			TotalAmount: o.TotalAmount,
+			Outstanding: o.Outstanding,
+			DaysPastDue: o.DaysPastDue,
+			Bucket:      entity.AgingBucket(o.DaysPastDue),
+		}
+	}
+
+	return ReceivablesAgingResponse{
+		AsOf:     a.AsOf.Format(dateLayout),
+		Totals:   a.Totals,
+		Invoices: invoices,
+	}
+}
+
+// parseDateRange membaca query 'from' dan 'to' (YYYY-MM-DD, inklusif).
+// Default: awal bulan berjalan sampai hari ini. Nilai 'to' yang dikembalikan
+// sudah eksklusif (to + 1 hari).
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var err error
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse(dateLayout, v); err != nil {
+			return time.Time{}, time.Time{}, errors.New("format 'from' harus YYYY-MM-DD")
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse(dateLayout, v); err != nil {
+			return time.Time{}, time.Time{}, errors.New("format 'to' harus YYYY-MM-DD")
+		}
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func wantsCSV(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "csv"
+}
+
+func writeCSV(w http.ResponseWriter, filename string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.WriteAll(rows)
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func (h *RevenueMetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID)
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Admin tidak ditemukan di context")
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Rentang tanggal tidak valid", err.Error())
+		return
+	}
+
+	metrics, err := h.usecase.GetMetrics(r.Context(), adminID, from, to)
+	if errors.Is(err, usecase.ErrRevenueReportForbidden) {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menghitung metrik revenue", err.Error())
+		return
+	}
+
+	response := newRevenueMetricsResponse(metrics)
+
+	if wantsCSV(r) {
+		rows := [][]string{
+			{"metric", "value"},
+			{"from", response.From},
+			{"to", response.To},
+			{"mrr", formatAmount(response.MRR)},
+			{"arr", formatAmount(response.ARR)},
+			{"new_mrr", formatAmount(response.NewMRR)},
+			{"expansion_mrr", formatAmount(response.ExpansionMRR)},
+			{"contraction_mrr", formatAmount(response.ContractionMRR)},
+			{"churned_mrr", formatAmount(response.ChurnedMRR)},
+			{"net_new_mrr", formatAmount(response.NetNewMRR)},
+			{"collected_revenue", formatAmount(response.CollectedRevenue)},
+			{"credit_notes_issued", formatAmount(response.CreditNotesIssued)},
+			{"refunds", formatAmount(response.Refunds)},
+			{"trials_started", strconv.Itoa(response.TrialsStarted)},
+			{"trials_converted", strconv.Itoa(response.TrialsConverted)},
+			{"trial_conversion_rate", formatAmount(response.TrialConversionRate)},
+		}
+		statuses := make([]string, 0, len(response.TenantsByStatus))
+		for status := range response.TenantsByStatus {
+			statuses = append(statuses, string(status))
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			rows = append(rows, []string{"tenants_status_" + status, strconv.Itoa(response.TenantsByStatus[entity.StatusTenant(status)])})
+		}
+		for _, p := range response.TenantsByPlan {
+			rows = append(rows,
+				[]string{"tenants_plan_" + p.PlanName, strconv.Itoa(p.Tenants)},
+				[]string{"mrr_plan_" + p.PlanName, formatAmount(p.MRR)},
+			)
+		}
+
+		writeCSV(w, fmt.Sprintf("revenue-metrics_%s_%s.csv", response.From, response.To), rows)
+		return
+	}
+
+	util.SuccessResponse(w, "Metrik revenue berhasil diambil", response)
+}
+
+func (h *RevenueMetricsHandler) GetReceivablesAging(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := r.Context().Value(security.AdminIDContextKey).(uuid.UUID)
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Admin tidak ditemukan di context")
+		return
+	}
+
+	asOf := time.Now().UTC()
+	if v := r.URL.Query().Get("as_of"); v != "" {
+		parsed, err := time.Parse(dateLayout, v)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid", "format 'as_of' harus YYYY-MM-DD")
+			return
+		}
+		asOf = parsed
+	}
+
+	aging, err := h.usecase.GetReceivablesAging(r.Context(), adminID, asOf)
+	if errors.Is(err, usecase.ErrRevenueReportForbidden) {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data piutang", err.Error())
+		return
+	}
+
+	response := newReceivablesAgingResponse(aging)
+
+	if wantsCSV(r) {
+		rows := [][]string{{"invoice_id", "tenant_id", "tenant_name", "issue_date", "due_date", "total_amount", "outstanding", "days_past_due", "bucket"}}
+		for _, o := range response.Invoices {
+			rows = append(rows, []string{
+				o.InvoiceID.String(),
+				o.TenantID.String(),
+				o.TenantName,
+				o.IssueDate,
+				o.DueDate,
+				formatAmount(o.TotalAmount),
+				formatAmount(o.Outstanding),
+				strconv.Itoa(o.DaysPastDue),
+				o.Bucket,
+			})
+		}
+
+		writeCSV(w, fmt.Sprintf("receivables-aging_%s.csv", response.AsOf), rows)
+		return
+	}
+
+	util.SuccessResponse(w, "Data umur piutang berhasil diambil", response)
+}
@@ -0,0 +1,429 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// PermissionGrantRequest memberikan satu hak akses dengan data scope-nya.
+// DataScope kosong berarti seluruh tenant.
+type PermissionGrantRequest struct {
+	Name      string `json:"name" validate:"required"`
+	DataScope string `json:"data_scope" validate:"omitempty,oneof=self direct_reports subordinates branch department sbu tenant"`
+}
+
+type RoleRequest struct {
+	Name        string                   `json:"name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	Description *string                  `json:"description"`
+	Permissions []PermissionGrantRequest `json:"permissions" validate:"dive"`
+}
+
+type SyncUserRolesRequest struct {
+	RoleIDs []string `json:"role_ids" validate:"dive,uuid"`
+}
+
+type SyncUserPermissionsRequest struct {
+	Permissions []PermissionGrantRequest `json:"permissions" validate:"dive"`
+}
+
+type InviteAdministratorRequest struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type RoleHandler struct {
+	usecase  *usecase.RoleUsecase
+	validate *validator.Validate
+}
+
+func NewRoleHandler(uc *usecase.RoleUsecase, v *validator.Validate) *RoleHandler {
+	return &RoleHandler{
+		usecase:  uc,
+		validate: v,
+	}
+}
+
+type PermissionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	GroupName string    `json:"group_name"`
+}
+
+type PermissionGrantResponse struct {
+	Name      string `json:"name"`
+	DataScope string `json:"data_scope"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	Name        string                    `json:"name"`
+	Description *string                   `json:"description"`
+	Permissions []PermissionGrantResponse `json:"permissions"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+type ListRolesResponse struct {
+	Data       []RoleResponse  `json:"data"`
+	Pagination util.Pagination `json:"pagination"`
+}
+
+type UserAccessResponse struct {
+	UserID               uuid.UUID                 `json:"user_id"`
+	Roles                []RoleResponse            `json:"roles"`
+	DirectPermissions    []PermissionGrantResponse `json:"direct_permissions"`
+	EffectivePermissions []string                  `json:"effective_permissions"`
+	DataScopes           map[string][]string       `json:"data_scopes"`
+}
+
+func newPermissionGrantResponses(permissions []entity.Permission) []PermissionGrantResponse {
+	grants := make([]PermissionGrantResponse, len(permissions))
+	for i, p := range permissions {
+		grants[i] = PermissionGrantResponse{Name: p.Name, DataScope: string(p.DataScope)}
+	}
+	return grants
+}
+
+func newPermissionGrants(reqs []PermissionGrantRequest) []entity.PermissionGrant {
+	grants := make([]entity.PermissionGrant, len(reqs))
+	for i, req := range reqs {
+		grants[i] = entity.PermissionGrant{Name: req.Name, DataScope: entity.DataScope(req.DataScope)}
+	}
+	return grants
+}
+
+func newPermissionResponse(p *entity.Permission) PermissionResponse {
+	return PermissionResponse{
+		ID:        p.ID,
+		Name:      p.Name,
+		GroupName: p.GroupName,
+	}
+}
+
+func newRoleResponse(role *entity.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: newPermissionGrantResponses(role.Permissions),
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func newUserAccessResponse(access *usecase.UserAccess) UserAccessResponse {
+	roles := make([]RoleResponse, len(access.Roles))
+	for i, role := range access.Roles {
+		roles[i] = newRoleResponse(role)
+	}
+
+	direct := make([]entity.Permission, len(access.DirectPermissions))
+	for i, p := range access.DirectPermissions {
+		direct[i] = *p
+	}
+
+	scopes := make(map[string][]string, len(access.DataScopes))
+	for name, list := range access.DataScopes {
+		scopes[name] = make([]string, len(list))
+		for i, s := range list {
+			scopes[name][i] = string(s)
+		}
+	}
+
+	return UserAccessResponse{
+		UserID:               access.UserID,
+		Roles:                roles,
+		DirectPermissions:    newPermissionGrantResponses(direct),
+		EffectivePermissions: access.EffectivePermissions,
+		DataScopes:           scopes,
+	}
+}
+
+func (h *RoleHandler) decodeRole(w http.ResponseWriter, r *http.Request) (usecase.RoleInput, bool) {
+	var req RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return usecase.RoleInput{}, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return usecase.RoleInput{}, false
+	}
+
+	return usecase.RoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: newPermissionGrants(req.Permissions),
+	}, true
+}
+
+func (h *RoleHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.usecase.ListPermissions(r.Context())
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data hak akses", err.Error())
+		return
+	}
+
+	responses := make([]PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		responses[i] = newPermissionResponse(p)
+	}
+
+	util.SuccessResponse(w, "Data hak akses berhasil diambil", responses)
+}
+
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	input, ok := h.decodeRole(w, r)
+	if !ok {
+		return
+	}
+
+	role, err := h.usecase.CreateRole(r.Context(), claims.TenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal membuat role", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Role berhasil dibuat", newRoleResponse(role))
+}
+
+func (h *RoleHandler) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	paginationQuery := util.GetPaginationQuery(r)
+
+	roles, pagination, err := h.usecase.ListRoles(r.Context(), claims.TenantID, paginationQuery)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data role", err.Error())
+		return
+	}
+
+	responses := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = newRoleResponse(role)
+	}
+
+	util.SuccessResponse(w, "Data role berhasil diambil", ListRolesResponse{
+		Data:       responses,
+		Pagination: pagination,
+	})
+}
+
+func (h *RoleHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID role tidak valid", err.Error())
+		return
+	}
+
+	role, err := h.usecase.GetRole(r.Context(), claims.TenantID, id)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Role tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Role berhasil diambil", newRoleResponse(role))
+}
+
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID role tidak valid", err.Error())
+		return
+	}
+
+	input, ok := h.decodeRole(w, r)
+	if !ok {
+		return
+	}
+
+	role, err := h.usecase.UpdateRole(r.Context(), claims.TenantID, id, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memperbarui role", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Role berhasil diperbarui", newRoleResponse(role))
+}
+
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID role tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.DeleteRole(r.Context(), claims.TenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menghapus role", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Role berhasil dihapus", nil)
+}
+
+func (h *RoleHandler) GetUserAccess(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID user tidak valid", err.Error())
+		return
+	}
+
+	access, err := h.usecase.GetUserAccess(r.Context(), claims.TenantID, userID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "User tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Hak akses user berhasil diambil", newUserAccessResponse(access))
+}
+
+func (h *RoleHandler) SyncUserRoles(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID user tidak valid", err.Error())
+		return
+	}
+
+	var req SyncUserRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	roleIDs := make([]uuid.UUID, len(req.RoleIDs))
+	for i, id := range req.RoleIDs {
+		roleIDs[i] = uuid.MustParse(id)
+	}
+
+	access, err := h.usecase.SyncUserRoles(r.Context(), claims.TenantID, userID, roleIDs)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menyimpan role user", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Role user berhasil disimpan", newUserAccessResponse(access))
+}
+
+func (h *RoleHandler) SyncUserPermissions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID user tidak valid", err.Error())
+		return
+	}
+
+	var req SyncUserPermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	access, err := h.usecase.SyncUserPermissions(r.Context(), claims.TenantID, userID, newPermissionGrants(req.Permissions))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menyimpan hak akses user", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Hak akses user berhasil disimpan", newUserAccessResponse(access))
+}
+
+// InviteAdministrator (superadmin) mengundang admin pertama sebuah tenant
+// dengan role Administrator.
+func (h *RoleHandler) InviteAdministrator(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	var req InviteAdministratorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	invitation, err := h.usecase.InviteAdministrator(r.Context(), tenantID, req.Name, req.Email)
+
+	writeInvitationResult(w, "Undangan administrator berhasil dikirim", invitation, err)
+}
@@ -0,0 +1,622 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/scim"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const scimDefaultPageSize = 100
+
+type SCIMTokenRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=255"`
+}
+
+type SCIMTokenResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// SCIMTokenCreatedResponse memuat plaintext token yang hanya ditampilkan
+// sekali, beserta base URL SCIM untuk dikonfigurasi di IdP.
+type SCIMTokenCreatedResponse struct {
+	SCIMTokenResponse
+	Token   string `json:"token"`
+	BaseURL string `json:"base_url"`
+}
+
+func newSCIMTokenResponse(t *entity.SCIMToken) SCIMTokenResponse {
+	return SCIMTokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		LastUsedAt:  t.LastUsedAt,
+		RevokedAt:   t.RevokedAt,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+// SCIMHandler melayani endpoint SCIM 2.0 (/scim/v2/{tenant}/...) dan
+// pengelolaan token SCIM oleh admin tenant. Endpoint SCIM memakai format
+// respons dan error SCIM, bukan util.JSONResponse, karena dibaca oleh IdP.
+type SCIMHandler struct {
+	usecase  *usecase.SCIMUsecase
+	validate *validator.Validate
+	apiURL   string
+}
+
+func NewSCIMHandler(uc *usecase.SCIMUsecase, v *validator.Validate, apiURL string) *SCIMHandler {
+	return &SCIMHandler{
+		usecase:  uc,
+		validate: v,
+		apiURL:   strings.TrimRight(apiURL, "/"),
+	}
+}
+
+func (h *SCIMHandler) baseURL(tenantSlug string) string {
+	return h.apiURL + "/scim/v2/" + tenantSlug
+}
+
+// ------------------------------------------------------------------------
+// Token (API tenant)
+// ------------------------------------------------------------------------
+
+func (h *SCIMHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	tokens, err := h.usecase.ListTokens(r.Context(), claims.TenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil token SCIM", err.Error())
+		return
+	}
+
+	res := make([]SCIMTokenResponse, len(tokens))
+	for i, t := range tokens {
+		res[i] = newSCIMTokenResponse(t)
+	}
+	util.SuccessResponse(w, "Token SCIM berhasil diambil", res)
+}
+
+func (h *SCIMHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	var req SCIMTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	token, plaintext, err := h.usecase.CreateToken(r.Context(), claims.TenantID, strings.TrimSpace(req.Name))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal membuat token SCIM", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Token SCIM berhasil dibuat. Simpan token ini, token tidak akan ditampilkan lagi", SCIMTokenCreatedResponse{
+		SCIMTokenResponse: newSCIMTokenResponse(token),
+		Token:             plaintext,
+		BaseURL:           h.baseURL(r.Header.Get(TenantSlugHeader)),
+	})
+}
+
+func (h *SCIMHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "token tidak valid")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID token tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.RevokeToken(r.Context(), claims.TenantID, id); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Token SCIM tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Token SCIM berhasil dicabut", nil)
+}
+
+// ------------------------------------------------------------------------
+// Autentikasi SCIM
+// ------------------------------------------------------------------------
+
+// Authenticate memvalidasi header "Authorization: Bearer <token>" terhadap
+// token SCIM tenant. Harus dipasang setelah TenantResolver.PathMiddleware.
+func (h *SCIMHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, ok := TenantFromContext(r.Context())
+		if !ok {
+			writeSCIMError(w, scim.NewError(http.StatusBadRequest, "", "tenant belum di-resolve"))
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		plaintext, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+			writeSCIMError(w, scim.NewError(http.StatusUnauthorized, "", "header Authorization Bearer dibutuhkan"))
+			return
+		}
+
+		if _, err := h.usecase.Authenticate(r.Context(), tenant.ID, strings.TrimSpace(plaintext)); err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM", error="invalid_token"`)
+			writeSCIMError(w, scim.NewError(http.StatusUnauthorized, "", err.Error()))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ------------------------------------------------------------------------
+// Discovery
+// ------------------------------------------------------------------------
+
+func (h *SCIMHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	supported := func(v bool) map[string]bool { return map[string]bool{"supported": v} }
+	writeSCIM(w, http.StatusOK, map[string]any{
+		"schemas":          []string{scim.SchemaServiceProviderConfig},
+		"documentationUri": "https://datatracker.ietf.org/doc/html/rfc7644",
+		"patch":            supported(true),
+		"bulk":             map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]any{"supported": true, "maxResults": scim.MaxPageSize},
+		"changePassword":   supported(false),
+		"sort":             supported(false),
+		"etag":             supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Token SCIM yang dibuat admin tenant",
+			"primary":     true,
+		}},
+		"meta": map[string]string{
+			"resourceType": "ServiceProviderConfig",
+			"location":     h.baseURL(tenant.Slug) + "/ServiceProviderConfig",
+		},
+	})
+}
+
+func (h *SCIMHandler) ResourceTypes(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+	base := h.baseURL(tenant.Slug)
+
+	resources := []any{
+		map[string]any{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scim.SchemaUser,
+			"schemaExtensions": []map[string]any{
+				{"schema": scim.SchemaEnterpriseUser, "required": false},
+			},
+			"meta": map[string]string{"resourceType": "ResourceType", "location": base + "/ResourceTypes/User"},
+		},
+		map[string]any{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   scim.SchemaGroup,
+			"meta":     map[string]string{"resourceType": "ResourceType", "location": base + "/ResourceTypes/Group"},
+		},
+	}
+
+	writeSCIM(w, http.StatusOK, scim.ListResponse{
+		Schemas:      []string{scim.SchemaListResponse},
+		TotalResults: int64(len(resources)),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// ------------------------------------------------------------------------
+// Users
+// ------------------------------------------------------------------------
+
+func (h *SCIMHandler) newSCIMUser(tenant *entity.Tenant, su *entity.SCIMUser) *scim.User {
+	base := h.baseURL(tenant.Slug)
+	active := scim.Bool(su.Active())
+	given, family, _ := strings.Cut(su.User.Name, " ")
+
+	res := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          su.User.ID.String(),
+		UserName:    su.User.Email,
+		Name:        &scim.Name{Formatted: su.User.Name, GivenName: given, FamilyName: family},
+		DisplayName: su.User.Name,
+		Emails:      []scim.MultiValue{{Value: su.User.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Groups:      []scim.MultiValue{},
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      su.User.CreatedAt,
+			LastModified: su.User.UpdatedAt,
+			Location:     base + "/Users/" + su.User.ID.String(),
+		},
+	}
+	if su.User.SCIMExternalID != nil {
+		res.ExternalID = *su.User.SCIMExternalID
+	}
+	if su.EmployeeNumber != nil {
+		res.Schemas = append(res.Schemas, scim.SchemaEnterpriseUser)
+		res.Enterprise = &scim.EnterpriseUser{EmployeeNumber: *su.EmployeeNumber}
+	}
+	for _, g := range su.Groups {
+		res.Groups = append(res.Groups, scim.MultiValue{
+			Value:   g.ID.String(),
+			Display: g.Display,
+			Ref:     base + "/Groups/" + g.ID.String(),
+		})
+	}
+	return res
+}
+
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+	startIndex, count := scimPaging(r)
+
+	users, total, err := h.usecase.ListUsers(r.Context(), tenant.ID, r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	resources := make([]any, len(users))
+	for i, su := range users {
+		resources[i] = h.newSCIMUser(tenant, su)
+	}
+	writeSCIMList(w, total, startIndex, resources)
+}
+
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	su, err := h.usecase.GetUser(r.Context(), tenant.ID, id)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMUser(tenant, su))
+}
+
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	var req scim.User
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	su, err := h.usecase.CreateUser(r.Context(), tenant.ID, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	res := h.newSCIMUser(tenant, su)
+	w.Header().Set("Location", res.Meta.Location)
+	writeSCIM(w, http.StatusCreated, res)
+}
+
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scim.User
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	su, err := h.usecase.ReplaceUser(r.Context(), tenant.ID, id, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMUser(tenant, su))
+}
+
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scim.PatchRequest
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	su, err := h.usecase.PatchUser(r.Context(), tenant.ID, id, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMUser(tenant, su))
+}
+
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteUser(r.Context(), tenant.ID, id); err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ------------------------------------------------------------------------
+// Groups
+// ------------------------------------------------------------------------
+
+func (h *SCIMHandler) newSCIMGroup(tenant *entity.Tenant, g *entity.SCIMGroup) *scim.Group {
+	base := h.baseURL(tenant.Slug)
+
+	res := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          g.Role.ID.String(),
+		DisplayName: g.Role.Name,
+		Members:     []scim.MultiValue{},
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      g.Role.CreatedAt,
+			LastModified: g.Role.UpdatedAt,
+			Location:     base + "/Groups/" + g.Role.ID.String(),
+		},
+	}
+	for _, m := range g.Members {
+		res.Members = append(res.Members, scim.MultiValue{
+			Value:   m.ID.String(),
+			Display: m.Display,
+			Ref:     base + "/Users/" + m.ID.String(),
+		})
+	}
+	return res
+}
+
+func (h *SCIMHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+	startIndex, count := scimPaging(r)
+
+	groups, total, err := h.usecase.ListGroups(r.Context(), tenant.ID, r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	resources := make([]any, len(groups))
+	for i, g := range groups {
+		resources[i] = h.newSCIMGroup(tenant, g)
+	}
+	writeSCIMList(w, total, startIndex, resources)
+}
+
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	g, err := h.usecase.GetGroup(r.Context(), tenant.ID, id)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMGroup(tenant, g))
+}
+
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	var req scim.Group
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	g, err := h.usecase.CreateGroup(r.Context(), tenant.ID, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	res := h.newSCIMGroup(tenant, g)
+	w.Header().Set("Location", res.Meta.Location)
+	writeSCIM(w, http.StatusCreated, res)
+}
+
+func (h *SCIMHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scim.Group
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	g, err := h.usecase.ReplaceGroup(r.Context(), tenant.ID, id, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMGroup(tenant, g))
+}
+
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req scim.PatchRequest
+	if !decodeSCIM(w, r, &req) {
+		return
+	}
+
+	g, err := h.usecase.PatchGroup(r.Context(), tenant.ID, id, &req)
+	if err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, h.newSCIMGroup(tenant, g))
+}
+
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	tenant, _ := TenantFromContext(r.Context())
+
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteGroup(r.Context(), tenant.ID, id); err != nil {
+		writeSCIMError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ------------------------------------------------------------------------
+// Helper
+// ------------------------------------------------------------------------
+
+func writeSCIM(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", scim.ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeSCIMList(w http.ResponseWriter, total int64, startIndex int, resources []any) {
+	writeSCIM(w, http.StatusOK, scim.ListResponse{
+		Schemas:      []string{scim.SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// writeSCIMError menulis error dalam format SCIM. Kuota karyawan yang
+// terlampaui dikembalikan sebagai 402 seperti API lainnya.
+func writeSCIMError(w http.ResponseWriter, err error) {
+	var scimErr *scim.Error
+	if errors.As(err, &scimErr) {
+		writeSCIM(w, scimErr.Status, scimErr)
+		return
+	}
+
+	var limitErr *usecase.EmployeeLimitError
+	if errors.As(err, &limitErr) {
+		writeSCIM(w, http.StatusPaymentRequired, scim.NewError(http.StatusPaymentRequired, "", limitErr.Error()+". "+limitErr.UpgradeHint))
+		return
+	}
+
+	writeSCIM(w, http.StatusInternalServerError, scim.NewError(http.StatusInternalServerError, "", err.Error()))
+}
+
+func decodeSCIM(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeSCIMError(w, scim.NewError(http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, "JSON tidak valid: "+err.Error()))
+		return false
+	}
+	return true
+}
+
+func scimResourceID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		// ID yang bukan UUID pasti tidak ada di tenant ini.
+		writeSCIMError(w, scim.NotFound("resource tidak ditemukan"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// scimPaging membaca startIndex (mulai 1) dan count (maksimal scim.MaxPageSize).
+func scimPaging(r *http.Request) (int, int) {
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil {
+		count = scimDefaultPageSize
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > scim.MaxPageSize {
+		count = scim.MaxPageSize
+	}
+	return startIndex, count
+}
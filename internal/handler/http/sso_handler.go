@@ -0,0 +1,236 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type SSOConfigRequest struct {
+	Protocol              string `json:"protocol" validate:"required,oneof=oidc saml"`
+	Enabled               bool   `json:"enabled"`
+	JITProvisioning       bool   `json:"jit_provisioning"`
+	PasswordLoginDisabled bool   `json:"password_login_disabled"`
+
+	OIDCIssuer       string `json:"oidc_issuer" validate:"required_if=Protocol oidc,omitempty,url"`
+	OIDCClientID     string `json:"oidc_client_id" validate:"required_if=Protocol oidc"`
+	OIDCClientSecret string `json:"oidc_client_secret"`
+
+	SAMLIDPMetadataURL string `json:"saml_idp_metadata_url" validate:"omitempty,url"`
+	SAMLIDPMetadataXML string `json:"saml_idp_metadata_xml"`
+}
+
+type SSOHandler struct {
+	usecase  *usecase.SSOUsecase
+	validate *validator.Validate
+	appURL   string
+	apiURL   string
+}
+
+func NewSSOHandler(uc *usecase.SSOUsecase, v *validator.Validate, appURL, apiURL string) *SSOHandler {
+	return &SSOHandler{
+		usecase:  uc,
+		validate: v,
+		appURL:   strings.TrimRight(appURL, "/"),
+		apiURL:   strings.TrimRight(apiURL, "/"),
+	}
+}
+
+// SSOConfigResponse tidak pernah mengirim client secret, hanya penanda
+// apakah secret sudah diisi.
+type SSOConfigResponse struct {
+	ID                    uuid.UUID `json:"id"`
+	Protocol              string    `json:"protocol"`
+	Enabled               bool      `json:"enabled"`
+	JITProvisioning       bool      `json:"jit_provisioning"`
+	PasswordLoginDisabled bool      `json:"password_login_disabled"`
+	OIDCIssuer            string    `json:"oidc_issuer,omitempty"`
+	OIDCClientID          string    `json:"oidc_client_id,omitempty"`
+	OIDCHasClientSecret   bool      `json:"oidc_has_client_secret"`
+	SAMLIDPMetadataURL    string    `json:"saml_idp_metadata_url,omitempty"`
+	// Endpoint milik aplikasi yang perlu didaftarkan di IdP.
+	LoginURL        string    `json:"login_url"`
+	OIDCRedirectURL string    `json:"oidc_redirect_url,omitempty"`
+	SAMLMetadataURL string    `json:"saml_metadata_url,omitempty"`
+	SAMLACSURL      string    `json:"saml_acs_url,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (h *SSOHandler) newSSOConfigResponse(config *entity.SSOConfig, tenantSlug string) SSOConfigResponse {
+	base := h.apiURL + "/sso/" + tenantSlug
+	res := SSOConfigResponse{
+		ID:                    config.ID,
+		Protocol:              string(config.Protocol),
+		Enabled:               config.Enabled,
+		JITProvisioning:       config.JITProvisioning,
+		PasswordLoginDisabled: config.PasswordLoginDisabled,
+		OIDCIssuer:            config.OIDCIssuer,
+		OIDCClientID:          config.OIDCClientID,
+		OIDCHasClientSecret:   config.OIDCClientSecret != "",
+		SAMLIDPMetadataURL:    config.SAMLIDPMetadataURL,
+		LoginURL:              base + "/login",
+		UpdatedAt:             config.UpdatedAt,
+	}
+
+	switch config.Protocol {
+	case entity.SSOProtocolOIDC:
+		res.OIDCRedirectURL = base + "/oidc/callback"
+	case entity.SSOProtocolSAML:
+		res.SAMLMetadataURL = base + "/saml/metadata"
+		res.SAMLACSURL = base + "/saml/acs"
+	}
+	return res
+}
+
+func (h *SSOHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+		return
+	}
+
+	config, err := h.usecase.GetConfig(r.Context(), claims.TenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Konfigurasi SSO belum dibuat", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Konfigurasi SSO berhasil diambil", h.newSSOConfigResponse(config, r.Header.Get(TenantSlugHeader)))
+}
+
+func (h *SSOHandler) SaveConfig(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+		return
+	}
+
+	var req SSOConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	config, err := h.usecase.SaveConfig(r.Context(), claims.TenantID, usecase.SSOConfigInput{
+		Protocol:              entity.SSOProtocol(req.Protocol),
+		Enabled:               req.Enabled,
+		JITProvisioning:       req.JITProvisioning,
+		PasswordLoginDisabled: req.PasswordLoginDisabled,
+		OIDCIssuer:            strings.TrimSpace(req.OIDCIssuer),
+		OIDCClientID:          strings.TrimSpace(req.OIDCClientID),
+		OIDCClientSecret:      req.OIDCClientSecret,
+		SAMLIDPMetadataURL:    strings.TrimSpace(req.SAMLIDPMetadataURL),
+		SAMLIDPMetadataXML:    req.SAMLIDPMetadataXML,
+	})
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menyimpan konfigurasi SSO", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Konfigurasi SSO berhasil disimpan", h.newSSOConfigResponse(config, r.Header.Get(TenantSlugHeader)))
+}
+
+// Login dibuka langsung oleh browser dan me-redirect ke IdP tenant.
+func (h *SSOHandler) Login(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := TenantFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	redirectURL, err := h.usecase.BeginLogin(r.Context(), tenant)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memulai login SSO", err.Error())
+		return
+	}
+
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+func (h *SSOHandler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := TenantFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	query := r.URL.Query()
+	if idpErr := query.Get("error"); idpErr != "" {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Login SSO ditolak IdP", idpErr+": "+query.Get("error_description"))
+		return
+	}
+
+	token, err := h.usecase.CompleteOIDC(r.Context(), tenant, query.Get("state"), query.Get("code"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Login SSO gagal", err.Error())
+		return
+	}
+
+	h.redirectWithToken(w, r, tenant, token)
+}
+
+func (h *SSOHandler) SAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := TenantFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	metadata, err := h.usecase.SAMLMetadata(tenant)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal membuat metadata SAML", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(metadata)
+}
+
+// SAMLACS menerima SAMLResponse lewat binding HTTP-POST.
+func (h *SSOHandler) SAMLACS(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := TenantFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Form tidak valid", err.Error())
+		return
+	}
+
+	token, err := h.usecase.CompleteSAML(r.Context(), tenant, r.PostForm.Get("RelayState"), r.PostForm.Get("SAMLResponse"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Login SSO gagal", err.Error())
+		return
+	}
+
+	h.redirectWithToken(w, r, tenant, token)
+}
+
+// redirectWithToken mengembalikan browser ke frontend. Token ditaruh di
+// fragment (#) supaya tidak ikut terkirim ke server atau tercatat di log.
+func (h *SSOHandler) redirectWithToken(w http.ResponseWriter, r *http.Request, tenant *entity.Tenant, token string) {
+	fragment := url.Values{}
+	fragment.Set("token", token)
+	fragment.Set("tenant", tenant.Slug)
+
+	http.Redirect(w, r, h.appURL+"/sso/callback#"+fragment.Encode(), http.StatusFound)
+}
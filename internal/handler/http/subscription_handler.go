@@ -0,0 +1,383 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type SubscribeRequest struct {
+	PlanID      string     `json:"plan_id" validate:"required,uuid"`
+	Status      string     `json:"status" validate:"omitempty,oneof=active trialing"`
+	TrialEndsAt *time.Time `json:"trial_ends_at"`
+}
+
+type GrantAddonRequest struct {
+	FeatureSlug        string     `json:"feature_slug" validate:"required"`
+	ExtraEmployeeSeats int        `json:"extra_employee_seats" validate:"gte=0"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+type ChangePlanRequest struct {
+	PlanID string `json:"plan_id" validate:"required,uuid"`
+}
+
+type SubscriptionHandler struct {
+	usecase      *usecase.SubscriptionUsecase
+	entitlements *usecase.EntitlementUsecase
+	validate     *validator.Validate
+}
+
+func NewSubscriptionHandler(uc *usecase.SubscriptionUsecase, entitlements *usecase.EntitlementUsecase, v *validator.Validate) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		usecase:      uc,
+		entitlements: entitlements,
+		validate:     v,
+	}
+}
+
+type SubscriptionResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	TenantID    uuid.UUID                 `json:"tenant_id"`
+	PlanID      uuid.UUID                 `json:"plan_id"`
+	Status      entity.StatusSubscription `json:"status"`
+	StartedAt   time.Time                 `json:"started_at"`
+	EndsAt      *time.Time                `json:"ends_at"`
+	TrialEndsAt *time.Time                `json:"trial_ends_at"`
+}
+
+type ProrationResponse struct {
+	UnusedRatio    float64   `json:"unused_ratio"`
+	Credit         float64   `json:"credit"`
+	Charge         float64   `json:"charge"`
+	Net            float64   `json:"net"`
+	NewPeriodStart time.Time `json:"new_period_start"`
+	NewPeriodEnd   time.Time `json:"new_period_end"`
+}
+
+type PlanChangePreviewResponse struct {
+	CurrentPlanID uuid.UUID         `json:"current_plan_id"`
+	NewPlanID     uuid.UUID         `json:"new_plan_id"`
+	Proration     ProrationResponse `json:"proration"`
+	// AmountDue adalah nominal yang langsung ditagihkan, CreditAmount adalah
+	// kredit yang akan diterima tenant. Paling banyak salah satunya > 0.
+	AmountDue    float64 `json:"amount_due"`
+	CreditAmount float64 `json:"credit_amount"`
+}
+
+type PlanChangeResponse struct {
+	Subscription SubscriptionResponse `json:"subscription"`
+	Proration    ProrationResponse    `json:"proration"`
+	Invoice      *InvoiceResponse     `json:"invoice"`
+	CreditAmount float64              `json:"credit_amount"`
+}
+
+type TenantAddonResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	TenantID           uuid.UUID  `json:"tenant_id"`
+	FeatureID          uuid.UUID  `json:"feature_id"`
+	ExtraEmployeeSeats int        `json:"extra_employee_seats"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+type EntitlementResponse struct {
+	Feature   string     `json:"feature"`
+	Source    string     `json:"source"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func newSubscriptionResponse(s *entity.Subscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		ID:          s.ID,
+		TenantID:    s.TenantID,
+		PlanID:      s.PlanID,
+		Status:      s.Status,
+		StartedAt:   s.StartedAt,
+		EndsAt:      s.EndsAt,
+		TrialEndsAt: s.TrialEndsAt,
+	}
+}
+
+func newProrationResponse(p entity.Proration) ProrationResponse {
+	return ProrationResponse{
+		UnusedRatio:    p.UnusedRatio,
+		Credit:         p.Credit,
+		Charge:         p.Charge,
+		Net:            p.Net,
+		NewPeriodStart: p.NewPeriodStart,
+		NewPeriodEnd:   p.NewPeriodEnd,
+	}
+}
+
+func newPlanChangePreviewResponse(p *usecase.PlanChangePreview) PlanChangePreviewResponse {
+	response := PlanChangePreviewResponse{
+		CurrentPlanID: p.CurrentPlan.ID,
+		NewPlanID:     p.NewPlan.ID,
+		Proration:     newProrationResponse(p.Proration),
+	}
+	if p.CurrentSubscription.Status != entity.StatusSubscriptionTrialing {
+		if p.Proration.Net > 0 {
+			response.AmountDue = p.Proration.Net
+		} else {
+			response.CreditAmount = -p.Proration.Net
+		}
+	}
+	return response
+}
+
+func newPlanChangeResponse(result *usecase.PlanChangeResult) PlanChangeResponse {
+	response := PlanChangeResponse{
+		Subscription: newSubscriptionResponse(result.Subscription),
+		Proration:    newProrationResponse(result.Proration),
+	}
+	if result.Invoice != nil {
+		invoice := newInvoiceResponse(result.Invoice)
+		response.Invoice = &invoice
+	}
+	if result.Credit != nil {
+		response.CreditAmount = result.Credit.Amount
+	}
+	return response
+}
+
+func newTenantAddonResponse(a *entity.TenantAddon) TenantAddonResponse {
+	return TenantAddonResponse{
+		ID:                 a.ID,
+		TenantID:           a.TenantID,
+		FeatureID:          a.FeatureID,
+		ExtraEmployeeSeats: a.ExtraEmployeeSeats,
+		ExpiresAt:          a.ExpiresAt,
+	}
+}
+
+func newEntitlementListResponse(entitlements []entity.Entitlement) []EntitlementResponse {
+	responses := make([]EntitlementResponse, len(entitlements))
+	for i, e := range entitlements {
+		responses[i] = EntitlementResponse{
+			Feature:   e.FeatureSlug,
+			Source:    e.Source,
+			ExpiresAt: e.ExpiresAt,
+		}
+	}
+	return responses
+}
+
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	subscription, err := h.usecase.GetCurrentSubscription(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Langganan tidak ditemukan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Langganan berhasil diambil", newSubscriptionResponse(subscription))
+}
+
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	var req SubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Status = strings.TrimSpace(req.Status)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.SubscribeInput{
+		PlanID:      uuid.MustParse(req.PlanID),
+		Status:      req.Status,
+		TrialEndsAt: req.TrialEndsAt,
+	}
+
+	subscription, err := h.usecase.Subscribe(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menyimpan langganan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Langganan berhasil disimpan", newSubscriptionResponse(subscription))
+}
+
+func (h *SubscriptionHandler) decodeChangePlan(w http.ResponseWriter, r *http.Request) (uuid.UUID, usecase.ChangePlanInput, bool) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return uuid.Nil, usecase.ChangePlanInput{}, false
+	}
+
+	var req ChangePlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return uuid.Nil, usecase.ChangePlanInput{}, false
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return uuid.Nil, usecase.ChangePlanInput{}, false
+	}
+
+	return tenantID, usecase.ChangePlanInput{PlanID: uuid.MustParse(req.PlanID)}, true
+}
+
+// PreviewChangePlan menghitung prorata tanpa menyimpan apa pun, supaya nominal
+// bisa ditampilkan sebelum perpindahan paket dikonfirmasi.
+func (h *SubscriptionHandler) PreviewChangePlan(w http.ResponseWriter, r *http.Request) {
+	tenantID, input, ok := h.decodeChangePlan(w, r)
+	if !ok {
+		return
+	}
+
+	preview, err := h.usecase.PreviewPlanChange(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menghitung perpindahan paket", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Perhitungan perpindahan paket berhasil", newPlanChangePreviewResponse(preview))
+}
+
+func (h *SubscriptionHandler) ChangePlan(w http.ResponseWriter, r *http.Request) {
+	tenantID, input, ok := h.decodeChangePlan(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.usecase.ChangePlan(r.Context(), tenantID, input)
+	if errors.Is(err, repository.ErrSubscriptionChanged) {
+		util.ErrorResponse(w, http.StatusConflict, "Gagal memindahkan paket", err.Error())
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal memindahkan paket", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Paket berhasil dipindahkan", newPlanChangeResponse(result))
+}
+
+func (h *SubscriptionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.CancelSubscription(r.Context(), tenantID); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Gagal membatalkan langganan", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Langganan berhasil dibatalkan", nil)
+}
+
+func (h *SubscriptionHandler) GrantAddon(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	var req GrantAddonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.FeatureSlug = strings.TrimSpace(req.FeatureSlug)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	input := usecase.GrantAddonInput{
+		FeatureSlug:        req.FeatureSlug,
+		ExtraEmployeeSeats: req.ExtraEmployeeSeats,
+		ExpiresAt:          req.ExpiresAt,
+	}
+
+	addon, err := h.usecase.GrantAddon(r.Context(), tenantID, input)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal menambahkan addon", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Addon berhasil ditambahkan", newTenantAddonResponse(addon))
+}
+
+func (h *SubscriptionHandler) RevokeAddon(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	if err := h.usecase.RevokeAddon(r.Context(), tenantID, chi.URLParam(r, "feature")); err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Gagal mencabut addon", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Addon berhasil dicabut", nil)
+}
+
+// ListTenantEntitlements dipakai superadmin untuk melihat fitur milik tenant tertentu.
+func (h *SubscriptionHandler) ListTenantEntitlements(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "ID tenant tidak valid", err.Error())
+		return
+	}
+
+	h.writeEntitlements(w, r, tenantID)
+}
+
+// ListMyEntitlements dipakai aplikasi tenant untuk mengetahui modul apa yang boleh ditampilkan.
+func (h *SubscriptionHandler) ListMyEntitlements(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := security.TenantIDFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	h.writeEntitlements(w, r, tenantID)
+}
+
+func (h *SubscriptionHandler) writeEntitlements(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID) {
+	entitlements, err := h.entitlements.ListEntitlements(r.Context(), tenantID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusInternalServerError, "Gagal mengambil data fitur", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Data fitur berhasil diambil", newEntitlementListResponse(entitlements))
+}
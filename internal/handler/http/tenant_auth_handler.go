@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,password_policy,no_consecutive_spaces"`
+}
+
+// ChangeExpiredPasswordRequest dipakai tanpa token, setelah Login menolak
+// password yang kedaluwarsa.
+type ChangeExpiredPasswordRequest struct {
+	Email           string `json:"email" validate:"required,email"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,password_policy,no_consecutive_spaces"`
+}
+
+type TenantAuthHandler struct {
+	authUsecase *usecase.TenantAuthUsecase
+	validate    *validator.Validate
+}
+
+func NewTenantAuthHandler(uc *usecase.TenantAuthUsecase, v *validator.Validate) *TenantAuthHandler {
+	return &TenantAuthHandler{
+		authUsecase: uc,
+		validate:    v,
+	}
+}
+
+type TenantUserResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Timezone        string     `json:"timezone"`
+	Roles           []string   `json:"roles"`
+	Permissions     []string   `json:"permissions"`
+	// Impersonation terisi jika request memakai token impersonasi superadmin,
+	// supaya frontend bisa menampilkan banner.
+	Impersonation *ImpersonationInfoResponse `json:"impersonation,omitempty"`
+}
+
+type ImpersonationInfoResponse struct {
+	Impersonated   bool      `json:"impersonated"`
+	ImpersonatorID string    `json:"impersonator_id"`
+	SessionID      uuid.UUID `json:"session_id"`
+	ReadOnly       bool      `json:"read_only"`
+}
+
+func newTenantUserResponse(user *entity.User, roles, permissions []string) TenantUserResponse {
+	return TenantUserResponse{
+		ID:              user.ID,
+		TenantID:        user.TenantID,
+		Name:            user.Name,
+		Email:           user.Email,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Timezone:        user.Timezone,
+		Roles:           roles,
+		Permissions:     permissions,
+	}
+}
+
+// Login harus dipanggil lewat route yang sudah melewati TenantResolver.
+func (h *TenantAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := security.TenantIDFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			util.ErrorResponse(w, http.StatusBadRequest, "Request body tidak boleh kosong", err.Error())
+			return
+		}
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+
+	if err := h.validate.Struct(req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	token, err := h.authUsecase.Login(r.Context(), tenantID, req.Email, req.Password)
+	if errors.Is(err, usecase.ErrPasswordExpired) {
+		util.ErrorResponseWithData(w, http.StatusForbidden, "Password kedaluwarsa", err.Error(), map[string]bool{
+			"password_expired": true,
+		})
+		return
+	}
+	if err != nil {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Login gagal", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Login berhasil", map[string]string{
+		"token": token,
+	})
+}
+
+func (h *TenantAuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+		return
+	}
+
+	user, roles, permissions, err := h.authUsecase.Me(r.Context(), claims.TenantID, claims.UserID)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "User tidak ditemukan", err.Error())
+		return
+	}
+
+	response := newTenantUserResponse(user, roles, permissions)
+	if claims.IsImpersonated() {
+		response.Impersonation = &ImpersonationInfoResponse{
+			Impersonated:   true,
+			ImpersonatorID: claims.Act.Subject,
+			SessionID:      claims.Act.SessionID,
+			ReadOnly:       claims.Act.ReadOnly,
+		}
+	}
+
+	util.SuccessResponse(w, "Data user berhasil diambil", response)
+}
+
+// ChangePassword mengganti password user yang sedang login. Validasi
+// password_policy memakai aturan tenant dari PasswordPolicyHandler.Middleware.
+func (h *TenantAuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := security.TenantClaimsFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+		return
+	}
+	if claims.IsImpersonated() {
+		util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", "Password tidak bisa diganti saat impersonasi")
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	if err := h.authUsecase.ChangePassword(r.Context(), claims.TenantID, claims.UserID, req.CurrentPassword, req.NewPassword); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengganti password", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Password berhasil diganti", nil)
+}
+
+func (h *TenantAuthHandler) ChangeExpiredPassword(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := security.TenantIDFromContext(r.Context())
+	if !ok {
+		util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+		return
+	}
+
+	var req ChangeExpiredPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Input JSON tidak valid", err.Error())
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		errors := util.FormatValidationErrors(err.(validator.ValidationErrors))
+		util.ErrorResponse(w, http.StatusUnprocessableEntity, "Input tidak valid", fmt.Sprintf("%v", errors))
+		return
+	}
+
+	token, err := h.authUsecase.ChangeExpiredPassword(r.Context(), tenantID, req.Email, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusBadRequest, "Gagal mengganti password", err.Error())
+		return
+	}
+
+	util.SuccessResponse(w, "Password berhasil diganti", map[string]string{
+		"token": token,
+	})
+}
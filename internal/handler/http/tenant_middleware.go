@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/usecase"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// TenantSlugHeader adalah header yang dipakai client untuk memilih tenant.
+const TenantSlugHeader = "X-Tenant-Slug"
+
+type TenantResolver struct {
+	tenantUsecase *usecase.TenantUsecase
+}
+
+func NewTenantResolver(uc *usecase.TenantUsecase) *TenantResolver {
+	return &TenantResolver{
+		tenantUsecase: uc,
+	}
+}
+
+// Middleware mencari tenant berdasarkan header X-Tenant-Slug lalu menyimpan
+// ID-nya ke context. Tenant yang suspended/inactive tidak boleh mengakses API.
+func (m *TenantResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := strings.TrimSpace(r.Header.Get(TenantSlugHeader))
+		if slug == "" {
+			util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Header "+TenantSlugHeader+" dibutuhkan")
+			return
+		}
+
+		m.resolve(w, r, next, slug)
+	})
+}
+
+// PathMiddleware sama seperti Middleware, tetapi slug diambil dari parameter
+// route {tenant}. Dipakai route yang dibuka browser lewat redirect (mis. SSO)
+// sehingga tidak bisa mengirim header. Tenant juga disimpan ke context untuk
+// TenantFromContext.
+func (m *TenantResolver) PathMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.resolve(w, r, next, strings.TrimSpace(chi.URLParam(r, "tenant")))
+	})
+}
+
+func (m *TenantResolver) resolve(w http.ResponseWriter, r *http.Request, next http.Handler, slug string) {
+	tenant, err := m.tenantUsecase.GetTenantBySlug(r.Context(), slug)
+	if err != nil {
+		util.ErrorResponse(w, http.StatusNotFound, "Tenant tidak ditemukan", err.Error())
+		return
+	}
+
+	if tenant.Status == entity.StatusTenantSuspended || tenant.Status == entity.StatusTenantInactive {
+		util.ErrorResponse(w, http.StatusForbidden, "Tenant tidak aktif", "Status tenant: "+string(tenant.Status))
+		return
+	}
+
+	ctx := security.WithTenantID(r.Context(), tenant.ID)
+	ctx = context.WithValue(ctx, tenantContextKey{}, tenant)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type tenantContextKey struct{}
+
+// TenantFromContext mengambil tenant yang sudah di-resolve TenantResolver.
+func TenantFromContext(ctx context.Context) (*entity.Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(*entity.Tenant)
+	return tenant, ok
+}
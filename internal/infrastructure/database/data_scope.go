@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// subordinatesQuery mengambil semua bawahan (langsung maupun tidak langsung)
+// seorang karyawan. UNION (bukan UNION ALL) mencegah loop jika data
+// manager_id terlanjur membentuk siklus.
+const subordinatesQuery = `WITH RECURSIVE subordinates AS (
+	SELECT id FROM employees WHERE manager_id = ? AND deleted_at IS NULL
+	UNION
+	SELECT e.id FROM employees e
+	JOIN subordinates s ON e.manager_id = s.id
+	WHERE e.deleted_at IS NULL
+) SELECT id FROM subordinates`
+
+// applyEmployeeScope menambahkan filter data scope dari context ke query yang
+// memuat tabel employees dengan alias 'alias'. Semua repository yang membaca
+// data karyawan untuk request tenant wajib melewatkan query-nya ke sini.
+func applyEmployeeScope(ctx context.Context, sb sq.SelectBuilder, alias string) sq.SelectBuilder {
+	rule, ok := repository.DataScopeFromContext(ctx)
+	if !ok || rule.IsTenantWide() {
+		return sb
+	}
+	return sb.Where(employeeScopeCondition(rule, alias))
+}
+
+// employeeScopeCondition menerjemahkan data scope menjadi kondisi SQL. Data
+// milik user sendiri selalu ikut terlihat pada scope apa pun.
+func employeeScopeCondition(rule *entity.DataScopeRule, alias string) sq.Sqlizer {
+	col := func(name string) string { return alias + "." + name }
+	subject := rule.Subject
+
+	conditions := sq.Or{sq.Eq{col("user_id"): subject.UserID}}
+
+	for _, scope := range rule.Scopes {
+		switch scope {
+		case entity.DataScopeDirectReports:
+			if subject.EmployeeID != nil {
+				conditions = append(conditions, sq.Eq{col("manager_id"): *subject.EmployeeID})
+			}
+		case entity.DataScopeSubordinates:
+			if subject.EmployeeID != nil {
+				conditions = append(conditions, sq.Expr(col("id")+" IN ("+subordinatesQuery+")", *subject.EmployeeID))
+			}
+		case entity.DataScopeBranch:
+			if subject.BranchID != nil {
+				conditions = append(conditions, sq.Eq{col("branch_id"): *subject.BranchID})
+			}
+		case entity.DataScopeDepartment:
+			if subject.DepartmentID != nil {
+				conditions = append(conditions, sq.Eq{col("department_id"): *subject.DepartmentID})
+			}
+		case entity.DataScopeSBU:
+			if subject.SBUID != nil {
+				conditions = append(conditions, sq.Eq{col("sbu_id"): *subject.SBUID})
+			}
+		}
+	}
+
+	return conditions
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func uuidPtr(id uuid.UUID) *uuid.UUID {
+	return &id
+}
+
+func TestEmployeeScopeCondition(t *testing.T) {
+	userID := uuid.New()
+	employeeID := uuid.New()
+	branchID := uuid.New()
+	departmentID := uuid.New()
+	sbuID := uuid.New()
+
+	subject := entity.DataScopeSubject{
+		UserID:       userID,
+		EmployeeID:   uuidPtr(employeeID),
+		BranchID:     uuidPtr(branchID),
+		DepartmentID: uuidPtr(departmentID),
+		SBUID:        uuidPtr(sbuID),
+	}
+	// unlinked adalah user yang belum terhubung ke data karyawan.
+	unlinked := entity.DataScopeSubject{UserID: userID}
+
+	tests := []struct {
+		name     string
+		scopes   []entity.DataScope
+		subject  entity.DataScopeSubject
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "self",
+			scopes:   []entity.DataScope{entity.DataScopeSelf},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ?)",
+			wantArgs: []any{userID},
+		},
+		{
+			name:     "direct_reports",
+			scopes:   []entity.DataScope{entity.DataScopeDirectReports},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.manager_id = ?)",
+			wantArgs: []any{userID, employeeID},
+		},
+		{
+			name:     "subordinates",
+			scopes:   []entity.DataScope{entity.DataScopeSubordinates},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.id IN (" + subordinatesQuery + "))",
+			wantArgs: []any{userID, employeeID},
+		},
+		{
+			name:     "branch",
+			scopes:   []entity.DataScope{entity.DataScopeBranch},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.branch_id = ?)",
+			wantArgs: []any{userID, branchID},
+		},
+		{
+			name:     "department",
+			scopes:   []entity.DataScope{entity.DataScopeDepartment},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.department_id = ?)",
+			wantArgs: []any{userID, departmentID},
+		},
+		{
+			name:     "sbu",
+			scopes:   []entity.DataScope{entity.DataScopeSBU},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.sbu_id = ?)",
+			wantArgs: []any{userID, sbuID},
+		},
+		{
+			name:     "gabungan beberapa scope",
+			scopes:   []entity.DataScope{entity.DataScopeDirectReports, entity.DataScopeBranch},
+			subject:  subject,
+			wantSQL:  "(e.user_id = ? OR e.manager_id = ? OR e.branch_id = ?)",
+			wantArgs: []any{userID, employeeID, branchID},
+		},
+		{
+			name: "user tanpa data karyawan hanya melihat dirinya",
+			scopes: []entity.DataScope{
+				entity.DataScopeDirectReports, entity.DataScopeSubordinates,
+				entity.DataScopeBranch, entity.DataScopeDepartment, entity.DataScopeSBU,
+			},
+			subject:  unlinked,
+			wantSQL:  "(e.user_id = ?)",
+			wantArgs: []any{userID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &entity.DataScopeRule{Scopes: tt.scopes, Subject: tt.subject}
+			sql, args, err := employeeScopeCondition(rule, "e").ToSql()
+			if err != nil {
+				t.Fatalf("ToSql gagal: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.wantSQL)
+			}
+			// Squirrel bisa mengubah uuid.UUID menjadi nilai driver, jadi yang
+			// dibandingkan adalah representasi teksnya.
+			if fmt.Sprint(args) != fmt.Sprint(tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestApplyEmployeeScope(t *testing.T) {
+	userID := uuid.New()
+	base := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select("e.id").From("employees e")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantSQL string
+	}{
+		{
+			name:    "tanpa data scope",
+			ctx:     context.Background(),
+			wantSQL: "SELECT e.id FROM employees e",
+		},
+		{
+			name: "scope tenant tidak menambah filter",
+			ctx: repository.WithDataScope(context.Background(), &entity.DataScopeRule{
+				Scopes:  []entity.DataScope{entity.DataScopeSelf, entity.DataScopeTenant},
+				Subject: entity.DataScopeSubject{UserID: userID},
+			}),
+			wantSQL: "SELECT e.id FROM employees e",
+		},
+		{
+			name: "scope self",
+			ctx: repository.WithDataScope(context.Background(), &entity.DataScopeRule{
+				Scopes:  []entity.DataScope{entity.DataScopeSelf},
+				Subject: entity.DataScopeSubject{UserID: userID},
+			}),
+			wantSQL: "SELECT e.id FROM employees e WHERE (e.user_id = $1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := applyEmployeeScope(tt.ctx, base, "e").ToSql()
+			if err != nil {
+				t.Fatalf("ToSql gagal: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.wantSQL)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DELETE FROM "features" WHERE "slug" IN ('core_hr', 'attendance', 'time_off', 'payroll', 'ewa', 'recruitment', 'performance');
+DROP TABLE IF EXISTS "tenant_addons";
+DROP TABLE IF EXISTS "subscriptions";
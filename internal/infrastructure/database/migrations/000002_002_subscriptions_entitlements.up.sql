@@ -0,0 +1,38 @@
+-- 3. BILLING (Langganan & Entitlement Fitur)
+CREATE TABLE "subscriptions" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "plan_id" UUID NOT NULL REFERENCES "plans"("id"),
+  "status" VARCHAR(50) NOT NULL DEFAULT 'trialing' CHECK ("status" IN ('active', 'trialing', 'past_due', 'canceled')),
+  "started_at" TIMESTAMPTZ NOT NULL,
+  "ends_at" TIMESTAMPTZ NULL,
+  "trial_ends_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE TABLE "tenant_addons" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "feature_id" UUID NOT NULL REFERENCES "features"("id") ON DELETE CASCADE,
+  "expires_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  UNIQUE ("tenant_id", "feature_id")
+);
+
+CREATE INDEX ON "subscriptions" ("tenant_id");
+CREATE INDEX ON "subscriptions" ("plan_id");
+CREATE INDEX ON "tenant_addons" ("tenant_id");
+CREATE INDEX ON "tenant_addons" ("feature_id");
+
+-- Katalog fitur dasar yang dipakai oleh middleware RequireFeature.
+INSERT INTO "features" ("id", "name", "slug", "description", "is_addon") VALUES
+  (uuid_generate_v4(), 'Core HR', 'core_hr', 'Data karyawan dan struktur organisasi', FALSE),
+  (uuid_generate_v4(), 'Attendance', 'attendance', 'Absensi dan manajemen shift', FALSE),
+  (uuid_generate_v4(), 'Time Off', 'time_off', 'Pengajuan dan saldo cuti', FALSE),
+  (uuid_generate_v4(), 'Payroll', 'payroll', 'Penggajian dan komponen gaji', FALSE),
+  (uuid_generate_v4(), 'Early Wage Access', 'ewa', 'Akses gaji lebih awal', TRUE),
+  (uuid_generate_v4(), 'Recruitment', 'recruitment', 'Lowongan dan kandidat', TRUE),
+  (uuid_generate_v4(), 'Performance', 'performance', 'Penilaian kinerja dan goals', TRUE)
+ON CONFLICT ("slug") DO NOTHING;
@@ -0,0 +1,12 @@
+DELETE FROM "features" WHERE "slug" = 'employee_seats';
+ALTER TABLE "tenant_addons" DROP COLUMN IF EXISTS "extra_employee_seats";
+DROP TABLE IF EXISTS "employee_financials";
+DROP TABLE IF EXISTS "employee_profiles";
+DROP TABLE IF EXISTS "employees";
+DROP TABLE IF EXISTS "employment_statuses";
+DROP TABLE IF EXISTS "job_levels";
+DROP TABLE IF EXISTS "positions";
+DROP TABLE IF EXISTS "departments";
+DROP TABLE IF EXISTS "branches";
+DROP TABLE IF EXISTS "sbus";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,149 @@
+-- 4. CORE HR & PERUSAHAAN
+CREATE TABLE "users" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "email" VARCHAR(255) NOT NULL UNIQUE,
+  "email_verified_at" TIMESTAMPTZ NULL,
+  "password" VARCHAR(255) NOT NULL,
+  "timezone" VARCHAR(100) NOT NULL DEFAULT 'UTC',
+  "remember_token" VARCHAR(100) NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "sbus" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "description" TEXT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "branches" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "address" TEXT NULL,
+  "latitude" DECIMAL(10, 8) NULL,
+  "longitude" DECIMAL(11, 8) NULL,
+  "attendance_radius_meters" INT NOT NULL DEFAULT 100 CHECK ("attendance_radius_meters" >= 0),
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "departments" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "positions" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "job_levels" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "employment_statuses" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "employees" (
+  "id" UUID PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "branch_id" UUID NULL REFERENCES "branches"("id"),
+  "department_id" UUID NULL REFERENCES "departments"("id"),
+  "position_id" UUID NULL REFERENCES "positions"("id"),
+  "job_level_id" UUID NULL REFERENCES "job_levels"("id"),
+  "employment_status_id" UUID NULL REFERENCES "employment_statuses"("id"),
+  "sbu_id" UUID NULL REFERENCES "sbus"("id"),
+  "manager_id" UUID NULL REFERENCES "employees"("id") ON DELETE SET NULL,
+  "employee_id_number" VARCHAR(255) NOT NULL,
+  "join_date" DATE NOT NULL,
+  "resign_date" DATE NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL,
+  UNIQUE ("tenant_id", "employee_id_number"),
+  UNIQUE ("user_id")
+);
+
+CREATE TABLE "employee_profiles" (
+  "employee_id" UUID PRIMARY KEY REFERENCES "employees"("id") ON DELETE CASCADE,
+  "full_name" VARCHAR(255) NOT NULL,
+  "nik_ktp" VARCHAR(50) NULL,
+  "place_of_birth" VARCHAR(255) NULL,
+  "date_of_birth" DATE NULL,
+  "gender" VARCHAR(50) NULL CHECK ("gender" IN ('male', 'female')),
+  "marital_status" VARCHAR(50) NULL,
+  "religion" VARCHAR(50) NULL,
+  "address_ktp" TEXT NULL,
+  "address_domicile" TEXT NULL,
+  "emergency_contact_name" VARCHAR(255) NULL,
+  "emergency_contact_phone" VARCHAR(50) NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "employee_financials" (
+  "employee_id" UUID PRIMARY KEY REFERENCES "employees"("id") ON DELETE CASCADE,
+  "bank_name" VARCHAR(100) NULL,
+  "bank_account_number" VARCHAR(100) NULL,
+  "bank_account_holder" VARCHAR(255) NULL,
+  "npwp" VARCHAR(50) NULL,
+  "ptkp_status" VARCHAR(10) NULL,
+  "bpjs_ketenagakerjaan_no" VARCHAR(50) NULL,
+  "bpjs_kesehatan_no" VARCHAR(50) NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE INDEX ON "users" ("tenant_id");
+CREATE INDEX ON "branches" ("tenant_id");
+CREATE INDEX ON "departments" ("tenant_id");
+CREATE INDEX ON "positions" ("tenant_id");
+CREATE INDEX ON "job_levels" ("tenant_id");
+CREATE INDEX ON "employment_statuses" ("tenant_id");
+CREATE INDEX ON "sbus" ("tenant_id");
+
+CREATE INDEX ON "employees" ("tenant_id");
+CREATE INDEX ON "employees" ("branch_id");
+CREATE INDEX ON "employees" ("department_id");
+CREATE INDEX ON "employees" ("position_id");
+CREATE INDEX ON "employees" ("manager_id");
+CREATE INDEX ON "employee_profiles" ("full_name");
+
+-- Addon kursi karyawan: setiap baris tenant_addons bisa menambah kapasitas
+-- di atas plans.employee_limit.
+ALTER TABLE "tenant_addons" ADD COLUMN "extra_employee_seats" INT NOT NULL DEFAULT 0 CHECK ("extra_employee_seats" >= 0);
+
+INSERT INTO "features" ("id", "name", "slug", "description", "is_addon") VALUES
+  (uuid_generate_v4(), 'Extra Employee Seats', 'employee_seats', 'Tambahan kuota karyawan di atas batas paket', TRUE)
+ON CONFLICT ("slug") DO NOTHING;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "billing_credits";
+DROP TABLE IF EXISTS "invoice_payments";
+DROP TABLE IF EXISTS "invoice_items";
+DROP TABLE IF EXISTS "invoices";
+ALTER TABLE "subscriptions" DROP COLUMN IF EXISTS "current_period_end";
+ALTER TABLE "subscriptions" DROP COLUMN IF EXISTS "current_period_start";
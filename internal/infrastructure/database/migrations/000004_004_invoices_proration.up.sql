@@ -0,0 +1,58 @@
+-- 5. BILLING (Tagihan, Pembayaran & Kredit)
+ALTER TABLE "subscriptions" ADD COLUMN "current_period_start" TIMESTAMPTZ NULL;
+ALTER TABLE "subscriptions" ADD COLUMN "current_period_end" TIMESTAMPTZ NULL;
+
+CREATE TABLE "invoices" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id"),
+  "subscription_id" UUID NOT NULL REFERENCES "subscriptions"("id"),
+  "issue_date" DATE NOT NULL,
+  "due_date" DATE NOT NULL,
+  "total_amount" DECIMAL(15, 2) NOT NULL,
+  "status" VARCHAR(50) NOT NULL DEFAULT 'unpaid' CHECK ("status" IN ('unpaid', 'paid', 'overdue', 'void')),
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "invoice_items" (
+  "id" UUID PRIMARY KEY,
+  "invoice_id" UUID NOT NULL REFERENCES "invoices"("id") ON DELETE CASCADE,
+  "description" VARCHAR(255) NOT NULL,
+  "quantity" INT NOT NULL DEFAULT 1,
+  "unit_price" DECIMAL(15, 2) NOT NULL,
+  "total_price" DECIMAL(15, 2) NOT NULL
+);
+
+CREATE TABLE "invoice_payments" (
+  "id" UUID PRIMARY KEY,
+  "invoice_id" UUID NOT NULL REFERENCES "invoices"("id") ON DELETE CASCADE,
+  "payment_date" TIMESTAMPTZ NOT NULL,
+  "amount_paid" DECIMAL(15, 2) NOT NULL,
+  "payment_method" VARCHAR(100) NULL,
+  "transaction_id" VARCHAR(255) NULL,
+  "notes" TEXT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+-- Saldo kredit tenant (misalnya hasil downgrade di tengah periode).
+-- remaining_amount berkurang saat kredit dipakai untuk membayar invoice berikutnya.
+CREATE TABLE "billing_credits" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "source" VARCHAR(50) NOT NULL CHECK ("source" IN ('proration')),
+  "description" VARCHAR(255) NOT NULL,
+  "amount" DECIMAL(15, 2) NOT NULL CHECK ("amount" > 0),
+  "remaining_amount" DECIMAL(15, 2) NOT NULL CHECK ("remaining_amount" >= 0),
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "invoices" ("tenant_id");
+CREATE INDEX ON "invoices" ("subscription_id");
+CREATE INDEX ON "invoices" ("status");
+CREATE INDEX ON "invoice_items" ("invoice_id");
+CREATE INDEX ON "invoice_payments" ("invoice_id");
+CREATE INDEX ON "invoice_payments" ("transaction_id");
+CREATE INDEX ON "billing_credits" ("tenant_id");
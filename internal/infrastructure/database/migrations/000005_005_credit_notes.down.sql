@@ -0,0 +1,7 @@
+ALTER TABLE "billing_credits" DROP CONSTRAINT IF EXISTS "billing_credits_source_check";
+DELETE FROM "billing_credits" WHERE "source" = 'credit_note';
+ALTER TABLE "billing_credits" ADD CONSTRAINT "billing_credits_source_check" CHECK ("source" IN ('proration'));
+ALTER TABLE "billing_credits" DROP COLUMN IF EXISTS "credit_note_id";
+DROP TABLE IF EXISTS "credit_note_items";
+DROP TABLE IF EXISTS "credit_notes";
+DROP TABLE IF EXISTS "billing_sequences";
@@ -0,0 +1,41 @@
+-- 6. BILLING (Credit Note & Refund)
+-- Penomoran dokumen billing per prefix dan tahun, misalnya CN-2026-0001.
+CREATE TABLE "billing_sequences" (
+  "prefix" VARCHAR(20) NOT NULL,
+  "period_year" INT NOT NULL,
+  "last_value" INT NOT NULL DEFAULT 0,
+  PRIMARY KEY ("prefix", "period_year")
+);
+
+CREATE TABLE "credit_notes" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id"),
+  "invoice_id" UUID NOT NULL REFERENCES "invoices"("id"),
+  "credit_note_number" VARCHAR(50) NOT NULL UNIQUE,
+  "issue_date" DATE NOT NULL,
+  "reason" TEXT NOT NULL,
+  "resolution" VARCHAR(50) NOT NULL CHECK ("resolution" IN ('credit', 'refund')),
+  "total_amount" DECIMAL(15, 2) NOT NULL CHECK ("total_amount" > 0),
+  "status" VARCHAR(50) NOT NULL DEFAULT 'issued' CHECK ("status" IN ('issued', 'void')),
+  "created_by" UUID NULL REFERENCES "admin_users"("id"),
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE TABLE "credit_note_items" (
+  "id" UUID PRIMARY KEY,
+  "credit_note_id" UUID NOT NULL REFERENCES "credit_notes"("id") ON DELETE CASCADE,
+  "description" VARCHAR(255) NOT NULL,
+  "quantity" INT NOT NULL DEFAULT 1,
+  "unit_price" DECIMAL(15, 2) NOT NULL,
+  "total_price" DECIMAL(15, 2) NOT NULL
+);
+
+-- Kredit dari credit note (resolution = credit) masuk ke saldo kredit tenant.
+ALTER TABLE "billing_credits" ADD COLUMN "credit_note_id" UUID NULL REFERENCES "credit_notes"("id");
+ALTER TABLE "billing_credits" DROP CONSTRAINT IF EXISTS "billing_credits_source_check";
+ALTER TABLE "billing_credits" ADD CONSTRAINT "billing_credits_source_check" CHECK ("source" IN ('proration', 'credit_note'));
+
+CREATE INDEX ON "credit_notes" ("tenant_id");
+CREATE INDEX ON "credit_notes" ("invoice_id");
+CREATE INDEX ON "credit_note_items" ("credit_note_id");
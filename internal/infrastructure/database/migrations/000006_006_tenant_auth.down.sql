@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "users_tenant_id_email_idx";
+DROP TABLE IF EXISTS "role_user";
+DROP TABLE IF EXISTS "roles";
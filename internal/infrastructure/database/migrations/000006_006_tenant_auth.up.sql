@@ -0,0 +1,22 @@
+-- Peran pengguna tenant. Permission (tenant_permissions dan pivot-nya)
+-- ditambahkan bersama subsistem RBAC.
+CREATE TABLE "roles" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "description" TEXT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL,
+  UNIQUE("tenant_id", "name")
+);
+
+CREATE TABLE "role_user" (
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "role_id" UUID NOT NULL REFERENCES "roles"("id") ON DELETE CASCADE,
+  PRIMARY KEY ("user_id", "role_id")
+);
+
+CREATE INDEX ON "roles" ("tenant_id");
+CREATE INDEX ON "role_user" ("role_id");
+CREATE INDEX ON "users" ("tenant_id", "email");
@@ -0,0 +1,17 @@
+-- Undangan staf tenant. Setiap kirim ulang membuat baris baru dan mencabut
+-- (revoked_at) undangan lama, sehingga link lama tidak bisa dipakai lagi.
+CREATE TABLE "user_invitations" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "email" VARCHAR(255) NOT NULL,
+  "expires_at" TIMESTAMPTZ NOT NULL,
+  "accepted_at" TIMESTAMPTZ NULL,
+  "revoked_at" TIMESTAMPTZ NULL,
+  "invited_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "user_invitations" ("tenant_id");
+CREATE INDEX ON "user_invitations" ("user_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "roles_tenant_id_name_active_key";
+ALTER TABLE "roles" ADD CONSTRAINT "roles_tenant_id_name_key" UNIQUE ("tenant_id", "name");
+DROP TABLE IF EXISTS "user_has_permissions";
+DROP TABLE IF EXISTS "permission_role";
+DROP TABLE IF EXISTS "tenant_permissions";
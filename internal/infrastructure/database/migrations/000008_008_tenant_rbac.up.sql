@@ -0,0 +1,44 @@
+-- Master hak akses (global). Nama mengikuti format "aksi:resource".
+CREATE TABLE "tenant_permissions" (
+  "id" UUID PRIMARY KEY,
+  "name" VARCHAR(255) NOT NULL UNIQUE,
+  "group_name" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "permission_role" (
+  "permission_id" UUID NOT NULL REFERENCES "tenant_permissions"("id") ON DELETE CASCADE,
+  "role_id" UUID NOT NULL REFERENCES "roles"("id") ON DELETE CASCADE,
+  PRIMARY KEY ("permission_id", "role_id")
+);
+
+CREATE TABLE "user_has_permissions" (
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "permission_id" UUID NOT NULL REFERENCES "tenant_permissions"("id") ON DELETE CASCADE,
+  PRIMARY KEY ("user_id", "permission_id")
+);
+
+CREATE INDEX ON "permission_role" ("role_id");
+
+-- Role yang sudah dihapus (soft delete) tidak boleh mengunci namanya.
+ALTER TABLE "roles" DROP CONSTRAINT IF EXISTS "roles_tenant_id_name_key";
+CREATE UNIQUE INDEX "roles_tenant_id_name_active_key" ON "roles" ("tenant_id", "name") WHERE "deleted_at" IS NULL;
+
+INSERT INTO "tenant_permissions" ("id", "name", "group_name", "created_at", "updated_at") VALUES
+  (uuid_generate_v4(), 'manage:roles', 'Akses', now(), now()),
+  (uuid_generate_v4(), 'manage:users', 'Akses', now(), now()),
+  (uuid_generate_v4(), 'view:employees', 'Karyawan', now(), now()),
+  (uuid_generate_v4(), 'manage:employees', 'Karyawan', now(), now()),
+  (uuid_generate_v4(), 'manage:organization', 'Organisasi', now(), now()),
+  (uuid_generate_v4(), 'view:attendance', 'Absensi', now(), now()),
+  (uuid_generate_v4(), 'manage:attendance', 'Absensi', now(), now()),
+  (uuid_generate_v4(), 'request:leave', 'Cuti', now(), now()),
+  (uuid_generate_v4(), 'approve:leave', 'Cuti', now(), now()),
+  (uuid_generate_v4(), 'view:payroll', 'Payroll', now(), now()),
+  (uuid_generate_v4(), 'manage:payroll', 'Payroll', now(), now()),
+  (uuid_generate_v4(), 'manage:documents', 'Dokumen', now(), now()),
+  (uuid_generate_v4(), 'view:reports', 'Laporan', now(), now()),
+  (uuid_generate_v4(), 'manage:settings', 'Pengaturan', now(), now())
+ON CONFLICT ("name") DO NOTHING;
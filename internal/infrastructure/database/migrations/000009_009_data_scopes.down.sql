@@ -0,0 +1,2 @@
+ALTER TABLE "user_has_permissions" DROP COLUMN IF EXISTS "data_scope";
+ALTER TABLE "permission_role" DROP COLUMN IF EXISTS "data_scope";
@@ -0,0 +1,8 @@
+-- Data scope membatasi baris karyawan yang bisa diakses lewat sebuah hak akses.
+ALTER TABLE "permission_role"
+  ADD COLUMN "data_scope" VARCHAR(50) NOT NULL DEFAULT 'tenant'
+  CHECK ("data_scope" IN ('self', 'direct_reports', 'subordinates', 'branch', 'department', 'sbu', 'tenant'));
+
+ALTER TABLE "user_has_permissions"
+  ADD COLUMN "data_scope" VARCHAR(50) NOT NULL DEFAULT 'tenant'
+  CHECK ("data_scope" IN ('self', 'direct_reports', 'subordinates', 'branch', 'department', 'sbu', 'tenant'));
@@ -0,0 +1,19 @@
+DO $$
+DECLARE
+  t TEXT;
+BEGIN
+  FOREACH t IN ARRAY ARRAY[
+    'users', 'sbus', 'branches', 'departments', 'positions', 'job_levels',
+    'employment_statuses', 'employees', 'roles', 'user_invitations',
+    'employee_profiles', 'employee_financials', 'role_user', 'permission_role',
+    'user_has_permissions'
+  ] LOOP
+    EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+    EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+    EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+  END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS enable_tenant_rls(TEXT);
+DROP FUNCTION IF EXISTS app_tenant_visible(UUID);
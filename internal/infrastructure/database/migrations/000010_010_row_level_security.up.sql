@@ -0,0 +1,72 @@
+-- Row Level Security untuk tabel milik tenant. Aplikasi mengisi
+-- 'app.tenant_id' per transaksi (set_config(..., true) = SET LOCAL) lewat
+-- database.TenantDB; tanpa tenant, baris tenant tidak terlihat sama sekali.
+-- Route superadmin dan job platform mengisi 'app.bypass_rls' = 'on'.
+--
+-- FORCE dipakai agar aturan tetap berlaku untuk owner tabel. Role database
+-- aplikasi TIDAK boleh superuser atau BYPASSRLS, karena keduanya selalu
+-- melewati policy.
+CREATE OR REPLACE FUNCTION app_tenant_visible(row_tenant_id UUID) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+  SELECT COALESCE(current_setting('app.bypass_rls', true), '') = 'on'
+      OR row_tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::UUID
+$$;
+
+-- enable_tenant_rls memasang policy standar pada tabel yang punya kolom
+-- tenant_id. Migrasi berikutnya cukup memanggil SELECT enable_tenant_rls('x').
+CREATE OR REPLACE FUNCTION enable_tenant_rls(table_name TEXT) RETURNS VOID
+LANGUAGE plpgsql AS $$
+BEGIN
+  EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', table_name);
+  EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', table_name);
+  EXECUTE format(
+    'CREATE POLICY tenant_isolation ON %I USING (app_tenant_visible(tenant_id)) WITH CHECK (app_tenant_visible(tenant_id))',
+    table_name
+  );
+END
+$$;
+
+SELECT enable_tenant_rls(t) FROM unnest(ARRAY[
+  'users',
+  'sbus',
+  'branches',
+  'departments',
+  'positions',
+  'job_levels',
+  'employment_statuses',
+  'employees',
+  'roles',
+  'user_invitations'
+]) AS t;
+
+-- Tabel anak tanpa tenant_id ikut terlihat hanya jika induknya terlihat
+-- (subquery ke induk juga tunduk pada policy induk).
+ALTER TABLE "employee_profiles" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_profiles" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_profiles"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
+
+ALTER TABLE "employee_financials" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_financials" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_financials"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
+
+ALTER TABLE "role_user" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "role_user" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "role_user"
+  USING (EXISTS (SELECT 1 FROM "roles" r WHERE r.id = role_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "roles" r WHERE r.id = role_id));
+
+ALTER TABLE "permission_role" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "permission_role" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "permission_role"
+  USING (EXISTS (SELECT 1 FROM "roles" r WHERE r.id = role_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "roles" r WHERE r.id = role_id));
+
+ALTER TABLE "user_has_permissions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "user_has_permissions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "user_has_permissions"
+  USING (EXISTS (SELECT 1 FROM "users" u WHERE u.id = user_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "users" u WHERE u.id = user_id));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "audit_logs";
+DROP TABLE IF EXISTS "impersonation_sessions";
+
+DELETE FROM "admin_roles" WHERE "name" = 'Support';
+DELETE FROM "admin_permissions" WHERE "name" = 'impersonate:tenant_users';
@@ -0,0 +1,53 @@
+-- Impersonasi superadmin: hanya admin dengan hak akses
+-- 'impersonate:tenant_users' yang boleh membuat token tenant atas nama user.
+INSERT INTO "admin_permissions" ("id", "name", "group_name") VALUES
+  (uuid_generate_v4(), 'impersonate:tenant_users', 'Support')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "admin_roles" ("id", "name", "description") VALUES
+  (uuid_generate_v4(), 'Support', 'Tim support yang boleh masuk sebagai user tenant')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "admin_permission_role" ("permission_id", "admin_role_id")
+SELECT p."id", r."id"
+FROM "admin_permissions" p, "admin_roles" r
+WHERE p."name" = 'impersonate:tenant_users' AND r."name" = 'Support'
+ON CONFLICT DO NOTHING;
+
+-- Sesi impersonasi milik platform (tanpa RLS), dibaca ulang di setiap
+-- request supaya sesi yang diakhiri langsung tidak berlaku.
+CREATE TABLE "impersonation_sessions" (
+  "id" UUID PRIMARY KEY,
+  "admin_user_id" UUID NOT NULL REFERENCES "admin_users"("id") ON DELETE CASCADE,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "reason" TEXT NOT NULL,
+  "read_only" BOOLEAN NOT NULL DEFAULT TRUE,
+  "expires_at" TIMESTAMPTZ NOT NULL,
+  "ended_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "impersonation_sessions" ("tenant_id");
+CREATE INDEX ON "impersonation_sessions" ("admin_user_id");
+
+-- Jejak aksi di aplikasi tenant. Saat ini diisi untuk setiap request yang
+-- dilakukan lewat token impersonasi.
+CREATE TABLE "audit_logs" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "user_id" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "impersonator_admin_id" UUID NULL REFERENCES "admin_users"("id") ON DELETE SET NULL,
+  "impersonation_session_id" UUID NULL REFERENCES "impersonation_sessions"("id") ON DELETE SET NULL,
+  "method" VARCHAR(10) NOT NULL,
+  "path" TEXT NOT NULL,
+  "status_code" INT NOT NULL,
+  "ip_address" VARCHAR(64) NULL,
+  "user_agent" TEXT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_logs" ("tenant_id", "created_at");
+CREATE INDEX ON "audit_logs" ("impersonation_session_id");
+
+SELECT enable_tenant_rls('audit_logs');
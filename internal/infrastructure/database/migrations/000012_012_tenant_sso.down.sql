@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "sso_login_requests";
+DROP TABLE IF EXISTS "tenant_sso_configs";
@@ -0,0 +1,37 @@
+-- Konfigurasi SSO per tenant (satu IdP per tenant). Kolom OIDC atau SAML
+-- diisi sesuai protocol; yang tidak dipakai dibiarkan kosong.
+CREATE TABLE "tenant_sso_configs" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL UNIQUE REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "protocol" VARCHAR(10) NOT NULL CHECK ("protocol" IN ('oidc', 'saml')),
+  "enabled" BOOLEAN NOT NULL DEFAULT FALSE,
+  "jit_provisioning" BOOLEAN NOT NULL DEFAULT FALSE,
+  "password_login_disabled" BOOLEAN NOT NULL DEFAULT FALSE,
+  "oidc_issuer" TEXT NOT NULL DEFAULT '',
+  "oidc_client_id" TEXT NOT NULL DEFAULT '',
+  "oidc_client_secret" TEXT NOT NULL DEFAULT '',
+  "saml_idp_metadata_url" TEXT NOT NULL DEFAULT '',
+  "saml_idp_metadata_xml" TEXT NOT NULL DEFAULT '',
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+-- Satu baris per percobaan login SSO. ID dipakai sebagai 'state' (OIDC) atau
+-- 'RelayState' (SAML) dan hanya bisa dipakai sekali.
+CREATE TABLE "sso_login_requests" (
+  "id" VARCHAR(64) PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "protocol" VARCHAR(10) NOT NULL CHECK ("protocol" IN ('oidc', 'saml')),
+  "code_verifier" TEXT NOT NULL DEFAULT '',
+  "nonce" TEXT NOT NULL DEFAULT '',
+  "saml_request_id" TEXT NOT NULL DEFAULT '',
+  "expires_at" TIMESTAMPTZ NOT NULL,
+  "consumed_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "sso_login_requests" ("tenant_id");
+CREATE INDEX ON "sso_login_requests" ("expires_at");
+
+SELECT enable_tenant_rls('tenant_sso_configs');
+SELECT enable_tenant_rls('sso_login_requests');
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "scim_tokens";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "scim_external_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "login_disabled_at";
@@ -0,0 +1,25 @@
+-- login_disabled_at menonaktifkan login user tanpa menghapus datanya. Diisi
+-- saat user dinonaktifkan lewat SCIM (dan proses offboarding).
+ALTER TABLE "users" ADD COLUMN "login_disabled_at" TIMESTAMPTZ NULL;
+
+-- externalId SCIM: ID user di Identity Provider tenant.
+ALTER TABLE "users" ADD COLUMN "scim_external_id" VARCHAR(255) NULL;
+CREATE UNIQUE INDEX ON "users" ("tenant_id", "scim_external_id")
+  WHERE "scim_external_id" IS NOT NULL AND "deleted_at" IS NULL;
+
+-- Bearer token untuk endpoint SCIM. Hanya hash SHA-256 yang disimpan;
+-- token_prefix ditampilkan agar admin bisa mengenali token-nya.
+CREATE TABLE "scim_tokens" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "token_prefix" VARCHAR(32) NOT NULL,
+  "token_hash" VARCHAR(64) NOT NULL UNIQUE,
+  "last_used_at" TIMESTAMPTZ NULL,
+  "revoked_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "scim_tokens" ("tenant_id");
+
+SELECT enable_tenant_rls('scim_tokens');
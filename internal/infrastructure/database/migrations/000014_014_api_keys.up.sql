@@ -0,0 +1,39 @@
+-- API key per tenant untuk integrasi mesin-ke-mesin (ERP, mesin absensi).
+-- Seperti token SCIM, hanya hash SHA-256 yang disimpan dan key_prefix
+-- ditampilkan agar admin bisa mengenali key-nya.
+CREATE TABLE "api_keys" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "key_prefix" VARCHAR(32) NOT NULL,
+  "key_hash" VARCHAR(64) NOT NULL UNIQUE,
+  -- Daftar IP atau CIDR yang boleh memakai key. Kosong berarti semua IP.
+  "allowed_ips" TEXT[] NOT NULL DEFAULT '{}',
+  "expires_at" TIMESTAMPTZ NULL,
+  "last_used_at" TIMESTAMPTZ NULL,
+  "last_used_ip" VARCHAR(45) NULL,
+  "revoked_at" TIMESTAMPTZ NULL,
+  -- Diisi saat key dirotasi, menunjuk ke key penggantinya.
+  "replaced_by_id" UUID NULL REFERENCES "api_keys"("id") ON DELETE SET NULL,
+  "created_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("tenant_id");
+
+-- Hak akses API key selalu berlaku untuk seluruh tenant (tidak ada data
+-- scope, karena API key tidak terhubung ke karyawan).
+CREATE TABLE "api_key_permissions" (
+  "api_key_id" UUID NOT NULL REFERENCES "api_keys"("id") ON DELETE CASCADE,
+  "permission_id" UUID NOT NULL REFERENCES "tenant_permissions"("id") ON DELETE CASCADE,
+  PRIMARY KEY ("api_key_id", "permission_id")
+);
+
+SELECT enable_tenant_rls('api_keys');
+
+ALTER TABLE "api_key_permissions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "api_key_permissions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "api_key_permissions"
+  USING (EXISTS (SELECT 1 FROM "api_keys" k WHERE k.id = api_key_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "api_keys" k WHERE k.id = api_key_id));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "user_password_histories";
+DROP TABLE IF EXISTS "tenant_password_policies";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "password_changed_at";
@@ -0,0 +1,39 @@
+-- Kebijakan password staf per tenant. Tenant tanpa baris di sini memakai
+-- kebijakan bawaan (minimal 10 karakter, tolak password umum).
+CREATE TABLE "tenant_password_policies" (
+  "tenant_id" UUID PRIMARY KEY REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "min_length" INT NOT NULL DEFAULT 10,
+  "require_uppercase" BOOLEAN NOT NULL DEFAULT false,
+  "require_lowercase" BOOLEAN NOT NULL DEFAULT false,
+  "require_digit" BOOLEAN NOT NULL DEFAULT false,
+  "require_symbol" BOOLEAN NOT NULL DEFAULT false,
+  -- 0 berarti password tidak pernah kedaluwarsa.
+  "expiry_days" INT NOT NULL DEFAULT 0,
+  -- Jumlah password terakhir yang tidak boleh dipakai ulang.
+  "history_count" INT NOT NULL DEFAULT 0,
+  "block_common" BOOLEAN NOT NULL DEFAULT true,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+SELECT enable_tenant_rls('tenant_password_policies');
+
+ALTER TABLE "users" ADD COLUMN "password_changed_at" TIMESTAMPTZ NULL;
+UPDATE "users" SET "password_changed_at" = COALESCE("updated_at", now()) WHERE "password" <> '';
+
+-- Hash password lama untuk aturan "tidak boleh memakai ulang N password
+-- terakhir".
+CREATE TABLE "user_password_histories" (
+  "id" UUID PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+  "password_hash" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "user_password_histories" ("user_id", "created_at");
+
+ALTER TABLE "user_password_histories" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "user_password_histories" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "user_password_histories"
+  USING (EXISTS (SELECT 1 FROM "users" u WHERE u.id = user_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "users" u WHERE u.id = user_id));
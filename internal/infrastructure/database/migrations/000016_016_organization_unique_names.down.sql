@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "sbus_tenant_name_key";
+DROP INDEX IF EXISTS "branches_tenant_name_key";
+DROP INDEX IF EXISTS "departments_tenant_name_key";
+DROP INDEX IF EXISTS "positions_tenant_name_key";
+DROP INDEX IF EXISTS "job_levels_tenant_name_key";
+DROP INDEX IF EXISTS "employment_statuses_tenant_name_key";
+
+DROP INDEX IF EXISTS "employees_job_level_id_idx";
+DROP INDEX IF EXISTS "employees_employment_status_id_idx";
+DROP INDEX IF EXISTS "employees_sbu_id_idx";
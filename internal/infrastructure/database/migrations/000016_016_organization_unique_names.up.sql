@@ -0,0 +1,13 @@
+-- Nama data master organisasi unik per tenant (tanpa membedakan huruf besar
+-- kecil) agar bisa dipakai sebagai acuan, misalnya saat impor karyawan.
+-- Baris yang sudah dihapus (soft delete) tidak ikut dihitung.
+CREATE UNIQUE INDEX "sbus_tenant_name_key" ON "sbus" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "branches_tenant_name_key" ON "branches" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "departments_tenant_name_key" ON "departments" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "positions_tenant_name_key" ON "positions" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "job_levels_tenant_name_key" ON "job_levels" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "employment_statuses_tenant_name_key" ON "employment_statuses" ("tenant_id", lower("name")) WHERE "deleted_at" IS NULL;
+
+CREATE INDEX ON "employees" ("job_level_id");
+CREATE INDEX ON "employees" ("employment_status_id");
+CREATE INDEX ON "employees" ("sbu_id");
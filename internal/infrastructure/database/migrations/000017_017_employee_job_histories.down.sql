@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "employee_job_histories";
+
+ALTER TABLE "employee_financials" DROP COLUMN IF EXISTS "basic_salary";
@@ -0,0 +1,57 @@
+-- Gaji pokok saat ini. Riwayat perubahannya ada di employee_job_histories.
+ALTER TABLE "employee_financials" ADD COLUMN "basic_salary" DECIMAL(15, 2) NULL;
+
+-- Riwayat jabatan karyawan (mutasi, promosi, demosi, perubahan status/gaji).
+-- Setiap baris menyimpan posisi LENGKAP karyawan sejak effective_date,
+-- sehingga posisi pada tanggal tertentu cukup dibaca dari baris terakhir
+-- sebelum tanggal itu. Perubahan dengan effective_date di masa depan
+-- berstatus 'scheduled' dan diterapkan job terjadwal pada tanggalnya.
+CREATE TABLE "employee_job_histories" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "change_type" VARCHAR(50) NOT NULL CHECK ("change_type" IN (
+    'hire', 'promotion', 'demotion', 'transfer', 'mutation', 'status_change', 'salary_adjustment', 'correction'
+  )),
+  "effective_date" DATE NOT NULL,
+  "status" VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK ("status" IN ('scheduled', 'applied', 'cancelled', 'failed')),
+  "branch_id" UUID NULL REFERENCES "branches"("id"),
+  "department_id" UUID NULL REFERENCES "departments"("id"),
+  "position_id" UUID NULL REFERENCES "positions"("id"),
+  "job_level_id" UUID NULL REFERENCES "job_levels"("id"),
+  "employment_status_id" UUID NULL REFERENCES "employment_statuses"("id"),
+  "sbu_id" UUID NULL REFERENCES "sbus"("id"),
+  "manager_id" UUID NULL REFERENCES "employees"("id") ON DELETE SET NULL,
+  "basic_salary" DECIMAL(15, 2) NULL,
+  "reason" TEXT NULL,
+  -- Nomor SK (surat keputusan) perubahan.
+  "decree_number" VARCHAR(100) NULL,
+  -- Alasan job terjadwal gagal menerapkan perubahan (status 'failed').
+  "failure_reason" TEXT NULL,
+  "created_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "applied_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "employee_job_histories" ("employee_id", "effective_date");
+CREATE INDEX ON "employee_job_histories" ("effective_date") WHERE "status" = 'scheduled';
+
+SELECT enable_tenant_rls('employee_job_histories');
+
+-- Karyawan yang sudah ada mendapat baris 'hire' berisi posisi saat ini agar
+-- riwayat tidak kosong. Backfill lintas tenant, jadi RLS di-bypass sementara.
+SELECT set_config('app.bypass_rls', 'on', false);
+
+INSERT INTO "employee_job_histories" (
+  "id", "tenant_id", "employee_id", "change_type", "effective_date", "status",
+  "branch_id", "department_id", "position_id", "job_level_id", "employment_status_id", "sbu_id", "manager_id",
+  "applied_at", "created_at", "updated_at"
+)
+SELECT uuid_generate_v4(), e.tenant_id, e.id, 'hire', e.join_date, 'applied',
+       e.branch_id, e.department_id, e.position_id, e.job_level_id, e.employment_status_id, e.sbu_id, e.manager_id,
+       now(), now(), now()
+FROM "employees" e
+WHERE e.deleted_at IS NULL;
+
+SELECT set_config('app.bypass_rls', '', false);
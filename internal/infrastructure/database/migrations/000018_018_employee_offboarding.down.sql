@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "employee_final_pay_items";
+DROP TABLE IF EXISTS "employee_terminations";
+DROP TABLE IF EXISTS "employee_onboarding_offboardings";
+DROP TABLE IF EXISTS "onboarding_offboarding_templates";
+DROP TABLE IF EXISTS "expense_claims";
+DROP TABLE IF EXISTS "loans";
+DROP TABLE IF EXISTS "employee_time_off_balances";
+DROP TABLE IF EXISTS "time_off_policies";
@@ -0,0 +1,167 @@
+-- Tabel berikut mengikuti desain di DB.md. Offboarding membaca saldo cuti,
+-- pinjaman, dan klaim untuk menandai komponen pembayaran akhir; modul
+-- pengelolanya menulis ke tabel yang sama.
+CREATE TABLE "time_off_policies" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "default_balance" DECIMAL(8, 2) NOT NULL DEFAULT 12.00,
+  "is_unlimited" BOOLEAN NOT NULL DEFAULT FALSE,
+  "is_prorated" BOOLEAN NOT NULL DEFAULT FALSE,
+  "can_carry_forward" BOOLEAN NOT NULL DEFAULT FALSE,
+  "carry_forward_max_days" DECIMAL(8, 2) NULL,
+  "carry_forward_expiry_months" INT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "employee_time_off_balances" (
+  "id" UUID PRIMARY KEY,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "policy_id" UUID NOT NULL REFERENCES "time_off_policies"("id") ON DELETE CASCADE,
+  "period_year" INT NOT NULL,
+  "balance" DECIMAL(8, 2) NOT NULL DEFAULT 0.00,
+  "accrued" DECIMAL(8, 2) NOT NULL DEFAULT 0.00,
+  "taken" DECIMAL(8, 2) NOT NULL DEFAULT 0.00,
+  "carry_forward" DECIMAL(8, 2) NOT NULL DEFAULT 0.00,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  UNIQUE("employee_id", "policy_id", "period_year")
+);
+
+CREATE TABLE "loans" (
+  "id" UUID PRIMARY KEY,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "principal_amount" DECIMAL(15, 2) NOT NULL,
+  "installments_count" INT NOT NULL CHECK ("installments_count" > 0),
+  "monthly_installment" DECIMAL(15, 2) NOT NULL,
+  "start_date" DATE NOT NULL,
+  "status" VARCHAR(50) NOT NULL DEFAULT 'ongoing' CHECK ("status" IN ('ongoing', 'paid_off')),
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "expense_claims" (
+  "id" UUID PRIMARY KEY,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "claim_date" DATE NOT NULL,
+  "description" VARCHAR(255) NOT NULL,
+  "amount" DECIMAL(15, 2) NOT NULL,
+  "status" VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "approved_by" UUID NULL REFERENCES "users"("id"),
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "onboarding_offboarding_templates" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "name" VARCHAR(255) NOT NULL,
+  "type" VARCHAR(50) NOT NULL CHECK ("type" IN ('onboarding', 'offboarding')),
+  -- Array judul tugas, mis. ["Kembalikan laptop", "Serah terima pekerjaan"].
+  "tasks" JSONB NOT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE TABLE "employee_onboarding_offboardings" (
+  "id" UUID PRIMARY KEY,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "template_id" UUID NOT NULL REFERENCES "onboarding_offboarding_templates"("id") ON DELETE CASCADE,
+  "status" VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'in_progress', 'completed')),
+  -- Salinan tugas template saat checklist dibuat beserta statusnya:
+  -- [{"title": "...", "done": false, "done_at": null, "done_by": null}].
+  "task_statuses" JSONB NOT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL
+);
+
+CREATE INDEX ON "employee_time_off_balances" ("employee_id");
+CREATE INDEX ON "loans" ("employee_id");
+CREATE INDEX ON "expense_claims" ("employee_id");
+CREATE INDEX ON "onboarding_offboarding_templates" ("tenant_id", "type");
+CREATE INDEX ON "employee_onboarding_offboardings" ("employee_id");
+
+-- Pengakhiran hubungan kerja. Satu karyawan hanya punya satu pengakhiran
+-- yang belum dibatalkan.
+CREATE TABLE "employee_terminations" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "termination_type" VARCHAR(50) NOT NULL CHECK ("termination_type" IN (
+    'resignation', 'dismissal', 'layoff', 'contract_end', 'retirement', 'death', 'mutual_agreement'
+  )),
+  "reason" TEXT NOT NULL,
+  "last_working_day" DATE NOT NULL,
+  -- Tanggal login dinonaktifkan.
+  "effective_date" DATE NOT NULL,
+  "eligible_for_rehire" BOOLEAN NOT NULL DEFAULT TRUE,
+  "status" VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK ("status" IN ('scheduled', 'completed', 'cancelled')),
+  "checklist_id" UUID NULL REFERENCES "employee_onboarding_offboardings"("id") ON DELETE SET NULL,
+  "created_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "completed_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "employee_terminations_active_key" ON "employee_terminations" ("employee_id") WHERE "status" <> 'cancelled';
+CREATE INDEX ON "employee_terminations" ("effective_date") WHERE "status" = 'scheduled';
+
+-- Komponen pembayaran akhir yang harus diperhitungkan payroll berikutnya.
+-- Dibuat saat pengakhiran dicatat; payroll menandainya settled.
+CREATE TABLE "employee_final_pay_items" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "termination_id" UUID NOT NULL REFERENCES "employee_terminations"("id") ON DELETE CASCADE,
+  "item_type" VARCHAR(50) NOT NULL CHECK ("item_type" IN ('leave_payout', 'loan_deduction', 'expense_claim')),
+  -- ID baris sumber (saldo cuti, pinjaman, atau klaim).
+  "source_id" UUID NULL,
+  "description" VARCHAR(255) NOT NULL,
+  -- Jumlah hari untuk leave_payout.
+  "quantity" DECIMAL(8, 2) NULL,
+  -- Perkiraan nominal; NULL jika dihitung payroll (mis. uang pengganti cuti).
+  "amount" DECIMAL(15, 2) NULL,
+  "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'settled')),
+  "settled_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "employee_final_pay_items" ("tenant_id", "status");
+CREATE INDEX ON "employee_final_pay_items" ("termination_id");
+
+SELECT enable_tenant_rls(t) FROM unnest(ARRAY[
+  'time_off_policies',
+  'onboarding_offboarding_templates',
+  'employee_terminations',
+  'employee_final_pay_items'
+]) AS t;
+
+ALTER TABLE "employee_time_off_balances" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_time_off_balances" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_time_off_balances"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
+
+ALTER TABLE "loans" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "loans" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "loans"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
+
+ALTER TABLE "expense_claims" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "expense_claims" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "expense_claims"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
+
+ALTER TABLE "employee_onboarding_offboardings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_onboarding_offboardings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_onboarding_offboardings"
+  USING (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employees" e WHERE e.id = employee_id));
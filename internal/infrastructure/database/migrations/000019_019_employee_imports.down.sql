@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "employee_import_rows";
+DROP TABLE IF EXISTS "employee_imports";
+
+ALTER TABLE "employees" DROP COLUMN IF EXISTS "custom_fields";
@@ -0,0 +1,56 @@
+-- Nilai custom field karyawan, disimpan per kunci field.
+ALTER TABLE "employees" ADD COLUMN "custom_fields" JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Impor karyawan massal dari CSV/XLSX. Baris file disimpan di
+-- employee_import_rows lalu diproses job latar per chunk, sehingga job yang
+-- terputus bisa dilanjutkan dari baris yang masih pending.
+CREATE TABLE "employee_imports" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "file_name" VARCHAR(255) NOT NULL,
+  "file_format" VARCHAR(10) NOT NULL CHECK ("file_format" IN ('csv', 'xlsx')),
+  -- Pemetaan kolom file ke field karyawan: {"Nama Lengkap": "full_name"}.
+  "mapping" JSONB NOT NULL,
+  -- Dry run hanya memvalidasi, tidak menyimpan karyawan.
+  "dry_run" BOOLEAN NOT NULL DEFAULT FALSE,
+  "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'processing', 'completed', 'failed')),
+  "total_rows" INT NOT NULL DEFAULT 0,
+  "processed_rows" INT NOT NULL DEFAULT 0,
+  -- Baris valid (dry run) atau berhasil diimpor.
+  "success_rows" INT NOT NULL DEFAULT 0,
+  "failed_rows" INT NOT NULL DEFAULT 0,
+  "error_message" TEXT NULL,
+  "created_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "started_at" TIMESTAMPTZ NULL,
+  "finished_at" TIMESTAMPTZ NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+CREATE INDEX ON "employee_imports" ("tenant_id", "created_at");
+CREATE INDEX ON "employee_imports" ("status") WHERE "status" IN ('pending', 'processing');
+
+CREATE TABLE "employee_import_rows" (
+  "id" UUID PRIMARY KEY,
+  "import_id" UUID NOT NULL REFERENCES "employee_imports"("id") ON DELETE CASCADE,
+  -- Nomor baris di file (header = baris 1).
+  "row_number" INT NOT NULL,
+  "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'valid', 'invalid', 'imported', 'failed')),
+  -- Nilai baris setelah dipetakan: {"full_name": "...", "custom.shirt_size": "L"}.
+  "data" JSONB NOT NULL,
+  "errors" JSONB NOT NULL DEFAULT '[]'::jsonb,
+  "employee_id" UUID NULL REFERENCES "employees"("id") ON DELETE SET NULL,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  UNIQUE ("import_id", "row_number")
+);
+
+CREATE INDEX ON "employee_import_rows" ("import_id", "status");
+
+SELECT enable_tenant_rls('employee_imports');
+
+ALTER TABLE "employee_import_rows" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_import_rows" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_import_rows"
+  USING (EXISTS (SELECT 1 FROM "employee_imports" i WHERE i.id = import_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employee_imports" i WHERE i.id = import_id));
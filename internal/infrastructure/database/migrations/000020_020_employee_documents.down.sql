@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "employee_document_versions";
+DROP TABLE IF EXISTS "employee_documents";
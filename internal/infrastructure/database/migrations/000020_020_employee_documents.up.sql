@@ -0,0 +1,49 @@
+-- Dokumen karyawan mengikuti desain di DB.md, ditambah tenant_id (untuk RLS
+-- dan dashboard), metadata file, serta riwayat versi. file_path menunjuk
+-- versi terbaru; setiap unggahan ulang menambah baris di
+-- employee_document_versions.
+CREATE TABLE "employee_documents" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "employee_id" UUID NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+  "document_type" VARCHAR(255) NOT NULL, -- e.g., 'KTP', 'Kontrak Kerja', 'Sertifikat'
+  "file_path" VARCHAR(255) NOT NULL, -- Key file di storage
+  "file_name" VARCHAR(255) NOT NULL,
+  "content_type" VARCHAR(255) NOT NULL,
+  "file_size" BIGINT NOT NULL,
+  "version" INT NOT NULL DEFAULT 1,
+  "expiry_date" DATE NULL, -- Untuk pengingat masa berlaku (PRD 2.2.2)
+  -- Tanggal kedaluwarsa yang sudah diingatkan. Pengingat dikirim lagi jika
+  -- expiry_date diperbarui.
+  "reminder_sent_for" DATE NULL,
+  "uploaded_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE INDEX ON "employee_documents" ("employee_id");
+CREATE INDEX ON "employee_documents" ("tenant_id", "expiry_date") WHERE "deleted_at" IS NULL AND "expiry_date" IS NOT NULL;
+
+CREATE TABLE "employee_document_versions" (
+  "id" UUID PRIMARY KEY,
+  "document_id" UUID NOT NULL REFERENCES "employee_documents"("id") ON DELETE CASCADE,
+  "version" INT NOT NULL,
+  "file_path" VARCHAR(255) NOT NULL,
+  "file_name" VARCHAR(255) NOT NULL,
+  "content_type" VARCHAR(255) NOT NULL,
+  "file_size" BIGINT NOT NULL,
+  -- SHA-256 isi file (hex).
+  "checksum" VARCHAR(64) NOT NULL,
+  "uploaded_by" UUID NULL REFERENCES "users"("id") ON DELETE SET NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  UNIQUE ("document_id", "version")
+);
+
+SELECT enable_tenant_rls('employee_documents');
+
+ALTER TABLE "employee_document_versions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "employee_document_versions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "employee_document_versions"
+  USING (EXISTS (SELECT 1 FROM "employee_documents" d WHERE d.id = document_id))
+  WITH CHECK (EXISTS (SELECT 1 FROM "employee_documents" d WHERE d.id = document_id));
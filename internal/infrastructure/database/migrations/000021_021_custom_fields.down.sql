@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "employees_custom_fields_idx";
+DROP TABLE IF EXISTS "custom_fields";
@@ -0,0 +1,34 @@
+-- Definisi custom field per tenant. Desain awal di DB.md (custom_fields +
+-- employee_custom_field_values bernilai TEXT) diganti: nilai disimpan bertipe
+-- di kolom JSONB custom_fields milik tabel entitasnya (mis. employees), dengan
+-- kunci field sebagai key JSON, sehingga bisa difilter dan diurutkan langsung.
+CREATE TABLE "custom_fields" (
+  "id" UUID PRIMARY KEY,
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  -- Entitas pemilik field, e.g., 'employee'.
+  "entity_type" VARCHAR(50) NOT NULL,
+  -- Kunci field di JSON nilai dan di kolom impor "custom.<key>".
+  "key" VARCHAR(100) NOT NULL CHECK ("key" ~ '^[a-z0-9_]+$'),
+  "name" VARCHAR(255) NOT NULL,
+  "type" VARCHAR(20) NOT NULL CHECK ("type" IN ('text', 'number', 'date', 'boolean', 'select', 'multi_select')),
+  -- Pilihan untuk select dan multi_select.
+  "options" JSONB NOT NULL DEFAULT '[]'::jsonb,
+  "is_required" BOOLEAN NOT NULL DEFAULT FALSE,
+  "is_unique" BOOLEAN NOT NULL DEFAULT FALSE,
+  -- Batas panjang (text), nilai (number), atau jumlah pilihan (multi_select).
+  "min_value" NUMERIC NULL,
+  "max_value" NUMERIC NULL,
+  -- Regex yang harus cocok dengan nilai text.
+  "pattern" VARCHAR(500) NULL,
+  "sort_order" INT NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMPTZ NULL,
+  "updated_at" TIMESTAMPTZ NULL,
+  "deleted_at" TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX "custom_fields_tenant_entity_key_key" ON "custom_fields" ("tenant_id", "entity_type", "key") WHERE "deleted_at" IS NULL;
+
+SELECT enable_tenant_rls('custom_fields');
+
+-- Filter nilai custom field pada list karyawan memakai operator @>.
+CREATE INDEX "employees_custom_fields_idx" ON "employees" USING GIN ("custom_fields" jsonb_path_ops);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "employee_number_sequences";
+DROP TABLE IF EXISTS "employee_number_schemes";
+
+DROP INDEX IF EXISTS "branches_tenant_code_key";
+ALTER TABLE "branches" DROP COLUMN IF EXISTS "code";
@@ -0,0 +1,33 @@
+-- Kode cabang untuk token {BRANCH} pada format nomor induk karyawan.
+ALTER TABLE "branches" ADD COLUMN "code" VARCHAR(20) NULL;
+CREATE UNIQUE INDEX "branches_tenant_code_key" ON "branches" ("tenant_id", upper("code")) WHERE "deleted_at" IS NULL AND "code" IS NOT NULL;
+
+-- Format nomor induk karyawan otomatis per tenant, e.g., '{BRANCH}-{YYYY}-{SEQ:4}'.
+-- Tenant tanpa baris di sini memakai format bawaan ('{YYYY}-{SEQ:4}', urutan
+-- diulang setiap tahun).
+CREATE TABLE "employee_number_schemes" (
+  "tenant_id" UUID PRIMARY KEY REFERENCES "tenants"("id") ON DELETE CASCADE,
+  "template" VARCHAR(100) NOT NULL,
+  -- Urutan diulang dari 1 untuk setiap tahun bergabung.
+  "reset_yearly" BOOLEAN NOT NULL DEFAULT false,
+  -- Urutan dihitung terpisah untuk setiap cabang.
+  "reset_per_branch" BOOLEAN NOT NULL DEFAULT false,
+  "created_at" TIMESTAMPTZ DEFAULT (now()),
+  "updated_at" TIMESTAMPTZ DEFAULT (now())
+);
+
+SELECT enable_tenant_rls('employee_number_schemes');
+
+-- Nomor urut terakhir per lingkup (tahun dan/atau cabang, sesuai aturan
+-- reset). Dinaikkan dengan INSERT ... ON CONFLICT DO UPDATE sehingga aman
+-- dipakai bersamaan.
+CREATE TABLE "employee_number_sequences" (
+  "tenant_id" UUID NOT NULL REFERENCES "tenants"("id") ON DELETE CASCADE,
+  -- e.g., '' (tanpa reset), 'Y2025', 'Y2025:B<branch_id>'.
+  "scope" VARCHAR(100) NOT NULL,
+  "last_value" BIGINT NOT NULL DEFAULT 0,
+  "updated_at" TIMESTAMPTZ DEFAULT (now()),
+  PRIMARY KEY ("tenant_id", "scope")
+);
+
+SELECT enable_tenant_rls('employee_number_sequences');
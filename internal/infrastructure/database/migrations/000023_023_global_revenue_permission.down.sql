@@ -0,0 +1,2 @@
+DELETE FROM "admin_roles" WHERE "name" = 'Finance';
+DELETE FROM "admin_permissions" WHERE "name" = 'view:global_revenue';
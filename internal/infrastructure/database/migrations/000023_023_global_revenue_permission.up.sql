@@ -0,0 +1,15 @@
+-- Laporan revenue dan umur piutang seluruh tenant hanya untuk admin dengan
+-- hak akses 'view:global_revenue'.
+INSERT INTO "admin_permissions" ("id", "name", "group_name") VALUES
+  (uuid_generate_v4(), 'view:global_revenue', 'Finance')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "admin_roles" ("id", "name", "description") VALUES
+  (uuid_generate_v4(), 'Finance', 'Tim finance yang boleh melihat laporan revenue dan piutang')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "admin_permission_role" ("permission_id", "admin_role_id")
+SELECT p."id", r."id"
+FROM "admin_permissions" p, "admin_roles" r
+WHERE p."name" = 'view:global_revenue' AND r."name" = 'Finance'
+ON CONFLICT DO NOTHING;
@@ -8,7 +8,6 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/maskholilaziz/hris-go/internal/entity"
 	"github.com/maskholilaziz/hris-go/internal/repository"
 	"github.com/maskholilaziz/hris-go/pkg/util"
@@ -18,12 +17,12 @@ import (
 
 // postgresAdminUserRepo adalah implementasi konkret dari AdminUserRepository
 type postgresAdminUserRepo struct {
-	db  *pgxpool.Pool     // Koneksi pool
+	db  *TenantDB     // Koneksi pool
 	sqb sq.StatementBuilderType // SQL query builder (Squirrel)
 }
 
 // NewPostgresAdminUserRepo adalah constructor
-func NewPostgresAdminUserRepo(dbPool *pgxpool.Pool) repository.AdminUserRepository {
+func NewPostgresAdminUserRepo(dbPool *TenantDB) repository.AdminUserRepository {
 	return &postgresAdminUserRepo{
 		db:  dbPool,
 		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar), // Format $1, $2 untuk Postgres
@@ -175,4 +174,18 @@ func (r *postgresAdminUserRepo) Count(ctx context.Context, query util.Pagination
 	var count int64
 	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
 	return count, err
-}
\ No newline at end of file
+}
+func (r *postgresAdminUserRepo) HasPermission(ctx context.Context, adminID uuid.UUID, permission string) (bool, error) {
+	query := `SELECT EXISTS (
+				SELECT 1
+				FROM admin_role_user aru
+				JOIN admin_roles ar ON ar.id = aru.admin_role_id AND ar.deleted_at IS NULL
+				JOIN admin_permission_role apr ON apr.admin_role_id = ar.id
+				JOIN admin_permissions ap ON ap.id = apr.permission_id AND ap.deleted_at IS NULL
+				WHERE aru.admin_user_id = $1 AND ap.name = $2
+			  )`
+
+	var allowed bool
+	err := r.db.QueryRow(ctx, query, adminID, permission).Scan(&allowed)
+	return allowed, err
+}
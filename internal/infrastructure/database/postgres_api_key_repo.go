@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresAPIKeyRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresAPIKeyRepo(dbPool *TenantDB) repository.APIKeyRepository {
+	return &postgresAPIKeyRepo{
+		db: dbPool,
+	}
+}
+
+const apiKeyColumns = `k.id, k.tenant_id, k.name, k.key_prefix, k.key_hash,
+	ARRAY(SELECT p.name FROM api_key_permissions kp
+		  JOIN tenant_permissions p ON p.id = kp.permission_id AND p.deleted_at IS NULL
+		  WHERE kp.api_key_id = k.id ORDER BY p.name),
+	k.allowed_ips, k.expires_at, k.last_used_at, k.last_used_ip, k.revoked_at,
+	k.replaced_by_id, k.created_by, COALESCE(k.created_at, now()), COALESCE(k.updated_at, now())`
+
+func scanAPIKey(row pgx.Row) (*entity.APIKey, error) {
+	var k entity.APIKey
+	err := row.Scan(
+		&k.ID,
+		&k.TenantID,
+		&k.Name,
+		&k.KeyPrefix,
+		&k.KeyHash,
+		&k.Permissions,
+		&k.AllowedIPs,
+		&k.ExpiresAt,
+		&k.LastUsedAt,
+		&k.LastUsedIP,
+		&k.RevokedAt,
+		&k.ReplacedByID,
+		&k.CreatedBy,
+		&k.CreatedAt,
+		&k.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("api key not found")
+		}
+		return nil, err
+	}
+	return &k, nil
+}
+
+func insertAPIKey(ctx context.Context, q dbtx, k *entity.APIKey, permissionIDs []uuid.UUID) error {
+	query := `INSERT INTO api_keys (id, tenant_id, name, key_prefix, key_hash, allowed_ips, expires_at, created_by, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := q.Exec(ctx, query,
+		k.ID,
+		k.TenantID,
+		k.Name,
+		k.KeyPrefix,
+		k.KeyHash,
+		k.AllowedIPs,
+		k.ExpiresAt,
+		k.CreatedBy,
+		k.CreatedAt,
+		k.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return syncAPIKeyPermissions(ctx, q, k.ID, permissionIDs)
+}
+
+func syncAPIKeyPermissions(ctx context.Context, q dbtx, keyID uuid.UUID, permissionIDs []uuid.UUID) error {
+	if _, err := q.Exec(ctx, `DELETE FROM api_key_permissions WHERE api_key_id = $1`, keyID); err != nil {
+		return err
+	}
+
+	for _, id := range permissionIDs {
+		if _, err := q.Exec(ctx, `INSERT INTO api_key_permissions (api_key_id, permission_id)
+								  VALUES ($1, $2) ON CONFLICT DO NOTHING`, keyID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresAPIKeyRepo) Create(ctx context.Context, key *entity.APIKey, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertAPIKey(ctx, tx, key, permissionIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresAPIKeyRepo) FindAll(ctx context.Context, tenantID uuid.UUID) ([]*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k WHERE k.tenant_id = $1 ORDER BY k.created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*entity.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *postgresAPIKeyRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k WHERE k.id = $1 AND k.tenant_id = $2`
+
+	return scanAPIKey(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+func (r *postgresAPIKeyRepo) FindByHash(ctx context.Context, tenantID uuid.UUID, hash string) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+			  FROM api_keys k
+			  WHERE k.tenant_id = $1 AND k.key_hash = $2 AND k.revoked_at IS NULL`
+
+	return scanAPIKey(r.db.QueryRow(ctx, query, tenantID, hash))
+}
+
+func (r *postgresAPIKeyRepo) Update(ctx context.Context, key *entity.APIKey, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	key.UpdatedAt = time.Now()
+
+	query := `UPDATE api_keys
+			  SET name = $1, allowed_ips = $2, expires_at = $3, updated_at = $4
+			  WHERE id = $5 AND tenant_id = $6 AND revoked_at IS NULL`
+
+	tag, err := tx.Exec(ctx, query,
+		key.Name,
+		key.AllowedIPs,
+		key.ExpiresAt,
+		key.UpdatedAt,
+		key.ID,
+		key.TenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("api key not found")
+	}
+
+	if err := syncAPIKeyPermissions(ctx, tx, key.ID, permissionIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresAPIKeyRepo) Rotate(ctx context.Context, old *entity.APIKey, replacement *entity.APIKey, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertAPIKey(ctx, tx, replacement, permissionIDs); err != nil {
+		return err
+	}
+
+	query := `UPDATE api_keys
+			  SET replaced_by_id = $1, expires_at = $2, revoked_at = $3, updated_at = $4
+			  WHERE id = $5 AND tenant_id = $6 AND revoked_at IS NULL`
+
+	tag, err := tx.Exec(ctx, query,
+		replacement.ID,
+		old.ExpiresAt,
+		old.RevokedAt,
+		time.Now(),
+		old.ID,
+		old.TenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("api key not found")
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresAPIKeyRepo) Revoke(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE api_keys SET revoked_at = $1, updated_at = $1
+								WHERE id = $2 AND tenant_id = $3 AND revoked_at IS NULL`, at, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("api key not found")
+	}
+	return nil
+}
+
+func (r *postgresAPIKeyRepo) Touch(ctx context.Context, id uuid.UUID, at time.Time, ip string) error {
+	_, err := r.db.Exec(ctx, `UPDATE api_keys SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`, at, ip, id)
+	return err
+}
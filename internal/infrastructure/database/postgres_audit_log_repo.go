@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresAuditLogRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresAuditLogRepo(dbPool *TenantDB) repository.AuditLogRepository {
+	return &postgresAuditLogRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresAuditLogRepo) Create(ctx context.Context, l *entity.AuditLog) error {
+	query := `INSERT INTO audit_logs (id, tenant_id, user_id, impersonator_admin_id, impersonation_session_id, method, path, status_code, ip_address, user_agent, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(ctx, query,
+		l.ID,
+		l.TenantID,
+		l.UserID,
+		l.ImpersonatorAdminID,
+		l.ImpersonationSessionID,
+		l.Method,
+		l.Path,
+		l.StatusCode,
+		l.IPAddress,
+		l.UserAgent,
+		l.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresAuditLogRepo) FindByImpersonationSession(ctx context.Context, sessionID uuid.UUID) ([]*entity.AuditLog, error) {
+	query := `SELECT id, tenant_id, user_id, impersonator_admin_id, impersonation_session_id, method, path, status_code,
+					 COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at
+			  FROM audit_logs
+			  WHERE impersonation_session_id = $1
+			  ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*entity.AuditLog{}
+	for rows.Next() {
+		var l entity.AuditLog
+		if err := rows.Scan(
+			&l.ID,
+			&l.TenantID,
+			&l.UserID,
+			&l.ImpersonatorAdminID,
+			&l.ImpersonationSessionID,
+			&l.Method,
+			&l.Path,
+			&l.StatusCode,
+			&l.IPAddress,
+			&l.UserAgent,
+			&l.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		logs = append(logs, &l)
+	}
+
+	return logs, rows.Err()
+}
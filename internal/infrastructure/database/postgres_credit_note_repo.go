@@ -0,0 +1,251 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresCreditNoteRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresCreditNoteRepo(dbPool *TenantDB) repository.CreditNoteRepository {
+	return &postgresCreditNoteRepo{
+		db: dbPool,
+	}
+}
+
+// nextBillingSequence mengambil nomor urut berikutnya untuk prefix dan tahun.
+// Baris billing_sequences ter-lock oleh UPDATE sampai transaksi selesai,
+// sehingga aman dipanggil bersamaan.
+func nextBillingSequence(ctx context.Context, q dbtx, prefix string, year int) (int, error) {
+	query := `INSERT INTO billing_sequences (prefix, period_year, last_value)
+			  VALUES ($1, $2, 1)
+			  ON CONFLICT (prefix, period_year)
+			  DO UPDATE SET last_value = billing_sequences.last_value + 1
+			  RETURNING last_value`
+
+	var next int
+	err := q.QueryRow(ctx, query, prefix, year).Scan(&next)
+	return next, err
+}
+
+func (r *postgresCreditNoteRepo) Create(ctx context.Context, cn *entity.CreditNote) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock invoice supaya dua credit note yang dibuat bersamaan tidak bisa
+	// melebihi total invoice.
+	var invoiceTotal, credited float64
+	err = tx.QueryRow(ctx, `SELECT i.total_amount,
+								   (SELECT COALESCE(SUM(cn.total_amount), 0) FROM credit_notes cn
+									WHERE cn.invoice_id = i.id AND cn.status = 'issued')
+							FROM invoices i
+							WHERE i.id = $1 AND i.deleted_at IS NULL
+							FOR UPDATE`, cn.InvoiceID).Scan(&invoiceTotal, &credited)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("invoice not found")
+		}
+		return err
+	}
+	if entity.RoundMoney(credited+cn.TotalAmount) > invoiceTotal {
+		return fmt.Errorf("total credit note melebihi nilai invoice (sisa yang bisa dikreditkan: %.2f)", entity.RoundMoney(invoiceTotal-credited))
+	}
+
+	seq, err := nextBillingSequence(ctx, tx, entity.CreditNoteNumberPrefix, cn.IssueDate.Year())
+	if err != nil {
+		return fmt.Errorf("gagal membuat nomor credit note: %w", err)
+	}
+	cn.Number = fmt.Sprintf("%s-%d-%04d", entity.CreditNoteNumberPrefix, cn.IssueDate.Year(), seq)
+
+	query := `INSERT INTO credit_notes (id, tenant_id, invoice_id, credit_note_number, issue_date, reason, resolution,
+							   total_amount, status, created_by, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	if _, err := tx.Exec(ctx, query,
+		cn.ID,
+		cn.TenantID,
+		cn.InvoiceID,
+		cn.Number,
+		cn.IssueDate,
+		cn.Reason,
+		cn.Resolution,
+		cn.TotalAmount,
+		cn.Status,
+		cn.CreatedBy,
+		cn.CreatedAt,
+		cn.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	itemQuery := `INSERT INTO credit_note_items (id, credit_note_id, description, quantity, unit_price, total_price)
+				  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range cn.Items {
+		if _, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			cn.ID,
+			item.Description,
+			item.Quantity,
+			item.UnitPrice,
+			item.TotalPrice,
+		); err != nil {
+			return err
+		}
+	}
+
+	switch cn.Resolution {
+	case entity.CreditNoteResolutionCredit:
+		creditID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		if err := insertBillingCredit(ctx, tx, &entity.BillingCredit{
+			ID:              creditID,
+			TenantID:        cn.TenantID,
+			Source:          entity.BillingCreditSourceCreditNote,
+			Description:     "Credit note " + cn.Number,
+			CreditNoteID:    &cn.ID,
+			Amount:          cn.TotalAmount,
+			RemainingAmount: cn.TotalAmount,
+			CreatedAt:       cn.CreatedAt,
+			UpdatedAt:       cn.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+
+	case entity.CreditNoteResolutionRefund:
+		paymentID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		method := entity.PaymentMethodRefund
+		notes := "Refund credit note " + cn.Number
+		if err := insertInvoicePayment(ctx, tx, &entity.InvoicePayment{
+			ID:            paymentID,
+			InvoiceID:     cn.InvoiceID,
+			PaymentDate:   cn.CreatedAt,
+			AmountPaid:    -cn.TotalAmount,
+			PaymentMethod: &method,
+			Notes:         &notes,
+			CreatedAt:     cn.CreatedAt,
+			UpdatedAt:     cn.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const creditNoteColumns = `id, tenant_id, invoice_id, credit_note_number, issue_date, reason, resolution,
+		total_amount, status, created_by, created_at, updated_at`
+
+func (r *postgresCreditNoteRepo) scanCreditNote(row pgx.Row) (*entity.CreditNote, error) {
+	var cn entity.CreditNote
+	err := row.Scan(
+		&cn.ID,
+		&cn.TenantID,
+		&cn.InvoiceID,
+		&cn.Number,
+		&cn.IssueDate,
+		&cn.Reason,
+		&cn.Resolution,
+		&cn.TotalAmount,
+		&cn.Status,
+		&cn.CreatedBy,
+		&cn.CreatedAt,
+		&cn.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("credit note not found")
+		}
+		return nil, err
+	}
+	return &cn, nil
+}
+
+func (r *postgresCreditNoteRepo) findItems(ctx context.Context, cn *entity.CreditNote) error {
+	query := `SELECT id, credit_note_id, description, quantity, unit_price, total_price
+			  FROM credit_note_items
+			  WHERE credit_note_id = $1
+			  ORDER BY total_price DESC`
+
+	rows, err := r.db.Query(ctx, query, cn.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.CreditNoteItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.CreditNoteID,
+			&item.Description,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.TotalPrice,
+		); err != nil {
+			return err
+		}
+		cn.Items = append(cn.Items, item)
+	}
+	return rows.Err()
+}
+
+func (r *postgresCreditNoteRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error) {
+	query := `SELECT ` + creditNoteColumns + ` FROM credit_notes WHERE id = $1`
+
+	cn, err := r.scanCreditNote(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.findItems(ctx, cn); err != nil {
+		return nil, err
+	}
+	return cn, nil
+}
+
+func (r *postgresCreditNoteRepo) FindByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entity.CreditNote, error) {
+	query := `SELECT ` + creditNoteColumns + ` FROM credit_notes WHERE invoice_id = $1 ORDER BY issue_date, credit_note_number`
+
+	rows, err := r.db.Query(ctx, query, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNotes := []*entity.CreditNote{}
+	for rows.Next() {
+		cn, err := r.scanCreditNote(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		creditNotes = append(creditNotes, cn)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, cn := range creditNotes {
+		if err := r.findItems(ctx, cn); err != nil {
+			return nil, err
+		}
+	}
+	return creditNotes, nil
+}
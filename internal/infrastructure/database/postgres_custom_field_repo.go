@@ -0,0 +1,294 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresCustomFieldRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresCustomFieldRepo(dbPool *TenantDB) repository.CustomFieldRepository {
+	return &postgresCustomFieldRepo{
+		db: dbPool,
+	}
+}
+
+const customFieldColumns = `id, tenant_id, entity_type, key, name, type, options, is_required, is_unique,
+	min_value, max_value, pattern, sort_order, COALESCE(created_at, now()), COALESCE(updated_at, now())`
+
+func scanCustomField(row pgx.Row) (*entity.CustomField, error) {
+	var f entity.CustomField
+	err := row.Scan(
+		&f.ID,
+		&f.TenantID,
+		&f.EntityType,
+		&f.Key,
+		&f.Name,
+		&f.Type,
+		&f.Options,
+		&f.Required,
+		&f.Unique,
+		&f.Min,
+		&f.Max,
+		&f.Pattern,
+		&f.SortOrder,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("custom field not found")
+		}
+		return nil, err
+	}
+	if f.Options == nil {
+		f.Options = []string{}
+	}
+	return &f, nil
+}
+
+func marshalCustomFieldOptions(f *entity.CustomField) ([]byte, error) {
+	if f.Options == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f.Options)
+}
+
+func (r *postgresCustomFieldRepo) Create(ctx context.Context, f *entity.CustomField) error {
+	options, err := marshalCustomFieldOptions(f)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO custom_fields (id, tenant_id, entity_type, key, name, type, options, is_required, is_unique,
+					min_value, max_value, pattern, sort_order, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = r.db.Exec(ctx, query,
+		f.ID,
+		f.TenantID,
+		f.EntityType,
+		f.Key,
+		f.Name,
+		f.Type,
+		options,
+		f.Required,
+		f.Unique,
+		f.Min,
+		f.Max,
+		f.Pattern,
+		f.SortOrder,
+		f.CreatedAt,
+		f.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("kunci custom field sudah dipakai")
+	}
+	return err
+}
+
+// Update tidak mengubah entity_type, key, dan type karena nilai yang sudah
+// tersimpan bergantung pada ketiganya.
+func (r *postgresCustomFieldRepo) Update(ctx context.Context, f *entity.CustomField) error {
+	options, err := marshalCustomFieldOptions(f)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE custom_fields
+			  SET name = $1, options = $2, is_required = $3, is_unique = $4, min_value = $5, max_value = $6,
+				  pattern = $7, sort_order = $8, updated_at = $9
+			  WHERE id = $10 AND tenant_id = $11 AND deleted_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query,
+		f.Name,
+		options,
+		f.Required,
+		f.Unique,
+		f.Min,
+		f.Max,
+		f.Pattern,
+		f.SortOrder,
+		f.UpdatedAt,
+		f.ID,
+		f.TenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("custom field not found")
+	}
+	return nil
+}
+
+func (r *postgresCustomFieldRepo) Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var entityType entity.CustomFieldEntity
+	var key string
+	err = tx.QueryRow(ctx, `UPDATE custom_fields SET deleted_at = $1, updated_at = $1
+							WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+							RETURNING entity_type, key`, at, id, tenantID).Scan(&entityType, &key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("custom field not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if table := entityType.Table(); table != "" {
+		if _, err := tx.Exec(ctx, `UPDATE `+table+` SET custom_fields = custom_fields - $1::text
+								   WHERE tenant_id = $2 AND custom_fields -> $1::text IS NOT NULL`, key, tenantID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresCustomFieldRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields
+			  WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+
+	return scanCustomField(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+func (r *postgresCustomFieldRepo) FindByEntity(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity) ([]*entity.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields
+			  WHERE tenant_id = $1 AND entity_type = $2 AND deleted_at IS NULL
+			  ORDER BY sort_order, name`
+
+	rows, err := r.db.Query(ctx, query, tenantID, entityType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []*entity.CustomField{}
+	for rows.Next() {
+		f, err := scanCustomField(rows)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, rows.Err()
+}
+
+func customFieldTable(entityType entity.CustomFieldEntity) (string, error) {
+	table := entityType.Table()
+	if table == "" {
+		return "", fmt.Errorf("entitas '%s' tidak mendukung custom field", entityType)
+	}
+	return table, nil
+}
+
+func (r *postgresCustomFieldRepo) ValueExists(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, key string, value any, excludeID *uuid.UUID) (bool, error) {
+	table, err := customFieldTable(entityType)
+	if err != nil {
+		return false, err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	query := `SELECT EXISTS (
+				SELECT 1 FROM ` + table + `
+				WHERE tenant_id = $1 AND deleted_at IS NULL
+				  AND custom_fields -> $2::text = $3::jsonb
+				  AND ($4::uuid IS NULL OR id <> $4)
+			  )`
+
+	var exists bool
+	err = r.db.QueryRow(ctx, query, tenantID, key, string(encoded), excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *postgresCustomFieldRepo) HasDuplicateValues(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, key string) (bool, error) {
+	table, err := customFieldTable(entityType)
+	if err != nil {
+		return false, err
+	}
+
+	query := `SELECT EXISTS (
+				SELECT 1 FROM ` + table + `
+				WHERE tenant_id = $1 AND deleted_at IS NULL AND custom_fields -> $2::text IS NOT NULL
+				GROUP BY custom_fields -> $2::text
+				HAVING COUNT(*) > 1
+			  )`
+
+	var exists bool
+	err = r.db.QueryRow(ctx, query, tenantID, key).Scan(&exists)
+	return exists, err
+}
+
+// ------------------------------------------------------------------------
+// Filter dan sorting list entitas berdasarkan custom field
+// ------------------------------------------------------------------------
+
+// applyCustomFieldFilters menerapkan filter "custom.<key>" dari query.Filters
+// (lihat repository.CustomFieldPrefix) pada kolom JSONB 'column'. Nilai JSON
+// dengan tipe yang sama dibandingkan sesuai tipenya, sehingga angka
+// dibandingkan sebagai angka dan tanggal YYYY-MM-DD sebagai teks.
+func applyCustomFieldFilters(sb sq.SelectBuilder, column string, filters map[string]any) (sq.SelectBuilder, error) {
+	names := []string{}
+	for name := range filters {
+		if strings.HasPrefix(name, repository.CustomFieldPrefix) {
+			names = append(names, name)
+		}
+	}
+	// Urutan tetap agar teks SQL yang sama bisa memakai ulang prepared
+	// statement.
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := json.Marshal(filters[name])
+		if err != nil {
+			return sb, err
+		}
+
+		key := strings.TrimPrefix(name, repository.CustomFieldPrefix)
+		if k, ok := strings.CutSuffix(key, repository.CustomFieldFilterMin); ok {
+			sb = sb.Where(column+" -> ?::text >= ?::jsonb", k, string(value))
+		} else if k, ok := strings.CutSuffix(key, repository.CustomFieldFilterMax); ok {
+			sb = sb.Where(column+" -> ?::text <= ?::jsonb", k, string(value))
+		} else {
+			sb = sb.Where(column+" @> jsonb_build_object(?::text, ?::jsonb)", key, string(value))
+		}
+	}
+	return sb, nil
+}
+
+// applyCustomFieldSort mengurutkan berdasarkan sortBy "custom.<key>".
+// Mengembalikan false jika sortBy bukan custom field. Record tanpa nilai
+// selalu diletakkan di akhir.
+func applyCustomFieldSort(sb sq.SelectBuilder, column, sortBy, sortDir string) (sq.SelectBuilder, bool) {
+	key, ok := strings.CutPrefix(sortBy, repository.CustomFieldPrefix)
+	if !ok || key == "" {
+		return sb, false
+	}
+	if sortDir != "desc" {
+		sortDir = "asc"
+	}
+	return sb.OrderByClause(column+" -> ?::text "+sortDir+" NULLS LAST", key), true
+}
@@ -0,0 +1,335 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresEmployeeDocumentRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresEmployeeDocumentRepo(dbPool *TenantDB) repository.EmployeeDocumentRepository {
+	return &postgresEmployeeDocumentRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+var employeeDocumentSortColumns = map[string]string{
+	"created_at":    "d.created_at",
+	"updated_at":    "d.updated_at",
+	"expiry_date":   "d.expiry_date",
+	"document_type": "d.document_type",
+	"file_name":     "d.file_name",
+}
+
+const employeeDocumentColumns = `d.id, d.tenant_id, d.employee_id, e.user_id, COALESCE(p.full_name, u.name), u.email,
+	d.document_type, d.file_path, d.file_name, d.content_type, d.file_size, d.version, d.expiry_date,
+	d.reminder_sent_for, d.uploaded_by, COALESCE(d.created_at, now()), COALESCE(d.updated_at, now()), d.deleted_at`
+
+const employeeDocumentFrom = `employee_documents d
+	JOIN employees e ON e.id = d.employee_id AND e.deleted_at IS NULL
+	JOIN users u ON u.id = e.user_id
+	LEFT JOIN employee_profiles p ON p.employee_id = e.id`
+
+func scanEmployeeDocument(row pgx.Row) (*entity.EmployeeDocument, error) {
+	var d entity.EmployeeDocument
+	err := row.Scan(
+		&d.ID, &d.TenantID, &d.EmployeeID, &d.EmployeeUserID, &d.EmployeeName, &d.EmployeeEmail,
+		&d.DocumentType, &d.FilePath, &d.FileName, &d.ContentType, &d.FileSize, &d.Version, &d.ExpiryDate,
+		&d.ReminderSentFor, &d.UploadedBy, &d.CreatedAt, &d.UpdatedAt, &d.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("employee document not found")
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+const employeeDocumentVersionColumns = `v.id, v.document_id, v.version, v.file_path, v.file_name, v.content_type,
+	v.file_size, v.checksum, v.uploaded_by, COALESCE(v.created_at, now())`
+
+func scanEmployeeDocumentVersion(row pgx.Row) (*entity.EmployeeDocumentVersion, error) {
+	var v entity.EmployeeDocumentVersion
+	err := row.Scan(&v.ID, &v.DocumentID, &v.Version, &v.FilePath, &v.FileName, &v.ContentType,
+		&v.FileSize, &v.Checksum, &v.UploadedBy, &v.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("document version not found")
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func insertDocumentVersion(ctx context.Context, q dbtx, v *entity.EmployeeDocumentVersion) error {
+	query := `INSERT INTO employee_document_versions (id, document_id, version, file_path, file_name, content_type,
+					file_size, checksum, uploaded_by, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := q.Exec(ctx, query, v.ID, v.DocumentID, v.Version, v.FilePath, v.FileName, v.ContentType,
+		v.FileSize, v.Checksum, v.UploadedBy, v.CreatedAt)
+	return err
+}
+
+func (r *postgresEmployeeDocumentRepo) Create(ctx context.Context, d *entity.EmployeeDocument, v *entity.EmployeeDocumentVersion) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM employees WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL FOR SHARE)`
+	if err := tx.QueryRow(ctx, query, d.EmployeeID, d.TenantID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("karyawan tidak ditemukan")
+	}
+
+	query = `INSERT INTO employee_documents (id, tenant_id, employee_id, document_type, file_path, file_name,
+				content_type, file_size, version, expiry_date, uploaded_by, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err = tx.Exec(ctx, query, d.ID, d.TenantID, d.EmployeeID, d.DocumentType, d.FilePath, d.FileName,
+		d.ContentType, d.FileSize, d.Version, d.ExpiryDate, d.UploadedBy, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertDocumentVersion(ctx, tx, v); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *postgresEmployeeDocumentRepo) AddVersion(ctx context.Context, d *entity.EmployeeDocument, v *entity.EmployeeDocumentVersion) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Kunci baris dokumen agar dua unggahan bersamaan tidak mendapat nomor
+	// versi yang sama.
+	var current int
+	query := `SELECT version FROM employee_documents WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.QueryRow(ctx, query, d.ID, d.TenantID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("employee document not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	v.Version = current + 1
+	if err := insertDocumentVersion(ctx, tx, v); err != nil {
+		return err
+	}
+
+	query = `UPDATE employee_documents
+			 SET file_path = $3, file_name = $4, content_type = $5, file_size = $6, version = $7,
+				 expiry_date = $8, uploaded_by = $9, updated_at = $10
+			 WHERE id = $1 AND tenant_id = $2`
+	_, err = tx.Exec(ctx, query, d.ID, d.TenantID, v.FilePath, v.FileName, v.ContentType, v.FileSize, v.Version,
+		d.ExpiryDate, v.UploadedBy, v.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	d.FilePath = v.FilePath
+	d.FileName = v.FileName
+	d.ContentType = v.ContentType
+	d.FileSize = v.FileSize
+	d.Version = v.Version
+	d.UploadedBy = v.UploadedBy
+	d.UpdatedAt = v.CreatedAt
+	return nil
+}
+
+func (r *postgresEmployeeDocumentRepo) Update(ctx context.Context, d *entity.EmployeeDocument) error {
+	query := `UPDATE employee_documents SET document_type = $3, expiry_date = $4, updated_at = $5
+			  WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, d.ID, d.TenantID, d.DocumentType, d.ExpiryDate, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("employee document not found")
+	}
+	return nil
+}
+
+func (r *postgresEmployeeDocumentRepo) Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error {
+	query := `UPDATE employee_documents SET deleted_at = $3, updated_at = $3
+			  WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, id, tenantID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("employee document not found")
+	}
+	return nil
+}
+
+func (r *postgresEmployeeDocumentRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeDocument, error) {
+	sb := r.sqb.Select(employeeDocumentColumns).From(employeeDocumentFrom).
+		Where(sq.Eq{"d.id": id, "d.tenant_id": tenantID, "d.deleted_at": nil})
+	sb = applyEmployeeScope(ctx, sb, "e")
+
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return scanEmployeeDocument(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresEmployeeDocumentRepo) filterQuery(ctx context.Context, columns string, tenantID uuid.UUID, filter repository.EmployeeDocumentFilter) sq.SelectBuilder {
+	sb := r.sqb.Select(columns).From(employeeDocumentFrom).
+		Where(sq.Eq{"d.tenant_id": tenantID, "d.deleted_at": nil})
+
+	if filter.EmployeeID != nil {
+		sb = sb.Where(sq.Eq{"d.employee_id": *filter.EmployeeID})
+	}
+	if filter.UserID != nil {
+		sb = sb.Where(sq.Eq{"e.user_id": *filter.UserID})
+	}
+	if filter.DocumentType != "" {
+		sb = sb.Where("lower(d.document_type) = lower(?)", filter.DocumentType)
+	}
+	if filter.ExpiringBefore != nil {
+		sb = sb.Where(sq.LtOrEq{"d.expiry_date": *filter.ExpiringBefore})
+	}
+	if filter.IDs != nil {
+		sb = sb.Where(sq.Eq{"d.id": filter.IDs})
+	}
+
+	return applyEmployeeScope(ctx, sb, "e")
+}
+
+func (r *postgresEmployeeDocumentRepo) Find(ctx context.Context, tenantID uuid.UUID, filter repository.EmployeeDocumentFilter, query util.PaginationQuery) ([]*entity.EmployeeDocument, error) {
+	sortColumn, ok := employeeDocumentSortColumns[query.SortBy]
+	if !ok {
+		sortColumn = "d.created_at"
+	}
+
+	sql, args, err := r.filterQuery(ctx, employeeDocumentColumns, tenantID, filter).
+		OrderBy(sortColumn+" "+query.SortDir, "d.id").
+		Limit(uint64(query.Limit)).
+		Offset(uint64(query.GetOffset())).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return r.queryDocuments(ctx, sql, args...)
+}
+
+func (r *postgresEmployeeDocumentRepo) Count(ctx context.Context, tenantID uuid.UUID, filter repository.EmployeeDocumentFilter) (int64, error) {
+	sql, args, err := r.filterQuery(ctx, "COUNT(*)", tenantID, filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	var total int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	return total, err
+}
+
+func (r *postgresEmployeeDocumentRepo) queryDocuments(ctx context.Context, sql string, args ...any) ([]*entity.EmployeeDocument, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []*entity.EmployeeDocument{}
+	for rows.Next() {
+		d, err := scanEmployeeDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	return documents, rows.Err()
+}
+
+func (r *postgresEmployeeDocumentRepo) FindVersions(ctx context.Context, tenantID, documentID uuid.UUID) ([]*entity.EmployeeDocumentVersion, error) {
+	sql, args, err := r.sqb.Select(employeeDocumentVersionColumns).From("employee_document_versions v").
+		Join("employee_documents d ON d.id = v.document_id").
+		Where(sq.Eq{"v.document_id": documentID, "d.tenant_id": tenantID}).
+		OrderBy("v.version DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []*entity.EmployeeDocumentVersion{}
+	for rows.Next() {
+		v, err := scanEmployeeDocumentVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func (r *postgresEmployeeDocumentRepo) FindVersion(ctx context.Context, tenantID, documentID uuid.UUID, version int) (*entity.EmployeeDocumentVersion, error) {
+	sql, args, err := r.sqb.Select(employeeDocumentVersionColumns).From("employee_document_versions v").
+		Join("employee_documents d ON d.id = v.document_id").
+		Where(sq.Eq{"v.document_id": documentID, "v.version": version, "d.tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return scanEmployeeDocumentVersion(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresEmployeeDocumentRepo) FindReminderDue(ctx context.Context, from, until time.Time, limit int) ([]*entity.EmployeeDocument, error) {
+	sql, args, err := r.sqb.Select(employeeDocumentColumns).From(employeeDocumentFrom).
+		Where(sq.Eq{"d.deleted_at": nil}).
+		Where(sq.GtOrEq{"d.expiry_date": from}).
+		Where(sq.LtOrEq{"d.expiry_date": until}).
+		Where("d.reminder_sent_for IS DISTINCT FROM d.expiry_date").
+		OrderBy("d.tenant_id", "d.expiry_date", "d.id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return r.queryDocuments(ctx, sql, args...)
+}
+
+func (r *postgresEmployeeDocumentRepo) MarkReminded(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `UPDATE employee_documents SET reminder_sent_for = expiry_date
+			  WHERE tenant_id = $1 AND id = ANY($2)`
+	_, err := r.db.Exec(ctx, query, tenantID, ids)
+	return err
+}
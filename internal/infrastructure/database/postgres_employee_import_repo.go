@@ -0,0 +1,545 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresEmployeeImportRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresEmployeeImportRepo(dbPool *TenantDB) repository.EmployeeImportRepository {
+	return &postgresEmployeeImportRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+const employeeImportColumns = `i.id, i.tenant_id, i.file_name, i.file_format, i.mapping, i.dry_run, i.status,
+	i.total_rows, i.processed_rows, i.success_rows, i.failed_rows, i.error_message, i.created_by,
+	i.started_at, i.finished_at, COALESCE(i.created_at, now()), COALESCE(i.updated_at, now())`
+
+func scanEmployeeImport(row pgx.Row) (*entity.EmployeeImport, error) {
+	var i entity.EmployeeImport
+	var mapping []byte
+	err := row.Scan(
+		&i.ID, &i.TenantID, &i.FileName, &i.Format, &mapping, &i.DryRun, &i.Status,
+		&i.TotalRows, &i.ProcessedRows, &i.SuccessRows, &i.FailedRows, &i.ErrorMessage, &i.CreatedBy,
+		&i.StartedAt, &i.FinishedAt, &i.CreatedAt, &i.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("employee import not found")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(mapping, &i.Mapping); err != nil {
+		return nil, fmt.Errorf("gagal membaca mapping impor: %w", err)
+	}
+	return &i, nil
+}
+
+const employeeImportRowColumns = `r.id, r.import_id, r.row_number, r.status, r.data, r.errors, r.employee_id`
+
+func scanEmployeeImportRow(row pgx.Row) (*entity.EmployeeImportRow, error) {
+	var r entity.EmployeeImportRow
+	var data, rowErrors []byte
+	if err := row.Scan(&r.ID, &r.ImportID, &r.RowNumber, &r.Status, &data, &rowErrors, &r.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.Data); err != nil {
+		return nil, fmt.Errorf("gagal membaca data baris impor: %w", err)
+	}
+	if err := json.Unmarshal(rowErrors, &r.Errors); err != nil {
+		return nil, fmt.Errorf("gagal membaca error baris impor: %w", err)
+	}
+	return &r, nil
+}
+
+func (r *postgresEmployeeImportRepo) Create(ctx context.Context, job *entity.EmployeeImport, rows []*entity.EmployeeImportRow) error {
+	mapping, err := json.Marshal(job.Mapping)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO employee_imports (id, tenant_id, file_name, file_format, mapping, dry_run, status,
+					total_rows, processed_rows, success_rows, failed_rows, error_message, created_by, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err = tx.Exec(ctx, query,
+		job.ID, job.TenantID, job.FileName, job.Format, mapping, job.DryRun, job.Status,
+		job.TotalRows, job.ProcessedRows, job.SuccessRows, job.FailedRows, job.ErrorMessage, job.CreatedBy,
+		job.CreatedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	values := make([][]any, 0, len(rows))
+	for _, row := range rows {
+		data, err := json.Marshal(row.Data)
+		if err != nil {
+			return err
+		}
+		rowErrors, err := json.Marshal(nonNilStrings(row.Errors))
+		if err != nil {
+			return err
+		}
+		values = append(values, []any{row.ID, row.ImportID, row.RowNumber, string(row.Status), string(data), string(rowErrors), job.CreatedAt, job.CreatedAt})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"employee_import_rows"},
+		[]string{"id", "import_id", "row_number", "status", "data", "errors", "created_at", "updated_at"},
+		pgx.CopyFromRows(values),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func (r *postgresEmployeeImportRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeImport, error) {
+	sql, args, err := r.sqb.Select(employeeImportColumns).From("employee_imports i").
+		Where(sq.Eq{"i.id": id, "i.tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return scanEmployeeImport(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresEmployeeImportRepo) Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.EmployeeImport, error) {
+	sql, args, err := r.sqb.Select(employeeImportColumns).From("employee_imports i").
+		Where(sq.Eq{"i.tenant_id": tenantID}).
+		OrderBy("i.created_at DESC").
+		Limit(uint64(query.Limit)).
+		Offset(uint64(query.GetOffset())).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*entity.EmployeeImport{}
+	for rows.Next() {
+		job, err := scanEmployeeImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *postgresEmployeeImportRepo) Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.sqb.Select("COUNT(*)").From("employee_imports i").
+		Where(sq.Eq{"i.tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	var total int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	return total, err
+}
+
+func (r *postgresEmployeeImportRepo) rowsQuery(tenantID, importID uuid.UUID, status entity.ImportRowStatus, columns string) sq.SelectBuilder {
+	sb := r.sqb.Select(columns).From("employee_import_rows r").
+		Join("employee_imports i ON i.id = r.import_id").
+		Where(sq.Eq{"r.import_id": importID, "i.tenant_id": tenantID})
+	if status != "" {
+		sb = sb.Where(sq.Eq{"r.status": status})
+	}
+	return sb
+}
+
+func (r *postgresEmployeeImportRepo) FindRows(ctx context.Context, tenantID, importID uuid.UUID, status entity.ImportRowStatus, query util.PaginationQuery) ([]*entity.EmployeeImportRow, error) {
+	sql, args, err := r.rowsQuery(tenantID, importID, status, employeeImportRowColumns).
+		OrderBy("r.row_number").
+		Limit(uint64(query.Limit)).
+		Offset(uint64(query.GetOffset())).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return r.queryRows(ctx, sql, args...)
+}
+
+func (r *postgresEmployeeImportRepo) CountRows(ctx context.Context, tenantID, importID uuid.UUID, status entity.ImportRowStatus) (int64, error) {
+	sql, args, err := r.rowsQuery(tenantID, importID, status, "COUNT(*)").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	var total int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	return total, err
+}
+
+func (r *postgresEmployeeImportRepo) queryRows(ctx context.Context, sql string, args ...any) ([]*entity.EmployeeImportRow, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*entity.EmployeeImportRow{}
+	for rows.Next() {
+		row, err := scanEmployeeImportRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// ------------------------------------------------------------------------
+// Pemrosesan job
+
+func (r *postgresEmployeeImportRepo) Claim(ctx context.Context, job *entity.EmployeeImport, staleBefore, at time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE employee_imports
+			  SET status = $3, started_at = COALESCE(started_at, $4), updated_at = $4
+			  WHERE id = $1 AND tenant_id = $2
+				AND (status = $5 OR (status = $3 AND updated_at < $6))
+			  RETURNING status, started_at, updated_at`
+	err = tx.QueryRow(ctx, query, job.ID, job.TenantID, entity.ImportProcessing, at, entity.ImportPending, staleBefore).
+		Scan(&job.Status, &job.StartedAt, &job.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if job.DryRun {
+		query = `UPDATE employee_import_rows SET status = $2, updated_at = $3 WHERE import_id = $1 AND status = $4`
+		if _, err := tx.Exec(ctx, query, job.ID, entity.ImportRowPending, at, entity.ImportRowValid); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func (r *postgresEmployeeImportRepo) FindResumable(ctx context.Context, staleBefore time.Time, limit int) ([]*entity.EmployeeImport, error) {
+	sql, args, err := r.sqb.Select(employeeImportColumns).From("employee_imports i").
+		Where(sq.Or{
+			sq.Eq{"i.status": entity.ImportPending},
+			sq.And{sq.Eq{"i.status": entity.ImportProcessing}, sq.Lt{"i.updated_at": staleBefore}},
+		}).
+		OrderBy("i.created_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*entity.EmployeeImport{}
+	for rows.Next() {
+		job, err := scanEmployeeImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *postgresEmployeeImportRepo) FindPendingRows(ctx context.Context, importID uuid.UUID, limit int) ([]*entity.EmployeeImportRow, error) {
+	sql, args, err := r.sqb.Select(employeeImportRowColumns).From("employee_import_rows r").
+		Where(sq.Eq{"r.import_id": importID, "r.status": entity.ImportRowPending}).
+		OrderBy("r.row_number").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+	return r.queryRows(ctx, sql, args...)
+}
+
+// importMasterTables adalah tabel data master yang bisa dirujuk per nama pada
+// file impor, per field impor.
+var importMasterTables = map[string]string{
+	"branch":                               "branches",
+	string(entity.OrgUnitDepartment):       entity.OrgUnitDepartment.Table(),
+	string(entity.OrgUnitPosition):         entity.OrgUnitPosition.Table(),
+	string(entity.OrgUnitJobLevel):         entity.OrgUnitJobLevel.Table(),
+	string(entity.OrgUnitEmploymentStatus): entity.OrgUnitEmploymentStatus.Table(),
+	string(entity.OrgUnitSBU):              entity.OrgUnitSBU.Table(),
+}
+
+func (r *postgresEmployeeImportRepo) FindExportLookups(ctx context.Context, tenantID uuid.UUID) (*repository.EmployeeExportLookups, error) {
+	lookups := &repository.EmployeeExportLookups{
+		Masters:           map[string]map[uuid.UUID]string{},
+		EmployeeIDNumbers: map[uuid.UUID]string{},
+	}
+
+	// Data master yang sudah dihapus tetap diambil karena masih bisa dirujuk
+	// karyawan yang sudah keluar.
+	for field, table := range importMasterTables {
+		rows, err := r.db.Query(ctx, `SELECT id, name FROM `+table+` WHERE tenant_id = $1`, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		names, err := collectNamesByID(rows)
+		if err != nil {
+			return nil, err
+		}
+		lookups.Masters[field] = names
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT id, employee_id_number FROM employees WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if lookups.EmployeeIDNumbers, err = collectNamesByID(rows); err != nil {
+		return nil, err
+	}
+	return lookups, nil
+}
+
+func collectNamesByID(rows pgx.Rows) (map[uuid.UUID]string, error) {
+	defer rows.Close()
+
+	names := map[uuid.UUID]string{}
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+func (r *postgresEmployeeImportRepo) FindLookups(ctx context.Context, tenantID uuid.UUID) (*repository.EmployeeImportLookups, error) {
+	lookups := &repository.EmployeeImportLookups{
+		Masters:           map[string]map[string]uuid.UUID{},
+		Emails:            map[string]bool{},
+		EmployeeIDNumbers: map[string]bool{},
+	}
+
+	for field, table := range importMasterTables {
+		rows, err := r.db.Query(ctx, `SELECT id, lower(name) FROM `+table+` WHERE tenant_id = $1 AND deleted_at IS NULL`, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		names := map[string]uuid.UUID{}
+		for rows.Next() {
+			var id uuid.UUID
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			names[name] = id
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		lookups.Masters[field] = names
+	}
+
+	// Email dicek terhadap semua user tenant, termasuk yang bukan karyawan.
+	rows, err := r.db.Query(ctx, `SELECT lower(email) FROM users WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lookups.Emails[email] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `SELECT lower(employee_id_number) FROM employees WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var number string
+		if err := rows.Scan(&number); err != nil {
+			return nil, err
+		}
+		lookups.EmployeeIDNumbers[number] = true
+	}
+	return lookups, rows.Err()
+}
+
+// importEmployee menyimpan satu karyawan hasil impor di dalam savepoint.
+func importEmployee(ctx context.Context, tx pgx.Tx, record *repository.EmployeeImportRecord) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer sp.Rollback(ctx)
+
+	if record.ManagerIDNumber != nil {
+		var managerID uuid.UUID
+		query := `SELECT id FROM employees WHERE tenant_id = $1 AND lower(employee_id_number) = lower($2) AND deleted_at IS NULL`
+		err := sp.QueryRow(ctx, query, record.Employee.TenantID, *record.ManagerIDNumber).Scan(&managerID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("atasan dengan nomor induk %s tidak ditemukan", *record.ManagerIDNumber)
+		}
+		if err != nil {
+			return err
+		}
+		record.Employee.ManagerID = &managerID
+	}
+
+	if err := insertEmployee(ctx, sp, record.User, record.Employee); err != nil {
+		return err
+	}
+	return sp.Commit(ctx)
+}
+
+func (r *postgresEmployeeImportRepo) ProcessChunk(ctx context.Context, job *entity.EmployeeImport, records []*repository.EmployeeImportRecord, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	importable := 0
+	for _, record := range records {
+		if record.Employee != nil {
+			importable++
+		}
+	}
+	if err := reserveEmployeeSeats(ctx, tx, job.TenantID, importable, at); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		row := record.Row
+		if record.Employee != nil {
+			if err := importEmployee(ctx, tx, record); err != nil {
+				// Error koneksi atau context dibatalkan bukan kesalahan baris;
+				// chunk diulang saat job dilanjutkan.
+				if ctx.Err() != nil || tx.Conn().IsClosed() {
+					return err
+				}
+				row.Status = entity.ImportRowFailed
+				row.Errors = []string{err.Error()}
+			} else {
+				row.Status = entity.ImportRowImported
+				row.EmployeeID = &record.Employee.ID
+			}
+		}
+
+		rowErrors, err := json.Marshal(nonNilStrings(row.Errors))
+		if err != nil {
+			return err
+		}
+		query := `UPDATE employee_import_rows SET status = $2, errors = $3, employee_id = $4, updated_at = $5 WHERE id = $1`
+		if _, err := tx.Exec(ctx, query, row.ID, row.Status, rowErrors, row.EmployeeID, at); err != nil {
+			return err
+		}
+	}
+
+	if err := refreshImportCounters(ctx, tx, job, at); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// refreshImportCounters menghitung ulang progres job dari status barisnya,
+// sehingga tetap benar walaupun job pernah terputus di tengah jalan.
+func refreshImportCounters(ctx context.Context, q dbtx, job *entity.EmployeeImport, at time.Time) error {
+	query := `UPDATE employee_imports i
+			  SET processed_rows = c.processed, success_rows = c.success, failed_rows = c.failed, updated_at = $2
+			  FROM (
+				SELECT COUNT(*) FILTER (WHERE status <> 'pending') AS processed,
+					   COUNT(*) FILTER (WHERE status IN ('valid', 'imported')) AS success,
+					   COUNT(*) FILTER (WHERE status IN ('invalid', 'failed')) AS failed
+				FROM employee_import_rows WHERE import_id = $1
+			  ) c
+			  WHERE i.id = $1
+			  RETURNING i.processed_rows, i.success_rows, i.failed_rows, i.updated_at`
+	return q.QueryRow(ctx, query, job.ID, at).Scan(&job.ProcessedRows, &job.SuccessRows, &job.FailedRows, &job.UpdatedAt)
+}
+
+func (r *postgresEmployeeImportRepo) Finish(ctx context.Context, job *entity.EmployeeImport, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if job.Status == entity.ImportFailed && job.ErrorMessage != nil {
+		rowErrors, err := json.Marshal([]string{*job.ErrorMessage})
+		if err != nil {
+			return err
+		}
+		query := `UPDATE employee_import_rows SET status = $2, errors = $3, updated_at = $4 WHERE import_id = $1 AND status = $5`
+		if _, err := tx.Exec(ctx, query, job.ID, entity.ImportRowFailed, rowErrors, at, entity.ImportRowPending); err != nil {
+			return err
+		}
+	}
+
+	if err := refreshImportCounters(ctx, tx, job, at); err != nil {
+		return err
+	}
+
+	job.FinishedAt = &at
+	query := `UPDATE employee_imports SET status = $2, error_message = $3, finished_at = $4, updated_at = $4 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, job.ID, job.Status, job.ErrorMessage, at); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresEmployeeNumberRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresEmployeeNumberRepo(dbPool *TenantDB) repository.EmployeeNumberRepository {
+	return &postgresEmployeeNumberRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresEmployeeNumberRepo) FindScheme(ctx context.Context, tenantID uuid.UUID) (*entity.EmployeeNumberScheme, error) {
+	query := `SELECT tenant_id, template, reset_yearly, reset_per_branch, COALESCE(created_at, now()), COALESCE(updated_at, now())
+			  FROM employee_number_schemes
+			  WHERE tenant_id = $1`
+
+	var s entity.EmployeeNumberScheme
+	err := r.db.QueryRow(ctx, query, tenantID).Scan(
+		&s.TenantID,
+		&s.Template,
+		&s.ResetYearly,
+		&s.ResetPerBranch,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrEmployeeNumberSchemeNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *postgresEmployeeNumberRepo) SaveScheme(ctx context.Context, s *entity.EmployeeNumberScheme) error {
+	query := `INSERT INTO employee_number_schemes (tenant_id, template, reset_yearly, reset_per_branch, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT (tenant_id) DO UPDATE SET
+				template = EXCLUDED.template,
+				reset_yearly = EXCLUDED.reset_yearly,
+				reset_per_branch = EXCLUDED.reset_per_branch,
+				updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(ctx, query,
+		s.TenantID,
+		s.Template,
+		s.ResetYearly,
+		s.ResetPerBranch,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresEmployeeNumberRepo) NextSequence(ctx context.Context, tenantID uuid.UUID, scope string, at time.Time) (int64, error) {
+	// Baris yang bentrok dikunci sampai statement selesai, sehingga dua
+	// permintaan bersamaan selalu mendapat nilai yang berbeda.
+	query := `INSERT INTO employee_number_sequences (tenant_id, scope, last_value, updated_at)
+			  VALUES ($1, $2, 1, $3)
+			  ON CONFLICT (tenant_id, scope) DO UPDATE SET
+				last_value = employee_number_sequences.last_value + 1,
+				updated_at = EXCLUDED.updated_at
+			  RETURNING last_value`
+
+	var value int64
+	err := r.db.QueryRow(ctx, query, tenantID, scope, at).Scan(&value)
+	return value, err
+}
+
+func (r *postgresEmployeeNumberRepo) CurrentSequence(ctx context.Context, tenantID uuid.UUID, scope string) (int64, error) {
+	query := `SELECT COALESCE((SELECT last_value FROM employee_number_sequences WHERE tenant_id = $1 AND scope = $2), 0)`
+
+	var value int64
+	err := r.db.QueryRow(ctx, query, tenantID, scope).Scan(&value)
+	return value, err
+}
+
+func (r *postgresEmployeeNumberRepo) NumberExists(ctx context.Context, tenantID uuid.UUID, number string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM employees WHERE tenant_id = $1 AND lower(employee_id_number) = lower($2))`
+
+	var exists bool
+	err := r.db.QueryRow(ctx, query, tenantID, number).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,684 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresEmployeeRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresEmployeeRepo(dbPool *TenantDB) repository.EmployeeRepository {
+	return &postgresEmployeeRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// employeeSortColumns adalah kolom yang boleh dipakai untuk sorting list karyawan.
+var employeeSortColumns = map[string]string{
+	"full_name":          "p.full_name",
+	"employee_id_number": "e.employee_id_number",
+	"join_date":          "e.join_date",
+	"created_at":         "e.created_at",
+}
+
+const employeeColumns = `e.id, e.user_id, e.tenant_id, u.email,
+	e.branch_id, e.department_id, e.position_id, e.job_level_id, e.employment_status_id, e.sbu_id, e.manager_id,
+	e.employee_id_number, e.join_date, e.resign_date,
+	COALESCE(p.full_name, u.name), p.nik_ktp, p.place_of_birth, p.date_of_birth, p.gender, p.marital_status,
+	p.religion, p.address_ktp, p.address_domicile, p.emergency_contact_name, p.emergency_contact_phone,
+	f.bank_name, f.bank_account_number, f.bank_account_holder, f.npwp, f.ptkp_status,
+	f.bpjs_ketenagakerjaan_no, f.bpjs_kesehatan_no, f.basic_salary, e.custom_fields,
+	COALESCE(e.created_at, now()), COALESCE(e.updated_at, now()), e.deleted_at`
+
+// employeeFrom memuat profil dan data keuangan dengan LEFT JOIN karena
+// karyawan hasil provisioning SCIM belum tentu punya data keuangan.
+const employeeFrom = `employees e
+	JOIN users u ON u.id = e.user_id
+	LEFT JOIN employee_profiles p ON p.employee_id = e.id AND p.deleted_at IS NULL
+	LEFT JOIN employee_financials f ON f.employee_id = e.id AND f.deleted_at IS NULL`
+
+func scanEmployee(row pgx.Row) (*entity.Employee, error) {
+	var e entity.Employee
+	err := row.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.TenantID,
+		&e.Email,
+		&e.BranchID,
+		&e.DepartmentID,
+		&e.PositionID,
+		&e.JobLevelID,
+		&e.EmploymentStatusID,
+		&e.SBUID,
+		&e.ManagerID,
+		&e.EmployeeIDNumber,
+		&e.JoinDate,
+		&e.ResignDate,
+		&e.Profile.FullName,
+		&e.Profile.NIKKTP,
+		&e.Profile.PlaceOfBirth,
+		&e.Profile.DateOfBirth,
+		&e.Profile.Gender,
+		&e.Profile.MaritalStatus,
+		&e.Profile.Religion,
+		&e.Profile.AddressKTP,
+		&e.Profile.AddressDomicile,
+		&e.Profile.EmergencyContactName,
+		&e.Profile.EmergencyContactPhone,
+		&e.Financial.BankName,
+		&e.Financial.BankAccountNumber,
+		&e.Financial.BankAccountHolder,
+		&e.Financial.NPWP,
+		&e.Financial.PTKPStatus,
+		&e.Financial.BPJSKetenagakerjaanNo,
+		&e.Financial.BPJSKesehatanNo,
+		&e.Financial.BasicSalary,
+		&e.CustomFields,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("employee not found")
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// checkEmployeeReferences memastikan cabang, departemen, dan referensi
+// organisasi lain milik tenant yang sama. Foreign key tidak memeriksa
+// tenant_id, sehingga tanpa pengecekan ini ID milik tenant lain bisa dipakai.
+// FOR SHARE menahan penghapusan data master yang sedang berjalan bersamaan.
+func checkEmployeeReferences(ctx context.Context, q dbtx, e *entity.Employee) error {
+	refs := []struct {
+		table string
+		label string
+		id    *uuid.UUID
+	}{
+		{"branches", "cabang", e.BranchID},
+		{"departments", "departemen", e.DepartmentID},
+		{"positions", "jabatan", e.PositionID},
+		{"job_levels", "level jabatan", e.JobLevelID},
+		{"employment_statuses", "status kepegawaian", e.EmploymentStatusID},
+		{"sbus", "SBU", e.SBUID},
+		{"employees", "atasan", e.ManagerID},
+	}
+
+	for _, ref := range refs {
+		if ref.id == nil {
+			continue
+		}
+
+		var exists bool
+		query := `SELECT EXISTS (SELECT 1 FROM ` + ref.table + ` WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL FOR SHARE)`
+		if err := q.QueryRow(ctx, query, *ref.id, e.TenantID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%s tidak ditemukan", ref.label)
+		}
+	}
+	return nil
+}
+
+// checkManagerCycle menolak atasan baru yang ternyata bawahan karyawan itu
+// sendiri. Advisory lock per tenant membuat dua perubahan atasan yang saling
+// silang tidak bisa lolos bersamaan.
+func checkManagerCycle(ctx context.Context, q dbtx, e *entity.Employee) error {
+	if _, err := q.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('employee_hierarchy:' || $1::text, 0))`, e.TenantID); err != nil {
+		return err
+	}
+
+	query := `WITH RECURSIVE chain AS (
+				SELECT id, manager_id, ARRAY[id] AS path FROM employees WHERE id = $1 AND tenant_id = $2
+				UNION ALL
+				SELECT m.id, m.manager_id, c.path || m.id
+				FROM employees m
+				JOIN chain c ON m.id = c.manager_id
+				WHERE NOT m.id = ANY(c.path)
+			  )
+			  SELECT EXISTS (SELECT 1 FROM chain WHERE id = $3)`
+
+	var cycle bool
+	if err := q.QueryRow(ctx, query, *e.ManagerID, e.TenantID, e.ID).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return repository.ErrManagerCycle
+	}
+	return nil
+}
+
+func upsertEmployeeProfile(ctx context.Context, q dbtx, employeeID uuid.UUID, p *entity.EmployeeProfile, now time.Time) error {
+	query := `INSERT INTO employee_profiles (employee_id, full_name, nik_ktp, place_of_birth, date_of_birth, gender,
+					marital_status, religion, address_ktp, address_domicile, emergency_contact_name, emergency_contact_phone,
+					created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
+			  ON CONFLICT (employee_id) DO UPDATE
+			  SET full_name = EXCLUDED.full_name, nik_ktp = EXCLUDED.nik_ktp, place_of_birth = EXCLUDED.place_of_birth,
+				  date_of_birth = EXCLUDED.date_of_birth, gender = EXCLUDED.gender, marital_status = EXCLUDED.marital_status,
+				  religion = EXCLUDED.religion, address_ktp = EXCLUDED.address_ktp, address_domicile = EXCLUDED.address_domicile,
+				  emergency_contact_name = EXCLUDED.emergency_contact_name,
+				  emergency_contact_phone = EXCLUDED.emergency_contact_phone,
+				  updated_at = EXCLUDED.updated_at, deleted_at = NULL`
+
+	_, err := q.Exec(ctx, query,
+		employeeID,
+		p.FullName,
+		p.NIKKTP,
+		p.PlaceOfBirth,
+		p.DateOfBirth,
+		p.Gender,
+		p.MaritalStatus,
+		p.Religion,
+		p.AddressKTP,
+		p.AddressDomicile,
+		p.EmergencyContactName,
+		p.EmergencyContactPhone,
+		now,
+	)
+	return err
+}
+
+func upsertEmployeeFinancial(ctx context.Context, q dbtx, employeeID uuid.UUID, f *entity.EmployeeFinancial, now time.Time) error {
+	query := `INSERT INTO employee_financials (employee_id, bank_name, bank_account_number, bank_account_holder, npwp,
+					ptkp_status, bpjs_ketenagakerjaan_no, bpjs_kesehatan_no, basic_salary, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+			  ON CONFLICT (employee_id) DO UPDATE
+			  SET bank_name = EXCLUDED.bank_name, bank_account_number = EXCLUDED.bank_account_number,
+				  bank_account_holder = EXCLUDED.bank_account_holder, npwp = EXCLUDED.npwp,
+				  ptkp_status = EXCLUDED.ptkp_status, bpjs_ketenagakerjaan_no = EXCLUDED.bpjs_ketenagakerjaan_no,
+				  bpjs_kesehatan_no = EXCLUDED.bpjs_kesehatan_no, basic_salary = EXCLUDED.basic_salary,
+				  updated_at = EXCLUDED.updated_at, deleted_at = NULL`
+
+	_, err := q.Exec(ctx, query,
+		employeeID,
+		f.BankName,
+		f.BankAccountNumber,
+		f.BankAccountHolder,
+		f.NPWP,
+		f.PTKPStatus,
+		f.BPJSKetenagakerjaanNo,
+		f.BPJSKesehatanNo,
+		f.BasicSalary,
+		now,
+	)
+	return err
+}
+
+// insertEmployee menyimpan akun login, karyawan, profil, data keuangan, dan
+// riwayat hire. Dipakai oleh Create dan impor karyawan.
+func insertEmployee(ctx context.Context, q dbtx, user *entity.User, employee *entity.Employee) error {
+	if err := checkEmployeeReferences(ctx, q, employee); err != nil {
+		return err
+	}
+
+	if err := insertUser(ctx, q, user); err != nil {
+		return err
+	}
+
+	if employee.CustomFields == nil {
+		employee.CustomFields = map[string]any{}
+	}
+	customFields, err := json.Marshal(employee.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO employees (id, user_id, tenant_id, branch_id, department_id, position_id, job_level_id,
+					employment_status_id, sbu_id, manager_id, employee_id_number, join_date, custom_fields, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = q.Exec(ctx, query,
+		employee.ID,
+		employee.UserID,
+		employee.TenantID,
+		employee.BranchID,
+		employee.DepartmentID,
+		employee.PositionID,
+		employee.JobLevelID,
+		employee.EmploymentStatusID,
+		employee.SBUID,
+		employee.ManagerID,
+		employee.EmployeeIDNumber,
+		employee.JoinDate,
+		customFields,
+		employee.CreatedAt,
+		employee.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("nomor induk karyawan sudah dipakai")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := upsertEmployeeProfile(ctx, q, employee.ID, &employee.Profile, employee.CreatedAt); err != nil {
+		return err
+	}
+	if err := upsertEmployeeFinancial(ctx, q, employee.ID, &employee.Financial, employee.CreatedAt); err != nil {
+		return err
+	}
+
+	historyID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+	hire := &entity.EmployeeJobHistory{
+		ID:            historyID,
+		TenantID:      employee.TenantID,
+		EmployeeID:    employee.ID,
+		ChangeType:    entity.JobChangeHire,
+		EffectiveDate: employee.JoinDate,
+		Status:        entity.JobChangeApplied,
+		Employment:    employee.Employment(),
+		AppliedAt:     &employee.CreatedAt,
+		CreatedAt:     employee.CreatedAt,
+		UpdatedAt:     employee.CreatedAt,
+	}
+	return insertJobHistory(ctx, q, hire)
+}
+
+func (r *postgresEmployeeRepo) Create(ctx context.Context, user *entity.User, employee *entity.Employee) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := reserveEmployeeSeats(ctx, tx, employee.TenantID, 1, time.Now()); err != nil {
+		return err
+	}
+
+	if err := insertEmployee(ctx, tx, user, employee); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresEmployeeRepo) Update(ctx context.Context, employee *entity.Employee) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := lockEmployeeEmployment(ctx, tx, employee.TenantID, employee.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkEmployeeReferences(ctx, tx, employee); err != nil {
+		return err
+	}
+
+	if employee.ManagerID != nil {
+		if err := checkManagerCycle(ctx, tx, employee); err != nil {
+			return err
+		}
+	}
+
+	employee.UpdatedAt = time.Now()
+
+	employmentChanged := !current.Equal(employee.Employment())
+	if employmentChanged {
+		if err := checkJobHistoryOrder(ctx, tx, employee.TenantID, employee.ID, employee.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if employee.CustomFields == nil {
+		employee.CustomFields = map[string]any{}
+	}
+	customFields, err := json.Marshal(employee.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE employees
+			  SET branch_id = $1, department_id = $2, position_id = $3, job_level_id = $4, employment_status_id = $5,
+				  sbu_id = $6, manager_id = $7, employee_id_number = $8, join_date = $9, custom_fields = $10, updated_at = $11
+			  WHERE id = $12 AND tenant_id = $13 AND deleted_at IS NULL`
+
+	tag, err := tx.Exec(ctx, query,
+		employee.BranchID,
+		employee.DepartmentID,
+		employee.PositionID,
+		employee.JobLevelID,
+		employee.EmploymentStatusID,
+		employee.SBUID,
+		employee.ManagerID,
+		employee.EmployeeIDNumber,
+		employee.JoinDate,
+		customFields,
+		employee.UpdatedAt,
+		employee.ID,
+		employee.TenantID,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("nomor induk karyawan sudah dipakai")
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("employee not found")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET name = $1, updated_at = $2 WHERE id = $3 AND tenant_id = $4`,
+		employee.Profile.FullName, employee.UpdatedAt, employee.UserID, employee.TenantID); err != nil {
+		return err
+	}
+
+	if err := upsertEmployeeProfile(ctx, tx, employee.ID, &employee.Profile, employee.UpdatedAt); err != nil {
+		return err
+	}
+	if err := upsertEmployeeFinancial(ctx, tx, employee.ID, &employee.Financial, employee.UpdatedAt); err != nil {
+		return err
+	}
+
+	if employmentChanged {
+		if err := recordEmploymentChanges(ctx, tx, employee.TenantID, []uuid.UUID{employee.ID},
+			entity.JobChangeCorrection, "Koreksi data karyawan", employee.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete juga melepas karyawan dari posisi atasan bawahannya dan mencabut
+// semua role akun login-nya.
+func (r *postgresEmployeeRepo) Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID uuid.UUID
+	err = tx.QueryRow(ctx, `UPDATE employees
+							SET resign_date = COALESCE(resign_date, $1::date), deleted_at = $1, updated_at = $1
+							WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+							RETURNING user_id`, at, id, tenantID).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("employee not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `UPDATE employees SET manager_id = NULL, updated_at = $1
+								WHERE manager_id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+								RETURNING id`, at, id, tenantID)
+	if err != nil {
+		return err
+	}
+	reports, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return err
+	}
+	if err := recordEmploymentChanges(ctx, tx, tenantID, reports, entity.JobChangeCorrection, "Atasan dihapus dari data karyawan", at); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE employee_job_histories SET status = $1, updated_at = $2
+							   WHERE employee_id = $3 AND tenant_id = $4 AND status = $5`,
+		entity.JobChangeCancelled, at, id, tenantID, entity.JobChangeScheduled); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE employee_profiles SET deleted_at = $1, updated_at = $1 WHERE employee_id = $2`, at, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE employee_financials SET deleted_at = $1, updated_at = $1 WHERE employee_id = $2`, at, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users
+							   SET login_disabled_at = COALESCE(login_disabled_at, $1), deleted_at = $1, updated_at = $1
+							   WHERE id = $2 AND tenant_id = $3`, at, userID, tenantID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM role_user WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresEmployeeRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Employee, error) {
+	sb := r.sqb.Select(employeeColumns).From(employeeFrom).
+		Where(sq.Eq{"e.id": id, "e.tenant_id": tenantID}).
+		Where("e.deleted_at IS NULL")
+	sb = applyEmployeeScope(ctx, sb, "e")
+
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	return scanEmployee(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresEmployeeRepo) buildFindQuery(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery, isCount bool) (string, []interface{}, error) {
+	var sb sq.SelectBuilder
+	if isCount {
+		sb = r.sqb.Select("COUNT(*)").From(employeeFrom)
+	} else {
+		sb = r.sqb.Select(employeeColumns).From(employeeFrom)
+	}
+
+	sb = sb.Where(sq.Eq{"e.tenant_id": tenantID}).Where("e.deleted_at IS NULL")
+	sb = applyEmployeeScope(ctx, sb, "e")
+
+	if query.Search != "" {
+		sb = sb.Where(
+			sq.Or{
+				sq.ILike{"p.full_name": "%" + query.Search + "%"},
+				sq.ILike{"e.employee_id_number": "%" + query.Search + "%"},
+				sq.ILike{"u.email": "%" + query.Search + "%"},
+			},
+		)
+	}
+
+	if query.Filters != nil {
+		for _, key := range repository.EmployeeFilterKeys {
+			if value, ok := query.Filters[key].(string); ok && value != "" {
+				sb = sb.Where(sq.Eq{"e." + key: value})
+			}
+		}
+
+		switch query.Filters["status"] {
+		case "active":
+			sb = sb.Where("(e.resign_date IS NULL OR e.resign_date >= CURRENT_DATE)")
+		case "resigned":
+			sb = sb.Where("e.resign_date < CURRENT_DATE")
+		}
+
+		var err error
+		if sb, err = applyCustomFieldFilters(sb, "e.custom_fields", query.Filters); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if !isCount {
+		var ok bool
+		if sb, ok = applyCustomFieldSort(sb, "e.custom_fields", query.SortBy, query.SortDir); !ok {
+			sortBy, ok := employeeSortColumns[query.SortBy]
+			if !ok {
+				sortBy = "p.full_name"
+			}
+			sb = sb.OrderBy(sortBy + " " + query.SortDir)
+		}
+		sb = sb.Limit(uint64(query.Limit)).
+			Offset(uint64(query.GetOffset()))
+	}
+
+	return sb.ToSql()
+}
+
+func (r *postgresEmployeeRepo) Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Employee, error) {
+	sql, args, err := r.buildFindQuery(ctx, tenantID, query, false)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []*entity.Employee{}
+	for rows.Next() {
+		e, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, e)
+	}
+	return employees, rows.Err()
+}
+
+func (r *postgresEmployeeRepo) Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.buildFindQuery(ctx, tenantID, query, true)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL count: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
+
+// ------------------------------------------------------------------------
+// Garis pelaporan
+// ------------------------------------------------------------------------
+
+const orgChartColumns = `e.id, e.manager_id, COALESCE(p.full_name, u.name), e.employee_id_number, pos.name, d.name`
+
+const orgChartFrom = `employees e
+	JOIN users u ON u.id = e.user_id
+	LEFT JOIN employee_profiles p ON p.employee_id = e.id AND p.deleted_at IS NULL
+	LEFT JOIN positions pos ON pos.id = e.position_id
+	LEFT JOIN departments d ON d.id = e.department_id`
+
+// chainOfCommandQuery menelusuri manager_id ke atas. Kolom path mencegah
+// loop jika data lama terlanjur membentuk siklus.
+const chainOfCommandQuery = `WITH RECURSIVE chain AS (
+	SELECT m.id, m.manager_id, ARRAY[s.id, m.id] AS path, 1 AS level
+	FROM employees s
+	JOIN employees m ON m.id = s.manager_id AND m.deleted_at IS NULL
+	WHERE s.id = ? AND s.tenant_id = ?
+	UNION ALL
+	SELECT m.id, m.manager_id, c.path || m.id, c.level + 1
+	FROM employees m
+	JOIN chain c ON m.id = c.manager_id
+	WHERE m.deleted_at IS NULL AND NOT m.id = ANY(c.path)
+)`
+
+// reportsQuery menelusuri bawahan ke bawah sampai kedalaman ? (0 = tanpa
+// batas). Bawahan dari karyawan yang sudah resign tetap ditelusuri.
+const reportsQuery = `WITH RECURSIVE reports AS (
+	SELECT e.id, ARRAY[e.manager_id, e.id] AS path, 1 AS level
+	FROM employees e
+	WHERE e.manager_id = ? AND e.tenant_id = ? AND e.deleted_at IS NULL
+	UNION ALL
+	SELECT e.id, r.path || e.id, r.level + 1
+	FROM employees e
+	JOIN reports r ON e.manager_id = r.id
+	WHERE e.deleted_at IS NULL AND NOT e.id = ANY(r.path) AND (? = 0 OR r.level < ?)
+)`
+
+func scanOrgChartNode(row pgx.Row) (*entity.OrgChartNode, error) {
+	var n entity.OrgChartNode
+	err := row.Scan(
+		&n.EmployeeID,
+		&n.ManagerID,
+		&n.FullName,
+		&n.EmployeeIDNumber,
+		&n.PositionName,
+		&n.DepartmentName,
+		&n.Level,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *postgresEmployeeRepo) queryOrgChartNodes(ctx context.Context, sb sq.SelectBuilder) ([]*entity.OrgChartNode, error) {
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := []*entity.OrgChartNode{}
+	for rows.Next() {
+		n, err := scanOrgChartNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, rows.Err()
+}
+
+// FindChainOfCommand tidak dibatasi data scope: atasan karyawan yang boleh
+// dilihat selalu boleh diketahui.
+func (r *postgresEmployeeRepo) FindChainOfCommand(ctx context.Context, tenantID, id uuid.UUID) ([]*entity.OrgChartNode, error) {
+	sb := r.sqb.Select(orgChartColumns+", c.level").
+		Prefix(chainOfCommandQuery, id, tenantID).
+		From(orgChartFrom).
+		Join("chain c ON c.id = e.id").
+		OrderBy("c.level")
+
+	return r.queryOrgChartNodes(ctx, sb)
+}
+
+func (r *postgresEmployeeRepo) FindReports(ctx context.Context, tenantID, id uuid.UUID, maxDepth int) ([]*entity.OrgChartNode, error) {
+	sb := r.sqb.Select(orgChartColumns+", rp.level").
+		Prefix(reportsQuery, id, tenantID, maxDepth, maxDepth).
+		From(orgChartFrom).
+		Join("reports rp ON rp.id = e.id").
+		Where("(e.resign_date IS NULL OR e.resign_date >= CURRENT_DATE)").
+		OrderBy("rp.level", "COALESCE(p.full_name, u.name)")
+	sb = applyEmployeeScope(ctx, sb, "e")
+
+	return r.queryOrgChartNodes(ctx, sb)
+}
+
+func (r *postgresEmployeeRepo) FindHierarchy(ctx context.Context, tenantID uuid.UUID) ([]*entity.OrgChartNode, error) {
+	sb := r.sqb.Select(orgChartColumns + ", 0").
+		From(orgChartFrom).
+		Where(sq.Eq{"e.tenant_id": tenantID}).
+		Where("e.deleted_at IS NULL").
+		Where("(e.resign_date IS NULL OR e.resign_date >= CURRENT_DATE)").
+		OrderBy("COALESCE(p.full_name, u.name)")
+	sb = applyEmployeeScope(ctx, sb, "e")
+
+	return r.queryOrgChartNodes(ctx, sb)
+}
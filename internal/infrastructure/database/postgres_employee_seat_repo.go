@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresEmployeeSeatRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresEmployeeSeatRepo(dbPool *TenantDB) repository.EmployeeSeatRepository {
+	return &postgresEmployeeSeatRepo{
+		db: dbPool,
+	}
+}
+
+// seatUsageQuery menghitung, per tenant:
+//   - paket dari langganan yang berlaku pada $1 (LATERAL, ambil yang terbaru)
+//   - total kursi tambahan dari addon yang belum kedaluwarsa
+//   - jumlah karyawan aktif (belum dihapus dan belum melewati resign_date)
+const seatUsageQuery = `SELECT t.id, t.name, p.id, p.name,
+		COALESCE(p.employee_limit, 0),
+		COALESCE((SELECT SUM(ta.extra_employee_seats)
+				  FROM tenant_addons ta
+				  WHERE ta.tenant_id = t.id AND (ta.expires_at IS NULL OR ta.expires_at > $1)), 0),
+		(SELECT COUNT(*)
+		 FROM employees e
+		 WHERE e.tenant_id = t.id AND e.deleted_at IS NULL
+		   AND (e.resign_date IS NULL OR e.resign_date >= $1::date))
+	FROM tenants t
+	LEFT JOIN LATERAL (
+		SELECT s.plan_id
+		FROM subscriptions s
+		WHERE s.tenant_id = t.id
+		  AND s.status IN ('active', 'trialing', 'past_due')
+		  AND s.started_at <= $1
+		  AND (s.ends_at IS NULL OR s.ends_at > $1)
+		  AND (s.status <> 'trialing' OR s.trial_ends_at IS NULL OR s.trial_ends_at > $1)
+		ORDER BY s.started_at DESC
+		LIMIT 1
+	) cs ON TRUE
+	LEFT JOIN plans p ON p.id = cs.plan_id
+	WHERE t.deleted_at IS NULL`
+
+func scanSeatUsage(row pgx.Row) (*entity.EmployeeSeatUsage, error) {
+	var u entity.EmployeeSeatUsage
+	err := row.Scan(
+		&u.TenantID,
+		&u.TenantName,
+		&u.PlanID,
+		&u.PlanName,
+		&u.PlanLimit,
+		&u.AddonSeats,
+		&u.ActiveEmployees,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("tenant not found")
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *postgresEmployeeSeatRepo) FindUsage(ctx context.Context, tenantID uuid.UUID, at time.Time) (*entity.EmployeeSeatUsage, error) {
+	row := r.db.QueryRow(ctx, seatUsageQuery+` AND t.id = $2`, at, tenantID)
+	return scanSeatUsage(row)
+}
+
+func (r *postgresEmployeeSeatRepo) FindAllUsages(ctx context.Context, at time.Time) ([]*entity.EmployeeSeatUsage, error) {
+	rows, err := r.db.Query(ctx, seatUsageQuery+` ORDER BY t.name`, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usages := []*entity.EmployeeSeatUsage{}
+	for rows.Next() {
+		usage, err := scanSeatUsage(rows)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, rows.Err()
+}
+
+// reserveEmployeeSeats memastikan kuota tenant cukup untuk 'additional'
+// karyawan aktif baru. Harus dipanggil di dalam transaksi yang menyimpan
+// karyawan tersebut: baris tenant dikunci (FOR UPDATE) sebelum menghitung,
+// sehingga transaksi lain yang menambah karyawan di tenant yang sama menunggu
+// sampai transaksi ini selesai dan menghitung dengan data terbaru.
+func reserveEmployeeSeats(ctx context.Context, q dbtx, tenantID uuid.UUID, additional int, at time.Time) error {
+	if additional <= 0 {
+		return nil
+	}
+
+	var locked uuid.UUID
+	err := q.QueryRow(ctx, `SELECT id FROM tenants WHERE id = $1 FOR UPDATE`, tenantID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("tenant not found")
+		}
+		return err
+	}
+
+	usage, err := scanSeatUsage(q.QueryRow(ctx, seatUsageQuery+` AND t.id = $2`, at, tenantID))
+	if err != nil {
+		return err
+	}
+	if usage.ActiveEmployees+additional > usage.Capacity() {
+		return &repository.EmployeeSeatLimitError{Usage: usage, Requested: additional}
+	}
+	return nil
+}
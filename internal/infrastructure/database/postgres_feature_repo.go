@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresFeatureRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresFeatureRepo(dbPool *TenantDB) repository.FeatureRepository {
+	return &postgresFeatureRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresFeatureRepo) FindBySlug(ctx context.Context, slug string) (*entity.Feature, error) {
+	query := `SELECT id, name, slug, description, is_addon, created_at, updated_at
+			  FROM features
+			  WHERE slug = $1 AND deleted_at IS NULL`
+
+	var f entity.Feature
+	err := r.db.QueryRow(ctx, query, slug).Scan(
+		&f.ID,
+		&f.Name,
+		&f.Slug,
+		&f.Description,
+		&f.IsAddon,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("feature not found")
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *postgresFeatureRepo) FindEntitlements(ctx context.Context, tenantID uuid.UUID, at time.Time) ([]entity.Entitlement, error) {
+	// Bagian pertama: fitur dari paket langganan yang sedang berlaku.
+	// LEAST() di Postgres mengabaikan NULL, jadi hasilnya adalah batas waktu
+	// paling awal antara ends_at dan trial_ends_at (khusus status trialing).
+	// Bagian kedua: addon yang dibeli terpisah dan belum kedaluwarsa.
+	query := `SELECT f.slug, 'plan' AS source,
+					 LEAST(s.ends_at, CASE WHEN s.status = 'trialing' THEN s.trial_ends_at END) AS expires_at
+			  FROM subscriptions s
+			  JOIN feature_plan fp ON fp.plan_id = s.plan_id
+			  JOIN features f ON f.id = fp.feature_id AND f.deleted_at IS NULL
+			  WHERE s.tenant_id = $1
+				AND s.status IN ('active', 'trialing', 'past_due')
+				AND s.started_at <= $2
+				AND (s.ends_at IS NULL OR s.ends_at > $2)
+				AND (s.status <> 'trialing' OR s.trial_ends_at IS NULL OR s.trial_ends_at > $2)
+			  UNION ALL
+			  SELECT f.slug, 'addon' AS source, ta.expires_at
+			  FROM tenant_addons ta
+			  JOIN features f ON f.id = ta.feature_id AND f.deleted_at IS NULL
+			  WHERE ta.tenant_id = $1
+				AND (ta.expires_at IS NULL OR ta.expires_at > $2)`
+
+	rows, err := r.db.Query(ctx, query, tenantID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entitlements := []entity.Entitlement{}
+	for rows.Next() {
+		var e entity.Entitlement
+		if err := rows.Scan(&e.FeatureSlug, &e.Source, &e.ExpiresAt); err != nil {
+			return nil, err
+		}
+		entitlements = append(entitlements, e)
+	}
+	return entitlements, rows.Err()
+}
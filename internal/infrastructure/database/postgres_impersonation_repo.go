@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresImpersonationRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresImpersonationRepo(dbPool *TenantDB) repository.ImpersonationRepository {
+	return &postgresImpersonationRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresImpersonationRepo) Create(ctx context.Context, s *entity.ImpersonationSession) error {
+	query := `INSERT INTO impersonation_sessions (id, admin_user_id, tenant_id, user_id, reason, read_only, expires_at, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Exec(ctx, query,
+		s.ID,
+		s.AdminUserID,
+		s.TenantID,
+		s.UserID,
+		s.Reason,
+		s.ReadOnly,
+		s.ExpiresAt,
+		s.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresImpersonationRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImpersonationSession, error) {
+	query := `SELECT id, admin_user_id, tenant_id, user_id, reason, read_only, expires_at, ended_at, created_at
+			  FROM impersonation_sessions
+			  WHERE id = $1`
+
+	var s entity.ImpersonationSession
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&s.ID,
+		&s.AdminUserID,
+		&s.TenantID,
+		&s.UserID,
+		&s.Reason,
+		&s.ReadOnly,
+		&s.ExpiresAt,
+		&s.EndedAt,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("impersonation session not found")
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *postgresImpersonationRepo) End(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE impersonation_sessions SET ended_at = $2 WHERE id = $1 AND ended_at IS NULL`, id, at)
+	return err
+}
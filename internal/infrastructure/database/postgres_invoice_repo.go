@@ -0,0 +1,390 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type postgresInvoiceRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresInvoiceRepo(dbPool *TenantDB) repository.InvoiceRepository {
+	return &postgresInvoiceRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func insertInvoice(ctx context.Context, q dbtx, invoice *entity.Invoice) error {
+	query := `INSERT INTO invoices (id, tenant_id, subscription_id, issue_date, due_date, total_amount, status, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := q.Exec(ctx, query,
+		invoice.ID,
+		invoice.TenantID,
+		invoice.SubscriptionID,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.TotalAmount,
+		invoice.Status,
+		invoice.CreatedAt,
+		invoice.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	itemQuery := `INSERT INTO invoice_items (id, invoice_id, description, quantity, unit_price, total_price)
+				  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range invoice.Items {
+		if _, err := q.Exec(ctx, itemQuery,
+			item.ID,
+			invoice.ID,
+			item.Description,
+			item.Quantity,
+			item.UnitPrice,
+			item.TotalPrice,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertBillingCredit(ctx context.Context, q dbtx, credit *entity.BillingCredit) error {
+	query := `INSERT INTO billing_credits (id, tenant_id, source, description, credit_note_id, amount, remaining_amount, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := q.Exec(ctx, query,
+		credit.ID,
+		credit.TenantID,
+		credit.Source,
+		credit.Description,
+		credit.CreditNoteID,
+		credit.Amount,
+		credit.RemainingAmount,
+		credit.CreatedAt,
+		credit.UpdatedAt,
+	)
+	return err
+}
+
+func insertInvoicePayment(ctx context.Context, q dbtx, payment *entity.InvoicePayment) error {
+	query := `INSERT INTO invoice_payments (id, invoice_id, payment_date, amount_paid, payment_method, transaction_id, notes, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := q.Exec(ctx, query,
+		payment.ID,
+		payment.InvoiceID,
+		payment.PaymentDate,
+		payment.AmountPaid,
+		payment.PaymentMethod,
+		payment.TransactionID,
+		payment.Notes,
+		payment.CreatedAt,
+		payment.UpdatedAt,
+	)
+	return err
+}
+
+// markInvoicePaidIfSettled mengubah status invoice menjadi 'paid' jika total
+// pembayaran (termasuk pemakaian kredit) sudah menutup total tagihan.
+func markInvoicePaidIfSettled(ctx context.Context, q dbtx, invoiceID uuid.UUID) error {
+	query := `UPDATE invoices i
+			  SET status = 'paid', updated_at = now()
+			  WHERE i.id = $1
+				AND i.status IN ('unpaid', 'overdue')
+				AND (SELECT COALESCE(SUM(ip.amount_paid), 0) FROM invoice_payments ip WHERE ip.invoice_id = i.id) >= i.total_amount`
+
+	_, err := q.Exec(ctx, query, invoiceID)
+	return err
+}
+
+func (r *postgresInvoiceRepo) scanInvoice(row pgx.Row) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.TenantID,
+		&invoice.SubscriptionID,
+		&invoice.IssueDate,
+		&invoice.DueDate,
+		&invoice.TotalAmount,
+		&invoice.Status,
+		&invoice.CreatedAt,
+		&invoice.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("invoice not found")
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *postgresInvoiceRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	query := `SELECT id, tenant_id, subscription_id, issue_date, due_date, total_amount, status, created_at, updated_at
+			  FROM invoices
+			  WHERE id = $1 AND deleted_at IS NULL`
+
+	invoice, err := r.scanInvoice(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+
+	itemQuery := `SELECT id, invoice_id, description, quantity, unit_price, total_price
+				  FROM invoice_items
+				  WHERE invoice_id = $1
+				  ORDER BY total_price DESC`
+
+	rows, err := r.db.Query(ctx, itemQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.InvoiceItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.InvoiceID,
+			&item.Description,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.TotalPrice,
+		); err != nil {
+			return nil, err
+		}
+		invoice.Items = append(invoice.Items, item)
+	}
+	return invoice, rows.Err()
+}
+
+func (r *postgresInvoiceRepo) buildFindQuery(tenantID uuid.UUID, query util.PaginationQuery, isCount bool) (string, []interface{}, error) {
+	var sb sq.SelectBuilder
+	if isCount {
+		sb = r.sqb.Select("COUNT(*)").From("invoices")
+	} else {
+		sb = r.sqb.Select("id", "tenant_id", "subscription_id", "issue_date", "due_date", "total_amount", "status", "created_at", "updated_at").
+			From("invoices")
+	}
+
+	sb = sb.Where("deleted_at IS NULL").Where(sq.Eq{"tenant_id": tenantID})
+
+	if query.Filters != nil {
+		if status, ok := query.Filters["status"].(string); ok && status != "" {
+			sb = sb.Where(sq.Eq{"status": status})
+		}
+	}
+
+	if !isCount {
+		sb = sb.OrderBy(query.SortBy + " " + query.SortDir)
+		sb = sb.Limit(uint64(query.Limit)).
+			Offset(uint64(query.GetOffset()))
+	}
+
+	return sb.ToSql()
+}
+
+func (r *postgresInvoiceRepo) FindByTenant(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Invoice, error) {
+	sql, args, err := r.buildFindQuery(tenantID, query, false)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := []*entity.Invoice{}
+	for rows.Next() {
+		invoice, err := r.scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+	return invoices, rows.Err()
+}
+
+func (r *postgresInvoiceRepo) CountByTenant(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.buildFindQuery(tenantID, query, true)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL count: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
+
+// lockInvoiceOutstanding mengunci invoice yang belum lunas dan menghitung sisa
+// tagihannya: total - credit note yang terbit - pembayaran. Refund dari credit
+// note tercatat sebagai pembayaran negatif, sehingga invoice yang sudah
+// di-refund tetap tidak memiliki sisa tagihan.
+func lockInvoiceOutstanding(ctx context.Context, q dbtx, invoiceID uuid.UUID) (uuid.UUID, float64, error) {
+	var tenantID uuid.UUID
+	var outstanding float64
+	err := q.QueryRow(ctx, `SELECT i.tenant_id,
+								   i.total_amount
+								   - (SELECT COALESCE(SUM(cn.total_amount), 0) FROM credit_notes cn WHERE cn.invoice_id = i.id AND cn.status = 'issued')
+								   - (SELECT COALESCE(SUM(ip.amount_paid), 0) FROM invoice_payments ip WHERE ip.invoice_id = i.id)
+							FROM invoices i
+							WHERE i.id = $1 AND i.deleted_at IS NULL AND i.status IN ('unpaid', 'overdue')
+							FOR UPDATE`, invoiceID).Scan(&tenantID, &outstanding)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, 0, errors.New("invoice tidak ditemukan atau sudah lunas")
+		}
+		return uuid.Nil, 0, err
+	}
+	return tenantID, entity.RoundMoney(outstanding), nil
+}
+
+// CreatePayment mengunci invoice lalu menolak pembayaran yang melebihi sisa
+// tagihan, sehingga pembayaran bersamaan tidak bisa membayar lebih.
+func (r *postgresInvoiceRepo) CreatePayment(ctx context.Context, payment *entity.InvoicePayment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, outstanding, err := lockInvoiceOutstanding(ctx, tx, payment.InvoiceID)
+	if err != nil {
+		return err
+	}
+	if outstanding <= 0 {
+		return errors.New("invoice sudah lunas atau sudah dikreditkan penuh")
+	}
+	if payment.AmountPaid > outstanding {
+		return fmt.Errorf("nominal pembayaran melebihi sisa tagihan (%.2f)", outstanding)
+	}
+
+	if err := insertInvoicePayment(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	if err := markInvoicePaidIfSettled(ctx, tx, payment.InvoiceID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresInvoiceRepo) SumPayments(ctx context.Context, invoiceID uuid.UUID) (float64, error) {
+	query := `SELECT COALESCE(SUM(amount_paid), 0) FROM invoice_payments WHERE invoice_id = $1`
+
+	var total float64
+	err := r.db.QueryRow(ctx, query, invoiceID).Scan(&total)
+	return total, err
+}
+
+func (r *postgresInvoiceRepo) SumAvailableCredits(ctx context.Context, tenantID uuid.UUID) (float64, error) {
+	query := `SELECT COALESCE(SUM(remaining_amount), 0) FROM billing_credits WHERE tenant_id = $1`
+
+	var total float64
+	err := r.db.QueryRow(ctx, query, tenantID).Scan(&total)
+	return total, err
+}
+
+// ApplyCredits memakai saldo kredit tenant (FIFO) untuk melunasi invoice.
+// Setiap pemakaian kredit dicatat sebagai invoice_payments dengan metode 'credit'.
+func (r *postgresInvoiceRepo) ApplyCredits(ctx context.Context, invoiceID uuid.UUID) (float64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	tenantID, outstanding, err := lockInvoiceOutstanding(ctx, tx, invoiceID)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id, remaining_amount
+								FROM billing_credits
+								WHERE tenant_id = $1 AND remaining_amount > 0
+								ORDER BY created_at
+								FOR UPDATE`, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	type availableCredit struct {
+		id        uuid.UUID
+		remaining float64
+	}
+	credits := []availableCredit{}
+	for rows.Next() {
+		var c availableCredit
+		if err := rows.Scan(&c.id, &c.remaining); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		credits = append(credits, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	applied := 0.0
+	now := time.Now()
+	method := entity.PaymentMethodCredit
+
+	for _, c := range credits {
+		if outstanding <= 0 {
+			break
+		}
+
+		use := entity.RoundMoney(math.Min(c.remaining, outstanding))
+
+		if _, err := tx.Exec(ctx, `UPDATE billing_credits SET remaining_amount = remaining_amount - $1, updated_at = $2 WHERE id = $3`,
+			use, now, c.id); err != nil {
+			return 0, err
+		}
+
+		paymentID, err := uuid.NewV7()
+		if err != nil {
+			return 0, err
+		}
+		notes := "Pemakaian kredit " + c.id.String()
+		if err := insertInvoicePayment(ctx, tx, &entity.InvoicePayment{
+			ID:            paymentID,
+			InvoiceID:     invoiceID,
+			PaymentDate:   now,
+			AmountPaid:    use,
+			PaymentMethod: &method,
+			Notes:         &notes,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}); err != nil {
+			return 0, err
+		}
+
+		applied = entity.RoundMoney(applied + use)
+		outstanding = entity.RoundMoney(outstanding - use)
+	}
+
+	if err := markInvoicePaidIfSettled(ctx, tx, invoiceID); err != nil {
+		return 0, err
+	}
+
+	return applied, tx.Commit(ctx)
+}
@@ -0,0 +1,391 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresJobHistoryRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresJobHistoryRepo(dbPool *TenantDB) repository.EmployeeJobHistoryRepository {
+	return &postgresJobHistoryRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+const jobHistoryColumns = `h.id, h.tenant_id, h.employee_id, h.change_type, h.effective_date, h.status,
+	h.branch_id, h.department_id, h.position_id, h.job_level_id, h.employment_status_id, h.sbu_id, h.manager_id,
+	h.basic_salary, h.reason, h.decree_number, h.failure_reason, h.created_by, h.applied_at,
+	COALESCE(h.created_at, now()), COALESCE(h.updated_at, now())`
+
+func scanJobHistory(row pgx.Row) (*entity.EmployeeJobHistory, error) {
+	var h entity.EmployeeJobHistory
+	err := row.Scan(
+		&h.ID,
+		&h.TenantID,
+		&h.EmployeeID,
+		&h.ChangeType,
+		&h.EffectiveDate,
+		&h.Status,
+		&h.Employment.BranchID,
+		&h.Employment.DepartmentID,
+		&h.Employment.PositionID,
+		&h.Employment.JobLevelID,
+		&h.Employment.EmploymentStatusID,
+		&h.Employment.SBUID,
+		&h.Employment.ManagerID,
+		&h.Employment.BasicSalary,
+		&h.Reason,
+		&h.DecreeNumber,
+		&h.FailureReason,
+		&h.CreatedBy,
+		&h.AppliedAt,
+		&h.CreatedAt,
+		&h.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("job history not found")
+		}
+		return nil, err
+	}
+	return &h, nil
+}
+
+func insertJobHistory(ctx context.Context, q dbtx, h *entity.EmployeeJobHistory) error {
+	query := `INSERT INTO employee_job_histories (id, tenant_id, employee_id, change_type, effective_date, status,
+					branch_id, department_id, position_id, job_level_id, employment_status_id, sbu_id, manager_id,
+					basic_salary, reason, decree_number, created_by, applied_at, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
+
+	_, err := q.Exec(ctx, query,
+		h.ID,
+		h.TenantID,
+		h.EmployeeID,
+		h.ChangeType,
+		h.EffectiveDate,
+		h.Status,
+		h.Employment.BranchID,
+		h.Employment.DepartmentID,
+		h.Employment.PositionID,
+		h.Employment.JobLevelID,
+		h.Employment.EmploymentStatusID,
+		h.Employment.SBUID,
+		h.Employment.ManagerID,
+		h.Employment.BasicSalary,
+		h.Reason,
+		h.DecreeNumber,
+		h.CreatedBy,
+		h.AppliedAt,
+		h.CreatedAt,
+		h.UpdatedAt,
+	)
+	return err
+}
+
+// checkJobHistoryOrder menolak perubahan yang berlaku sebelum perubahan lain
+// yang sudah tercatat atau terjadwal.
+func checkJobHistoryOrder(ctx context.Context, q dbtx, tenantID, employeeID uuid.UUID, effectiveDate time.Time) error {
+	var later bool
+	query := `SELECT EXISTS (SELECT 1 FROM employee_job_histories
+			  WHERE employee_id = $1 AND tenant_id = $2 AND status IN ('scheduled', 'applied') AND effective_date > $3)`
+	if err := q.QueryRow(ctx, query, employeeID, tenantID, effectiveDate).Scan(&later); err != nil {
+		return err
+	}
+	if later {
+		return repository.ErrJobChangeOutOfOrder
+	}
+	return nil
+}
+
+// lockEmployeeEmployment mengunci baris karyawan dan mengembalikan posisinya
+// saat ini.
+func lockEmployeeEmployment(ctx context.Context, q dbtx, tenantID, employeeID uuid.UUID) (entity.Employment, error) {
+	var m entity.Employment
+	query := `SELECT e.branch_id, e.department_id, e.position_id, e.job_level_id, e.employment_status_id, e.sbu_id,
+					 e.manager_id, f.basic_salary
+			  FROM employees e
+			  LEFT JOIN employee_financials f ON f.employee_id = e.id AND f.deleted_at IS NULL
+			  WHERE e.id = $1 AND e.tenant_id = $2 AND e.deleted_at IS NULL
+			  FOR UPDATE OF e`
+
+	err := q.QueryRow(ctx, query, employeeID, tenantID).Scan(
+		&m.BranchID,
+		&m.DepartmentID,
+		&m.PositionID,
+		&m.JobLevelID,
+		&m.EmploymentStatusID,
+		&m.SBUID,
+		&m.ManagerID,
+		&m.BasicSalary,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return m, errors.New("employee not found")
+	}
+	return m, err
+}
+
+// applyEmployment menulis posisi dari riwayat jabatan ke data karyawan
+// setelah memeriksa referensi organisasi dan siklus atasan.
+func applyEmployment(ctx context.Context, q dbtx, tenantID, employeeID uuid.UUID, m entity.Employment, at time.Time) error {
+	employee := &entity.Employee{ID: employeeID, TenantID: tenantID}
+	employee.SetEmployment(m)
+
+	if employee.ManagerID != nil && *employee.ManagerID == employeeID {
+		return errors.New("karyawan tidak bisa menjadi atasan dirinya sendiri")
+	}
+	if err := checkEmployeeReferences(ctx, q, employee); err != nil {
+		return err
+	}
+	if employee.ManagerID != nil {
+		if err := checkManagerCycle(ctx, q, employee); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE employees
+			  SET branch_id = $1, department_id = $2, position_id = $3, job_level_id = $4, employment_status_id = $5,
+				  sbu_id = $6, manager_id = $7, updated_at = $8
+			  WHERE id = $9 AND tenant_id = $10 AND deleted_at IS NULL`
+
+	tag, err := q.Exec(ctx, query,
+		m.BranchID,
+		m.DepartmentID,
+		m.PositionID,
+		m.JobLevelID,
+		m.EmploymentStatusID,
+		m.SBUID,
+		m.ManagerID,
+		at,
+		employeeID,
+		tenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("employee not found")
+	}
+
+	query = `INSERT INTO employee_financials (employee_id, basic_salary, created_at, updated_at)
+			 VALUES ($1, $2, $3, $3)
+			 ON CONFLICT (employee_id) DO UPDATE
+			 SET basic_salary = EXCLUDED.basic_salary, updated_at = EXCLUDED.updated_at`
+
+	_, err = q.Exec(ctx, query, employeeID, m.BasicSalary, at)
+	return err
+}
+
+// recordEmploymentChanges mencatat posisi terkini karyawan yang diubah di luar
+// alur riwayat jabatan (misalnya pemindahan massal saat data master dihapus).
+func recordEmploymentChanges(ctx context.Context, q dbtx, tenantID uuid.UUID, employeeIDs []uuid.UUID, changeType entity.JobChangeType, reason string, at time.Time) error {
+	query := `INSERT INTO employee_job_histories (id, tenant_id, employee_id, change_type, effective_date, status,
+					branch_id, department_id, position_id, job_level_id, employment_status_id, sbu_id, manager_id,
+					basic_salary, reason, applied_at, created_at, updated_at)
+			  SELECT $1, e.tenant_id, e.id, $2, $3, 'applied',
+					 e.branch_id, e.department_id, e.position_id, e.job_level_id, e.employment_status_id, e.sbu_id,
+					 e.manager_id, f.basic_salary, $4, $5, $5, $5
+			  FROM employees e
+			  LEFT JOIN employee_financials f ON f.employee_id = e.id AND f.deleted_at IS NULL
+			  WHERE e.id = $6 AND e.tenant_id = $7`
+
+	for _, employeeID := range employeeIDs {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+		if _, err := q.Exec(ctx, query, id, changeType, at, reason, at, employeeID, tenantID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresJobHistoryRepo) Create(ctx context.Context, h *entity.EmployeeJobHistory) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockEmployeeEmployment(ctx, tx, h.TenantID, h.EmployeeID); err != nil {
+		return err
+	}
+	if err := checkJobHistoryOrder(ctx, tx, h.TenantID, h.EmployeeID, h.EffectiveDate); err != nil {
+		return err
+	}
+
+	if h.Status == entity.JobChangeApplied {
+		if err := applyEmployment(ctx, tx, h.TenantID, h.EmployeeID, h.Employment, h.CreatedAt); err != nil {
+			return err
+		}
+	} else {
+		// Referensi tetap diperiksa sekarang agar kesalahan input tidak
+		// baru ketahuan saat job terjadwal berjalan.
+		employee := &entity.Employee{ID: h.EmployeeID, TenantID: h.TenantID}
+		employee.SetEmployment(h.Employment)
+		if err := checkEmployeeReferences(ctx, tx, employee); err != nil {
+			return err
+		}
+	}
+
+	if err := insertJobHistory(ctx, tx, h); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresJobHistoryRepo) Apply(ctx context.Context, h *entity.EmployeeJobHistory, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var status entity.JobChangeStatus
+	err = tx.QueryRow(ctx, `SELECT status FROM employee_job_histories WHERE id = $1 AND tenant_id = $2 FOR UPDATE`,
+		h.ID, h.TenantID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("job history not found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != entity.JobChangeScheduled {
+		// Sudah dibatalkan atau diterapkan proses lain.
+		return nil
+	}
+
+	if _, err := lockEmployeeEmployment(ctx, tx, h.TenantID, h.EmployeeID); err != nil {
+		return err
+	}
+	if err := applyEmployment(ctx, tx, h.TenantID, h.EmployeeID, h.Employment, at); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE employee_job_histories SET status = $1, applied_at = $2, updated_at = $2 WHERE id = $3`,
+		entity.JobChangeApplied, at, h.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresJobHistoryRepo) MarkFailed(ctx context.Context, id uuid.UUID, reason string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE employee_job_histories SET status = $1, failure_reason = $2, updated_at = $3
+							  WHERE id = $4 AND status = $5`,
+		entity.JobChangeFailed, reason, at, id, entity.JobChangeScheduled)
+	return err
+}
+
+func (r *postgresJobHistoryRepo) Cancel(ctx context.Context, tenantID, employeeID, id uuid.UUID, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var status entity.JobChangeStatus
+	err = tx.QueryRow(ctx, `SELECT status FROM employee_job_histories WHERE id = $1 AND employee_id = $2 AND tenant_id = $3 FOR UPDATE`,
+		id, employeeID, tenantID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("job history not found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != entity.JobChangeScheduled {
+		return errors.New("hanya perubahan jabatan yang masih terjadwal yang bisa dibatalkan")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE employee_job_histories SET status = $1, updated_at = $2 WHERE id = $3`,
+		entity.JobChangeCancelled, at, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresJobHistoryRepo) FindByID(ctx context.Context, tenantID, employeeID, id uuid.UUID) (*entity.EmployeeJobHistory, error) {
+	sql, args, err := r.sqb.Select(jobHistoryColumns).From("employee_job_histories h").
+		Where(sq.Eq{"h.id": id, "h.employee_id": employeeID, "h.tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	return scanJobHistory(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresJobHistoryRepo) queryJobHistories(ctx context.Context, sb sq.SelectBuilder) ([]*entity.EmployeeJobHistory, error) {
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histories := []*entity.EmployeeJobHistory{}
+	for rows.Next() {
+		h, err := scanJobHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+	return histories, rows.Err()
+}
+
+func (r *postgresJobHistoryRepo) FindByEmployee(ctx context.Context, tenantID, employeeID uuid.UUID) ([]*entity.EmployeeJobHistory, error) {
+	sb := r.sqb.Select(jobHistoryColumns).From("employee_job_histories h").
+		Where(sq.Eq{"h.employee_id": employeeID, "h.tenant_id": tenantID}).
+		OrderBy("h.effective_date DESC", "h.created_at DESC")
+
+	return r.queryJobHistories(ctx, sb)
+}
+
+func (r *postgresJobHistoryRepo) FindAsOf(ctx context.Context, tenantID, employeeID uuid.UUID, date time.Time) (*entity.EmployeeJobHistory, error) {
+	sql, args, err := r.sqb.Select(jobHistoryColumns).From("employee_job_histories h").
+		Where(sq.Eq{
+			"h.employee_id": employeeID,
+			"h.tenant_id":   tenantID,
+			"h.status":      []entity.JobChangeStatus{entity.JobChangeScheduled, entity.JobChangeApplied},
+		}).
+		Where(sq.LtOrEq{"h.effective_date": date}).
+		OrderBy("h.effective_date DESC", "h.created_at DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	return scanJobHistory(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresJobHistoryRepo) FindDue(ctx context.Context, date time.Time, limit int) ([]*entity.EmployeeJobHistory, error) {
+	sb := r.sqb.Select(jobHistoryColumns).From("employee_job_histories h").
+		Where(sq.Eq{"h.status": entity.JobChangeScheduled}).
+		Where(sq.LtOrEq{"h.effective_date": date}).
+		OrderBy("h.effective_date", "h.created_at").
+		Limit(uint64(limit))
+
+	return r.queryJobHistories(ctx, sb)
+}
@@ -0,0 +1,699 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresOffboardingRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresOffboardingRepo(dbPool *TenantDB) repository.OffboardingRepository {
+	return &postgresOffboardingRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// checklistTaskJSON adalah bentuk satu elemen task_statuses.
+type checklistTaskJSON struct {
+	Title  string     `json:"title"`
+	Done   bool       `json:"done"`
+	DoneAt *time.Time `json:"done_at"`
+	DoneBy *uuid.UUID `json:"done_by"`
+}
+
+func encodeChecklistTasks(tasks []entity.ChecklistTask) ([]byte, error) {
+	items := make([]checklistTaskJSON, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, checklistTaskJSON{Title: t.Title, Done: t.Done, DoneAt: t.DoneAt, DoneBy: t.DoneBy})
+	}
+	return json.Marshal(items)
+}
+
+func decodeChecklistTasks(raw []byte) ([]entity.ChecklistTask, error) {
+	var items []checklistTaskJSON
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("gagal membaca tugas checklist: %w", err)
+	}
+	tasks := make([]entity.ChecklistTask, 0, len(items))
+	for _, t := range items {
+		tasks = append(tasks, entity.ChecklistTask{Title: t.Title, Done: t.Done, DoneAt: t.DoneAt, DoneBy: t.DoneBy})
+	}
+	return tasks, nil
+}
+
+// ------------------------------------------------------------------------
+// Template checklist
+
+const checklistTemplateColumns = `t.id, t.tenant_id, t.name, t.type, t.tasks,
+	COALESCE(t.created_at, now()), COALESCE(t.updated_at, now())`
+
+func scanChecklistTemplate(row pgx.Row) (*entity.ChecklistTemplate, error) {
+	var t entity.ChecklistTemplate
+	var tasks []byte
+	err := row.Scan(&t.ID, &t.TenantID, &t.Name, &t.Type, &tasks, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("checklist template not found")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(tasks, &t.Tasks); err != nil {
+		return nil, fmt.Errorf("gagal membaca tugas template: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *postgresOffboardingRepo) CreateTemplate(ctx context.Context, t *entity.ChecklistTemplate) error {
+	tasks, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO onboarding_offboarding_templates (id, tenant_id, name, type, tasks, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = r.db.Exec(ctx, query, t.ID, t.TenantID, t.Name, t.Type, tasks, t.CreatedAt, t.UpdatedAt)
+	return err
+}
+
+func (r *postgresOffboardingRepo) UpdateTemplate(ctx context.Context, t *entity.ChecklistTemplate) error {
+	tasks, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE onboarding_offboarding_templates
+			  SET name = $1, type = $2, tasks = $3, updated_at = $4
+			  WHERE id = $5 AND tenant_id = $6 AND deleted_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, t.Name, t.Type, tasks, t.UpdatedAt, t.ID, t.TenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("checklist template not found")
+	}
+	return nil
+}
+
+func (r *postgresOffboardingRepo) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `UPDATE onboarding_offboarding_templates SET deleted_at = $1
+								WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL`, time.Now(), id, tenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("checklist template not found")
+	}
+	return nil
+}
+
+func (r *postgresOffboardingRepo) FindTemplateByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.ChecklistTemplate, error) {
+	sql, args, err := r.sqb.Select(checklistTemplateColumns).From("onboarding_offboarding_templates t").
+		Where(sq.Eq{"t.id": id, "t.tenant_id": tenantID, "t.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	return scanChecklistTemplate(r.db.QueryRow(ctx, sql, args...))
+}
+
+func (r *postgresOffboardingRepo) FindTemplates(ctx context.Context, tenantID uuid.UUID, checklistType entity.ChecklistType) ([]*entity.ChecklistTemplate, error) {
+	sb := r.sqb.Select(checklistTemplateColumns).From("onboarding_offboarding_templates t").
+		Where(sq.Eq{"t.tenant_id": tenantID, "t.deleted_at": nil}).
+		OrderBy("t.created_at", "t.id")
+	if checklistType != "" {
+		sb = sb.Where(sq.Eq{"t.type": checklistType})
+	}
+
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []*entity.ChecklistTemplate{}
+	for rows.Next() {
+		t, err := scanChecklistTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// ------------------------------------------------------------------------
+// Pengakhiran hubungan kerja
+
+const terminationColumns = `t.id, t.tenant_id, t.employee_id, t.termination_type, t.reason, t.last_working_day,
+	t.effective_date, t.eligible_for_rehire, t.status, t.checklist_id, t.created_by, t.completed_at,
+	COALESCE(t.created_at, now()), COALESCE(t.updated_at, now())`
+
+func scanTermination(row pgx.Row) (*entity.EmployeeTermination, error) {
+	var t entity.EmployeeTermination
+	err := row.Scan(
+		&t.ID,
+		&t.TenantID,
+		&t.EmployeeID,
+		&t.Type,
+		&t.Reason,
+		&t.LastWorkingDay,
+		&t.EffectiveDate,
+		&t.EligibleForRehire,
+		&t.Status,
+		&t.ChecklistID,
+		&t.CreatedBy,
+		&t.CompletedAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("termination not found")
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// finalPayItemSourcesQuery mengumpulkan komponen pembayaran akhir karyawan
+// $1 per hari kerja terakhir $2:
+//   - sisa saldo cuti tahun berjalan dari kebijakan yang tidak unlimited;
+//   - perkiraan sisa pinjaman berjalan, dengan asumsi satu cicilan dipotong
+//     per bulan penuh sejak tanggal mulai;
+//   - klaim biaya yang belum diputuskan.
+const finalPayItemSourcesQuery = `
+SELECT 'leave_payout', b.id, LEFT('Sisa cuti ' || p.name || ' ' || b.period_year, 255), b.balance, NULL::numeric
+FROM employee_time_off_balances b
+JOIN time_off_policies p ON p.id = b.policy_id AND p.deleted_at IS NULL
+WHERE b.employee_id = $1 AND b.period_year = EXTRACT(YEAR FROM $2::date)::int
+  AND NOT p.is_unlimited AND b.balance > 0
+UNION ALL
+SELECT 'loan_deduction', l.id, 'Sisa pinjaman mulai ' || to_char(l.start_date, 'DD-MM-YYYY'), NULL::numeric, o.amount
+FROM loans l
+CROSS JOIN LATERAL (
+  SELECT GREATEST(l.principal_amount - l.monthly_installment * LEAST(l.installments_count,
+    GREATEST(EXTRACT(YEAR FROM age($2::date, l.start_date)) * 12 + EXTRACT(MONTH FROM age($2::date, l.start_date)), 0)), 0) AS amount
+) o
+WHERE l.employee_id = $1 AND l.status = 'ongoing' AND l.deleted_at IS NULL AND o.amount > 0
+UNION ALL
+SELECT 'expense_claim', c.id, c.description, NULL::numeric, c.amount
+FROM expense_claims c
+WHERE c.employee_id = $1 AND c.status = 'pending' AND c.deleted_at IS NULL`
+
+// createFinalPayItems mencatat komponen pembayaran akhir untuk pengakhiran t.
+func createFinalPayItems(ctx context.Context, q dbtx, t *entity.EmployeeTermination) ([]*entity.FinalPayItem, error) {
+	rows, err := q.Query(ctx, finalPayItemSourcesQuery, t.EmployeeID, t.LastWorkingDay)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*entity.FinalPayItem{}
+	for rows.Next() {
+		item := &entity.FinalPayItem{
+			TenantID:      t.TenantID,
+			EmployeeID:    t.EmployeeID,
+			TerminationID: t.ID,
+			Status:        entity.FinalPayPending,
+			CreatedAt:     t.CreatedAt,
+		}
+		if err := rows.Scan(&item.Type, &item.SourceID, &item.Description, &item.Quantity, &item.Amount); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO employee_final_pay_items (id, tenant_id, employee_id, termination_id, item_type, source_id,
+					description, quantity, amount, status, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)`
+
+	for _, item := range items {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+		item.ID = id
+
+		if _, err := q.Exec(ctx, query,
+			item.ID,
+			item.TenantID,
+			item.EmployeeID,
+			item.TerminationID,
+			item.Type,
+			item.SourceID,
+			item.Description,
+			item.Quantity,
+			item.Amount,
+			item.Status,
+			item.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// disableEmployeeLogin menonaktifkan login user milik karyawan.
+func disableEmployeeLogin(ctx context.Context, q dbtx, tenantID, employeeID uuid.UUID, at time.Time) error {
+	_, err := q.Exec(ctx, `UPDATE users
+						   SET login_disabled_at = COALESCE(login_disabled_at, $1), updated_at = $1
+						   WHERE id = (SELECT user_id FROM employees WHERE id = $2 AND tenant_id = $3)
+							 AND deleted_at IS NULL`, at, employeeID, tenantID)
+	return err
+}
+
+func (r *postgresOffboardingRepo) Terminate(ctx context.Context, t *entity.EmployeeTermination, template *entity.ChecklistTemplate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockEmployeeEmployment(ctx, tx, t.TenantID, t.EmployeeID); err != nil {
+		return err
+	}
+
+	if template != nil {
+		checklist, err := createChecklist(ctx, tx, t.EmployeeID, template, t.CreatedAt)
+		if err != nil {
+			return err
+		}
+		t.ChecklistID = &checklist.ID
+		t.Checklist = checklist
+	}
+
+	query := `INSERT INTO employee_terminations (id, tenant_id, employee_id, termination_type, reason, last_working_day,
+					effective_date, eligible_for_rehire, status, checklist_id, created_by, completed_at, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err = tx.Exec(ctx, query,
+		t.ID,
+		t.TenantID,
+		t.EmployeeID,
+		t.Type,
+		t.Reason,
+		t.LastWorkingDay,
+		t.EffectiveDate,
+		t.EligibleForRehire,
+		t.Status,
+		t.ChecklistID,
+		t.CreatedBy,
+		t.CompletedAt,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return repository.ErrTerminationExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE employees SET resign_date = $1, updated_at = $2 WHERE id = $3 AND tenant_id = $4`,
+		t.LastWorkingDay, t.CreatedAt, t.EmployeeID, t.TenantID); err != nil {
+		return err
+	}
+
+	// Perubahan jabatan setelah hari kerja terakhir tidak akan pernah
+	// berlaku.
+	if _, err := tx.Exec(ctx, `UPDATE employee_job_histories SET status = $1, updated_at = $2
+							   WHERE employee_id = $3 AND tenant_id = $4 AND status = $5 AND effective_date > $6`,
+		entity.JobChangeCancelled, t.CreatedAt, t.EmployeeID, t.TenantID, entity.JobChangeScheduled, t.LastWorkingDay); err != nil {
+		return err
+	}
+
+	items, err := createFinalPayItems(ctx, tx, t)
+	if err != nil {
+		return err
+	}
+	t.FinalPayItems = items
+
+	if t.Status == entity.TerminationCompleted {
+		if err := disableEmployeeLogin(ctx, tx, t.TenantID, t.EmployeeID, t.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresOffboardingRepo) Complete(ctx context.Context, t *entity.EmployeeTermination, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var status entity.TerminationStatus
+	err = tx.QueryRow(ctx, `SELECT status FROM employee_terminations WHERE id = $1 AND tenant_id = $2 FOR UPDATE`,
+		t.ID, t.TenantID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("termination not found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != entity.TerminationScheduled {
+		// Sudah dibatalkan atau diselesaikan proses lain.
+		return nil
+	}
+
+	if err := disableEmployeeLogin(ctx, tx, t.TenantID, t.EmployeeID, at); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE employee_terminations SET status = $1, completed_at = $2, updated_at = $2 WHERE id = $3`,
+		entity.TerminationCompleted, at, t.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresOffboardingRepo) Cancel(ctx context.Context, tenantID, employeeID uuid.UUID, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockEmployeeEmployment(ctx, tx, tenantID, employeeID); err != nil {
+		return err
+	}
+
+	sql, args, err := r.sqb.Select(terminationColumns).From("employee_terminations t").
+		Where(sq.Eq{"t.employee_id": employeeID, "t.tenant_id": tenantID}).
+		Where(sq.NotEq{"t.status": entity.TerminationCancelled}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	t, err := scanTermination(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return err
+	}
+	if t.Status != entity.TerminationScheduled {
+		return errors.New("pengakhiran yang sudah berlaku tidak bisa dibatalkan")
+	}
+
+	var settled bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM employee_final_pay_items WHERE termination_id = $1 AND status = $2)`,
+		t.ID, entity.FinalPaySettled).Scan(&settled); err != nil {
+		return err
+	}
+	if settled {
+		return errors.New("pembayaran akhir karyawan sudah diproses payroll, pengakhiran tidak bisa dibatalkan")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE employee_terminations SET status = $1, checklist_id = NULL, updated_at = $2 WHERE id = $3`,
+		entity.TerminationCancelled, at, t.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM employee_final_pay_items WHERE termination_id = $1`, t.ID); err != nil {
+		return err
+	}
+	if t.ChecklistID != nil {
+		if _, err := tx.Exec(ctx, `DELETE FROM employee_onboarding_offboardings WHERE id = $1`, *t.ChecklistID); err != nil {
+			return err
+		}
+	}
+
+	// resign_date hanya dikosongkan jika belum diubah sejak pengakhiran
+	// dicatat. Perubahan jabatan yang ikut dibatalkan tidak dipulihkan.
+	if _, err := tx.Exec(ctx, `UPDATE employees SET resign_date = NULL, updated_at = $1
+							   WHERE id = $2 AND tenant_id = $3 AND resign_date = $4`,
+		at, employeeID, tenantID, t.LastWorkingDay); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresOffboardingRepo) FindByEmployee(ctx context.Context, tenantID, employeeID uuid.UUID) (*entity.EmployeeTermination, error) {
+	sql, args, err := r.sqb.Select(terminationColumns).From("employee_terminations t").
+		Where(sq.Eq{"t.employee_id": employeeID, "t.tenant_id": tenantID}).
+		Where(sq.NotEq{"t.status": entity.TerminationCancelled}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	t, err := scanTermination(r.db.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ChecklistID != nil {
+		checklist, err := findChecklist(ctx, r.db, employeeID, *t.ChecklistID, false)
+		if err != nil {
+			return nil, err
+		}
+		t.Checklist = checklist
+	}
+
+	items, err := r.queryFinalPayItems(ctx, r.sqb.Select(finalPayItemColumns).From("employee_final_pay_items i").
+		Where(sq.Eq{"i.termination_id": t.ID}).
+		OrderBy("i.item_type", "i.created_at"))
+	if err != nil {
+		return nil, err
+	}
+	t.FinalPayItems = items
+
+	return t, nil
+}
+
+func (r *postgresOffboardingRepo) FindDue(ctx context.Context, date time.Time, limit int) ([]*entity.EmployeeTermination, error) {
+	sql, args, err := r.sqb.Select(terminationColumns).From("employee_terminations t").
+		Where(sq.Eq{"t.status": entity.TerminationScheduled}).
+		Where(sq.LtOrEq{"t.effective_date": date}).
+		OrderBy("t.effective_date", "t.created_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terminations := []*entity.EmployeeTermination{}
+	for rows.Next() {
+		t, err := scanTermination(rows)
+		if err != nil {
+			return nil, err
+		}
+		terminations = append(terminations, t)
+	}
+	return terminations, rows.Err()
+}
+
+// ------------------------------------------------------------------------
+// Checklist karyawan
+
+// createChecklist menyalin tugas template menjadi checklist karyawan.
+func createChecklist(ctx context.Context, q dbtx, employeeID uuid.UUID, template *entity.ChecklistTemplate, at time.Time) (*entity.EmployeeChecklist, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	checklist := &entity.EmployeeChecklist{
+		ID:         id,
+		EmployeeID: employeeID,
+		TemplateID: template.ID,
+		Status:     entity.ChecklistPending,
+		Tasks:      make([]entity.ChecklistTask, 0, len(template.Tasks)),
+		CreatedAt:  at,
+		UpdatedAt:  at,
+	}
+	for _, title := range template.Tasks {
+		checklist.Tasks = append(checklist.Tasks, entity.ChecklistTask{Title: title})
+	}
+	checklist.RefreshStatus()
+
+	tasks, err := encodeChecklistTasks(checklist.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO employee_onboarding_offboardings (id, employee_id, template_id, status, task_statuses, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := q.Exec(ctx, query, checklist.ID, checklist.EmployeeID, checklist.TemplateID, checklist.Status, tasks,
+		checklist.CreatedAt, checklist.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+func findChecklist(ctx context.Context, q dbtx, employeeID, id uuid.UUID, forUpdate bool) (*entity.EmployeeChecklist, error) {
+	query := `SELECT id, employee_id, template_id, status, task_statuses,
+					 COALESCE(created_at, now()), COALESCE(updated_at, now())
+			  FROM employee_onboarding_offboardings
+			  WHERE id = $1 AND employee_id = $2`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var c entity.EmployeeChecklist
+	var tasks []byte
+	err := q.QueryRow(ctx, query, id, employeeID).Scan(&c.ID, &c.EmployeeID, &c.TemplateID, &c.Status, &tasks, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("checklist not found")
+		}
+		return nil, err
+	}
+
+	if c.Tasks, err = decodeChecklistTasks(tasks); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *postgresOffboardingRepo) UpdateChecklistTask(ctx context.Context, tenantID, employeeID, checklistID uuid.UUID, index int, done bool, by *uuid.UUID, at time.Time) (*entity.EmployeeChecklist, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM employees WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)`,
+		employeeID, tenantID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("employee not found")
+	}
+
+	checklist, err := findChecklist(ctx, tx, employeeID, checklistID, true)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(checklist.Tasks) {
+		return nil, errors.New("checklist task not found")
+	}
+
+	task := &checklist.Tasks[index]
+	task.Done = done
+	task.DoneAt, task.DoneBy = nil, nil
+	if done {
+		task.DoneAt = &at
+		task.DoneBy = by
+	}
+	checklist.RefreshStatus()
+	checklist.UpdatedAt = at
+
+	tasks, err := encodeChecklistTasks(checklist.Tasks)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE employee_onboarding_offboardings SET status = $1, task_statuses = $2, updated_at = $3 WHERE id = $4`,
+		checklist.Status, tasks, at, checklist.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// ------------------------------------------------------------------------
+// Komponen pembayaran akhir
+
+const finalPayItemColumns = `i.id, i.tenant_id, i.employee_id, i.termination_id, i.item_type, i.source_id, i.description,
+	i.quantity, i.amount, i.status, i.settled_at, COALESCE(i.created_at, now())`
+
+func (r *postgresOffboardingRepo) queryFinalPayItems(ctx context.Context, sb sq.SelectBuilder) ([]*entity.FinalPayItem, error) {
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*entity.FinalPayItem{}
+	for rows.Next() {
+		var i entity.FinalPayItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.EmployeeID,
+			&i.TerminationID,
+			&i.Type,
+			&i.SourceID,
+			&i.Description,
+			&i.Quantity,
+			&i.Amount,
+			&i.Status,
+			&i.SettledAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	return items, rows.Err()
+}
+
+func (r *postgresOffboardingRepo) FindFinalPayItems(ctx context.Context, tenantID uuid.UUID, status entity.FinalPayItemStatus) ([]*entity.FinalPayItem, error) {
+	sb := r.sqb.Select(finalPayItemColumns).From("employee_final_pay_items i").
+		Join("employee_terminations t ON t.id = i.termination_id").
+		Where(sq.Eq{"i.tenant_id": tenantID}).
+		Where(sq.NotEq{"t.status": entity.TerminationCancelled}).
+		OrderBy("t.last_working_day", "i.employee_id", "i.item_type", "i.created_at")
+	if status != "" {
+		sb = sb.Where(sq.Eq{"i.status": status})
+	}
+
+	return r.queryFinalPayItems(ctx, sb)
+}
+
+func (r *postgresOffboardingRepo) SettleFinalPayItem(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE employee_final_pay_items SET status = $1, settled_at = $2, updated_at = $2
+								WHERE id = $3 AND tenant_id = $4 AND status = $5`,
+		entity.FinalPaySettled, at, id, tenantID, entity.FinalPayPending)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("final pay item not found or already settled")
+	}
+	return nil
+}
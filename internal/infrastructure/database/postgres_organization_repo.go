@@ -0,0 +1,403 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresOrganizationRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresOrganizationRepo(dbPool *TenantDB) repository.OrganizationRepository {
+	return &postgresOrganizationRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// orgSortColumns adalah kolom yang boleh dipakai untuk sorting list data master.
+var orgSortColumns = map[string]string{
+	"name":       "t.name",
+	"created_at": "t.created_at",
+}
+
+// activeEmployeeCondition sama dengan aturan hitung kuota karyawan.
+const activeEmployeeCondition = `e.deleted_at IS NULL AND (e.resign_date IS NULL OR e.resign_date >= CURRENT_DATE)`
+
+func activeEmployeeCount(column string) string {
+	return `(SELECT COUNT(*) FROM employees e WHERE e.` + column + ` = t.id AND ` + activeEmployeeCondition + `)`
+}
+
+// ------------------------------------------------------------------------
+// Branches
+// ------------------------------------------------------------------------
+
+var branchColumns = `t.id, t.tenant_id, t.name, t.code, t.address, t.latitude, t.longitude, t.attendance_radius_meters, ` +
+	activeEmployeeCount("branch_id") + `, COALESCE(t.created_at, now()), COALESCE(t.updated_at, now())`
+
+func scanBranch(row pgx.Row) (*entity.Branch, error) {
+	var b entity.Branch
+	err := row.Scan(
+		&b.ID,
+		&b.TenantID,
+		&b.Name,
+		&b.Code,
+		&b.Address,
+		&b.Latitude,
+		&b.Longitude,
+		&b.AttendanceRadiusMeters,
+		&b.ActiveEmployees,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("branch not found")
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *postgresOrganizationRepo) CreateBranch(ctx context.Context, b *entity.Branch) error {
+	query := `INSERT INTO branches (id, tenant_id, name, code, address, latitude, longitude, attendance_radius_meters, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.Exec(ctx, query,
+		b.ID,
+		b.TenantID,
+		b.Name,
+		b.Code,
+		b.Address,
+		b.Latitude,
+		b.Longitude,
+		b.AttendanceRadiusMeters,
+		b.CreatedAt,
+		b.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return branchUniqueError(err)
+	}
+	return err
+}
+
+// branchUniqueError membedakan bentrok nama dan kode cabang.
+func branchUniqueError(err error) error {
+	if isUniqueViolationOn(err, "branches_tenant_code_key") {
+		return errors.New("kode cabang sudah dipakai")
+	}
+	return errors.New("nama cabang sudah dipakai")
+}
+
+func (r *postgresOrganizationRepo) UpdateBranch(ctx context.Context, b *entity.Branch) error {
+	b.UpdatedAt = time.Now()
+
+	query := `UPDATE branches
+			  SET name = $1, code = $2, address = $3, latitude = $4, longitude = $5, attendance_radius_meters = $6, updated_at = $7
+			  WHERE id = $8 AND tenant_id = $9 AND deleted_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query,
+		b.Name,
+		b.Code,
+		b.Address,
+		b.Latitude,
+		b.Longitude,
+		b.AttendanceRadiusMeters,
+		b.UpdatedAt,
+		b.ID,
+		b.TenantID,
+	)
+	if isUniqueViolation(err) {
+		return branchUniqueError(err)
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("branch not found")
+	}
+	return nil
+}
+
+func (r *postgresOrganizationRepo) FindBranchByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Branch, error) {
+	query := `SELECT ` + branchColumns + ` FROM branches t WHERE t.id = $1 AND t.tenant_id = $2 AND t.deleted_at IS NULL`
+
+	return scanBranch(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+func (r *postgresOrganizationRepo) buildFindQuery(table, columns string, tenantID uuid.UUID, query util.PaginationQuery, isCount bool) (string, []interface{}, error) {
+	var sb sq.SelectBuilder
+	if isCount {
+		sb = r.sqb.Select("COUNT(*)").From(table + " t")
+	} else {
+		sb = r.sqb.Select(columns).From(table + " t")
+	}
+
+	sb = sb.Where(sq.Eq{"t.tenant_id": tenantID}).Where("t.deleted_at IS NULL")
+
+	if query.Search != "" {
+		sb = sb.Where(sq.ILike{"t.name": "%" + query.Search + "%"})
+	}
+
+	if !isCount {
+		sortBy, ok := orgSortColumns[query.SortBy]
+		if !ok {
+			sortBy = "t.name"
+		}
+		sb = sb.OrderBy(sortBy + " " + query.SortDir).
+			Limit(uint64(query.Limit)).
+			Offset(uint64(query.GetOffset()))
+	}
+
+	return sb.ToSql()
+}
+
+func (r *postgresOrganizationRepo) FindBranches(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Branch, error) {
+	sql, args, err := r.buildFindQuery("branches", branchColumns, tenantID, query, false)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	branches := []*entity.Branch{}
+	for rows.Next() {
+		b, err := scanBranch(rows)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, b)
+	}
+	return branches, rows.Err()
+}
+
+func (r *postgresOrganizationRepo) CountBranches(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.buildFindQuery("branches", "", tenantID, query, true)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL count: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
+
+func (r *postgresOrganizationRepo) DeleteBranch(ctx context.Context, tenantID, id uuid.UUID, reassignTo *uuid.UUID, at time.Time) (int64, error) {
+	return r.deleteMaster(ctx, "branches", "branch_id", "cabang", tenantID, id, reassignTo, at)
+}
+
+// ------------------------------------------------------------------------
+// Org units
+// ------------------------------------------------------------------------
+
+func unitColumns(kind entity.OrgUnitKind) string {
+	description := "NULL::text"
+	if kind.HasDescription() {
+		description = "t.description"
+	}
+	return `t.id, t.tenant_id, t.name, ` + description + `, ` + activeEmployeeCount(kind.EmployeeColumn()) +
+		`, COALESCE(t.created_at, now()), COALESCE(t.updated_at, now())`
+}
+
+func scanOrgUnit(row pgx.Row, kind entity.OrgUnitKind) (*entity.OrgUnit, error) {
+	u := entity.OrgUnit{Kind: kind}
+	err := row.Scan(
+		&u.ID,
+		&u.TenantID,
+		&u.Name,
+		&u.Description,
+		&u.ActiveEmployees,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s not found", kind)
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *postgresOrganizationRepo) CreateUnit(ctx context.Context, u *entity.OrgUnit) error {
+	sb := r.sqb.Insert(u.Kind.Table()).
+		Columns("id", "tenant_id", "name", "created_at", "updated_at").
+		Values(u.ID, u.TenantID, u.Name, u.CreatedAt, u.UpdatedAt)
+	if u.Kind.HasDescription() {
+		sb = r.sqb.Insert(u.Kind.Table()).
+			Columns("id", "tenant_id", "name", "description", "created_at", "updated_at").
+			Values(u.ID, u.TenantID, u.Name, u.Description, u.CreatedAt, u.UpdatedAt)
+	}
+
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, sql, args...)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("nama %s sudah dipakai", u.Kind.Label())
+	}
+	return err
+}
+
+func (r *postgresOrganizationRepo) UpdateUnit(ctx context.Context, u *entity.OrgUnit) error {
+	u.UpdatedAt = time.Now()
+
+	sb := r.sqb.Update(u.Kind.Table()).
+		Set("name", u.Name).
+		Set("updated_at", u.UpdatedAt).
+		Where(sq.Eq{"id": u.ID, "tenant_id": u.TenantID}).
+		Where("deleted_at IS NULL")
+	if u.Kind.HasDescription() {
+		sb = sb.Set("description", u.Description)
+	}
+
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("nama %s sudah dipakai", u.Kind.Label())
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s not found", u.Kind)
+	}
+	return nil
+}
+
+func (r *postgresOrganizationRepo) FindUnitByID(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID) (*entity.OrgUnit, error) {
+	query := `SELECT ` + unitColumns(kind) + ` FROM ` + kind.Table() + ` t
+			  WHERE t.id = $1 AND t.tenant_id = $2 AND t.deleted_at IS NULL`
+
+	return scanOrgUnit(r.db.QueryRow(ctx, query, id, tenantID), kind)
+}
+
+func (r *postgresOrganizationRepo) FindUnits(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.OrgUnit, error) {
+	sql, args, err := r.buildFindQuery(kind.Table(), unitColumns(kind), tenantID, query, false)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	units := []*entity.OrgUnit{}
+	for rows.Next() {
+		u, err := scanOrgUnit(rows, kind)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+func (r *postgresOrganizationRepo) CountUnits(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.buildFindQuery(kind.Table(), "", tenantID, query, true)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL count: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
+
+func (r *postgresOrganizationRepo) DeleteUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID, reassignTo *uuid.UUID, at time.Time) (int64, error) {
+	return r.deleteMaster(ctx, kind.Table(), kind.EmployeeColumn(), kind.Label(), tenantID, id, reassignTo, at)
+}
+
+// deleteMaster mengunci baris data master, memindahkan karyawan aktif ke
+// reassignTo (jika ada), lalu melakukan soft delete. Karyawan yang sudah
+// resign tetap merujuk ke baris lama sebagai riwayat.
+func (r *postgresOrganizationRepo) deleteMaster(ctx context.Context, table, column, label string, tenantID, id uuid.UUID, reassignTo *uuid.UUID, at time.Time) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT id FROM `+table+` WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL FOR UPDATE`,
+		id, tenantID).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%s tidak ditemukan", label)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var moved int64
+	if reassignTo != nil {
+		if *reassignTo == id {
+			return 0, fmt.Errorf("%s tujuan tidak boleh sama dengan %s yang dihapus", label, label)
+		}
+
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)`,
+			*reassignTo, tenantID).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, fmt.Errorf("%s tujuan tidak ditemukan", label)
+		}
+
+		rows, err := tx.Query(ctx, `UPDATE employees e SET `+column+` = $1, updated_at = $2
+									WHERE e.`+column+` = $3 AND e.tenant_id = $4 AND `+activeEmployeeCondition+`
+									RETURNING e.id`,
+			*reassignTo, at, id, tenantID)
+		if err != nil {
+			return 0, err
+		}
+		movedIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			return 0, err
+		}
+		if err := recordEmploymentChanges(ctx, tx, tenantID, movedIDs, entity.JobChangeMutation,
+			fmt.Sprintf("Dipindahkan karena %s lama dihapus", label), at); err != nil {
+			return 0, err
+		}
+		moved = int64(len(movedIDs))
+	}
+
+	var active int64
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM employees e WHERE e.`+column+` = $1 AND e.tenant_id = $2 AND `+activeEmployeeCondition,
+		id, tenantID).Scan(&active); err != nil {
+		return 0, err
+	}
+	if active > 0 {
+		return 0, &repository.OrgUnitInUseError{Label: label, ActiveEmployees: active}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE `+table+` SET deleted_at = $1, updated_at = $1 WHERE id = $2 AND tenant_id = $3`,
+		at, id, tenantID); err != nil {
+		return 0, err
+	}
+
+	return moved, tx.Commit(ctx)
+}
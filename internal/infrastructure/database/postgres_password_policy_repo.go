@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresPasswordPolicyRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresPasswordPolicyRepo(dbPool *TenantDB) repository.PasswordPolicyRepository {
+	return &postgresPasswordPolicyRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresPasswordPolicyRepo) FindByTenant(ctx context.Context, tenantID uuid.UUID) (*entity.PasswordPolicy, error) {
+	query := `SELECT tenant_id, min_length, require_uppercase, require_lowercase, require_digit, require_symbol,
+					 expiry_days, history_count, block_common, COALESCE(created_at, now()), COALESCE(updated_at, now())
+			  FROM tenant_password_policies
+			  WHERE tenant_id = $1`
+
+	var p entity.PasswordPolicy
+	err := r.db.QueryRow(ctx, query, tenantID).Scan(
+		&p.TenantID,
+		&p.MinLength,
+		&p.RequireUppercase,
+		&p.RequireLowercase,
+		&p.RequireDigit,
+		&p.RequireSymbol,
+		&p.ExpiryDays,
+		&p.HistoryCount,
+		&p.BlockCommon,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrPasswordPolicyNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *postgresPasswordPolicyRepo) Save(ctx context.Context, p *entity.PasswordPolicy) error {
+	query := `INSERT INTO tenant_password_policies (tenant_id, min_length, require_uppercase, require_lowercase,
+					require_digit, require_symbol, expiry_days, history_count, block_common, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			  ON CONFLICT (tenant_id) DO UPDATE SET
+				min_length = EXCLUDED.min_length,
+				require_uppercase = EXCLUDED.require_uppercase,
+				require_lowercase = EXCLUDED.require_lowercase,
+				require_digit = EXCLUDED.require_digit,
+				require_symbol = EXCLUDED.require_symbol,
+				expiry_days = EXCLUDED.expiry_days,
+				history_count = EXCLUDED.history_count,
+				block_common = EXCLUDED.block_common,
+				updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(ctx, query,
+		p.TenantID,
+		p.MinLength,
+		p.RequireUppercase,
+		p.RequireLowercase,
+		p.RequireDigit,
+		p.RequireSymbol,
+		p.ExpiryDays,
+		p.HistoryCount,
+		p.BlockCommon,
+		p.CreatedAt,
+		p.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresPermissionRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresPermissionRepo(dbPool *TenantDB) repository.PermissionRepository {
+	return &postgresPermissionRepo{
+		db: dbPool,
+	}
+}
+
+const permissionColumns = `p.id, p.name, p.group_name, COALESCE(p.created_at, now()), COALESCE(p.updated_at, now())`
+
+func collectPermissions(rows pgx.Rows) ([]*entity.Permission, error) {
+	defer rows.Close()
+
+	permissions := []*entity.Permission{}
+	for rows.Next() {
+		var p entity.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.GroupName, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &p)
+	}
+	return permissions, rows.Err()
+}
+
+// collectScopedPermissions sama seperti collectPermissions, dengan kolom
+// data_scope tambahan dari tabel pivot.
+func collectScopedPermissions(rows pgx.Rows) ([]*entity.Permission, error) {
+	defer rows.Close()
+
+	permissions := []*entity.Permission{}
+	for rows.Next() {
+		var p entity.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.GroupName, &p.CreatedAt, &p.UpdatedAt, &p.DataScope); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *postgresPermissionRepo) FindAll(ctx context.Context) ([]*entity.Permission, error) {
+	query := `SELECT ` + permissionColumns + `
+			  FROM tenant_permissions p
+			  WHERE p.deleted_at IS NULL
+			  ORDER BY p.group_name, p.name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return collectPermissions(rows)
+}
+
+func (r *postgresPermissionRepo) FindByNames(ctx context.Context, names []string) ([]*entity.Permission, error) {
+	query := `SELECT ` + permissionColumns + `
+			  FROM tenant_permissions p
+			  WHERE p.name = ANY($1) AND p.deleted_at IS NULL
+			  ORDER BY p.group_name, p.name`
+
+	rows, err := r.db.Query(ctx, query, names)
+	if err != nil {
+		return nil, err
+	}
+	return collectPermissions(rows)
+}
+
+func (r *postgresPermissionRepo) FindDirectByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Permission, error) {
+	query := `SELECT ` + permissionColumns + `, up.data_scope
+			  FROM tenant_permissions p
+			  JOIN user_has_permissions up ON up.permission_id = p.id
+			  WHERE up.user_id = $1 AND p.deleted_at IS NULL
+			  ORDER BY p.group_name, p.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return collectScopedPermissions(rows)
+}
+
+func (r *postgresPermissionRepo) FindEffectiveGrants(ctx context.Context, tenantID, userID uuid.UUID) ([]entity.PermissionGrant, error) {
+	query := `SELECT p.id, p.name, pr.data_scope
+			  FROM tenant_permissions p
+			  JOIN permission_role pr ON pr.permission_id = p.id
+			  JOIN roles ro ON ro.id = pr.role_id AND ro.tenant_id = $1 AND ro.deleted_at IS NULL
+			  JOIN role_user ru ON ru.role_id = ro.id AND ru.user_id = $2
+			  JOIN users u ON u.id = ru.user_id AND u.login_disabled_at IS NULL
+			  WHERE p.deleted_at IS NULL
+			  UNION
+			  SELECT p.id, p.name, up.data_scope
+			  FROM tenant_permissions p
+			  JOIN user_has_permissions up ON up.permission_id = p.id AND up.user_id = $2
+			  JOIN users u ON u.id = up.user_id AND u.tenant_id = $1 AND u.login_disabled_at IS NULL
+			  WHERE p.deleted_at IS NULL
+			  ORDER BY 2, 3`
+
+	rows, err := r.db.Query(ctx, query, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []entity.PermissionGrant{}
+	for rows.Next() {
+		var g entity.PermissionGrant
+		if err := rows.Scan(&g.PermissionID, &g.Name, &g.DataScope); err != nil {
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+	return grants, rows.Err()
+}
+
+func (r *postgresPermissionRepo) FindUsersWithPermission(ctx context.Context, tenantID uuid.UUID, permission string) ([]*entity.User, error) {
+	query := `SELECT u.id, u.tenant_id, u.name, u.email
+			  FROM users u
+			  WHERE u.tenant_id = $1 AND u.login_disabled_at IS NULL AND u.deleted_at IS NULL
+				AND (
+				  EXISTS (SELECT 1 FROM role_user ru
+						  JOIN roles ro ON ro.id = ru.role_id AND ro.tenant_id = $1 AND ro.deleted_at IS NULL
+						  JOIN permission_role pr ON pr.role_id = ro.id
+						  JOIN tenant_permissions p ON p.id = pr.permission_id AND p.deleted_at IS NULL
+						  WHERE ru.user_id = u.id AND p.name = $2)
+				  OR EXISTS (SELECT 1 FROM user_has_permissions up
+							 JOIN tenant_permissions p ON p.id = up.permission_id AND p.deleted_at IS NULL
+							 WHERE up.user_id = u.id AND p.name = $2)
+				)
+			  ORDER BY u.name`
+
+	rows, err := r.db.Query(ctx, query, tenantID, permission)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(&u.ID, &u.TenantID, &u.Name, &u.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, &u)
+	}
+	return users, rows.Err()
+}
+
+func (r *postgresPermissionRepo) SyncUserPermissions(ctx context.Context, userID uuid.UUID, grants []entity.PermissionGrant) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_has_permissions WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, g := range grants {
+		if _, err := tx.Exec(ctx, `INSERT INTO user_has_permissions (user_id, permission_id, data_scope)
+								   VALUES ($1, $2, $3)
+								   ON CONFLICT (user_id, permission_id) DO UPDATE SET data_scope = EXCLUDED.data_scope`,
+			userID, g.PermissionID, g.DataScope); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresPlanRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresPlanRepo(dbPool *TenantDB) repository.PlanRepository {
+	return &postgresPlanRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresPlanRepo) scanPlan(row pgx.Row) (*entity.Plan, error) {
+	var plan entity.Plan
+	err := row.Scan(
+		&plan.ID,
+		&plan.Name,
+		&plan.Slug,
+		&plan.Description,
+		&plan.Price,
+		&plan.BillingCycle,
+		&plan.EmployeeLimit,
+		&plan.IsActive,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("plan not found")
+		}
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *postgresPlanRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Plan, error) {
+	query := `SELECT id, name, slug, description, price, billing_cycle, employee_limit, is_active, created_at, updated_at
+			  FROM plans
+			  WHERE id = $1 AND deleted_at IS NULL`
+
+	row := r.db.QueryRow(ctx, query, id)
+	return r.scanPlan(row)
+}
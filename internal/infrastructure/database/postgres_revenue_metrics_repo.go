@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresRevenueMetricsRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresRevenueMetricsRepo(dbPool *TenantDB) repository.RevenueMetricsRepository {
+	return &postgresRevenueMetricsRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresRevenueMetricsRepo) FindMRRByTenant(ctx context.Context, at time.Time) ([]repository.TenantMRR, error) {
+	// Langganan yang sudah dibatalkan selalu punya ends_at, jadi filter rentang
+	// waktu di bawah sudah cukup untuk melihat kondisi historis pada 'at'.
+	// Langganan trial (trial_ends_at terisi) tidak dihitung sebagai MRR karena
+	// belum menghasilkan tagihan.
+	query := `SELECT s.tenant_id, p.id, p.name,
+					 CASE WHEN p.billing_cycle = 'yearly' THEN p.price / 12 ELSE p.price END
+			  FROM subscriptions s
+			  JOIN plans p ON p.id = s.plan_id
+			  JOIN tenants t ON t.id = s.tenant_id AND t.deleted_at IS NULL
+			  WHERE s.started_at <= $1
+				AND (s.ends_at IS NULL OR s.ends_at > $1)
+				AND s.status <> 'trialing'
+				AND s.trial_ends_at IS NULL`
+
+	rows, err := r.db.Query(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []repository.TenantMRR{}
+	for rows.Next() {
+		var m repository.TenantMRR
+		if err := rows.Scan(&m.TenantID, &m.PlanID, &m.PlanName, &m.MRR); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+func (r *postgresRevenueMetricsRepo) CountTenantsByPlan(ctx context.Context, at time.Time) ([]entity.PlanTenantCount, error) {
+	query := `SELECT p.id, p.name, COUNT(DISTINCT s.tenant_id),
+					 COUNT(DISTINCT s.tenant_id) FILTER (WHERE s.trial_ends_at IS NOT NULL)
+			  FROM subscriptions s
+			  JOIN plans p ON p.id = s.plan_id
+			  JOIN tenants t ON t.id = s.tenant_id AND t.deleted_at IS NULL
+			  WHERE s.started_at <= $1
+				AND (s.ends_at IS NULL OR s.ends_at > $1)
+			  GROUP BY p.id, p.name
+			  ORDER BY p.name`
+
+	rows, err := r.db.Query(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []entity.PlanTenantCount{}
+	for rows.Next() {
+		var c entity.PlanTenantCount
+		if err := rows.Scan(&c.PlanID, &c.PlanName, &c.Tenants, &c.TrialTenants); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+func (r *postgresRevenueMetricsRepo) SumCollectedRevenue(ctx context.Context, from, to time.Time) (float64, error) {
+	query := `SELECT COALESCE(SUM(ip.amount_paid), 0)
+			  FROM invoice_payments ip
+			  JOIN invoices i ON i.id = ip.invoice_id AND i.deleted_at IS NULL
+			  WHERE ip.payment_date >= $1 AND ip.payment_date < $2
+				AND ip.payment_method IS DISTINCT FROM 'credit'`
+
+	var total float64
+	err := r.db.QueryRow(ctx, query, from, to).Scan(&total)
+	return total, err
+}
+
+func (r *postgresRevenueMetricsRepo) SumCreditNotes(ctx context.Context, from, to time.Time) (float64, float64, error) {
+	query := `SELECT COALESCE(SUM(total_amount), 0),
+					 COALESCE(SUM(total_amount) FILTER (WHERE resolution = 'refund'), 0)
+			  FROM credit_notes
+			  WHERE status = 'issued' AND issue_date >= $1 AND issue_date < $2`
+
+	var issued, refunded float64
+	err := r.db.QueryRow(ctx, query, from, to).Scan(&issued, &refunded)
+	return issued, refunded, err
+}
+
+// CountTrialConversions menghitung tenant yang memulai trial di [from, to) dan
+// berapa di antaranya yang kemudian punya langganan berbayar.
+// Langganan trial ditandai dengan trial_ends_at yang terisi.
+func (r *postgresRevenueMetricsRepo) CountTrialConversions(ctx context.Context, from, to time.Time) (int, int, error) {
+	query := `WITH trials AS (
+				SELECT tenant_id, MIN(started_at) AS trial_started
+				FROM subscriptions
+				WHERE trial_ends_at IS NOT NULL
+				GROUP BY tenant_id
+				HAVING MIN(started_at) >= $1 AND MIN(started_at) < $2
+			  )
+			  SELECT COUNT(*),
+					 COUNT(*) FILTER (WHERE EXISTS (
+						SELECT 1 FROM subscriptions s
+						WHERE s.tenant_id = trials.tenant_id
+						  AND s.trial_ends_at IS NULL
+						  AND s.started_at >= trials.trial_started
+					 ))
+			  FROM trials`
+
+	var started, converted int
+	err := r.db.QueryRow(ctx, query, from, to).Scan(&started, &converted)
+	return started, converted, err
+}
+
+func (r *postgresRevenueMetricsRepo) CountTenantsByStatus(ctx context.Context) (map[entity.StatusTenant]int, error) {
+	query := `SELECT status, COUNT(*) FROM tenants WHERE deleted_at IS NULL GROUP BY status`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[entity.StatusTenant]int{}
+	for rows.Next() {
+		var status entity.StatusTenant
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		result[status] = count
+	}
+	return result, rows.Err()
+}
+
+func (r *postgresRevenueMetricsRepo) FindOutstandingInvoices(ctx context.Context, asOf time.Time) ([]entity.OutstandingInvoice, error) {
+	query := `SELECT i.id, i.tenant_id, t.name, i.issue_date, i.due_date, i.total_amount,
+					 i.total_amount - COALESCE(paid.amount, 0) AS outstanding,
+					 ($1::date - i.due_date) AS days_past_due
+			  FROM invoices i
+			  JOIN tenants t ON t.id = i.tenant_id
+			  LEFT JOIN LATERAL (
+				SELECT SUM(ip.amount_paid) AS amount
+				FROM invoice_payments ip
+				WHERE ip.invoice_id = i.id AND ip.payment_date::date <= $1::date
+			  ) paid ON TRUE
+			  WHERE i.deleted_at IS NULL
+				AND i.status IN ('unpaid', 'overdue')
+				AND i.issue_date <= $1::date
+				AND i.total_amount - COALESCE(paid.amount, 0) > 0
+			  ORDER BY i.due_date`
+
+	rows, err := r.db.Query(ctx, query, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []entity.OutstandingInvoice{}
+	for rows.Next() {
+		var o entity.OutstandingInvoice
+		if err := rows.Scan(
+			&o.InvoiceID,
+			&o.TenantID,
+			&o.TenantName,
+			&o.IssueDate,
+			&o.DueDate,
+			&o.TotalAmount,
+			&o.Outstanding,
+			&o.DaysPastDue,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, rows.Err()
+}
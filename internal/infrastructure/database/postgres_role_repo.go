@@ -0,0 +1,320 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type postgresRoleRepo struct {
+	db  *TenantDB
+	sqb sq.StatementBuilderType
+}
+
+func NewPostgresRoleRepo(dbPool *TenantDB) repository.RoleRepository {
+	return &postgresRoleRepo{
+		db:  dbPool,
+		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// roleSortColumns adalah kolom yang boleh dipakai untuk sorting list role.
+var roleSortColumns = map[string]string{
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+const roleColumns = `id, tenant_id, name, description, COALESCE(created_at, now()), COALESCE(updated_at, now())`
+
+func scanRole(row pgx.Row) (*entity.Role, error) {
+	var role entity.Role
+	err := row.Scan(
+		&role.ID,
+		&role.TenantID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("role not found")
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func syncRolePermissions(ctx context.Context, q dbtx, roleID uuid.UUID, grants []entity.PermissionGrant) error {
+	if _, err := q.Exec(ctx, `DELETE FROM permission_role WHERE role_id = $1`, roleID); err != nil {
+		return err
+	}
+
+	for _, g := range grants {
+		if _, err := q.Exec(ctx, `INSERT INTO permission_role (permission_id, role_id, data_scope)
+								  VALUES ($1, $2, $3)
+								  ON CONFLICT (permission_id, role_id) DO UPDATE SET data_scope = EXCLUDED.data_scope`,
+			g.PermissionID, roleID, g.DataScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRoleRepo) Create(ctx context.Context, role *entity.Role, grants []entity.PermissionGrant) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO roles (id, tenant_id, name, description, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err = tx.Exec(ctx, query,
+		role.ID,
+		role.TenantID,
+		role.Name,
+		role.Description,
+		role.CreatedAt,
+		role.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("nama role sudah dipakai")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := syncRolePermissions(ctx, tx, role.ID, grants); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresRoleRepo) Update(ctx context.Context, role *entity.Role, grants []entity.PermissionGrant) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE roles
+			  SET name = $1, description = $2, updated_at = $3
+			  WHERE id = $4 AND tenant_id = $5 AND deleted_at IS NULL`
+
+	role.UpdatedAt = time.Now()
+
+	_, err = tx.Exec(ctx, query,
+		role.Name,
+		role.Description,
+		role.UpdatedAt,
+		role.ID,
+		role.TenantID,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("nama role sudah dipakai")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := syncRolePermissions(ctx, tx, role.ID, grants); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete melakukan soft delete dan melepas role dari semua user.
+func (r *postgresRoleRepo) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `UPDATE roles SET deleted_at = $1, updated_at = $1 WHERE id = $2 AND tenant_id = $3`, now, id, tenantID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM role_user WHERE role_id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresRoleRepo) findPermissions(ctx context.Context, role *entity.Role) error {
+	query := `SELECT ` + permissionColumns + `, pr.data_scope
+			  FROM tenant_permissions p
+			  JOIN permission_role pr ON pr.permission_id = p.id
+			  WHERE pr.role_id = $1 AND p.deleted_at IS NULL
+			  ORDER BY p.group_name, p.name`
+
+	rows, err := r.db.Query(ctx, query, role.ID)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := collectScopedPermissions(rows)
+	if err != nil {
+		return err
+	}
+
+	role.Permissions = make([]entity.Permission, len(permissions))
+	for i, p := range permissions {
+		role.Permissions[i] = *p
+	}
+	return nil
+}
+
+func (r *postgresRoleRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+
+	role, err := scanRole(r.db.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.findPermissions(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *postgresRoleRepo) FindByName(ctx context.Context, tenantID uuid.UUID, name string) (*entity.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE tenant_id = $1 AND name = $2 AND deleted_at IS NULL`
+
+	return scanRole(r.db.QueryRow(ctx, query, tenantID, name))
+}
+
+func (r *postgresRoleRepo) buildFindQuery(tenantID uuid.UUID, query util.PaginationQuery, isCount bool) (string, []interface{}, error) {
+	var sb sq.SelectBuilder
+	if isCount {
+		sb = r.sqb.Select("COUNT(*)").From("roles")
+	} else {
+		sb = r.sqb.Select(roleColumns).From("roles")
+	}
+
+	sb = sb.Where(sq.Eq{"tenant_id": tenantID}).Where("deleted_at IS NULL")
+
+	if query.Search != "" {
+		sb = sb.Where(sq.ILike{"name": "%" + query.Search + "%"})
+	}
+
+	if !isCount {
+		sortBy, ok := roleSortColumns[query.SortBy]
+		if !ok {
+			sortBy = "name"
+		}
+		sb = sb.OrderBy(sortBy + " " + query.SortDir).
+			Limit(uint64(query.Limit)).
+			Offset(uint64(query.GetOffset()))
+	}
+
+	return sb.ToSql()
+}
+
+func (r *postgresRoleRepo) Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Role, error) {
+	sql, args, err := r.buildFindQuery(tenantID, query, false)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membangun SQL query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []*entity.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if err := r.findPermissions(ctx, role); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func (r *postgresRoleRepo) Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error) {
+	sql, args, err := r.buildFindQuery(tenantID, query, true)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membangun SQL count: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
+
+func (r *postgresRoleRepo) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*entity.Role, error) {
+	query := `SELECT ro.id, ro.tenant_id, ro.name, ro.description,
+					 COALESCE(ro.created_at, now()), COALESCE(ro.updated_at, now())
+			  FROM roles ro
+			  JOIN role_user ru ON ru.role_id = ro.id
+			  WHERE ru.user_id = $1 AND ro.tenant_id = $2 AND ro.deleted_at IS NULL
+			  ORDER BY ro.name`
+
+	rows, err := r.db.Query(ctx, query, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*entity.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *postgresRoleRepo) SyncUserRoles(ctx context.Context, tenantID, userID uuid.UUID, roleIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM role_user WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if len(roleIDs) > 0 {
+		if _, err := tx.Exec(ctx, `INSERT INTO role_user (user_id, role_id)
+								   SELECT $1, ro.id
+								   FROM roles ro
+								   WHERE ro.id = ANY($2) AND ro.tenant_id = $3 AND ro.deleted_at IS NULL
+								   ON CONFLICT DO NOTHING`, userID, roleIDs, tenantID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresSSORepo struct {
+	db *TenantDB
+}
+
+func NewPostgresSSORepo(dbPool *TenantDB) repository.SSORepository {
+	return &postgresSSORepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresSSORepo) FindConfig(ctx context.Context, tenantID uuid.UUID) (*entity.SSOConfig, error) {
+	query := `SELECT id, tenant_id, protocol, enabled, jit_provisioning, password_login_disabled,
+					 oidc_issuer, oidc_client_id, oidc_client_secret, saml_idp_metadata_url, saml_idp_metadata_xml,
+					 created_at, updated_at
+			  FROM tenant_sso_configs
+			  WHERE tenant_id = $1`
+
+	var c entity.SSOConfig
+	err := r.db.QueryRow(ctx, query, tenantID).Scan(
+		&c.ID,
+		&c.TenantID,
+		&c.Protocol,
+		&c.Enabled,
+		&c.JITProvisioning,
+		&c.PasswordLoginDisabled,
+		&c.OIDCIssuer,
+		&c.OIDCClientID,
+		&c.OIDCClientSecret,
+		&c.SAMLIDPMetadataURL,
+		&c.SAMLIDPMetadataXML,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("sso config not found")
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *postgresSSORepo) SaveConfig(ctx context.Context, c *entity.SSOConfig) error {
+	query := `INSERT INTO tenant_sso_configs (id, tenant_id, protocol, enabled, jit_provisioning, password_login_disabled,
+					oidc_issuer, oidc_client_id, oidc_client_secret, saml_idp_metadata_url, saml_idp_metadata_xml,
+					created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			  ON CONFLICT (tenant_id) DO UPDATE SET
+					protocol = EXCLUDED.protocol,
+					enabled = EXCLUDED.enabled,
+					jit_provisioning = EXCLUDED.jit_provisioning,
+					password_login_disabled = EXCLUDED.password_login_disabled,
+					oidc_issuer = EXCLUDED.oidc_issuer,
+					oidc_client_id = EXCLUDED.oidc_client_id,
+					oidc_client_secret = EXCLUDED.oidc_client_secret,
+					saml_idp_metadata_url = EXCLUDED.saml_idp_metadata_url,
+					saml_idp_metadata_xml = EXCLUDED.saml_idp_metadata_xml,
+					updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(ctx, query,
+		c.ID,
+		c.TenantID,
+		c.Protocol,
+		c.Enabled,
+		c.JITProvisioning,
+		c.PasswordLoginDisabled,
+		c.OIDCIssuer,
+		c.OIDCClientID,
+		c.OIDCClientSecret,
+		c.SAMLIDPMetadataURL,
+		c.SAMLIDPMetadataXML,
+		c.CreatedAt,
+		c.UpdatedAt,
+	)
+	return err
+}
+
+func (r *postgresSSORepo) CreateLoginRequest(ctx context.Context, req *entity.SSOLoginRequest) error {
+	query := `INSERT INTO sso_login_requests (id, tenant_id, protocol, code_verifier, nonce, saml_request_id, expires_at, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Exec(ctx, query,
+		req.ID,
+		req.TenantID,
+		req.Protocol,
+		req.CodeVerifier,
+		req.Nonce,
+		req.SAMLRequestID,
+		req.ExpiresAt,
+		req.CreatedAt,
+	)
+	return err
+}
+
+func (r *postgresSSORepo) ConsumeLoginRequest(ctx context.Context, tenantID uuid.UUID, id string, at time.Time) (*entity.SSOLoginRequest, error) {
+	// UPDATE ... RETURNING memastikan state yang sama tidak bisa dipakai dua
+	// kali walaupun callback datang bersamaan.
+	query := `UPDATE sso_login_requests
+			  SET consumed_at = $3
+			  WHERE id = $1 AND tenant_id = $2 AND consumed_at IS NULL AND expires_at > $3
+			  RETURNING id, tenant_id, protocol, code_verifier, nonce, saml_request_id, expires_at, consumed_at, created_at`
+
+	var req entity.SSOLoginRequest
+	err := r.db.QueryRow(ctx, query, id, tenantID, at).Scan(
+		&req.ID,
+		&req.TenantID,
+		&req.Protocol,
+		&req.CodeVerifier,
+		&req.Nonce,
+		&req.SAMLRequestID,
+		&req.ExpiresAt,
+		&req.ConsumedAt,
+		&req.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("sesi login SSO tidak ditemukan atau sudah kedaluwarsa")
+		}
+		return nil, err
+	}
+	return &req, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresSubscriptionRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresSubscriptionRepo(dbPool *TenantDB) repository.SubscriptionRepository {
+	return &postgresSubscriptionRepo{
+		db: dbPool,
+	}
+}
+
+func insertSubscription(ctx context.Context, q dbtx, s *entity.Subscription) error {
+	query := `INSERT INTO subscriptions (id, tenant_id, plan_id, status, started_at, ends_at, trial_ends_at,
+								current_period_start, current_period_end, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := q.Exec(ctx, query,
+		s.ID,
+		s.TenantID,
+		s.PlanID,
+		s.Status,
+		s.StartedAt,
+		s.EndsAt,
+		s.TrialEndsAt,
+		s.CurrentPeriodStart,
+		s.CurrentPeriodEnd,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	return err
+}
+
+func updateSubscription(ctx context.Context, q dbtx, s *entity.Subscription) error {
+	query := `UPDATE subscriptions
+			  SET plan_id = $1, status = $2, ends_at = $3, trial_ends_at = $4,
+				  current_period_start = $5, current_period_end = $6, updated_at = $7
+			  WHERE id = $8`
+
+	s.UpdatedAt = time.Now()
+
+	_, err := q.Exec(ctx, query,
+		s.PlanID,
+		s.Status,
+		s.EndsAt,
+		s.TrialEndsAt,
+		s.CurrentPeriodStart,
+		s.CurrentPeriodEnd,
+		s.UpdatedAt,
+		s.ID,
+	)
+	return err
+}
+
+func (r *postgresSubscriptionRepo) Create(ctx context.Context, s *entity.Subscription) error {
+	return insertSubscription(ctx, r.db, s)
+}
+
+// FindCurrentByTenant mengambil langganan terbaru yang belum dibatalkan.
+func (r *postgresSubscriptionRepo) FindCurrentByTenant(ctx context.Context, tenantID uuid.UUID) (*entity.Subscription, error) {
+	query := `SELECT id, tenant_id, plan_id, status, started_at, ends_at, trial_ends_at,
+					 current_period_start, current_period_end, created_at, updated_at
+			  FROM subscriptions
+			  WHERE tenant_id = $1 AND status <> 'canceled'
+			  ORDER BY started_at DESC
+			  LIMIT 1`
+
+	var s entity.Subscription
+	err := r.db.QueryRow(ctx, query, tenantID).Scan(
+		&s.ID,
+		&s.TenantID,
+		&s.PlanID,
+		&s.Status,
+		&s.StartedAt,
+		&s.EndsAt,
+		&s.TrialEndsAt,
+		&s.CurrentPeriodStart,
+		&s.CurrentPeriodEnd,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("subscription not found")
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *postgresSubscriptionRepo) Update(ctx context.Context, s *entity.Subscription) error {
+	return updateSubscription(ctx, r.db, s)
+}
+
+// Replace mengunci baris tenant agar penggantian langganan yang berjalan
+// bersamaan menunggu satu sama lain (termasuk saat tenant belum punya
+// langganan), lalu mengunci dan menutup langganan yang masih berjalan sebelum
+// menyimpan langganan baru.
+func (r *postgresSubscriptionRepo) Replace(ctx context.Context, next *entity.Subscription) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var tenantID uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT id FROM tenants WHERE id = $1 FOR UPDATE`, next.TenantID).Scan(&tenantID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("tenant not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Baris yang dikunci sama dengan yang dikunci ChangePlan, sehingga
+	// perpindahan paket yang berjalan bersamaan menunggu lalu mendapat
+	// ErrSubscriptionChanged.
+	_, err = tx.Exec(ctx, `UPDATE subscriptions
+						   SET status = 'canceled', ends_at = $2, updated_at = $2
+						   WHERE id IN (SELECT id FROM subscriptions
+										WHERE tenant_id = $1 AND status <> 'canceled'
+										FOR UPDATE)`, next.TenantID, next.StartedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menutup langganan lama: %w", err)
+	}
+
+	if err := insertSubscription(ctx, tx, next); err != nil {
+		return fmt.Errorf("gagal menyimpan langganan: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ChangePlan menutup langganan lama, membuat langganan baru, lalu mencatat
+// invoice penyesuaian atau kredit prorata dalam satu transaksi.
+func (r *postgresSubscriptionRepo) ChangePlan(ctx context.Context, current, next *entity.Subscription, invoice *entity.Invoice, credit *entity.BillingCredit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rollback aman dipanggil meskipun transaksi sudah di-commit.
+	defer tx.Rollback(ctx)
+
+	// Kunci baris langganan lama agar perpindahan paket atau pembatalan yang
+	// berjalan bersamaan menunggu, lalu pastikan belum ditutup proses lain
+	// sehingga invoice/kredit tidak terbit dua kali.
+	var status entity.StatusSubscription
+	err = tx.QueryRow(ctx, `SELECT status FROM subscriptions WHERE id = $1 FOR UPDATE`, current.ID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("subscription not found")
+	}
+	if err != nil {
+		return err
+	}
+	if status == entity.StatusSubscriptionCanceled {
+		return repository.ErrSubscriptionChanged
+	}
+
+	if err := updateSubscription(ctx, tx, current); err != nil {
+		return fmt.Errorf("gagal menutup langganan lama: %w", err)
+	}
+
+	if err := insertSubscription(ctx, tx, next); err != nil {
+		return fmt.Errorf("gagal membuat langganan baru: %w", err)
+	}
+
+	if invoice != nil {
+		if err := insertInvoice(ctx, tx, invoice); err != nil {
+			return fmt.Errorf("gagal membuat invoice penyesuaian: %w", err)
+		}
+	}
+
+	if credit != nil {
+		if err := insertBillingCredit(ctx, tx, credit); err != nil {
+			return fmt.Errorf("gagal mencatat kredit: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
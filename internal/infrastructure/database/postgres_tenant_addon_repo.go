@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresTenantAddonRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresTenantAddonRepo(dbPool *TenantDB) repository.TenantAddonRepository {
+	return &postgresTenantAddonRepo{
+		db: dbPool,
+	}
+}
+
+// Upsert menambah addon baru, atau memperpanjang expires_at jika tenant
+// sudah pernah membeli addon yang sama (UNIQUE tenant_id, feature_id).
+func (r *postgresTenantAddonRepo) Upsert(ctx context.Context, addon *entity.TenantAddon) error {
+	query := `INSERT INTO tenant_addons (id, tenant_id, feature_id, extra_employee_seats, expires_at, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (tenant_id, feature_id)
+			  DO UPDATE SET extra_employee_seats = EXCLUDED.extra_employee_seats,
+							expires_at = EXCLUDED.expires_at,
+							updated_at = EXCLUDED.updated_at
+			  RETURNING id, created_at`
+
+	return r.db.QueryRow(ctx, query,
+		addon.ID,
+		addon.TenantID,
+		addon.FeatureID,
+		addon.ExtraEmployeeSeats,
+		addon.ExpiresAt,
+		addon.CreatedAt,
+		addon.UpdatedAt,
+	).Scan(&addon.ID, &addon.CreatedAt)
+}
+
+func (r *postgresTenantAddonRepo) Delete(ctx context.Context, tenantID, featureID uuid.UUID) error {
+	query := `DELETE FROM tenant_addons WHERE tenant_id = $1 AND feature_id = $2`
+
+	_, err := r.db.Exec(ctx, query, tenantID, featureID)
+	return err
+}
@@ -9,18 +9,17 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/maskholilaziz/hris-go/internal/entity"
 	"github.com/maskholilaziz/hris-go/internal/repository"
 	"github.com/maskholilaziz/hris-go/pkg/util"
 )
 
 type postgresTenantRepo struct {
-	db  *pgxpool.Pool
+	db  *TenantDB
 	sqb sq.StatementBuilderType
 }
 
-func NewPostgresTenantRepo(dbPool *pgxpool.Pool) repository.TenantRepository {
+func NewPostgresTenantRepo(dbPool *TenantDB) repository.TenantRepository {
 	return &postgresTenantRepo{
 		db:  dbPool,
 		sqb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
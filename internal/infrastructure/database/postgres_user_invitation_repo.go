@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresUserInvitationRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresUserInvitationRepo(dbPool *TenantDB) repository.UserInvitationRepository {
+	return &postgresUserInvitationRepo{
+		db: dbPool,
+	}
+}
+
+func (r *postgresUserInvitationRepo) Create(ctx context.Context, inv *entity.UserInvitation, newUser *entity.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if newUser != nil {
+		if err := insertUser(ctx, tx, newUser); err != nil {
+			return err
+		}
+	}
+
+	revokeQuery := `UPDATE user_invitations
+					SET revoked_at = $2, updated_at = $2
+					WHERE user_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`
+
+	if _, err := tx.Exec(ctx, revokeQuery, inv.UserID, inv.CreatedAt); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_invitations (id, tenant_id, user_id, email, expires_at, invited_by, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	if _, err := tx.Exec(ctx, query,
+		inv.ID,
+		inv.TenantID,
+		inv.UserID,
+		inv.Email,
+		inv.ExpiresAt,
+		inv.InvitedBy,
+		inv.CreatedAt,
+		inv.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresUserInvitationRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.UserInvitation, error) {
+	query := `SELECT id, tenant_id, user_id, email, expires_at, accepted_at, revoked_at, invited_by, created_at, updated_at
+			  FROM user_invitations
+			  WHERE id = $1`
+
+	var inv entity.UserInvitation
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&inv.ID,
+		&inv.TenantID,
+		&inv.UserID,
+		&inv.Email,
+		&inv.ExpiresAt,
+		&inv.AcceptedAt,
+		&inv.RevokedAt,
+		&inv.InvitedBy,
+		&inv.CreatedAt,
+		&inv.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *postgresUserInvitationRepo) Accept(ctx context.Context, inv *entity.UserInvitation, passwordHash string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Kondisi WHERE memastikan undangan yang sama tidak bisa diterima dua kali
+	// walaupun dua request datang bersamaan.
+	tag, err := tx.Exec(ctx, `UPDATE user_invitations
+							  SET accepted_at = $2, updated_at = $2
+							  WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2`,
+		inv.ID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("undangan sudah tidak berlaku")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users
+							   SET password = $3, password_changed_at = $4, email_verified_at = $4, updated_at = $4
+							   WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`,
+		inv.UserID, inv.TenantID, passwordHash, at); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type postgresUserRepo struct {
+	db *TenantDB
+}
+
+func NewPostgresUserRepo(dbPool *TenantDB) repository.UserRepository {
+	return &postgresUserRepo{
+		db: dbPool,
+	}
+}
+
+const userColumns = `id, tenant_id, name, email, email_verified_at, password, password_changed_at, timezone, login_disabled_at, scim_external_id, created_at, updated_at`
+
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.ID,
+		&user.TenantID,
+		&user.Name,
+		&user.Email,
+		&user.EmailVerifiedAt,
+		&user.Password,
+		&user.PasswordChangedAt,
+		&user.Timezone,
+		&user.LoginDisabledAt,
+		&user.SCIMExternalID,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *postgresUserRepo) FindByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*entity.User, error) {
+	query := `SELECT ` + userColumns + `
+			  FROM users
+			  WHERE tenant_id = $1 AND LOWER(email) = LOWER($2) AND deleted_at IS NULL`
+
+	return scanUser(r.db.QueryRow(ctx, query, tenantID, email))
+}
+
+func (r *postgresUserRepo) FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.User, error) {
+	query := `SELECT ` + userColumns + `
+			  FROM users
+			  WHERE tenant_id = $1 AND id = $2 AND deleted_at IS NULL`
+
+	return scanUser(r.db.QueryRow(ctx, query, tenantID, id))
+}
+
+func (r *postgresUserRepo) FindUnverified(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]*entity.User, error) {
+	query := `SELECT ` + userColumns + `
+			  FROM users
+			  WHERE tenant_id = $1 AND email_verified_at IS NULL AND deleted_at IS NULL
+				AND (cardinality($2::uuid[]) = 0 OR id = ANY($2))
+			  ORDER BY created_at`
+
+	if ids == nil {
+		ids = []uuid.UUID{}
+	}
+
+	rows, err := r.db.Query(ctx, query, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *postgresUserRepo) Create(ctx context.Context, user *entity.User) error {
+	return insertUser(ctx, r.db, user)
+}
+
+func insertUser(ctx context.Context, q dbtx, user *entity.User) error {
+	query := `INSERT INTO users (id, tenant_id, name, email, email_verified_at, password, password_changed_at, timezone,
+					login_disabled_at, scim_external_id, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := q.Exec(ctx, query,
+		user.ID,
+		user.TenantID,
+		user.Name,
+		user.Email,
+		user.EmailVerifiedAt,
+		user.Password,
+		user.PasswordChangedAt,
+		user.Timezone,
+		user.LoginDisabledAt,
+		user.SCIMExternalID,
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return errors.New("email sudah terdaftar")
+	}
+	return err
+}
+
+func (r *postgresUserRepo) FindScopeSubject(ctx context.Context, tenantID, userID uuid.UUID) (entity.DataScopeSubject, error) {
+	subject := entity.DataScopeSubject{UserID: userID}
+
+	query := `SELECT id, branch_id, department_id, sbu_id
+			  FROM employees
+			  WHERE tenant_id = $1 AND user_id = $2 AND deleted_at IS NULL`
+
+	var employeeID uuid.UUID
+	err := r.db.QueryRow(ctx, query, tenantID, userID).Scan(
+		&employeeID,
+		&subject.BranchID,
+		&subject.DepartmentID,
+		&subject.SBUID,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// User tanpa data karyawan hanya bisa melihat datanya sendiri.
+		return subject, nil
+	}
+	if err != nil {
+		return subject, err
+	}
+
+	subject.EmployeeID = &employeeID
+	return subject, nil
+}
+
+func (r *postgresUserRepo) FindPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	query := `SELECT password_hash FROM user_password_histories
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC
+			  LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
+
+func (r *postgresUserRepo) UpdatePassword(ctx context.Context, user *entity.User, keepHistory int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldHash string
+	err = tx.QueryRow(ctx, `SELECT password FROM users
+							WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+							FOR UPDATE`, user.ID, user.TenantID).Scan(&oldHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("user not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if oldHash != "" && keepHistory > 0 {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO user_password_histories (id, user_id, password_hash, created_at)
+								   VALUES ($1, $2, $3, $4)`, id, user.ID, oldHash, now); err != nil {
+			return err
+		}
+	}
+
+	// Riwayat di luar keepHistory terbaru tidak diperlukan lagi.
+	if _, err := tx.Exec(ctx, `DELETE FROM user_password_histories
+							   WHERE user_id = $1 AND id NOT IN (
+								 SELECT id FROM user_password_histories
+								 WHERE user_id = $1
+								 ORDER BY created_at DESC
+								 LIMIT $2
+							   )`, user.ID, keepHistory); err != nil {
+		return err
+	}
+
+	user.PasswordChangedAt = &now
+	user.UpdatedAt = now
+	if _, err := tx.Exec(ctx, `UPDATE users SET password = $1, password_changed_at = $2, updated_at = $2
+							   WHERE id = $3 AND tenant_id = $4`,
+		user.Password, now, user.ID, user.TenantID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresUserRepo) UpdatePasswordHash(ctx context.Context, tenantID, userID uuid.UUID, hash string) error {
+	_, err := r.db.Exec(ctx, `UPDATE users SET password = $1
+							  WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL`, hash, userID, tenantID)
+	return err
+}
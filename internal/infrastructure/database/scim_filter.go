@@ -0,0 +1,209 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maskholilaziz/hris-go/internal/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type scimAttributeKind int
+
+const (
+	scimString scimAttributeKind = iota
+	scimBool
+	scimTime
+)
+
+// scimAttribute memetakan atribut SCIM ke ekspresi SQL. Jika exists diisi,
+// perbandingan dibungkus subquery EXISTS (dipakai untuk members.value).
+type scimAttribute struct {
+	column string
+	kind   scimAttributeKind
+	exists string
+}
+
+// Atribut yang bisa difilter pada /Users. Query memakai alias u (users) dan
+// e (employees).
+var scimUserAttributes = map[string]scimAttribute{
+	"id":                {column: "u.id::text"},
+	"username":          {column: "u.email"},
+	"emails":            {column: "u.email"},
+	"emails.value":      {column: "u.email"},
+	"externalid":        {column: "u.scim_external_id"},
+	"displayname":       {column: "u.name"},
+	"name.formatted":    {column: "u.name"},
+	"employeenumber":    {column: "e.employee_id_number"},
+	"active":            {column: "(u.login_disabled_at IS NULL)", kind: scimBool},
+	"meta.created":      {column: "u.created_at", kind: scimTime},
+	"meta.lastmodified": {column: "u.updated_at", kind: scimTime},
+}
+
+// Atribut yang bisa difilter pada /Groups (alias ro untuk roles).
+var scimGroupAttributes = map[string]scimAttribute{
+	"id":                {column: "ro.id::text"},
+	"displayname":       {column: "ro.name"},
+	"members":           {column: "ru.user_id::text", exists: "EXISTS (SELECT 1 FROM role_user ru WHERE ru.role_id = ro.id AND %s)"},
+	"members.value":     {column: "ru.user_id::text", exists: "EXISTS (SELECT 1 FROM role_user ru WHERE ru.role_id = ro.id AND %s)"},
+	"meta.created":      {column: "ro.created_at", kind: scimTime},
+	"meta.lastmodified": {column: "ro.updated_at", kind: scimTime},
+}
+
+// buildSCIMFilter menerjemahkan filter SCIM ke kondisi WHERE. Perbandingan
+// string tidak case-sensitive sesuai caseExact=false pada atribut di atas.
+func buildSCIMFilter(f *repository.SCIMFilter, attributes map[string]scimAttribute) (sq.Sqlizer, error) {
+	switch f.Op {
+	case "and", "or":
+		left, err := buildSCIMFilter(f.Left, attributes)
+		if err != nil {
+			return nil, err
+		}
+		right, err := buildSCIMFilter(f.Right, attributes)
+		if err != nil {
+			return nil, err
+		}
+		if f.Op == "and" {
+			return sq.And{left, right}, nil
+		}
+		return sq.Or{left, right}, nil
+
+	case "not":
+		inner, err := buildSCIMFilter(f.Left, attributes)
+		if err != nil {
+			return nil, err
+		}
+		sql, args, err := inner.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		return sq.Expr("NOT ("+sql+")", args...), nil
+	}
+
+	attr, ok := attributes[f.Attribute]
+	if !ok {
+		return nil, fmt.Errorf("%w: atribut '%s' tidak bisa difilter", repository.ErrSCIMFilterUnsupported, f.Attribute)
+	}
+
+	var cond sq.Sqlizer
+	var err error
+	switch attr.kind {
+	case scimBool:
+		cond, err = scimBoolCondition(attr.column, f)
+	case scimTime:
+		cond, err = scimTimeCondition(attr.column, f)
+	default:
+		cond, err = scimStringCondition(attr.column, f)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if attr.exists == "" {
+		return cond, nil
+	}
+	sql, args, err := cond.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return sq.Expr(fmt.Sprintf(attr.exists, sql), args...), nil
+}
+
+func scimStringCondition(column string, f *repository.SCIMFilter) (sq.Sqlizer, error) {
+	if f.Op == "pr" {
+		return sq.Expr("(" + column + " IS NOT NULL AND " + column + " <> '')"), nil
+	}
+	if f.Value == nil {
+		return scimNullCondition(column, f)
+	}
+
+	value, ok := f.Value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: nilai '%s' harus string", repository.ErrSCIMFilterUnsupported, f.Attribute)
+	}
+
+	switch f.Op {
+	case "eq":
+		return sq.Expr("LOWER("+column+") = LOWER(?)", value), nil
+	case "ne":
+		return sq.Expr("("+column+" IS NULL OR LOWER("+column+") <> LOWER(?))", value), nil
+	case "co":
+		return sq.Expr(column+" ILIKE ?", "%"+escapeLike(value)+"%"), nil
+	case "sw":
+		return sq.Expr(column+" ILIKE ?", escapeLike(value)+"%"), nil
+	case "ew":
+		return sq.Expr(column+" ILIKE ?", "%"+escapeLike(value)), nil
+	}
+	return scimOrderCondition("LOWER("+column+")", f.Op, strings.ToLower(value))
+}
+
+func scimBoolCondition(column string, f *repository.SCIMFilter) (sq.Sqlizer, error) {
+	if f.Op == "pr" {
+		return sq.Expr("TRUE"), nil
+	}
+
+	value, ok := f.Value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%w: nilai '%s' harus boolean", repository.ErrSCIMFilterUnsupported, f.Attribute)
+	}
+
+	switch f.Op {
+	case "eq":
+		return sq.Expr(column+" = ?", value), nil
+	case "ne":
+		return sq.Expr(column+" <> ?", value), nil
+	}
+	return nil, fmt.Errorf("%w: operator '%s' tidak berlaku untuk boolean", repository.ErrSCIMFilterUnsupported, f.Op)
+}
+
+func scimTimeCondition(column string, f *repository.SCIMFilter) (sq.Sqlizer, error) {
+	if f.Op == "pr" {
+		return sq.Expr(column + " IS NOT NULL"), nil
+	}
+	if f.Value == nil {
+		return scimNullCondition(column, f)
+	}
+
+	raw, ok := f.Value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: nilai '%s' harus tanggal", repository.ErrSCIMFilterUnsupported, f.Attribute)
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: tanggal '%s' harus format RFC 3339", repository.ErrSCIMFilterUnsupported, raw)
+	}
+
+	switch f.Op {
+	case "eq":
+		return sq.Expr(column+" = ?", value), nil
+	case "ne":
+		return sq.Expr(column+" <> ?", value), nil
+	}
+	return scimOrderCondition(column, f.Op, value)
+}
+
+func scimNullCondition(column string, f *repository.SCIMFilter) (sq.Sqlizer, error) {
+	switch f.Op {
+	case "eq":
+		return sq.Expr(column + " IS NULL"), nil
+	case "ne":
+		return sq.Expr(column + " IS NOT NULL"), nil
+	}
+	return nil, fmt.Errorf("%w: null hanya bisa dibandingkan dengan eq/ne", repository.ErrSCIMFilterUnsupported)
+}
+
+func scimOrderCondition(column, op string, value any) (sq.Sqlizer, error) {
+	operators := map[string]string{"gt": ">", "ge": ">=", "lt": "<", "le": "<="}
+
+	sqlOp, ok := operators[op]
+	if !ok {
+		return nil, fmt.Errorf("%w: operator '%s' tidak didukung", repository.ErrSCIMFilterUnsupported, op)
+	}
+	return sq.Expr(column+" "+sqlOp+" ?", value), nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/scim"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+func TestBuildSCIMFilter(t *testing.T) {
+	created := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		filter     string
+		attributes map[string]scimAttribute
+		wantSQL    string
+		wantArgs   []any
+	}{
+		{"eq tidak case-sensitive", `userName eq "Budi@Example.com"`, scimUserAttributes,
+			"LOWER(u.email) = LOWER(?)", []any{"Budi@Example.com"}},
+		{"ne termasuk null", `externalId ne "x"`, scimUserAttributes,
+			"(u.scim_external_id IS NULL OR LOWER(u.scim_external_id) <> LOWER(?))", []any{"x"}},
+		{"co meng-escape wildcard LIKE", `displayName co "50%_a\\b"`, scimUserAttributes,
+			"u.name ILIKE ?", []any{`%50\%\_a\\b%`}},
+		{"sw", `userName sw "bu"`, scimUserAttributes, "u.email ILIKE ?", []any{"bu%"}},
+		{"ew", `userName ew ".com"`, scimUserAttributes, "u.email ILIKE ?", []any{"%.com"}},
+		{"gt string", `employeeNumber gt "E100"`, scimUserAttributes,
+			"LOWER(e.employee_id_number) > ?", []any{"e100"}},
+		{"pr string", `externalId pr`, scimUserAttributes,
+			"(u.scim_external_id IS NOT NULL AND u.scim_external_id <> '')", nil},
+		{"eq null", `externalId eq null`, scimUserAttributes, "u.scim_external_id IS NULL", nil},
+		{"boolean", `active eq false`, scimUserAttributes, "(u.login_disabled_at IS NULL) = ?", []any{false}},
+		{"tanggal", `meta.created ge "2025-01-02T03:04:05Z"`, scimUserAttributes, "u.created_at >= ?", []any{created}},
+		{"and", `userName eq "a" and active eq true`, scimUserAttributes,
+			"(LOWER(u.email) = LOWER(?) AND (u.login_disabled_at IS NULL) = ?)", []any{"a", true}},
+		{"or", `id eq "1" or id eq "2"`, scimUserAttributes,
+			"(LOWER(u.id::text) = LOWER(?) OR LOWER(u.id::text) = LOWER(?))", []any{"1", "2"}},
+		{"not", `not (userName sw "a")`, scimUserAttributes, "NOT (u.email ILIKE ?)", []any{"a%"}},
+		{"members memakai EXISTS", `members eq "abc"`, scimGroupAttributes,
+			"EXISTS (SELECT 1 FROM role_user ru WHERE ru.role_id = ro.id AND LOWER(ru.user_id::text) = LOWER(?))", []any{"abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := scim.ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter gagal: %v", err)
+			}
+			cond, err := buildSCIMFilter(filter, tt.attributes)
+			if err != nil {
+				t.Fatalf("buildSCIMFilter gagal: %v", err)
+			}
+			sql, args, err := cond.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql gagal: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.wantSQL)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildSCIMFilterRejectsUnsupported(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		attributes map[string]scimAttribute
+	}{
+		{"atribut tidak dikenal", `password eq "x"`, scimUserAttributes},
+		{"atribut tidak dikenal di ruas kanan", `userName eq "a" or title eq "x"`, scimUserAttributes},
+		{"atribut tidak dikenal di dalam not", `not (nickName pr)`, scimUserAttributes},
+		{"atribut Users pada Groups", `userName eq "a"`, scimGroupAttributes},
+		{"string dibandingkan angka", `userName eq 1`, scimUserAttributes},
+		{"boolean dibandingkan string", `active eq "true"`, scimUserAttributes},
+		{"operator urutan pada boolean", `active gt true`, scimUserAttributes},
+		{"tanggal bukan RFC 3339", `meta.created gt "2025-01-02"`, scimUserAttributes},
+		{"null dengan co", `externalId co null`, scimUserAttributes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := scim.ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter gagal: %v", err)
+			}
+			_, err = buildSCIMFilter(filter, tt.attributes)
+			if !errors.Is(err, repository.ErrSCIMFilterUnsupported) {
+				t.Errorf("err = %v, want ErrSCIMFilterUnsupported", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// TenantFunc mengambil tenant aktif dari context request.
+type TenantFunc func(ctx context.Context) (uuid.UUID, bool)
+
+// TenantDB membungkus *pgxpool.Pool sehingga setiap query berjalan di dalam
+// transaksi yang sudah mengisi 'app.tenant_id' (atau 'app.bypass_rls' untuk
+// context platform). Policy Row Level Security di database memakai nilai ini,
+// jadi query yang lupa menulis WHERE tenant_id tetap tidak bisa membaca data
+// tenant lain.
+//
+// Exec, Query, dan QueryRow di luar Begin masing-masing membuka transaksi
+// pendek sendiri (BEGIN, set_config, query, COMMIT).
+type TenantDB struct {
+	pool     *pgxpool.Pool
+	tenantOf TenantFunc
+}
+
+func NewTenantDB(pool *pgxpool.Pool, tenantOf TenantFunc) *TenantDB {
+	return &TenantDB{
+		pool:     pool,
+		tenantOf: tenantOf,
+	}
+}
+
+// Begin membuka transaksi dan mengisi setting tenant secara lokal
+// (set_config(..., true) setara SET LOCAL, tapi bisa memakai parameter).
+func (db *TenantDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := ""
+	if id, ok := db.tenantOf(ctx); ok {
+		tenantID = id.String()
+	}
+
+	bypass := "off"
+	if tenantID == "" && repository.HasPlatformAccess(ctx) {
+		bypass = "on"
+	}
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true), set_config('app.bypass_rls', $2, true)", tenantID, bypass); err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("gagal mengatur tenant untuk transaksi: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (db *TenantDB) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, arguments...)
+	if err != nil {
+		return tag, err
+	}
+
+	return tag, tx.Commit(ctx)
+}
+
+func (db *TenantDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	return &tenantRows{Rows: rows, ctx: ctx, tx: tx}, nil
+}
+
+func (db *TenantDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+
+	return &tenantRow{row: tx.QueryRow(ctx, sql, args...), ctx: ctx, tx: tx}
+}
+
+// tenantRows menutup transaksi begitu hasil query habis dibaca atau Close
+// dipanggil.
+type tenantRows struct {
+	pgx.Rows
+	ctx  context.Context
+	tx   pgx.Tx
+	err  error
+	done bool
+}
+
+func (r *tenantRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.finish()
+	return false
+}
+
+func (r *tenantRows) Close() {
+	r.Rows.Close()
+	r.finish()
+}
+
+func (r *tenantRows) Err() error {
+	if err := r.Rows.Err(); err != nil {
+		return err
+	}
+	return r.err
+}
+
+func (r *tenantRows) finish() {
+	if r.done {
+		return
+	}
+	r.done = true
+
+	if r.Rows.Err() != nil {
+		r.tx.Rollback(r.ctx)
+		return
+	}
+	r.err = r.tx.Commit(r.ctx)
+}
+
+type tenantRow struct {
+	row pgx.Row
+	ctx context.Context
+	tx  pgx.Tx
+}
+
+func (r *tenantRow) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != nil {
+		r.tx.Rollback(r.ctx)
+		return err
+	}
+	return r.tx.Commit(r.ctx)
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
@@ -0,0 +1,238 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+var compareOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// ParseFilter mem-parse parameter query 'filter'. String kosong menghasilkan
+// nil (tanpa filter). Filter atribut bertingkat seperti
+// emails[type eq "work"] belum didukung.
+func ParseFilter(input string) (*repository.SCIMFilter, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeFilter(input)
+	if err != nil {
+		return nil, invalidFilter(err.Error())
+	}
+
+	p := &filterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilter("token tidak terduga: " + p.tokens[p.pos].text)
+	}
+	return filter, nil
+}
+
+func invalidFilter(detail string) *Error {
+	return NewError(http.StatusBadRequest, ErrorTypeInvalidFilter, "filter tidak valid: "+detail)
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(input string) ([]filterToken, error) {
+	tokens := []filterToken{}
+
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '(' || c == ')':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+
+		case c == '"':
+			// String mengikuti aturan escape JSON.
+			j := i + 1
+			for ; j < len(input); j++ {
+				if input[j] == '\\' {
+					j++
+					continue
+				}
+				if input[j] == '"' {
+					break
+				}
+			}
+			if j >= len(input) {
+				return nil, fmt.Errorf("string tidak ditutup")
+			}
+
+			var value string
+			if err := json.Unmarshal([]byte(input[i:j+1]), &value); err != nil {
+				return nil, fmt.Errorf("string tidak valid: %s", input[i:j+1])
+			}
+			tokens = append(tokens, filterToken{text: value, quoted: true})
+			i = j + 1
+
+		default:
+			j := i
+			for j < len(input) && !strings.ContainsRune(" \t\n\r()\"", rune(input[j])) {
+				if input[j] == '[' {
+					return nil, fmt.Errorf("filter atribut bertingkat belum didukung")
+				}
+				j++
+			}
+			tokens = append(tokens, filterToken{text: input[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peekKeyword() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return strings.ToLower(p.tokens[p.pos].text)
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, invalidFilter("filter berakhir terlalu cepat")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) parseOr() (*repository.SCIMFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword() == "or" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &repository.SCIMFilter{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*repository.SCIMFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword() == "and" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &repository.SCIMFilter{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (*repository.SCIMFilter, error) {
+	switch p.peekKeyword() {
+	case "not":
+		p.pos++
+		if p.peekKeyword() != "(" {
+			return nil, invalidFilter("'not' harus diikuti tanda kurung")
+		}
+		inner, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return &repository.SCIMFilter{Op: "not", Left: inner}, nil
+	case "(":
+		return p.parseGroup()
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseGroup() (*repository.SCIMFilter, error) {
+	p.pos++ // "("
+	inner, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peekKeyword() != ")" {
+		return nil, invalidFilter("tanda kurung tidak ditutup")
+	}
+	p.pos++
+	return inner, nil
+}
+
+func (p *filterParser) parseComparison() (*repository.SCIMFilter, error) {
+	attr, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if attr.quoted || attr.text == "(" || attr.text == ")" {
+		return nil, invalidFilter("atribut diharapkan, ditemukan " + attr.text)
+	}
+
+	opToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opToken.text)
+
+	filter := &repository.SCIMFilter{Op: op, Attribute: NormalizeAttribute(attr.text)}
+	if op == "pr" && !opToken.quoted {
+		return filter, nil
+	}
+	if opToken.quoted || !compareOperators[op] {
+		return nil, invalidFilter("operator tidak dikenal: " + opToken.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	filter.Value, err = parseFilterValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func parseFilterValue(t filterToken) (any, error) {
+	if t.quoted {
+		return t.text, nil
+	}
+
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if n, err := strconv.ParseFloat(t.text, 64); err == nil {
+		return n, nil
+	}
+	return nil, invalidFilter("nilai tidak valid: " + t.text)
+}
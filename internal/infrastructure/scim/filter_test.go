@@ -0,0 +1,145 @@
+package scim
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+func compare(attr, op string, value any) *repository.SCIMFilter {
+	return &repository.SCIMFilter{Op: op, Attribute: attr, Value: value}
+}
+
+func logical(op string, left, right *repository.SCIMFilter) *repository.SCIMFilter {
+	return &repository.SCIMFilter{Op: op, Left: left, Right: right}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *repository.SCIMFilter
+	}{
+		{"kosong", "", nil},
+		{"hanya spasi", "  \t ", nil},
+
+		{"eq", `userName eq "budi@example.com"`, compare("username", "eq", "budi@example.com")},
+		{"ne", `displayName ne "Budi"`, compare("displayname", "ne", "Budi")},
+		{"co", `userName co "budi"`, compare("username", "co", "budi")},
+		{"sw", `userName sw "bu"`, compare("username", "sw", "bu")},
+		{"ew", `userName ew ".com"`, compare("username", "ew", ".com")},
+		{"gt", `meta.created gt "2025-01-01T00:00:00Z"`, compare("meta.created", "gt", "2025-01-01T00:00:00Z")},
+		{"ge", `meta.lastModified ge "2025-01-01T00:00:00Z"`, compare("meta.lastmodified", "ge", "2025-01-01T00:00:00Z")},
+		{"lt angka", `employeeNumber lt 10`, compare("employeenumber", "lt", float64(10))},
+		{"le angka desimal", `employeeNumber le 2.5`, compare("employeenumber", "le", 2.5)},
+		{"pr", `externalId pr`, &repository.SCIMFilter{Op: "pr", Attribute: "externalid"}},
+		{"operator huruf besar", `userName EQ "a"`, compare("username", "eq", "a")},
+		{"boolean true", `active eq true`, compare("active", "eq", true)},
+		{"boolean False", `active eq False`, compare("active", "eq", false)},
+		{"null", `externalId eq null`, compare("externalid", "eq", nil)},
+		{
+			"atribut dengan URN schema",
+			`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "a"`,
+			compare("username", "eq", "a"),
+		},
+
+		{"escape kutip", `displayName eq "Budi \"BS\" Santoso"`, compare("displayname", "eq", `Budi "BS" Santoso`)},
+		{"escape backslash", `displayName eq "a\\b"`, compare("displayname", "eq", `a\b`)},
+		{"escape unicode", `displayName eq "Andr\u00e9"`, compare("displayname", "eq", "André")},
+		{"kata kunci di dalam string", `displayName eq "x and y or not (z)"`, compare("displayname", "eq", "x and y or not (z)")},
+
+		{
+			"and",
+			`userName eq "a" and active eq true`,
+			logical("and", compare("username", "eq", "a"), compare("active", "eq", true)),
+		},
+		{
+			"or",
+			`userName eq "a" OR userName eq "b"`,
+			logical("or", compare("username", "eq", "a"), compare("username", "eq", "b")),
+		},
+		{
+			"and lebih kuat dari or",
+			`userName eq "a" or userName eq "b" and active eq true`,
+			logical("or",
+				compare("username", "eq", "a"),
+				logical("and", compare("username", "eq", "b"), compare("active", "eq", true))),
+		},
+		{
+			"and berantai dari kiri",
+			`id eq "1" and id eq "2" and id eq "3"`,
+			logical("and", logical("and", compare("id", "eq", "1"), compare("id", "eq", "2")), compare("id", "eq", "3")),
+		},
+		{
+			"tanda kurung mengubah urutan",
+			`(userName eq "a" or userName eq "b") and active eq true`,
+			logical("and",
+				logical("or", compare("username", "eq", "a"), compare("username", "eq", "b")),
+				compare("active", "eq", true)),
+		},
+		{
+			"not",
+			`not (active eq false)`,
+			&repository.SCIMFilter{Op: "not", Left: compare("active", "eq", false)},
+		},
+		{
+			"not di dalam and",
+			`userName sw "a" and not (externalId pr)`,
+			logical("and",
+				compare("username", "sw", "a"),
+				&repository.SCIMFilter{Op: "not", Left: &repository.SCIMFilter{Op: "pr", Attribute: "externalid"}}),
+		},
+		{"kurung bersarang", `((userName eq "a"))`, compare("username", "eq", "a")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.input)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"operator tidak dikenal", `userName like "a"`},
+		{"operator dikutip", `userName "eq" "a"`},
+		{"tanpa operator", `userName`},
+		{"tanpa nilai", `userName eq`},
+		{"nilai tanpa kutip", `userName eq budi`},
+		{"atribut dikutip", `"userName" eq "a"`},
+		{"string tidak ditutup", `userName eq "budi`},
+		{"escape tidak valid", `userName eq "a\x"`},
+		{"kurung tidak ditutup", `(userName eq "a"`},
+		{"kurung tutup berlebih", `userName eq "a")`},
+		{"kurung kosong", `()`},
+		{"not tanpa kurung", `not userName eq "a"`},
+		{"and tanpa ruas kanan", `userName eq "a" and`},
+		{"or di awal", `or userName eq "a"`},
+		{"token sisa", `userName eq "a" "b"`},
+		{"filter atribut bertingkat", `emails[type eq "work"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.input)
+			if err == nil {
+				t.Fatalf("ParseFilter(%q) = %+v, want error", tt.input, got)
+			}
+			var scimErr *Error
+			if !errors.As(err, &scimErr) || scimErr.ScimType != ErrorTypeInvalidFilter || scimErr.Status != 400 {
+				t.Errorf("ParseFilter(%q) error = %#v, want invalidFilter 400", tt.input, err)
+			}
+		})
+	}
+}
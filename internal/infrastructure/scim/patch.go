@@ -0,0 +1,97 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation adalah satu operasi PATCH (RFC 7644 3.5.2). Op sudah
+// dinormalisasi ke huruf kecil oleh Validate.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Validate memastikan request memakai schema PatchOp dan setiap operasi
+// dikenal.
+func (r *PatchRequest) Validate() error {
+	hasSchema := false
+	for _, s := range r.Schemas {
+		if s == SchemaPatchOp {
+			hasSchema = true
+		}
+	}
+	if !hasSchema {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "schemas harus memuat "+SchemaPatchOp)
+	}
+	if len(r.Operations) == 0 {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "Operations tidak boleh kosong")
+	}
+
+	for i := range r.Operations {
+		op := &r.Operations[i]
+		op.Op = strings.ToLower(op.Op)
+		switch op.Op {
+		case "add", "replace":
+			if len(op.Value) == 0 {
+				return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "value wajib diisi untuk operasi "+op.Op)
+			}
+		case "remove":
+			if op.Path == "" {
+				return NewError(http.StatusBadRequest, ErrorTypeNoTarget, "path wajib diisi untuk operasi remove")
+			}
+		default:
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "operasi PATCH tidak dikenal: "+op.Op)
+		}
+	}
+	return nil
+}
+
+// valuePathPattern mengenali path dengan filter nilai sederhana, mis.
+// members[value eq "id"] atau emails[type eq "work"].value.
+var valuePathPattern = regexp.MustCompile(`^([^\[]+)\[\s*(\w+)\s+eq\s+"([^"]*)"\s*\](?:\.(\w+))?$`)
+
+// PatchPath adalah path operasi PATCH yang sudah diurai.
+type PatchPath struct {
+	// Attribute adalah atribut yang dinormalisasi, mis. "members", "active",
+	// "name.givenname", "emails.value", atau "employeenumber".
+	Attribute string
+	// FilterAttribute dan FilterValue diisi jika path memakai filter nilai.
+	FilterAttribute string
+	FilterValue     string
+}
+
+// ParsePatchPath mengurai path PATCH. Filter nilai hanya mendukung operator eq.
+func ParsePatchPath(path string) (PatchPath, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return PatchPath{}, nil
+	}
+
+	if !strings.Contains(path, "[") {
+		return PatchPath{Attribute: NormalizeAttribute(path)}, nil
+	}
+
+	m := valuePathPattern.FindStringSubmatch(path)
+	if m == nil {
+		return PatchPath{}, NewError(http.StatusBadRequest, ErrorTypeInvalidPath, "path tidak didukung: "+path)
+	}
+
+	attribute := NormalizeAttribute(m[1])
+	if m[4] != "" {
+		attribute += "." + strings.ToLower(m[4])
+	}
+	return PatchPath{
+		Attribute:       attribute,
+		FilterAttribute: strings.ToLower(m[2]),
+		FilterValue:     m[3],
+	}, nil
+}
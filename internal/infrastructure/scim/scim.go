@@ -0,0 +1,230 @@
+// Package scim berisi tipe protokol SCIM 2.0 (RFC 7643/7644): resource User
+// dan Group, ListResponse, format error, parser filter, dan operasi PATCH.
+// Pemetaan ke data tenant dilakukan di usecase.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URN schema yang dipakai.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+// ContentType adalah media type respons SCIM.
+const ContentType = "application/scim+json"
+
+// MaxPageSize membatasi jumlah resource per halaman ListResponse.
+const MaxPageSize = 200
+
+// Nilai scimType pada respons error (RFC 7644 3.12).
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeNoTarget      = "noTarget"
+)
+
+// Error adalah error yang ditulis ke client dalam format error SCIM.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{Status: status, ScimType: scimType, Detail: detail}
+}
+
+func NotFound(detail string) *Error {
+	return NewError(http.StatusNotFound, "", detail)
+}
+
+func InvalidValue(detail string) *Error {
+	return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, detail)
+}
+
+// MarshalJSON menulis error sesuai schema urn:...:Error. Status ditulis
+// sebagai string seperti contoh di RFC.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+	})
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValue dipakai untuk emails, groups, dan members.
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type Manager struct {
+	Value string `json:"value,omitempty"`
+}
+
+type EnterpriseUser struct {
+	EmployeeNumber string   `json:"employeeNumber,omitempty"`
+	Department     string   `json:"department,omitempty"`
+	Manager        *Manager `json:"manager,omitempty"`
+}
+
+type User struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  string          `json:"externalId,omitempty"`
+	UserName    string          `json:"userName"`
+	Name        *Name           `json:"name,omitempty"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Emails      []MultiValue    `json:"emails,omitempty"`
+	Active      *Bool           `json:"active,omitempty"`
+	Groups      []MultiValue    `json:"groups,omitempty"`
+	Enterprise  *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *Meta           `json:"meta,omitempty"`
+}
+
+// FullName mengembalikan nama lengkap dari displayName, name.formatted, atau
+// gabungan givenName dan familyName.
+func (u *User) FullName() string {
+	if name := strings.TrimSpace(u.DisplayName); name != "" {
+		return name
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if name := strings.TrimSpace(u.Name.Formatted); name != "" {
+		return name
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// Email mengembalikan email utama, atau email pertama jika tidak ada yang
+// ditandai primary.
+func (u *User) Email() string {
+	return primaryValue(u.Emails)
+}
+
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// Bool menerima boolean JSON maupun string "true"/"false" (beberapa IdP,
+// mis. Azure AD, mengirim "False" sebagai string).
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	v, err := ParseBool(json.RawMessage(data))
+	if err != nil {
+		return err
+	}
+	*b = Bool(v)
+	return nil
+}
+
+// ParseBool membaca nilai boolean dari JSON mentah.
+func ParseBool(raw json.RawMessage) (bool, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return false, err
+	}
+
+	switch t := v.(type) {
+	case bool:
+		return t, nil
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(t))
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return false, fmt.Errorf("nilai boolean tidak valid: %s", string(raw))
+}
+
+// ParseString membaca nilai string dari JSON mentah.
+func ParseString(raw json.RawMessage) (string, error) {
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", fmt.Errorf("nilai string tidak valid: %s", string(raw))
+	}
+	return v, nil
+}
+
+func primaryValue(values []MultiValue) string {
+	for _, v := range values {
+		if v.Primary {
+			return strings.TrimSpace(v.Value)
+		}
+	}
+	if len(values) > 0 {
+		return strings.TrimSpace(values[0].Value)
+	}
+	return ""
+}
+
+// NormalizeAttribute mengubah path atribut ke bentuk pembanding: huruf kecil
+// dan tanpa URN schema, mis. "urn:...:enterprise:2.0:User:employeeNumber"
+// menjadi "employeenumber".
+func NormalizeAttribute(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		if i := strings.LastIndex(path, ":"); i >= 0 {
+			path = path[i+1:]
+		}
+	}
+	return strings.ToLower(path)
+}
@@ -0,0 +1,37 @@
+package security
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const APIKeyContextKey = contextKey("api_key")
+
+// APIKeyPrincipal adalah identitas request yang diautentikasi dengan API key
+// (bukan JWT). Request seperti ini tidak memiliki TenantClaims, sehingga
+// handler harus mengambil tenant lewat TenantIDFromContext.
+type APIKeyPrincipal struct {
+	KeyID       uuid.UUID
+	TenantID    uuid.UUID
+	Permissions []string
+}
+
+func (p *APIKeyPrincipal) HasPermission(permission string) bool {
+	for _, name := range p.Permissions {
+		if name == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func WithAPIKey(ctx context.Context, principal *APIKeyPrincipal) context.Context {
+	return context.WithValue(ctx, APIKeyContextKey, principal)
+}
+
+// APIKeyFromContext mengambil API key yang sudah divalidasi middleware API key.
+func APIKeyFromContext(ctx context.Context) (*APIKeyPrincipal, bool) {
+	principal, ok := ctx.Value(APIKeyContextKey).(*APIKeyPrincipal)
+	return principal, ok && principal != nil
+}
@@ -2,6 +2,7 @@ package security
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +16,13 @@ import (
 type contextKey string
 const AdminIDContextKey = contextKey("admin_user_id")
 
+// Audience membedakan token superadmin dan token tenant yang ditandatangani
+// dengan secret yang sama, sehingga keduanya tidak bisa saling dipakai.
+const (
+	AudienceSuperadmin = "superadmin"
+	AudienceTenant     = "tenant"
+)
+
 type SuperadminClaims struct {
 	jwt.RegisteredClaims
 }
@@ -33,6 +41,7 @@ func (s *JWTService) GenerateSuperadminToken(adminID uuid.UUID) (string, error)
 	claims := SuperadminClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   adminID.String(),
+			Audience:  jwt.ClaimStrings{AudienceSuperadmin},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "hris",
@@ -49,7 +58,7 @@ func (s *JWTService) ValidateSuperadminToken(tokenString string) (*SuperadminCla
 			return nil, fmt.Errorf("signing method tidak terduga: %v", token.Header["alg"])
 		}
 		return s.secretKey, nil
-	})
+	}, jwt.WithAudience(AudienceSuperadmin))
 
 	if err != nil {
 		return nil, err
@@ -73,10 +82,15 @@ func (s *JWTService) SuperadminAuthMiddleware(next http.Handler) http.Handler {
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
 			util.ErrorResponse(w, http.StatusUnauthorized, "Format token salah", "Format harus 'Bearer {token}'")
+			return
 		}
 
 		tokenString := parts[1]
 		claims, err := s.ValidateSuperadminToken(tokenString)
+		if errors.Is(err, jwt.ErrTokenInvalidAudience) {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Token tenant tidak bisa dipakai untuk route superadmin")
+			return
+		}
 		if err != nil {
 			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", err.Error())
 			return
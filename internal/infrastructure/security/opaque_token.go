@@ -0,0 +1,44 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// opaqueTokenPrefixLength adalah panjang awal token (termasuk prefix jenis)
+// yang disimpan apa adanya untuk ditampilkan ke admin.
+const opaqueTokenPrefixLength = 12
+
+// OpaqueToken adalah token acak untuk integrasi mesin-ke-mesin (SCIM, API
+// key). Plaintext hanya diketahui saat dibuat; database menyimpan Hash.
+type OpaqueToken struct {
+	Plaintext string
+	Prefix    string
+	Hash      string
+}
+
+// NewOpaqueToken membuat token baru berformat "<kind>_<acak>", mis. "scim_...".
+func NewOpaqueToken(kind string) (*OpaqueToken, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("gagal membuat token: %w", err)
+	}
+
+	plaintext := kind + "_" + base64.RawURLEncoding.EncodeToString(b)
+	return &OpaqueToken{
+		Plaintext: plaintext,
+		Prefix:    plaintext[:opaqueTokenPrefixLength],
+		Hash:      HashOpaqueToken(plaintext),
+	}, nil
+}
+
+// HashOpaqueToken mengembalikan hash SHA-256 (hex) dari token. Token sudah
+// memiliki entropi tinggi sehingga tidak perlu bcrypt, dan hash bisa dipakai
+// langsung sebagai kunci pencarian.
+func HashOpaqueToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
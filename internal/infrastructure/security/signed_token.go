@@ -0,0 +1,83 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purpose token bertanda tangan. Purpose ikut ditandatangani sehingga token
+// untuk satu keperluan tidak bisa dipakai untuk keperluan lain.
+const (
+	TokenPurposeInvitation = "invitation"
+)
+
+var (
+	ErrSignedTokenInvalid = errors.New("token tidak valid")
+	ErrSignedTokenExpired = errors.New("token sudah kedaluwarsa")
+)
+
+// TokenSigner membuat token pendek bertanda tangan HMAC-SHA256 untuk link
+// email (undangan, verifikasi). Token berisi ID record dan waktu kedaluwarsa;
+// status record (sudah dipakai/dicabut) tetap dicek di database.
+type TokenSigner struct {
+	secretKey []byte
+}
+
+func NewTokenSigner(secret string) *TokenSigner {
+	return &TokenSigner{
+		secretKey: []byte(secret),
+	}
+}
+
+func (s *TokenSigner) Sign(purpose string, id uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, 16+8)
+	copy(payload, id[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(s.mac(purpose, payload))
+}
+
+// Verify memeriksa tanda tangan dan masa berlaku, lalu mengembalikan ID record.
+func (s *TokenSigner) Verify(purpose, token string) (uuid.UUID, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, ErrSignedTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 16+8 {
+		return uuid.Nil, ErrSignedTokenInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, s.mac(purpose, payload)) {
+		return uuid.Nil, ErrSignedTokenInvalid
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if time.Now().After(expiresAt) {
+		return uuid.Nil, ErrSignedTokenExpired
+	}
+
+	id, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return uuid.Nil, ErrSignedTokenInvalid
+	}
+	return id, nil
+}
+
+func (s *TokenSigner) mac(purpose string, payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secretKey)
+	h.Write([]byte(purpose))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,19 @@
+package security
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const TenantIDContextKey = contextKey("tenant_id")
+
+func WithTenantID(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, TenantIDContextKey, tenantID)
+}
+
+// TenantIDFromContext mengambil ID tenant yang sudah di-resolve oleh TenantResolver.
+func TenantIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(TenantIDContextKey).(uuid.UUID)
+	return tenantID, ok
+}
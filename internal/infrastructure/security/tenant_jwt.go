@@ -0,0 +1,184 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const TenantClaimsContextKey = contextKey("tenant_claims")
+
+// TenantClaims adalah klaim token untuk staf tenant. TenantID dicocokkan
+// dengan tenant hasil TenantResolver di setiap request.
+type TenantClaims struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	Roles    []string  `json:"roles"`
+	// EmailVerified dipakai RequireVerifiedEmail tanpa perlu query database.
+	EmailVerified bool `json:"email_verified"`
+	// Act terisi jika token dibuat superadmin untuk bertindak sebagai user
+	// (klaim "act" RFC 8693).
+	Act *ActorClaims `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// ActorClaims mengidentifikasi superadmin yang sedang melakukan impersonasi.
+type ActorClaims struct {
+	Subject   string    `json:"sub"`
+	SessionID uuid.UUID `json:"sid"`
+	ReadOnly  bool      `json:"read_only"`
+}
+
+func (c *TenantClaims) IsImpersonated() bool {
+	return c.Act != nil
+}
+
+func (c *TenantClaims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *JWTService) GenerateTenantToken(userID, tenantID uuid.UUID, roles []string, emailVerified bool) (string, error) {
+	return s.signTenantToken(userID, tenantID, roles, emailVerified, nil, time.Now().Add(time.Hour*24))
+}
+
+// GenerateImpersonationToken membuat token tenant atas nama user untuk
+// superadmin, berlaku sampai expiresAt (akhir sesi impersonasi).
+func (s *JWTService) GenerateImpersonationToken(userID, tenantID uuid.UUID, roles []string, emailVerified bool, actor ActorClaims, expiresAt time.Time) (string, error) {
+	return s.signTenantToken(userID, tenantID, roles, emailVerified, &actor, expiresAt)
+}
+
+func (s *JWTService) signTenantToken(userID, tenantID uuid.UUID, roles []string, emailVerified bool, actor *ActorClaims, expiresAt time.Time) (string, error) {
+	claims := TenantClaims{
+		UserID:        userID,
+		TenantID:      tenantID,
+		Roles:         roles,
+		EmailVerified: emailVerified,
+		Act:           actor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			Audience:  jwt.ClaimStrings{AudienceTenant},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "hris",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secretKey)
+}
+
+func (s *JWTService) ValidateTenantToken(tokenString string) (*TenantClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &TenantClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("signing method tidak terduga: %v", token.Header["alg"])
+		}
+		return s.secretKey, nil
+	}, jwt.WithAudience(AudienceTenant))
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*TenantClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("token tidak valid")
+	}
+	if claims.UserID == uuid.Nil || claims.TenantID == uuid.Nil {
+		return nil, fmt.Errorf("klaim token tenant tidak lengkap")
+	}
+	if claims.Act != nil && claims.Act.SessionID == uuid.Nil {
+		return nil, fmt.Errorf("klaim impersonasi tidak lengkap")
+	}
+
+	return claims, nil
+}
+
+// TenantAuthMiddleware memvalidasi token staf tenant. Harus dipasang setelah
+// TenantResolver: tenant di token wajib sama dengan tenant yang di-resolve
+// dari request, dan token superadmin ditolak.
+func (s *JWTService) TenantAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, err := bearerToken(r)
+		if err != nil {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak ditemukan", err.Error())
+			return
+		}
+
+		claims, err := s.ValidateTenantToken(tokenString)
+		if errors.Is(err, jwt.ErrTokenInvalidAudience) {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Token superadmin tidak bisa dipakai untuk route tenant")
+			return
+		}
+		if err != nil {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", err.Error())
+			return
+		}
+
+		tenantID, ok := TenantIDFromContext(r.Context())
+		if !ok {
+			util.ErrorResponse(w, http.StatusBadRequest, "Tenant tidak diketahui", "Tenant belum di-resolve")
+			return
+		}
+		if claims.TenantID != tenantID {
+			util.ErrorResponse(w, http.StatusForbidden, "Akses ditolak", "Token bukan milik tenant ini")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), TenantClaimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireVerifiedEmail menolak user yang email-nya belum terverifikasi.
+// Dipasang pada aksi sensitif setelah TenantAuthMiddleware. Request dengan API
+// key tidak memiliki email dan diteruskan apa adanya.
+func RequireVerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := APIKeyFromContext(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, ok := TenantClaimsFromContext(r.Context())
+		if !ok {
+			util.ErrorResponse(w, http.StatusUnauthorized, "Token tidak valid", "Klaim tenant tidak ditemukan")
+			return
+		}
+		if !claims.EmailVerified {
+			util.ErrorResponse(w, http.StatusForbidden, "Email belum terverifikasi", "Verifikasi email dibutuhkan untuk aksi ini")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TenantClaimsFromContext mengambil klaim yang sudah divalidasi TenantAuthMiddleware.
+func TenantClaimsFromContext(ctx context.Context) (*TenantClaims, bool) {
+	claims, ok := ctx.Value(TenantClaimsContextKey).(*TenantClaims)
+	return claims, ok
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", errors.New("Authorization header dibutuhkan")
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", errors.New("Format harus 'Bearer {token}'")
+	}
+	return parts[1], nil
+}
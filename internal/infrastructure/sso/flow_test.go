@@ -0,0 +1,379 @@
+package sso_test
+
+import (
+	"context"
+	"encoding/base64"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso/mockidp"
+)
+
+const (
+	testClientID     = "hris"
+	testClientSecret = "secret"
+	testEmail        = "Budi@Example.com"
+	testName         = "Budi Santoso"
+)
+
+// startIDP menjalankan mock IdP di server lokal.
+func startIDP(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(nil)
+	idp, err := mockidp.New(mockidp.Config{
+		Issuer:       "http://" + srv.Listener.Addr().String(),
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		Email:        testEmail,
+		Name:         testName,
+	})
+	if err != nil {
+		t.Fatalf("gagal membuat mock IdP: %v", err)
+	}
+	srv.Config.Handler = idp.Handler()
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// noRedirectClient mengembalikan respons redirect apa adanya, seperti
+// browser yang berhenti di callback API.
+var noRedirectClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// ------------------------------------------------------------------------
+// OIDC
+
+type oidcLogin struct {
+	client   *sso.OIDC
+	params   sso.OIDCParams
+	state    string
+	nonce    string
+	verifier string
+}
+
+func newOIDCLogin(t *testing.T) *oidcLogin {
+	t.Helper()
+	idp := startIDP(t)
+	return &oidcLogin{
+		client: sso.NewOIDC(),
+		params: sso.OIDCParams{
+			Issuer:       idp.URL,
+			ClientID:     testClientID,
+			ClientSecret: testClientSecret,
+			RedirectURL:  "http://api.test/sso/acme/oidc/callback",
+		},
+		state:    sso.RandomToken(),
+		nonce:    sso.RandomToken(),
+		verifier: sso.RandomToken(),
+	}
+}
+
+// authorize membuka URL login dan mengembalikan query callback dari IdP.
+func (l *oidcLogin) authorize(t *testing.T) url.Values {
+	t.Helper()
+
+	authURL, err := l.client.AuthCodeURL(context.Background(), l.params, l.state, l.nonce, l.verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL gagal: %v", err)
+	}
+
+	resp, err := noRedirectClient.Get(authURL)
+	if err != nil {
+		t.Fatalf("GET authorize gagal: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("authorize status = %d (%s), want 302", resp.StatusCode, body)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatalf("authorize tanpa Location: %v", err)
+	}
+	if got := location.Scheme + "://" + location.Host + location.Path; got != l.params.RedirectURL {
+		t.Fatalf("redirect ke %s, want %s", got, l.params.RedirectURL)
+	}
+	return location.Query()
+}
+
+func TestOIDCLogin(t *testing.T) {
+	l := newOIDCLogin(t)
+
+	callback := l.authorize(t)
+	if callback.Get("state") != l.state {
+		t.Fatalf("state callback = %q, want %q", callback.Get("state"), l.state)
+	}
+
+	identity, err := l.client.Exchange(context.Background(), l.params, callback.Get("code"), l.verifier, l.nonce)
+	if err != nil {
+		t.Fatalf("Exchange gagal: %v", err)
+	}
+	if identity.Email != "budi@example.com" || identity.Name != testName {
+		t.Errorf("identity = %+v, want email budi@example.com dan nama %s", identity, testName)
+	}
+}
+
+func TestOIDCAuthCodeURLUsesPKCE(t *testing.T) {
+	l := newOIDCLogin(t)
+
+	authURL, err := l.client.AuthCodeURL(context.Background(), l.params, l.state, l.nonce, l.verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL gagal: %v", err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("URL login tidak valid: %v", err)
+	}
+
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Errorf("URL login tanpa PKCE S256: %s", authURL)
+	}
+	if q.Get("code_challenge") == l.verifier {
+		t.Error("code_verifier dikirim apa adanya ke IdP")
+	}
+	if q.Get("state") != l.state || q.Get("nonce") != l.nonce {
+		t.Errorf("state/nonce = %q/%q, want %q/%q", q.Get("state"), q.Get("nonce"), l.state, l.nonce)
+	}
+}
+
+func TestOIDCExchangeRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(l *oidcLogin, code *string, verifier, nonce *string)
+		want   string
+	}{
+		{
+			name:   "code_verifier salah",
+			modify: func(l *oidcLogin, code, verifier, nonce *string) { *verifier = sso.RandomToken() },
+			want:   "gagal menukar authorization code",
+		},
+		{
+			name:   "nonce tidak cocok",
+			modify: func(l *oidcLogin, code, verifier, nonce *string) { *nonce = sso.RandomToken() },
+			want:   "nonce",
+		},
+		{
+			name:   "code tidak dikenal",
+			modify: func(l *oidcLogin, code, verifier, nonce *string) { *code = "bukan-code-asli" },
+			want:   "gagal menukar authorization code",
+		},
+		{
+			name:   "client_secret salah",
+			modify: func(l *oidcLogin, code, verifier, nonce *string) { l.params.ClientSecret = "salah" },
+			want:   "gagal menukar authorization code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newOIDCLogin(t)
+			code := l.authorize(t).Get("code")
+			verifier, nonce := l.verifier, l.nonce
+			tt.modify(l, &code, &verifier, &nonce)
+
+			_, err := l.client.Exchange(context.Background(), l.params, code, verifier, nonce)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want error berisi %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCCodeCannotBeReused(t *testing.T) {
+	l := newOIDCLogin(t)
+	code := l.authorize(t).Get("code")
+
+	if _, err := l.client.Exchange(context.Background(), l.params, code, l.verifier, l.nonce); err != nil {
+		t.Fatalf("Exchange pertama gagal: %v", err)
+	}
+	if _, err := l.client.Exchange(context.Background(), l.params, code, l.verifier, l.nonce); err == nil {
+		t.Error("code yang sama bisa ditukar dua kali")
+	}
+}
+
+// ------------------------------------------------------------------------
+// SAML
+
+type samlLogin struct {
+	idpURL     string
+	params     sso.SAMLParams
+	relayState string
+	requestID  string
+	response   string
+}
+
+var (
+	samlResponseInput = regexp.MustCompile(`name="SAMLResponse" value="([^"]+)"`)
+	relayStateInput   = regexp.MustCompile(`name="RelayState" value="([^"]*)"`)
+)
+
+// newSAMLParams menyiapkan SP tenant "acme" yang mempercayai idp. Metadata SP
+// dilayani di entity ID-nya agar bisa diambil mock IdP.
+func newSAMLParams(t *testing.T, idp *httptest.Server) sso.SAMLParams {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	sp := httptest.NewServer(mux)
+	t.Cleanup(sp.Close)
+
+	params := sso.SAMLParams{
+		EntityID: sp.URL + "/sso/acme/saml/metadata",
+		AcsURL:   sp.URL + "/sso/acme/saml/acs",
+	}
+	mux.HandleFunc("GET /sso/acme/saml/metadata", func(w http.ResponseWriter, r *http.Request) {
+		metadata, err := sso.SAMLMetadata(params)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.Write(metadata)
+	})
+
+	metadata, err := sso.FetchSAMLMetadata(context.Background(), idp.URL+"/saml/metadata")
+	if err != nil {
+		t.Fatalf("gagal mengambil metadata IdP: %v", err)
+	}
+	params.IDPMetadataXML = metadata
+	return params
+}
+
+// newSAMLLogin menjalankan redirect AuthnRequest ke mock IdP dan mengambil
+// SAMLResponse dari form auto-POST yang dikirim IdP ke ACS.
+func newSAMLLogin(t *testing.T) *samlLogin {
+	t.Helper()
+
+	idp := startIDP(t)
+	l := &samlLogin{
+		idpURL:     idp.URL,
+		params:     newSAMLParams(t, idp),
+		relayState: sso.RandomToken(),
+	}
+
+	redirectURL, requestID, err := sso.SAMLAuthnRedirect(l.params, l.relayState)
+	if err != nil {
+		t.Fatalf("SAMLAuthnRedirect gagal: %v", err)
+	}
+	l.requestID = requestID
+
+	resp, err := noRedirectClient.Get(redirectURL)
+	if err != nil {
+		t.Fatalf("GET SSO IdP gagal: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("gagal membaca respons IdP: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("SSO IdP status = %d (%s), want 200", resp.StatusCode, body)
+	}
+
+	m := samlResponseInput.FindSubmatch(body)
+	if m == nil {
+		t.Fatalf("respons IdP tanpa SAMLResponse: %s", body)
+	}
+	l.response = html.UnescapeString(string(m[1]))
+
+	if m := relayStateInput.FindSubmatch(body); m == nil || html.UnescapeString(string(m[1])) != l.relayState {
+		t.Fatalf("RelayState tidak dikembalikan apa adanya: %s", body)
+	}
+	return l
+}
+
+func TestSAMLLogin(t *testing.T) {
+	l := newSAMLLogin(t)
+
+	identity, err := sso.ParseSAMLResponse(l.params, l.response, l.requestID)
+	if err != nil {
+		t.Fatalf("ParseSAMLResponse gagal: %v", err)
+	}
+	if identity.Email != "budi@example.com" || identity.Name != testName {
+		t.Errorf("identity = %+v, want email budi@example.com dan nama %s", identity, testName)
+	}
+}
+
+func TestSAMLResponseRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, l *samlLogin)
+	}{
+		{
+			name: "tanda tangan rusak karena email diubah",
+			modify: func(t *testing.T, l *samlLogin) {
+				raw, err := base64.StdEncoding.DecodeString(l.response)
+				if err != nil {
+					t.Fatalf("SAMLResponse bukan base64: %v", err)
+				}
+				if !strings.Contains(string(raw), testEmail) {
+					t.Fatalf("SAMLResponse tidak memuat email %s", testEmail)
+				}
+				tampered := strings.ReplaceAll(string(raw), testEmail, "penyusup@example.com")
+				l.response = base64.StdEncoding.EncodeToString([]byte(tampered))
+			},
+		},
+		{
+			name: "audience untuk SP lain",
+			modify: func(t *testing.T, l *samlLogin) {
+				l.params.EntityID = strings.Replace(l.params.EntityID, "/acme/", "/lain/", 1)
+			},
+		},
+		{
+			name: "sertifikat IdP di metadata berbeda",
+			modify: func(t *testing.T, l *samlLogin) {
+				// IdP lain dengan entity ID yang sama tetapi kunci berbeda.
+				other, err := mockidp.New(mockidp.Config{Issuer: l.idpURL})
+				if err != nil {
+					t.Fatalf("gagal membuat mock IdP: %v", err)
+				}
+				rec := httptest.NewRecorder()
+				other.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/saml/metadata", nil))
+				l.params.IDPMetadataXML = rec.Body.String()
+			},
+		},
+		{
+			name: "InResponseTo bukan request kita",
+			modify: func(t *testing.T, l *samlLogin) {
+				l.requestID = "id-" + sso.RandomToken()
+			},
+		},
+		{
+			name: "login dimulai IdP tanpa request",
+			modify: func(t *testing.T, l *samlLogin) {
+				l.requestID = ""
+			},
+		},
+		{
+			name: "bukan base64",
+			modify: func(t *testing.T, l *samlLogin) {
+				l.response = "<samlp:Response/>"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newSAMLLogin(t)
+			tt.modify(t, l)
+
+			if identity, err := sso.ParseSAMLResponse(l.params, l.response, l.requestID); err == nil {
+				t.Errorf("SAMLResponse diterima sebagai %+v, want ditolak", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Package mockidp adalah Identity Provider tiruan (OIDC dan SAML 2.0) untuk
+// menguji login SSO tanpa akun Azure AD/Google/Okta. Semua permintaan login
+// langsung disetujui sebagai user di Config.Email (atau parameter login_hint
+// untuk OIDC). Dipakai oleh command cmd/mockidp dan test alur SSO.
+//
+// SAML: metadata SP diambil otomatis dari entity ID AuthnRequest (URL
+// metadata SP milik API).
+//
+// JANGAN dipakai di production.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/logger"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mockidp"
+
+// Config adalah identitas IdP dan user yang selalu login.
+type Config struct {
+	// Issuer adalah base URL publik mock IdP, e.g., "http://localhost:9000".
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Email        string
+	Name         string
+}
+
+type authCode struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	email         string
+	expiresAt     time.Time
+}
+
+// IDP menyimpan kunci penandatangan dan authorization code OIDC yang belum
+// ditukar.
+type IDP struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	email        string
+	name         string
+	key          *rsa.PrivateKey
+	samlIDP      *saml.IdentityProvider
+
+	mu    sync.Mutex
+	codes map[string]authCode
+}
+
+// New membuat IdP dengan RSA key baru, sehingga tanda tangan dari instance
+// lain tidak pernah dipercaya.
+func New(cfg Config) (*IDP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat RSA key: %w", err)
+	}
+
+	idp := &IDP{
+		issuer:       strings.TrimRight(cfg.Issuer, "/"),
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		email:        cfg.Email,
+		name:         cfg.Name,
+		key:          key,
+		codes:        make(map[string]authCode),
+	}
+
+	idp.samlIDP, err = idp.newSAMLIdentityProvider()
+	if err != nil {
+		return nil, fmt.Errorf("gagal menyiapkan SAML IdP: %w", err)
+	}
+	return idp, nil
+}
+
+// Issuer mengembalikan base URL IdP, dipakai sebagai issuer OIDC.
+func (idp *IDP) Issuer() string {
+	return idp.issuer
+}
+
+// Handler mengembalikan semua endpoint OIDC dan SAML.
+func (idp *IDP) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("GET /saml/metadata", idp.samlIDP.ServeMetadata)
+	mux.HandleFunc("/saml/sso", idp.samlIDP.ServeSSO)
+	return mux
+}
+
+// ------------------------------------------------------------------------
+// OIDC
+// ------------------------------------------------------------------------
+
+func (idp *IDP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                idp.issuer,
+		"authorization_endpoint":                idp.issuer + "/authorize",
+		"token_endpoint":                        idp.issuer + "/token",
+		"jwks_uri":                              idp.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+// authorize langsung menyetujui login dan mengembalikan code ke redirect_uri.
+func (idp *IDP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("client_id") != idp.clientID {
+		http.Error(w, "client_id tidak dikenal", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "redirect_uri tidak valid", http.StatusBadRequest)
+		return
+	}
+	if q.Get("response_type") != "code" {
+		http.Error(w, "response_type harus code", http.StatusBadRequest)
+		return
+	}
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE S256 wajib", http.StatusBadRequest)
+		return
+	}
+
+	email := idp.email
+	if hint := q.Get("login_hint"); strings.Contains(hint, "@") {
+		email = hint
+	}
+
+	code := randomString()
+	idp.mu.Lock()
+	idp.codes[code] = authCode{
+		clientID:      idp.clientID,
+		redirectURI:   redirectURI.String(),
+		codeChallenge: q.Get("code_challenge"),
+		nonce:         q.Get("nonce"),
+		email:         email,
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	idp.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *IDP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != idp.clientID || clientSecret != idp.clientSecret {
+		tokenError(w, "invalid_client", "client_id atau client_secret salah")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "hanya authorization_code")
+		return
+	}
+
+	idp.mu.Lock()
+	code, found := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	if !found || time.Now().After(code.expiresAt) {
+		tokenError(w, "invalid_grant", "code tidak valid atau kedaluwarsa")
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != code.redirectURI {
+		tokenError(w, "invalid_grant", "redirect_uri tidak cocok")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+		tokenError(w, "invalid_grant", "code_verifier tidak cocok")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            idp.issuer,
+		"sub":            code.email,
+		"aud":            code.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          code.email,
+		"email_verified": true,
+		"name":           idp.name,
+	}
+	if code.nonce != "" {
+		claims["nonce"] = code.nonce
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(idp.key)
+	if err != nil {
+		tokenError(w, "server_error", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (idp *IDP) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := idp.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// ------------------------------------------------------------------------
+// SAML
+// ------------------------------------------------------------------------
+
+func (idp *IDP) newSAMLIdentityProvider() (*saml.IdentityProvider, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mockidp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &idp.key.PublicKey, idp.key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataURL, err := url.Parse(idp.issuer + "/saml/metadata")
+	if err != nil {
+		return nil, err
+	}
+	ssoURL, err := url.Parse(idp.issuer + "/saml/sso")
+	if err != nil {
+		return nil, err
+	}
+
+	return &saml.IdentityProvider{
+		Key:                     idp.key,
+		Logger:                  logger.DefaultLogger,
+		Certificate:             cert,
+		MetadataURL:             *metadataURL,
+		SSOURL:                  *ssoURL,
+		ServiceProviderProvider: spMetadataFetcher{},
+		SessionProvider:         idp,
+	}, nil
+}
+
+// GetSession menyetujui setiap AuthnRequest sebagai user default.
+func (idp *IDP) GetSession(w http.ResponseWriter, r *http.Request, req *saml.IdpAuthnRequest) *saml.Session {
+	now := time.Now()
+	return &saml.Session{
+		ID:             randomString(),
+		CreateTime:     now,
+		ExpireTime:     now.Add(time.Hour),
+		Index:          randomString(),
+		NameID:         idp.email,
+		NameIDFormat:   string(saml.EmailAddressNameIDFormat),
+		UserEmail:      idp.email,
+		UserCommonName: idp.name,
+	}
+}
+
+// spMetadataFetcher mengambil metadata SP dari entity ID-nya, yang pada API
+// ini selalu berupa URL metadata SP tenant.
+type spMetadataFetcher struct{}
+
+func (spMetadataFetcher) GetServiceProvider(r *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	if !strings.HasPrefix(serviceProviderID, "http://") && !strings.HasPrefix(serviceProviderID, "https://") {
+		return nil, os.ErrNotExist
+	}
+
+	resp, err := http.Get(serviceProviderID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("gagal mengambil metadata SP: status " + resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var descriptor saml.EntityDescriptor
+	if err := xml.Unmarshal(body, &descriptor); err != nil {
+		return nil, fmt.Errorf("metadata SP tidak valid: %w", err)
+	}
+	return &descriptor, nil
+}
+
+// ------------------------------------------------------------------------
+// Helper
+// ------------------------------------------------------------------------
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,132 @@
+package mockidp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testRedirectURI = "http://api.test/sso/acme/oidc/callback"
+
+func newTestIDP(t *testing.T) *IDP {
+	t.Helper()
+	idp, err := New(Config{
+		Issuer:       "http://idp.test",
+		ClientID:     "hris",
+		ClientSecret: "secret",
+		Email:        "admin@example.com",
+	})
+	if err != nil {
+		t.Fatalf("gagal membuat mock IdP: %v", err)
+	}
+	return idp
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authorize mengembalikan code dari redirect, atau "" jika IdP menolak.
+func authorize(t *testing.T, idp *IDP, q url.Values) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	idp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/authorize?"+q.Encode(), nil))
+	if rec.Code != http.StatusFound {
+		return rec.Code, ""
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("Location tidak valid: %v", err)
+	}
+	return rec.Code, location.Query().Get("code")
+}
+
+func authorizeQuery(verifier string) url.Values {
+	return url.Values{
+		"client_id":             {"hris"},
+		"redirect_uri":          {testRedirectURI},
+		"response_type":         {"code"},
+		"state":                 {"state-1"},
+		"code_challenge":        {challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+func TestAuthorizeRequiresPKCE(t *testing.T) {
+	idp := newTestIDP(t)
+
+	tests := []struct {
+		name   string
+		modify func(q url.Values)
+	}{
+		{"tanpa code_challenge", func(q url.Values) { q.Del("code_challenge") }},
+		{"method plain", func(q url.Values) { q.Set("code_challenge_method", "plain") }},
+		{"client_id tidak dikenal", func(q url.Values) { q.Set("client_id", "lain") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := authorizeQuery("verifier")
+			tt.modify(q)
+			if status, _ := authorize(t, idp, q); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", status)
+			}
+		})
+	}
+}
+
+func TestTokenChecksCodeVerifierAndRedirectURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		verifier    string
+		redirectURI string
+		want        string
+	}{
+		{"valid", "verifier-asli", testRedirectURI, ""},
+		{"code_verifier salah", "verifier-lain", testRedirectURI, "code_verifier tidak cocok"},
+		{"redirect_uri berbeda", "verifier-asli", "http://evil.test/callback", "redirect_uri tidak cocok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newTestIDP(t)
+			_, code := authorize(t, idp, authorizeQuery("verifier-asli"))
+			if code == "" {
+				t.Fatal("authorize tidak mengembalikan code")
+			}
+
+			form := url.Values{
+				"grant_type":    {"authorization_code"},
+				"client_id":     {"hris"},
+				"client_secret": {"secret"},
+				"code":          {code},
+				"redirect_uri":  {tt.redirectURI},
+				"code_verifier": {tt.verifier},
+			}
+			req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			idp.Handler().ServeHTTP(rec, req)
+
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("respons token bukan JSON: %v", err)
+			}
+			if tt.want == "" {
+				if rec.Code != http.StatusOK || body["id_token"] == nil {
+					t.Errorf("status = %d, body = %v, want id_token", rec.Code, body)
+				}
+				return
+			}
+			if rec.Code != http.StatusBadRequest || body["error_description"] != tt.want {
+				t.Errorf("status = %d, body = %v, want 400 %q", rec.Code, body, tt.want)
+			}
+		})
+	}
+}
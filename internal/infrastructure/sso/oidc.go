@@ -0,0 +1,134 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCParams adalah konfigurasi client OIDC satu tenant.
+type OIDCParams struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// OIDC menjalankan alur authorization code + PKCE. Dokumen discovery
+// (.well-known/openid-configuration) di-cache per issuer.
+type OIDC struct {
+	mu        sync.Mutex
+	providers map[string]*oidc.Provider
+}
+
+func NewOIDC() *OIDC {
+	return &OIDC{
+		providers: make(map[string]*oidc.Provider),
+	}
+}
+
+// Discover memastikan issuer bisa dihubungi dan dokumen discovery-nya valid.
+func (o *OIDC) Discover(issuer string) error {
+	_, err := o.provider(issuer)
+	return err
+}
+
+// AuthCodeURL membuat URL redirect ke IdP dengan state, nonce, dan PKCE
+// challenge (S256) dari verifier.
+func (o *OIDC) AuthCodeURL(ctx context.Context, p OIDCParams, state, nonce, verifier string) (string, error) {
+	provider, err := o.provider(p.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	return o.oauthConfig(provider, p).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange menukar authorization code dengan token, lalu memverifikasi ID
+// token (tanda tangan JWKS, issuer, audience, kedaluwarsa, dan nonce).
+func (o *OIDC) Exchange(ctx context.Context, p OIDCParams, code, verifier, nonce string) (*Identity, error) {
+	provider, err := o.provider(p.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := o.oauthConfig(provider, p).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("gagal menukar authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("IdP tidak mengirim id_token")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("id_token tidak valid: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("nonce id_token tidak cocok")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified *bool  `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("gagal membaca klaim id_token: %w", err)
+	}
+
+	if claims.Email == "" {
+		return nil, errors.New("id_token tidak memuat email")
+	}
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return nil, errors.New("email belum diverifikasi oleh IdP")
+	}
+
+	return &Identity{
+		Email: strings.ToLower(strings.TrimSpace(claims.Email)),
+		Name:  claims.Name,
+	}, nil
+}
+
+func (o *OIDC) oauthConfig(provider *oidc.Provider, p OIDCParams) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.RedirectURL,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+}
+
+func (o *OIDC) provider(issuer string) (*oidc.Provider, error) {
+	o.mu.Lock()
+	provider, ok := o.providers[issuer]
+	o.mu.Unlock()
+	if ok {
+		return provider, nil
+	}
+
+	// Discovery memakai context sendiri: go-oidc menyimpan context ini untuk
+	// mengambil ulang JWKS, jadi tidak boleh ikut batal bersama request.
+	discoverCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	provider, err := oidc.NewProvider(discoverCtx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca konfigurasi OIDC issuer %s: %w", issuer, err)
+	}
+
+	o.mu.Lock()
+	o.providers[issuer] = provider
+	o.mu.Unlock()
+
+	return provider, nil
+}
@@ -0,0 +1,207 @@
+package sso
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+)
+
+// SAMLParams adalah konfigurasi Service Provider SAML satu tenant.
+type SAMLParams struct {
+	EntityID       string
+	AcsURL         string
+	IDPMetadataXML string
+}
+
+// Atribut email yang umum dipakai Azure AD, Google Workspace, dan IdP lain.
+var samlEmailAttributes = []string{
+	"email",
+	"mail",
+	"emailaddress",
+	"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+	"urn:oid:0.9.2342.19200300.100.1.3",
+}
+
+var samlNameAttributes = []string{
+	"name",
+	"displayname",
+	"cn",
+	"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+	"http://schemas.microsoft.com/identity/claims/displayname",
+	"urn:oid:2.16.840.1.113730.3.1.241",
+	"urn:oid:2.5.4.3",
+}
+
+// ParseSAMLMetadata memvalidasi metadata IdP dan memastikan ada endpoint SSO
+// HTTP-Redirect.
+func ParseSAMLMetadata(data string) (*saml.EntityDescriptor, error) {
+	var descriptor saml.EntityDescriptor
+	if err := xml.Unmarshal([]byte(data), &descriptor); err != nil {
+		return nil, fmt.Errorf("metadata IdP tidak valid: %w", err)
+	}
+	if len(descriptor.IDPSSODescriptors) == 0 {
+		return nil, errors.New("metadata tidak memuat IDPSSODescriptor")
+	}
+
+	sp := saml.ServiceProvider{IDPMetadata: &descriptor}
+	if sp.GetSSOBindingLocation(saml.HTTPRedirectBinding) == "" {
+		return nil, errors.New("IdP tidak mendukung binding HTTP-Redirect")
+	}
+	return &descriptor, nil
+}
+
+// FetchSAMLMetadata mengunduh metadata IdP dari URL.
+func FetchSAMLMetadata(ctx context.Context, metadataURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gagal mengunduh metadata IdP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gagal mengunduh metadata IdP: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := ParseSAMLMetadata(string(body)); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// SAMLMetadata menghasilkan metadata SP untuk didaftarkan di IdP.
+func SAMLMetadata(p SAMLParams) ([]byte, error) {
+	sp, err := serviceProvider(p, false)
+	if err != nil {
+		return nil, err
+	}
+	return xml.MarshalIndent(sp.Metadata(), "", "  ")
+}
+
+// SAMLAuthnRedirect membuat AuthnRequest dan mengembalikan URL redirect ke
+// IdP beserta ID request-nya (dicocokkan dengan InResponseTo saat callback).
+func SAMLAuthnRedirect(p SAMLParams, relayState string) (string, string, error) {
+	sp, err := serviceProvider(p, true)
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := sp.MakeAuthenticationRequest(sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return "", "", err
+	}
+
+	redirectURL, err := req.Redirect(relayState, sp)
+	if err != nil {
+		return "", "", err
+	}
+	return redirectURL.String(), req.ID, nil
+}
+
+// ParseSAMLResponse memverifikasi SAMLResponse (base64, binding HTTP-POST):
+// tanda tangan IdP, audience, destination, masa berlaku, dan InResponseTo.
+func ParseSAMLResponse(p SAMLParams, samlResponse, requestID string) (*Identity, error) {
+	sp, err := serviceProvider(p, true)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, errors.New("SAMLResponse bukan base64 yang valid")
+	}
+
+	assertion, err := sp.ParseXMLResponse(raw, []string{requestID}, sp.AcsURL)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) && invalid.PrivateErr != nil {
+			return nil, fmt.Errorf("SAMLResponse tidak valid: %w", invalid.PrivateErr)
+		}
+		return nil, fmt.Errorf("SAMLResponse tidak valid: %w", err)
+	}
+
+	identity := &Identity{
+		Email: samlAttribute(assertion, samlEmailAttributes),
+		Name:  samlAttribute(assertion, samlNameAttributes),
+	}
+
+	if identity.Email == "" && assertion.Subject != nil && assertion.Subject.NameID != nil {
+		if strings.Contains(assertion.Subject.NameID.Value, "@") {
+			identity.Email = assertion.Subject.NameID.Value
+		}
+	}
+	if identity.Email == "" {
+		return nil, errors.New("assertion SAML tidak memuat email")
+	}
+
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+	return identity, nil
+}
+
+func samlAttribute(assertion *saml.Assertion, names []string) string {
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			for _, name := range names {
+				if !strings.EqualFold(attr.Name, name) && !strings.EqualFold(attr.FriendlyName, name) {
+					continue
+				}
+				for _, v := range attr.Values {
+					if value := strings.TrimSpace(v.Value); value != "" {
+						return value
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func serviceProvider(p SAMLParams, withIDP bool) (*saml.ServiceProvider, error) {
+	entityID, err := url.Parse(p.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(p.AcsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          p.EntityID,
+		MetadataURL:       *entityID,
+		AcsURL:            *acsURL,
+		AuthnNameIDFormat: saml.EmailAddressNameIDFormat,
+		AllowIDPInitiated: false,
+	}
+
+	if withIDP {
+		descriptor, err := ParseSAMLMetadata(p.IDPMetadataXML)
+		if err != nil {
+			return nil, err
+		}
+		sp.IDPMetadata = descriptor
+	}
+
+	return sp, nil
+}
@@ -0,0 +1,18 @@
+// Package sso berisi implementasi protokol single sign-on (OIDC dan SAML 2.0
+// sebagai Service Provider). Package ini tidak tahu soal tenant atau user;
+// hasilnya hanya Identity yang sudah diverifikasi dari IdP.
+package sso
+
+import "golang.org/x/oauth2"
+
+// Identity adalah data user yang sudah diverifikasi oleh IdP.
+type Identity struct {
+	Email string
+	Name  string
+}
+
+// RandomToken menghasilkan string acak URL-safe (32 byte) untuk state, nonce,
+// dan PKCE code verifier.
+func RandomToken() string {
+	return oauth2.GenerateVerifier()
+}
@@ -14,4 +14,6 @@ type AdminUserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.AdminUser, error)
 	Find(ctx context.Context, query util.PaginationQuery) ([]*entity.AdminUser, error)
 	Count(ctx context.Context, query util.PaginationQuery) (int64, error)
+	// HasPermission memeriksa hak akses admin lewat admin_roles.
+	HasPermission(ctx context.Context, adminID uuid.UUID, permission string) (bool, error)
 }
\ No newline at end of file
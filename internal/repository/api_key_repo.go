@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey, permissionIDs []uuid.UUID) error
+	FindAll(ctx context.Context, tenantID uuid.UUID) ([]*entity.APIKey, error)
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.APIKey, error)
+	// FindByHash mencari key yang belum dicabut. Masa berlaku dan allowlist IP
+	// diperiksa oleh usecase.
+	FindByHash(ctx context.Context, tenantID uuid.UUID, hash string) (*entity.APIKey, error)
+	// Update menyimpan nama, allowlist IP, masa berlaku, dan mengganti
+	// seluruh hak akses key.
+	Update(ctx context.Context, key *entity.APIKey, permissionIDs []uuid.UUID) error
+	// Rotate menyimpan key pengganti beserta hak aksesnya dan menandai key
+	// lama (replaced_by_id, expires_at, revoked_at) dalam satu transaksi.
+	Rotate(ctx context.Context, old *entity.APIKey, replacement *entity.APIKey, permissionIDs []uuid.UUID) error
+	Revoke(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+	Touch(ctx context.Context, id uuid.UUID, at time.Time, ip string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type CreditNoteRepository interface {
+	// Create memberi nomor credit note, menyimpannya beserta item, lalu mencatat
+	// kredit tenant atau refund (pembayaran negatif) dalam satu transaksi.
+	Create(ctx context.Context, creditNote *entity.CreditNote) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error)
+	FindByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entity.CreditNote, error)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// CustomFieldPrefix adalah awalan nama custom field pada filter dan sorting
+// list ("custom.<key>"), kolom impor, dan kolom ekspor.
+const CustomFieldPrefix = "custom."
+
+// Filter custom field pada query.Filters list entitas:
+//   - "custom.<key>": nilai sama dengan (multi_select: memuat pilihan ini)
+//   - "custom.<key>.min" dan "custom.<key>.max": rentang nilai number/date
+//
+// Nilai filter harus sudah bertipe sesuai tipe field (float64, bool, string
+// YYYY-MM-DD, atau []string); usecase yang mengubahnya dari query string.
+// Sorting memakai query.SortBy "custom.<key>".
+const (
+	CustomFieldFilterMin = ".min"
+	CustomFieldFilterMax = ".max"
+)
+
+// CustomFieldRepository mengelola definisi custom field. Nilainya disimpan di
+// kolom custom_fields tabel entitas (lihat entity.CustomFieldEntity.Table).
+type CustomFieldRepository interface {
+	Create(ctx context.Context, field *entity.CustomField) error
+	Update(ctx context.Context, field *entity.CustomField) error
+	// Delete melakukan soft delete definisi dan menghapus nilainya dari
+	// semua record entitas.
+	Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.CustomField, error)
+	// FindByEntity mengembalikan semua definisi untuk entitas, diurutkan
+	// berdasarkan sort_order lalu nama.
+	FindByEntity(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity) ([]*entity.CustomField, error)
+
+	// ValueExists memeriksa apakah record lain (selain excludeID) sudah
+	// memakai nilai field ini. Dipakai untuk aturan unique.
+	ValueExists(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, key string, value any, excludeID *uuid.UUID) (bool, error)
+	// HasDuplicateValues memeriksa apakah nilai field ini sudah ada yang sama
+	// di lebih dari satu record, sebelum field dijadikan unique.
+	HasDuplicateValues(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, key string) (bool, error)
+}
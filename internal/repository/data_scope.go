@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type dataScopeContextKey struct{}
+
+// WithDataScope menyimpan aturan data scope ke context. Repository yang
+// membaca data karyawan otomatis memfilter barisnya sesuai aturan ini.
+func WithDataScope(ctx context.Context, rule *entity.DataScopeRule) context.Context {
+	return context.WithValue(ctx, dataScopeContextKey{}, rule)
+}
+
+// DataScopeFromContext mengambil aturan data scope. Jika tidak ada (mis.
+// proses internal atau route superadmin), query tidak dibatasi data scope.
+func DataScopeFromContext(ctx context.Context) (*entity.DataScopeRule, bool) {
+	rule, ok := ctx.Value(dataScopeContextKey{}).(*entity.DataScopeRule)
+	return rule, ok && rule != nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// EmployeeDocumentFilter membatasi hasil EmployeeDocumentRepository.Find.
+// Field kosong tidak ikut difilter.
+type EmployeeDocumentFilter struct {
+	EmployeeID *uuid.UUID
+	// UserID membatasi ke dokumen milik karyawan dengan akun login ini
+	// (dokumen milik sendiri).
+	UserID       *uuid.UUID
+	DocumentType string
+	// ExpiringBefore membatasi ke dokumen yang kedaluwarsa paling lambat pada
+	// tanggal ini, termasuk yang sudah lewat.
+	ExpiringBefore *time.Time
+	IDs            []uuid.UUID
+}
+
+// EmployeeDocumentRepository mengelola metadata dokumen karyawan dan riwayat
+// versinya. File-nya sendiri disimpan lewat storage.Storage. Query baca
+// mengikuti data scope di context.
+type EmployeeDocumentRepository interface {
+	// Create menyimpan dokumen beserta versi pertamanya.
+	Create(ctx context.Context, document *entity.EmployeeDocument, version *entity.EmployeeDocumentVersion) error
+	// AddVersion menyimpan versi baru dan menjadikannya file terbaru dokumen.
+	// Nomor versi diisi di dalam transaksi.
+	AddVersion(ctx context.Context, document *entity.EmployeeDocument, version *entity.EmployeeDocumentVersion) error
+	// Update menyimpan jenis dokumen dan tanggal kedaluwarsa.
+	Update(ctx context.Context, document *entity.EmployeeDocument) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeDocument, error)
+	Find(ctx context.Context, tenantID uuid.UUID, filter EmployeeDocumentFilter, query util.PaginationQuery) ([]*entity.EmployeeDocument, error)
+	Count(ctx context.Context, tenantID uuid.UUID, filter EmployeeDocumentFilter) (int64, error)
+	FindVersions(ctx context.Context, tenantID, documentID uuid.UUID) ([]*entity.EmployeeDocumentVersion, error)
+	FindVersion(ctx context.Context, tenantID, documentID uuid.UUID, version int) (*entity.EmployeeDocumentVersion, error)
+
+	// FindReminderDue mengembalikan dokumen semua tenant yang kedaluwarsa
+	// antara from dan until dan belum diingatkan untuk tanggal tersebut.
+	FindReminderDue(ctx context.Context, from, until time.Time, limit int) ([]*entity.EmployeeDocument, error)
+	// MarkReminded mencatat bahwa pengingat untuk tanggal kedaluwarsa dokumen
+	// saat ini sudah dikirim.
+	MarkReminded(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// EmployeeImportRecord adalah satu baris impor yang sudah divalidasi. User dan
+// Employee diisi hanya untuk baris yang akan disimpan (bukan dry run dan tanpa
+// error).
+type EmployeeImportRecord struct {
+	Row      *entity.EmployeeImportRow
+	User     *entity.User
+	Employee *entity.Employee
+	// ManagerIDNumber dicari di dalam transaksi agar atasan yang diimpor pada
+	// baris sebelumnya ikut ditemukan.
+	ManagerIDNumber *string
+}
+
+// EmployeeImportLookups adalah data tenant yang dibutuhkan untuk memvalidasi
+// baris impor. Kunci map sudah dalam huruf kecil.
+type EmployeeImportLookups struct {
+	// Masters berisi ID data master per nama, dikelompokkan per field impor
+	// ("branch", "department", "position", ...).
+	Masters           map[string]map[string]uuid.UUID
+	Emails            map[string]bool
+	EmployeeIDNumbers map[string]bool
+}
+
+// EmployeeExportLookups berisi nama data master dan nomor induk karyawan per
+// ID, dipakai agar file ekspor memakai nilai yang sama dengan file impor.
+type EmployeeExportLookups struct {
+	// Masters dikelompokkan per field impor seperti EmployeeImportLookups.
+	Masters           map[string]map[uuid.UUID]string
+	EmployeeIDNumbers map[uuid.UUID]string
+}
+
+// EmployeeImportRepository mengelola job impor karyawan beserta baris-barisnya.
+type EmployeeImportRepository interface {
+	// Create menyimpan job beserta seluruh baris file.
+	Create(ctx context.Context, job *entity.EmployeeImport, rows []*entity.EmployeeImportRow) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeImport, error)
+	Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.EmployeeImport, error)
+	Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+	// FindRows mengembalikan laporan per baris, difilter per status jika
+	// status tidak kosong.
+	FindRows(ctx context.Context, tenantID, importID uuid.UUID, status entity.ImportRowStatus, query util.PaginationQuery) ([]*entity.EmployeeImportRow, error)
+	CountRows(ctx context.Context, tenantID, importID uuid.UUID, status entity.ImportRowStatus) (int64, error)
+
+	// Claim menandai job pending, atau job processing yang tidak bergerak
+	// sejak staleBefore, sebagai processing. Mengembalikan false jika job
+	// sudah diambil worker lain. Baris valid dari dry run yang terputus
+	// dikembalikan ke pending agar divalidasi ulang.
+	Claim(ctx context.Context, job *entity.EmployeeImport, staleBefore, at time.Time) (bool, error)
+	// FindResumable mengembalikan job semua tenant yang masih pending atau
+	// terhenti sejak staleBefore.
+	FindResumable(ctx context.Context, staleBefore time.Time, limit int) ([]*entity.EmployeeImport, error)
+	FindPendingRows(ctx context.Context, importID uuid.UUID, limit int) ([]*entity.EmployeeImportRow, error)
+	FindLookups(ctx context.Context, tenantID uuid.UUID) (*EmployeeImportLookups, error)
+	FindExportLookups(ctx context.Context, tenantID uuid.UUID) (*EmployeeExportLookups, error)
+
+	// ProcessChunk menyimpan karyawan dari record yang siap diimpor dalam satu
+	// transaksi (savepoint per baris, sehingga satu baris gagal tidak
+	// membatalkan baris lain), menyimpan status setiap baris, lalu menghitung
+	// ulang progres job. Mengembalikan EmployeeSeatLimitError (tanpa menyimpan
+	// apa pun) jika kuota tidak cukup untuk seluruh record siap impor.
+	ProcessChunk(ctx context.Context, job *entity.EmployeeImport, records []*EmployeeImportRecord, at time.Time) error
+	// Finish menyimpan status akhir job. Jika job gagal, baris yang masih
+	// pending ditandai failed dengan pesan error job.
+	Finish(ctx context.Context, job *entity.EmployeeImport, at time.Time) error
+}
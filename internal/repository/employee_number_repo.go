@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// ErrEmployeeNumberSchemeNotFound berarti tenant belum mengatur format nomor
+// induk karyawan dan format bawaan yang berlaku.
+var ErrEmployeeNumberSchemeNotFound = errors.New("employee number scheme not found")
+
+// EmployeeNumberRepository menyimpan format dan nomor urut nomor induk
+// karyawan otomatis.
+type EmployeeNumberRepository interface {
+	// FindScheme mengembalikan ErrEmployeeNumberSchemeNotFound jika tenant
+	// belum mengatur format.
+	FindScheme(ctx context.Context, tenantID uuid.UUID) (*entity.EmployeeNumberScheme, error)
+	SaveScheme(ctx context.Context, scheme *entity.EmployeeNumberScheme) error
+
+	// NextSequence menaikkan nomor urut lingkup 'scope' secara atomik dan
+	// mengembalikan nilai barunya (dimulai dari 1). Nomor yang sudah diambil
+	// tidak dikembalikan walaupun karyawannya gagal disimpan.
+	NextSequence(ctx context.Context, tenantID uuid.UUID, scope string, at time.Time) (int64, error)
+	// CurrentSequence mengembalikan nomor urut terakhir lingkup 'scope', atau
+	// 0 jika belum pernah dipakai.
+	CurrentSequence(ctx context.Context, tenantID uuid.UUID, scope string) (int64, error)
+	// NumberExists memeriksa (tanpa membedakan huruf besar/kecil) apakah
+	// nomor induk sudah dipakai karyawan tenant, termasuk yang sudah dihapus.
+	NumberExists(ctx context.Context, tenantID uuid.UUID, number string) (bool, error)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// ErrManagerCycle dikembalikan saat perubahan atasan membuat karyawan menjadi
+// atasan (langsung maupun tidak langsung) dari atasannya sendiri.
+var ErrManagerCycle = errors.New("perubahan atasan membentuk siklus pada garis pelaporan")
+
+// EmployeeFilterKeys adalah filter list karyawan berupa ID referensi. Nama
+// filter sama dengan nama kolom di tabel employees.
+var EmployeeFilterKeys = []string{
+	"branch_id",
+	"department_id",
+	"position_id",
+	"job_level_id",
+	"employment_status_id",
+	"sbu_id",
+	"manager_id",
+}
+
+// EmployeeRepository mengelola data induk karyawan (employees beserta profil
+// dan data keuangannya). Query baca mengikuti data scope di context.
+//
+// Filter list yang didukung (query.Filters): EmployeeFilterKeys, status
+// (active atau resigned), dan nilai custom field (lihat CustomFieldPrefix).
+// Sorting juga bisa memakai custom field.
+type EmployeeRepository interface {
+	// Create menyimpan akun login, karyawan, profil, dan data keuangan dalam
+	// satu transaksi. Mengembalikan EmployeeSeatLimitError jika kuota penuh.
+	Create(ctx context.Context, user *entity.User, employee *entity.Employee) error
+	// Update menyimpan data organisasi, profil, data keuangan, dan custom field karyawan,
+	// serta menyamakan nama akun login dengan nama lengkap di profil.
+	// Mengembalikan ErrManagerCycle jika atasan baru adalah bawahan karyawan.
+	Update(ctx context.Context, employee *entity.Employee) error
+	// Delete melakukan soft delete karyawan dan menonaktifkan akun login-nya.
+	Delete(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Employee, error)
+	Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Employee, error)
+	Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+
+	// FindChainOfCommand mengembalikan atasan karyawan berurutan dari atasan
+	// langsung (Level 1) sampai puncak.
+	FindChainOfCommand(ctx context.Context, tenantID, id uuid.UUID) ([]*entity.OrgChartNode, error)
+	// FindReports mengembalikan bawahan aktif karyawan, diurutkan per Level.
+	// maxDepth 1 berarti hanya bawahan langsung, 0 berarti tanpa batas.
+	FindReports(ctx context.Context, tenantID, id uuid.UUID, maxDepth int) ([]*entity.OrgChartNode, error)
+	// FindHierarchy mengembalikan semua karyawan aktif (tanpa susunan pohon)
+	// untuk bagan organisasi.
+	FindHierarchy(ctx context.Context, tenantID uuid.UUID) ([]*entity.OrgChartNode, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// EmployeeSeatLimitError dikembalikan repository yang menambah karyawan aktif
+// (create, impor, reaktivasi) jika kuota tenant tidak cukup. Kuota diperiksa
+// di dalam transaksi penyimpanan setelah baris tenant dikunci, sehingga
+// penambahan bersamaan tidak bisa melewati kuota.
+type EmployeeSeatLimitError struct {
+	Usage     *entity.EmployeeSeatUsage
+	Requested int
+}
+
+func (e *EmployeeSeatLimitError) Error() string {
+	return fmt.Sprintf("kuota karyawan tidak cukup: %d aktif dari kuota %d, tidak bisa menambah %d karyawan",
+		e.Usage.ActiveEmployees, e.Usage.Capacity(), e.Requested)
+}
+
+type EmployeeSeatRepository interface {
+	FindUsage(ctx context.Context, tenantID uuid.UUID, at time.Time) (*entity.EmployeeSeatUsage, error)
+	FindAllUsages(ctx context.Context, at time.Time) ([]*entity.EmployeeSeatUsage, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type FeatureRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*entity.Feature, error)
+	// FindEntitlements mengembalikan semua fitur yang aktif untuk tenant pada waktu 'at',
+	// gabungan dari feature_plan (via langganan) dan tenant_addons.
+	FindEntitlements(ctx context.Context, tenantID uuid.UUID, at time.Time) ([]entity.Entitlement, error)
+}
+
+type TenantAddonRepository interface {
+	Upsert(ctx context.Context, addon *entity.TenantAddon) error
+	Delete(ctx context.Context, tenantID, featureID uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, session *entity.ImpersonationSession) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ImpersonationSession, error)
+	// End mengakhiri sesi yang masih berjalan. Sesi yang sudah berakhir
+	// dibiarkan apa adanya.
+	End(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type InvoiceRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error)
+	FindByTenant(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Invoice, error)
+	CountByTenant(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+	// CreatePayment mencatat pembayaran dan otomatis menandai invoice 'paid' jika sudah lunas.
+	// Pembayaran ditolak jika melebihi sisa tagihan (total - credit note - pembayaran).
+	CreatePayment(ctx context.Context, payment *entity.InvoicePayment) error
+	SumPayments(ctx context.Context, invoiceID uuid.UUID) (float64, error)
+	SumAvailableCredits(ctx context.Context, tenantID uuid.UUID) (float64, error)
+	// ApplyCredits memakai saldo kredit tenant untuk invoice dan mengembalikan nominal yang terpakai.
+	ApplyCredits(ctx context.Context, invoiceID uuid.UUID) (float64, error)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// ErrJobChangeOutOfOrder dikembalikan saat perubahan jabatan berlaku sebelum
+// perubahan lain yang sudah tercatat atau terjadwal. Posisi di setiap baris
+// riwayat dihitung dari baris sebelumnya, jadi riwayat harus ditambah
+// berurutan.
+var ErrJobChangeOutOfOrder = errors.New("sudah ada perubahan jabatan dengan tanggal berlaku setelah tanggal ini, batalkan perubahan terjadwal terlebih dahulu")
+
+// EmployeeJobHistoryRepository mengelola riwayat jabatan karyawan.
+type EmployeeJobHistoryRepository interface {
+	// Create mencatat perubahan jabatan. Jika statusnya applied, posisi
+	// karyawan langsung diperbarui dalam transaksi yang sama.
+	Create(ctx context.Context, history *entity.EmployeeJobHistory) error
+	// Apply menerapkan perubahan terjadwal ke data karyawan dan menandainya
+	// applied.
+	Apply(ctx context.Context, history *entity.EmployeeJobHistory, at time.Time) error
+	// MarkFailed menandai perubahan terjadwal yang tidak bisa diterapkan.
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, at time.Time) error
+	// Cancel membatalkan perubahan yang masih terjadwal.
+	Cancel(ctx context.Context, tenantID, employeeID, id uuid.UUID, at time.Time) error
+	FindByID(ctx context.Context, tenantID, employeeID, id uuid.UUID) (*entity.EmployeeJobHistory, error)
+	// FindByEmployee mengembalikan seluruh riwayat karyawan, terbaru dulu.
+	FindByEmployee(ctx context.Context, tenantID, employeeID uuid.UUID) ([]*entity.EmployeeJobHistory, error)
+	// FindAsOf mengembalikan baris riwayat (applied atau scheduled) yang
+	// berlaku pada tanggal date.
+	FindAsOf(ctx context.Context, tenantID, employeeID uuid.UUID, date time.Time) (*entity.EmployeeJobHistory, error)
+	// FindDue mengembalikan perubahan terjadwal semua tenant yang sudah
+	// jatuh tempo pada tanggal date, terlama dulu.
+	FindDue(ctx context.Context, date time.Time, limit int) ([]*entity.EmployeeJobHistory, error)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// ErrTerminationExists dikembalikan saat karyawan sudah punya pengakhiran
+// hubungan kerja yang belum dibatalkan.
+var ErrTerminationExists = errors.New("karyawan sudah memiliki pengakhiran hubungan kerja yang aktif")
+
+// OffboardingRepository mengelola template checklist, pengakhiran hubungan
+// kerja, dan komponen pembayaran akhir.
+type OffboardingRepository interface {
+	CreateTemplate(ctx context.Context, template *entity.ChecklistTemplate) error
+	UpdateTemplate(ctx context.Context, template *entity.ChecklistTemplate) error
+	DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error
+	FindTemplateByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.ChecklistTemplate, error)
+	// FindTemplates mengembalikan template tenant, difilter per tipe jika
+	// checklistType tidak kosong.
+	FindTemplates(ctx context.Context, tenantID uuid.UUID, checklistType entity.ChecklistType) ([]*entity.ChecklistTemplate, error)
+
+	// Terminate mencatat pengakhiran hubungan kerja dalam satu transaksi:
+	// mengisi resign_date, membatalkan perubahan jabatan setelah hari kerja
+	// terakhir, membuat checklist dari template (jika ada), dan menandai
+	// komponen pembayaran akhir. Jika statusnya completed, login user
+	// langsung dinonaktifkan.
+	Terminate(ctx context.Context, termination *entity.EmployeeTermination, template *entity.ChecklistTemplate) error
+	// Complete menonaktifkan login karyawan dan menandai pengakhiran
+	// terjadwal sebagai completed.
+	Complete(ctx context.Context, termination *entity.EmployeeTermination, at time.Time) error
+	// Cancel membatalkan pengakhiran yang masih terjadwal beserta checklist
+	// dan komponen pembayaran akhirnya.
+	Cancel(ctx context.Context, tenantID, employeeID uuid.UUID, at time.Time) error
+	// FindByEmployee mengembalikan pengakhiran aktif karyawan lengkap dengan
+	// checklist dan komponen pembayaran akhir.
+	FindByEmployee(ctx context.Context, tenantID, employeeID uuid.UUID) (*entity.EmployeeTermination, error)
+	// FindDue mengembalikan pengakhiran terjadwal semua tenant yang sudah
+	// jatuh tempo pada tanggal date.
+	FindDue(ctx context.Context, date time.Time, limit int) ([]*entity.EmployeeTermination, error)
+
+	// UpdateChecklistTask menandai tugas ke-index pada checklist karyawan
+	// selesai atau belum, lalu menghitung ulang status checklist.
+	UpdateChecklistTask(ctx context.Context, tenantID, employeeID, checklistID uuid.UUID, index int, done bool, by *uuid.UUID, at time.Time) (*entity.EmployeeChecklist, error)
+
+	// FindFinalPayItems mengembalikan komponen pembayaran akhir tenant,
+	// difilter per status jika status tidak kosong.
+	FindFinalPayItems(ctx context.Context, tenantID uuid.UUID, status entity.FinalPayItemStatus) ([]*entity.FinalPayItem, error)
+	SettleFinalPayItem(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// OrgUnitInUseError dikembalikan saat data master yang akan dihapus masih
+// dipakai karyawan aktif dan tidak ada tujuan pemindahan.
+type OrgUnitInUseError struct {
+	Label           string
+	ActiveEmployees int64
+}
+
+func (e *OrgUnitInUseError) Error() string {
+	return fmt.Sprintf("%s masih dipakai %d karyawan aktif, pindahkan karyawan ke %s lain terlebih dahulu",
+		e.Label, e.ActiveEmployees, e.Label)
+}
+
+// OrganizationRepository mengelola data master organisasi: cabang dan
+// OrgUnit (departemen, jabatan, level jabatan, SBU, status kepegawaian).
+type OrganizationRepository interface {
+	CreateBranch(ctx context.Context, branch *entity.Branch) error
+	UpdateBranch(ctx context.Context, branch *entity.Branch) error
+	FindBranchByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Branch, error)
+	FindBranches(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Branch, error)
+	CountBranches(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+	// DeleteBranch melakukan soft delete cabang. Jika reassignTo diisi,
+	// karyawan aktif di cabang ini dipindahkan ke sana dalam transaksi yang
+	// sama; jika tidak dan masih ada karyawan aktif, OrgUnitInUseError
+	// dikembalikan. Mengembalikan jumlah karyawan yang dipindahkan.
+	DeleteBranch(ctx context.Context, tenantID, id uuid.UUID, reassignTo *uuid.UUID, at time.Time) (int64, error)
+
+	CreateUnit(ctx context.Context, unit *entity.OrgUnit) error
+	UpdateUnit(ctx context.Context, unit *entity.OrgUnit) error
+	FindUnitByID(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID) (*entity.OrgUnit, error)
+	FindUnits(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.OrgUnit, error)
+	CountUnits(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+	// DeleteUnit berperilaku sama dengan DeleteBranch.
+	DeleteUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID, reassignTo *uuid.UUID, at time.Time) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// ErrPasswordPolicyNotFound berarti tenant belum mengatur kebijakan password
+// dan kebijakan bawaan yang berlaku.
+var ErrPasswordPolicyNotFound = errors.New("password policy not found")
+
+type PasswordPolicyRepository interface {
+	// FindByTenant mengembalikan ErrPasswordPolicyNotFound jika tenant belum
+	// mengatur kebijakan.
+	FindByTenant(ctx context.Context, tenantID uuid.UUID) (*entity.PasswordPolicy, error)
+	Save(ctx context.Context, policy *entity.PasswordPolicy) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type PermissionRepository interface {
+	FindAll(ctx context.Context) ([]*entity.Permission, error)
+	FindByNames(ctx context.Context, names []string) ([]*entity.Permission, error)
+	FindDirectByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Permission, error)
+	// FindEffectiveGrants menggabungkan hak akses dari semua role user dan hak
+	// akses yang diberikan langsung ke user. Satu hak akses bisa muncul lebih
+	// dari sekali dengan data scope berbeda. User yang login-nya dinonaktifkan
+	// tidak memiliki hak akses apa pun.
+	FindEffectiveGrants(ctx context.Context, tenantID, userID uuid.UUID) ([]entity.PermissionGrant, error)
+	// FindUsersWithPermission mengembalikan user tenant yang login-nya aktif
+	// dan memiliki hak akses 'permission', lewat role maupun langsung.
+	FindUsersWithPermission(ctx context.Context, tenantID uuid.UUID, permission string) ([]*entity.User, error)
+	// SyncUserPermissions mengganti seluruh hak akses langsung milik user.
+	SyncUserPermissions(ctx context.Context, userID uuid.UUID, grants []entity.PermissionGrant) error
+}
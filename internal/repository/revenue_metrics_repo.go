@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type TenantMRR struct {
+	TenantID uuid.UUID
+	PlanID   uuid.UUID
+	PlanName string
+	MRR      float64
+}
+
+type RevenueMetricsRepository interface {
+	// FindMRRByTenant mengembalikan MRR per tenant dari langganan berbayar yang berlaku pada 'at'.
+	FindMRRByTenant(ctx context.Context, at time.Time) ([]TenantMRR, error)
+	CountTenantsByPlan(ctx context.Context, at time.Time) ([]entity.PlanTenantCount, error)
+	SumCollectedRevenue(ctx context.Context, from, to time.Time) (float64, error)
+	SumCreditNotes(ctx context.Context, from, to time.Time) (issued float64, refunded float64, err error)
+	CountTrialConversions(ctx context.Context, from, to time.Time) (started int, converted int, err error)
+	CountTenantsByStatus(ctx context.Context) (map[entity.StatusTenant]int, error)
+	FindOutstandingInvoices(ctx context.Context, asOf time.Time) ([]entity.OutstandingInvoice, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type RoleRepository interface {
+	// Create dan Update menyimpan role beserta daftar hak aksesnya
+	// (permission_role diganti seluruhnya).
+	Create(ctx context.Context, role *entity.Role, grants []entity.PermissionGrant) error
+	Update(ctx context.Context, role *entity.Role, grants []entity.PermissionGrant) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Role, error)
+	FindByName(ctx context.Context, tenantID uuid.UUID, name string) (*entity.Role, error)
+	Find(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Role, error)
+	Count(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) (int64, error)
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*entity.Role, error)
+	// SyncUserRoles mengganti seluruh role milik user. Role dari tenant lain diabaikan.
+	SyncUserRoles(ctx context.Context, tenantID, userID uuid.UUID, roleIDs []uuid.UUID) error
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+var (
+	ErrSCIMNotFound          = errors.New("resource SCIM tidak ditemukan")
+	ErrSCIMConflict          = errors.New("userName, externalId, atau displayName sudah dipakai")
+	ErrSCIMFilterUnsupported = errors.New("filter SCIM tidak didukung")
+)
+
+// SCIMFilter adalah filter SCIM (RFC 7644 3.4.2.2) yang sudah di-parse.
+type SCIMFilter struct {
+	// Op adalah "and", "or", "not", atau operator pembanding
+	// (eq, ne, co, sw, ew, gt, ge, lt, le, pr).
+	Op string
+	// Attribute adalah path atribut yang sudah dinormalisasi (huruf kecil,
+	// tanpa URN schema), mis. "username" atau "emails.value".
+	Attribute string
+	// Value berisi string, float64, bool, atau nil.
+	Value any
+	// Left dan Right diisi untuk "and"/"or"; "not" hanya memakai Left.
+	Left  *SCIMFilter
+	Right *SCIMFilter
+}
+
+// SCIMListQuery adalah parameter list resource SCIM. StartIndex dimulai dari 1.
+type SCIMListQuery struct {
+	Filter     *SCIMFilter
+	StartIndex int
+	Count      int
+}
+
+// SCIMRepository menyimpan token SCIM dan memetakan resource User/Group ke
+// tabel users, employees, roles, dan role_user.
+type SCIMRepository interface {
+	CreateToken(ctx context.Context, token *entity.SCIMToken) error
+	FindTokens(ctx context.Context, tenantID uuid.UUID) ([]*entity.SCIMToken, error)
+	// FindActiveTokenByHash mencari token tenant yang belum dicabut.
+	FindActiveTokenByHash(ctx context.Context, tenantID uuid.UUID, hash string) (*entity.SCIMToken, error)
+	TouchToken(ctx context.Context, id uuid.UUID, at time.Time) error
+	RevokeToken(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+
+	FindUsers(ctx context.Context, tenantID uuid.UUID, query SCIMListQuery) ([]*entity.SCIMUser, error)
+	CountUsers(ctx context.Context, tenantID uuid.UUID, filter *SCIMFilter) (int64, error)
+	FindUser(ctx context.Context, tenantID, id uuid.UUID) (*entity.SCIMUser, error)
+	// CreateUser menyimpan users, employees, dan employee_profiles dalam satu
+	// transaksi. Mengembalikan EmployeeSeatLimitError jika karyawan aktif
+	// melebihi kuota.
+	CreateUser(ctx context.Context, user *entity.SCIMUser) error
+	// UpdateUser menyimpan perubahan user dan data karyawannya, termasuk
+	// login_disabled_at dan resign_date. Mengembalikan EmployeeSeatLimitError
+	// jika karyawan yang sudah resign diaktifkan kembali saat kuota penuh.
+	UpdateUser(ctx context.Context, user *entity.SCIMUser) error
+	// DeleteUser menonaktifkan dan meng-soft delete user beserta karyawannya.
+	DeleteUser(ctx context.Context, tenantID, id uuid.UUID, at time.Time) error
+
+	FindGroups(ctx context.Context, tenantID uuid.UUID, query SCIMListQuery) ([]*entity.SCIMGroup, error)
+	CountGroups(ctx context.Context, tenantID uuid.UUID, filter *SCIMFilter) (int64, error)
+	FindGroup(ctx context.Context, tenantID, id uuid.UUID) (*entity.SCIMGroup, error)
+	// CreateGroup membuat role tanpa hak akses beserta anggotanya.
+	CreateGroup(ctx context.Context, group *entity.SCIMGroup) error
+	// UpdateGroup mengganti nama role dan seluruh anggotanya. Hak akses role
+	// tidak diubah.
+	UpdateGroup(ctx context.Context, group *entity.SCIMGroup) error
+	AddGroupMembers(ctx context.Context, tenantID, roleID uuid.UUID, userIDs []uuid.UUID) error
+	RemoveGroupMembers(ctx context.Context, tenantID, roleID uuid.UUID, userIDs []uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type SSORepository interface {
+	FindConfig(ctx context.Context, tenantID uuid.UUID) (*entity.SSOConfig, error)
+	// SaveConfig membuat atau mengganti konfigurasi SSO tenant.
+	SaveConfig(ctx context.Context, config *entity.SSOConfig) error
+	CreateLoginRequest(ctx context.Context, req *entity.SSOLoginRequest) error
+	// ConsumeLoginRequest menandai login request terpakai dan mengembalikannya.
+	// Request yang sudah terpakai atau kedaluwarsa tidak bisa dipakai lagi.
+	ConsumeLoginRequest(ctx context.Context, tenantID uuid.UUID, id string, at time.Time) (*entity.SSOLoginRequest, error)
+}
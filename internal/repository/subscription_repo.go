@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// ErrSubscriptionChanged dikembalikan ChangePlan jika langganan lama sudah
+// ditutup (dibatalkan atau berpindah paket) oleh proses lain.
+var ErrSubscriptionChanged = errors.New("langganan sudah berubah, muat ulang lalu coba lagi")
+
+type PlanRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Plan, error)
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.Subscription) error
+	FindCurrentByTenant(ctx context.Context, tenantID uuid.UUID) (*entity.Subscription, error)
+	Update(ctx context.Context, subscription *entity.Subscription) error
+	// Replace menutup langganan tenant yang masih berjalan (canceled + ends_at
+	// = next.StartedAt) lalu menyimpan next dalam satu transaksi.
+	Replace(ctx context.Context, next *entity.Subscription) error
+	// ChangePlan menyimpan perpindahan paket secara atomik. invoice dan credit
+	// boleh nil (paling banyak salah satunya terisi). Mengembalikan
+	// ErrSubscriptionChanged jika langganan lama sudah dibatalkan.
+	ChangePlan(ctx context.Context, current, next *entity.Subscription, invoice *entity.Invoice, credit *entity.BillingCredit) error
+}
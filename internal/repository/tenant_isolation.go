@@ -0,0 +1,19 @@
+package repository
+
+import "context"
+
+type platformAccessContextKey struct{}
+
+// WithPlatformAccess menandai context milik proses platform (route
+// superadmin, job terjadwal) yang memang perlu membaca data lintas tenant.
+// Tanpa tanda ini dan tanpa tenant di context, Row Level Security
+// menyembunyikan semua baris milik tenant.
+func WithPlatformAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, platformAccessContextKey{}, true)
+}
+
+// HasPlatformAccess melaporkan apakah context ditandai WithPlatformAccess.
+func HasPlatformAccess(ctx context.Context) bool {
+	allowed, _ := ctx.Value(platformAccessContextKey{}).(bool)
+	return allowed
+}
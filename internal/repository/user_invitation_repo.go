@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+type UserInvitationRepository interface {
+	// Create menyimpan undangan dan mencabut undangan lama milik user yang
+	// sama. Jika newUser tidak nil, user tersebut dibuat dalam transaksi yang sama.
+	Create(ctx context.Context, invitation *entity.UserInvitation, newUser *entity.User) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.UserInvitation, error)
+	// Accept menandai undangan diterima, menyimpan password user dan
+	// mengisi email_verified_at dalam satu transaksi.
+	Accept(ctx context.Context, invitation *entity.UserInvitation, passwordHash string, at time.Time) error
+}
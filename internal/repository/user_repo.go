@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// UserRepository mengelola akun staf tenant. Semua query dibatasi tenant_id
+// supaya user tenant lain tidak pernah ikut terbaca.
+type UserRepository interface {
+	FindByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*entity.User, error)
+	FindByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.User, error)
+	Create(ctx context.Context, user *entity.User) error
+	// FindScopeSubject mengambil posisi organisasi user (lewat data karyawan)
+	// sebagai acuan data scope.
+	FindScopeSubject(ctx context.Context, tenantID, userID uuid.UUID) (entity.DataScopeSubject, error)
+	// FindUnverified mengembalikan user yang email-nya belum terverifikasi.
+	// Jika ids kosong, semua user belum terverifikasi di tenant dikembalikan.
+	FindUnverified(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]*entity.User, error)
+	// FindPasswordHistory mengembalikan hash password lama, terbaru dulu.
+	FindPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+	// UpdatePassword menyimpan hash baru di user.Password, memindahkan hash
+	// lama ke riwayat, dan hanya menyisakan keepHistory riwayat terbaru.
+	UpdatePassword(ctx context.Context, user *entity.User, keepHistory int) error
+	// UpdatePasswordHash mengganti hash tanpa mengubah tanggal ganti password,
+	// dipakai saat hash lama di-upgrade ke cost bcrypt yang lebih tinggi.
+	UpdatePasswordHash(ctx context.Context, tenantID, userID uuid.UUID, hash string) error
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const (
+	// APIKeyKind adalah prefix plaintext API key ("hris_..."), dipakai
+	// middleware untuk membedakan API key dari JWT.
+	APIKeyKind = "hris"
+	// apiKeyTouchInterval membatasi update last_used_at seperti token SCIM.
+	apiKeyTouchInterval = time.Minute
+	// maxAPIKeyRotationGrace adalah batas waktu key lama masih berlaku
+	// setelah dirotasi, agar integrasi sempat mengganti key.
+	maxAPIKeyRotationGrace = 7 * 24 * time.Hour
+)
+
+var ErrAPIKeyIPNotAllowed = errors.New("IP tidak diizinkan memakai API key ini")
+
+type APIKeyInput struct {
+	Name        string
+	Permissions []string
+	// AllowedIPs berisi IP tunggal atau CIDR.
+	AllowedIPs []string
+	ExpiresAt  *time.Time
+}
+
+// APIKeyUsecase mengelola API key tenant untuk integrasi mesin-ke-mesin.
+// Plaintext key hanya dikembalikan saat dibuat atau dirotasi.
+type APIKeyUsecase struct {
+	apiKeyRepo     repository.APIKeyRepository
+	permissionRepo repository.PermissionRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository, permissionRepo repository.PermissionRepository) *APIKeyUsecase {
+	return &APIKeyUsecase{
+		apiKeyRepo:     apiKeyRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (uc *APIKeyUsecase) CreateKey(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, input APIKeyInput) (*entity.APIKey, string, error) {
+	permissionIDs, err := uc.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	allowedIPs, err := normalizeAllowedIPs(input.AllowedIPs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, "", errors.New("masa berlaku API key harus di masa depan")
+	}
+
+	key, secret, err := newAPIKey(tenantID, createdBy, now)
+	if err != nil {
+		return nil, "", err
+	}
+	key.Name = input.Name
+	key.AllowedIPs = allowedIPs
+	key.ExpiresAt = input.ExpiresAt
+
+	if err := uc.apiKeyRepo.Create(ctx, key, permissionIDs); err != nil {
+		return nil, "", fmt.Errorf("gagal menyimpan API key: %w", err)
+	}
+
+	key, err = uc.apiKeyRepo.FindByID(ctx, tenantID, key.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret.Plaintext, nil
+}
+
+func (uc *APIKeyUsecase) ListKeys(ctx context.Context, tenantID uuid.UUID) ([]*entity.APIKey, error) {
+	return uc.apiKeyRepo.FindAll(ctx, tenantID)
+}
+
+func (uc *APIKeyUsecase) GetKey(ctx context.Context, tenantID, id uuid.UUID) (*entity.APIKey, error) {
+	return uc.apiKeyRepo.FindByID(ctx, tenantID, id)
+}
+
+// UpdateKey mengubah nama, hak akses, allowlist IP, dan masa berlaku key.
+// Plaintext key tidak berubah.
+func (uc *APIKeyUsecase) UpdateKey(ctx context.Context, tenantID, id uuid.UUID, input APIKeyInput) (*entity.APIKey, error) {
+	key, err := uc.apiKeyRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, errors.New("API key sudah dicabut")
+	}
+
+	permissionIDs, err := uc.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedIPs, err := normalizeAllowedIPs(input.AllowedIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("masa berlaku API key harus di masa depan")
+	}
+
+	key.Name = input.Name
+	key.AllowedIPs = allowedIPs
+	key.ExpiresAt = input.ExpiresAt
+
+	if err := uc.apiKeyRepo.Update(ctx, key, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	return uc.apiKeyRepo.FindByID(ctx, tenantID, id)
+}
+
+// RotateKey membuat key baru dengan nama, hak akses, allowlist, dan masa
+// berlaku yang sama. Key lama langsung dicabut, atau tetap berlaku selama
+// grace agar integrasi sempat mengganti key.
+func (uc *APIKeyUsecase) RotateKey(ctx context.Context, tenantID, id uuid.UUID, createdBy *uuid.UUID, grace time.Duration) (*entity.APIKey, string, error) {
+	if grace < 0 || grace > maxAPIKeyRotationGrace {
+		return nil, "", fmt.Errorf("masa tenggang rotasi maksimal %d jam", int(maxAPIKeyRotationGrace.Hours()))
+	}
+
+	old, err := uc.apiKeyRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if old.Status(now) != entity.APIKeyStatusActive {
+		return nil, "", errors.New("hanya API key aktif yang bisa dirotasi")
+	}
+
+	permissions, err := uc.permissionRepo.FindByNames(ctx, old.Permissions)
+	if err != nil {
+		return nil, "", err
+	}
+	permissionIDs := make([]uuid.UUID, len(permissions))
+	for i, p := range permissions {
+		permissionIDs[i] = p.ID
+	}
+
+	replacement, secret, err := newAPIKey(tenantID, createdBy, now)
+	if err != nil {
+		return nil, "", err
+	}
+	replacement.Name = old.Name
+	replacement.AllowedIPs = old.AllowedIPs
+	replacement.ExpiresAt = old.ExpiresAt
+
+	if grace == 0 {
+		old.RevokedAt = &now
+	} else if graceEnd := now.Add(grace); old.ExpiresAt == nil || graceEnd.Before(*old.ExpiresAt) {
+		old.ExpiresAt = &graceEnd
+	}
+
+	if err := uc.apiKeyRepo.Rotate(ctx, old, replacement, permissionIDs); err != nil {
+		return nil, "", fmt.Errorf("gagal merotasi API key: %w", err)
+	}
+
+	replacement, err = uc.apiKeyRepo.FindByID(ctx, tenantID, replacement.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return replacement, secret.Plaintext, nil
+}
+
+func (uc *APIKeyUsecase) RevokeKey(ctx context.Context, tenantID, id uuid.UUID) error {
+	return uc.apiKeyRepo.Revoke(ctx, tenantID, id, time.Now())
+}
+
+// Authenticate memvalidasi plaintext API key milik tenant: belum dicabut,
+// belum kedaluwarsa, dan dipakai dari IP yang diizinkan.
+func (uc *APIKeyUsecase) Authenticate(ctx context.Context, tenantID uuid.UUID, plaintext string, clientIP netip.Addr) (*entity.APIKey, error) {
+	if !strings.HasPrefix(plaintext, APIKeyKind+"_") {
+		return nil, errors.New("API key tidak valid")
+	}
+
+	key, err := uc.apiKeyRepo.FindByHash(ctx, tenantID, security.HashOpaqueToken(plaintext))
+	if err != nil {
+		return nil, errors.New("API key tidak valid")
+	}
+
+	now := time.Now()
+	if key.Status(now) != entity.APIKeyStatusActive {
+		return nil, errors.New("API key sudah kedaluwarsa")
+	}
+	if !clientIP.IsValid() || !key.AllowsIP(clientIP) {
+		return nil, ErrAPIKeyIPNotAllowed
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		// Gagal mencatat pemakaian tidak boleh menggagalkan request.
+		_ = uc.apiKeyRepo.Touch(ctx, key.ID, now, clientIP.Unmap().String())
+	}
+	return key, nil
+}
+
+func newAPIKey(tenantID uuid.UUID, createdBy *uuid.UUID, now time.Time) (*entity.APIKey, *security.OpaqueToken, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	secret, err := security.NewOpaqueToken(APIKeyKind)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &entity.APIKey{
+		ID:        id,
+		TenantID:  tenantID,
+		KeyPrefix: secret.Prefix,
+		KeyHash:   secret.Hash,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, secret, nil
+}
+
+// resolvePermissions mencocokkan nama hak akses dengan katalog dan menolak
+// hak akses yang tidak boleh dimiliki API key.
+func (uc *APIKeyUsecase) resolvePermissions(ctx context.Context, names []string) ([]uuid.UUID, error) {
+	if len(names) == 0 {
+		return nil, errors.New("API key minimal memiliki satu hak akses")
+	}
+
+	for _, name := range names {
+		if !entity.IsAPIKeyAssignable(name) {
+			return nil, fmt.Errorf("hak akses %s tidak bisa diberikan ke API key", name)
+		}
+	}
+
+	permissions, err := uc.permissionRepo.FindByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]uuid.UUID, len(permissions))
+	for _, p := range permissions {
+		known[p.Name] = p.ID
+	}
+
+	unknown := []string{}
+	ids := make([]uuid.UUID, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		id, ok := known[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("hak akses tidak dikenal: %s", strings.Join(unknown, ", "))
+	}
+	return ids, nil
+}
+
+// normalizeAllowedIPs mengubah setiap IP atau CIDR ke bentuk prefix kanonik
+// (IP tunggal menjadi /32 atau /128).
+func normalizeAllowedIPs(values []string) ([]string, error) {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+
+		var prefix netip.Prefix
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("CIDR %s tidak valid", v)
+			}
+			prefix = p.Masked()
+		} else {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("IP %s tidak valid", v)
+			}
+			addr = addr.Unmap()
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+
+		if s := prefix.String(); !seen[s] {
+			seen[s] = true
+			normalized = append(normalized, s)
+		}
+	}
+	return normalized, nil
+}
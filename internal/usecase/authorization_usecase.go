@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const defaultPermissionCacheTTL = 5 * time.Minute
+
+type permissionCacheEntry struct {
+	tenantID  uuid.UUID
+	scopes    map[string][]entity.DataScope
+	names     []string
+	subject   entity.DataScopeSubject
+	expiresAt time.Time
+}
+
+// AuthorizationUsecase menghitung hak akses efektif user (gabungan role dan
+// hak akses langsung) beserta data scope-nya. Hasilnya di-cache per user dan
+// di-invalidate setiap kali role, hak akses role, atau hak akses user berubah.
+type AuthorizationUsecase struct {
+	permissionRepo repository.PermissionRepository
+	userRepo       repository.UserRepository
+	ttl            time.Duration
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]permissionCacheEntry
+}
+
+func NewAuthorizationUsecase(permissionRepo repository.PermissionRepository, userRepo repository.UserRepository) *AuthorizationUsecase {
+	return &AuthorizationUsecase{
+		permissionRepo: permissionRepo,
+		userRepo:       userRepo,
+		ttl:            defaultPermissionCacheTTL,
+		cache:          make(map[uuid.UUID]permissionCacheEntry),
+	}
+}
+
+func (uc *AuthorizationUsecase) HasPermission(ctx context.Context, tenantID, userID uuid.UUID, permission string) (bool, error) {
+	entry, err := uc.load(ctx, tenantID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := entry.scopes[permission]
+	return ok, nil
+}
+
+// DataScopeFor mengembalikan aturan data scope user untuk satu hak akses, atau
+// nil jika user tidak memiliki hak akses tersebut.
+func (uc *AuthorizationUsecase) DataScopeFor(ctx context.Context, tenantID, userID uuid.UUID, permission string) (*entity.DataScopeRule, error) {
+	entry, err := uc.load(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, ok := entry.scopes[permission]
+	if !ok {
+		return nil, nil
+	}
+
+	return &entity.DataScopeRule{
+		TenantID:   tenantID,
+		Permission: permission,
+		Scopes:     scopes,
+		Subject:    entry.subject,
+	}, nil
+}
+
+func (uc *AuthorizationUsecase) EffectivePermissions(ctx context.Context, tenantID, userID uuid.UUID) ([]string, error) {
+	entry, err := uc.load(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.names, nil
+}
+
+// EffectiveDataScopes mengembalikan data scope per hak akses efektif user.
+func (uc *AuthorizationUsecase) EffectiveDataScopes(ctx context.Context, tenantID, userID uuid.UUID) (map[string][]entity.DataScope, error) {
+	entry, err := uc.load(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.scopes, nil
+}
+
+// InvalidateUser menghapus cache hak akses satu user. Juga perlu dipanggil
+// saat posisi organisasi karyawan (cabang, departemen, atasan) berubah.
+func (uc *AuthorizationUsecase) InvalidateUser(userID uuid.UUID) {
+	uc.mu.Lock()
+	delete(uc.cache, userID)
+	uc.mu.Unlock()
+}
+
+// InvalidateTenant menghapus cache semua user di tenant, dipakai saat hak
+// akses sebuah role berubah.
+func (uc *AuthorizationUsecase) InvalidateTenant(tenantID uuid.UUID) {
+	uc.mu.Lock()
+	for userID, entry := range uc.cache {
+		if entry.tenantID == tenantID {
+			delete(uc.cache, userID)
+		}
+	}
+	uc.mu.Unlock()
+}
+
+func (uc *AuthorizationUsecase) load(ctx context.Context, tenantID, userID uuid.UUID) (permissionCacheEntry, error) {
+	now := time.Now()
+
+	uc.mu.RLock()
+	entry, ok := uc.cache[userID]
+	uc.mu.RUnlock()
+	if ok && entry.tenantID == tenantID && now.Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	grants, err := uc.permissionRepo.FindEffectiveGrants(ctx, tenantID, userID)
+	if err != nil {
+		return permissionCacheEntry{}, err
+	}
+
+	subject, err := uc.userRepo.FindScopeSubject(ctx, tenantID, userID)
+	if err != nil {
+		return permissionCacheEntry{}, err
+	}
+
+	entry = permissionCacheEntry{
+		tenantID:  tenantID,
+		scopes:    make(map[string][]entity.DataScope),
+		names:     []string{},
+		subject:   subject,
+		expiresAt: now.Add(uc.ttl),
+	}
+	for _, g := range grants {
+		if _, exists := entry.scopes[g.Name]; !exists {
+			entry.names = append(entry.names, g.Name)
+		}
+		entry.scopes[g.Name] = append(entry.scopes[g.Name], g.DataScope)
+	}
+
+	uc.mu.Lock()
+	uc.cache[userID] = entry
+	uc.mu.Unlock()
+
+	return entry, nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const billingDocumentDateLayout = "02 Jan 2006"
+
+// Lebar kolom tabel item pada dokumen billing (font Courier, 80 karakter).
+const (
+	billingColDescription = 40
+	billingColQuantity    = 6
+	billingColAmount      = 16
+)
+
+func billingItemRow(description string, quantity int, unitPrice, totalPrice float64) string {
+	return util.PadRight(truncateText(description, billingColDescription-1), billingColDescription) +
+		util.PadLeft(fmt.Sprintf("%d", quantity), billingColQuantity) +
+		util.PadLeft(formatMoney(unitPrice), billingColAmount) +
+		util.PadLeft(formatMoney(totalPrice), billingColAmount)
+}
+
+func billingItemHeader() []string {
+	header := util.PadRight("Deskripsi", billingColDescription) +
+		util.PadLeft("Qty", billingColQuantity) +
+		util.PadLeft("Harga", billingColAmount) +
+		util.PadLeft("Total", billingColAmount)
+	return []string{header, strings.Repeat("-", len(header))}
+}
+
+func billingTotalRow(label string, amount float64) string {
+	width := billingColDescription + billingColQuantity + billingColAmount
+	return util.PadLeft(label, width) + util.PadLeft(formatMoney(amount), billingColAmount)
+}
+
+func renderInvoicePDF(tenant *entity.Tenant, invoice *entity.Invoice, paid float64, creditNotes []*entity.CreditNote) []byte {
+	doc := util.NewPDFDocument()
+	doc.AddLines(
+		"INVOICE",
+		"",
+		"No. Invoice   : "+invoice.ID.String(),
+		"Tanggal       : "+invoice.IssueDate.Format(billingDocumentDateLayout),
+		"Jatuh tempo   : "+invoice.DueDate.Format(billingDocumentDateLayout),
+		"Status        : "+strings.ToUpper(string(invoice.Status)),
+		"",
+		"Ditagihkan ke : "+tenant.Name,
+		"                "+tenant.CompanyEmail,
+		"",
+	)
+
+	doc.AddLines(billingItemHeader()...)
+	for _, item := range invoice.Items {
+		doc.AddLine(billingItemRow(item.Description, item.Quantity, item.UnitPrice, item.TotalPrice))
+	}
+	doc.AddLines(
+		"",
+		billingTotalRow("Total", invoice.TotalAmount),
+		billingTotalRow("Dibayar", paid),
+		billingTotalRow("Sisa tagihan", entity.RoundMoney(invoice.TotalAmount-paid)),
+	)
+
+	if len(creditNotes) > 0 {
+		doc.AddLines("", "Credit note:")
+		for _, cn := range creditNotes {
+			doc.AddLine(fmt.Sprintf("  %s  %s  %-6s  %s  %s",
+				cn.Number,
+				cn.IssueDate.Format(billingDocumentDateLayout),
+				cn.Resolution,
+				util.PadLeft(formatMoney(cn.TotalAmount), billingColAmount),
+				cn.Status,
+			))
+		}
+	}
+
+	return doc.Bytes()
+}
+
+func renderCreditNotePDF(tenant *entity.Tenant, creditNote *entity.CreditNote) []byte {
+	resolution := "Kredit untuk invoice berikutnya"
+	if creditNote.Resolution == entity.CreditNoteResolutionRefund {
+		resolution = "Refund"
+	}
+
+	doc := util.NewPDFDocument()
+	doc.AddLines(
+		"CREDIT NOTE",
+		"",
+		"No. Credit Note : "+creditNote.Number,
+		"Tanggal         : "+creditNote.IssueDate.Format(billingDocumentDateLayout),
+		"Invoice asal    : "+creditNote.InvoiceID.String(),
+		"Resolusi        : "+resolution,
+		"Status          : "+strings.ToUpper(string(creditNote.Status)),
+		"",
+		"Diterbitkan untuk : "+tenant.Name,
+		"                    "+tenant.CompanyEmail,
+		"",
+	)
+	if creditNote.Reason != "" {
+		doc.AddLines("Alasan: "+creditNote.Reason, "")
+	}
+
+	doc.AddLines(billingItemHeader()...)
+	for _, item := range creditNote.Items {
+		doc.AddLine(billingItemRow(item.Description, item.Quantity, item.UnitPrice, item.TotalPrice))
+	}
+	doc.AddLines("", billingTotalRow("Total kredit", creditNote.TotalAmount))
+
+	return doc.Bytes()
+}
+
+// formatMoney memformat nominal dengan pemisah ribuan gaya Indonesia, mis. 1.250.000,50.
+func formatMoney(amount float64) string {
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+
+	raw := fmt.Sprintf("%.2f", amount)
+	whole, fraction := raw[:len(raw)-3], raw[len(raw)-2:]
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+
+	result := b.String() + "," + fraction
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+func truncateText(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "~"
+}
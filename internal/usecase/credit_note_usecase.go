@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type CreditNoteItemInput struct {
+	Description string
+	Quantity    int
+	UnitPrice   float64
+}
+
+type IssueCreditNoteInput struct {
+	Reason     string
+	Resolution entity.CreditNoteResolution
+	Items      []CreditNoteItemInput
+	// IssuedBy adalah admin yang menerbitkan credit note.
+	IssuedBy uuid.UUID
+}
+
+type CreditNoteUsecase struct {
+	creditNoteRepo repository.CreditNoteRepository
+	invoiceRepo    repository.InvoiceRepository
+	tenantRepo     repository.TenantRepository
+}
+
+func NewCreditNoteUsecase(creditNoteRepo repository.CreditNoteRepository, invoiceRepo repository.InvoiceRepository, tenantRepo repository.TenantRepository) *CreditNoteUsecase {
+	return &CreditNoteUsecase{
+		creditNoteRepo: creditNoteRepo,
+		invoiceRepo:    invoiceRepo,
+		tenantRepo:     tenantRepo,
+	}
+}
+
+// IssueCreditNote menerbitkan credit note untuk invoice yang sudah lunas.
+// Resolusi 'credit' menambah saldo kredit tenant untuk invoice berikutnya,
+// sedangkan 'refund' dicatat sebagai pembayaran negatif pada invoice asal.
+func (uc *CreditNoteUsecase) IssueCreditNote(ctx context.Context, invoiceID uuid.UUID, input IssueCreditNoteInput) (*entity.CreditNote, error) {
+	invoice, err := uc.invoiceRepo.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != entity.StatusInvoicePaid {
+		return nil, errors.New("credit note hanya bisa diterbitkan untuk invoice yang sudah lunas")
+	}
+
+	if input.Resolution != entity.CreditNoteResolutionCredit && input.Resolution != entity.CreditNoteResolutionRefund {
+		return nil, errors.New("resolusi credit note tidak valid")
+	}
+	if len(input.Items) == 0 {
+		return nil, errors.New("credit note minimal memiliki satu item")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	creditNote := &entity.CreditNote{
+		ID:         id,
+		TenantID:   invoice.TenantID,
+		InvoiceID:  invoice.ID,
+		IssueDate:  now,
+		Reason:     input.Reason,
+		Resolution: input.Resolution,
+		Status:     entity.StatusCreditNoteIssued,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if input.IssuedBy != uuid.Nil {
+		creditNote.CreatedBy = &input.IssuedBy
+	}
+
+	for _, itemInput := range input.Items {
+		if itemInput.Quantity <= 0 || itemInput.UnitPrice <= 0 {
+			return nil, errors.New("jumlah dan harga item credit note harus lebih dari 0")
+		}
+
+		itemID, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+
+		total := entity.RoundMoney(float64(itemInput.Quantity) * itemInput.UnitPrice)
+		creditNote.Items = append(creditNote.Items, entity.CreditNoteItem{
+			ID:           itemID,
+			CreditNoteID: id,
+			Description:  itemInput.Description,
+			Quantity:     itemInput.Quantity,
+			UnitPrice:    itemInput.UnitPrice,
+			TotalPrice:   total,
+		})
+		creditNote.TotalAmount += total
+	}
+	creditNote.TotalAmount = entity.RoundMoney(creditNote.TotalAmount)
+
+	if err := uc.creditNoteRepo.Create(ctx, creditNote); err != nil {
+		return nil, err
+	}
+
+	return creditNote, nil
+}
+
+func (uc *CreditNoteUsecase) GetCreditNote(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error) {
+	return uc.creditNoteRepo.FindByID(ctx, id)
+}
+
+func (uc *CreditNoteUsecase) ListInvoiceCreditNotes(ctx context.Context, invoiceID uuid.UUID) ([]*entity.CreditNote, error) {
+	if _, err := uc.invoiceRepo.FindByID(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	return uc.creditNoteRepo.FindByInvoice(ctx, invoiceID)
+}
+
+func (uc *CreditNoteUsecase) GetCreditNotePDF(ctx context.Context, id uuid.UUID) (*entity.CreditNote, []byte, error) {
+	creditNote, err := uc.creditNoteRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tenant, err := uc.tenantRepo.FindByID(ctx, creditNote.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return creditNote, renderCreditNotePDF(tenant, creditNote), nil
+}
@@ -0,0 +1,541 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// ErrInvalidCustomFieldQuery dikembalikan saat filter atau sorting custom
+// field pada list tidak valid.
+var ErrInvalidCustomFieldQuery = errors.New("filter custom field tidak valid")
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type CustomFieldInput struct {
+	// EntityType, Key, dan Type hanya dipakai saat membuat field.
+	EntityType entity.CustomFieldEntity
+	Key        string
+	Type       entity.CustomFieldType
+
+	Name      string
+	Options   []string
+	Required  bool
+	Unique    bool
+	Min       *float64
+	Max       *float64
+	Pattern   *string
+	SortOrder int
+}
+
+// CustomFieldUsecase mengelola definisi custom field dan memvalidasi nilainya
+// sebelum disimpan bersama record entitas.
+type CustomFieldUsecase struct {
+	fieldRepo repository.CustomFieldRepository
+}
+
+func NewCustomFieldUsecase(fieldRepo repository.CustomFieldRepository) *CustomFieldUsecase {
+	return &CustomFieldUsecase{
+		fieldRepo: fieldRepo,
+	}
+}
+
+func (uc *CustomFieldUsecase) ListFields(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity) ([]*entity.CustomField, error) {
+	if entityType.Table() == "" {
+		return nil, fmt.Errorf("entitas '%s' tidak mendukung custom field", entityType)
+	}
+	return uc.fieldRepo.FindByEntity(ctx, tenantID, entityType)
+}
+
+func (uc *CustomFieldUsecase) GetField(ctx context.Context, tenantID, id uuid.UUID) (*entity.CustomField, error) {
+	return uc.fieldRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *CustomFieldUsecase) CreateField(ctx context.Context, tenantID uuid.UUID, input CustomFieldInput) (*entity.CustomField, error) {
+	if input.EntityType.Table() == "" {
+		return nil, fmt.Errorf("entitas '%s' tidak mendukung custom field", input.EntityType)
+	}
+	if !customFieldKeyPattern.MatchString(input.Key) {
+		return nil, errors.New("kunci custom field hanya boleh berisi huruf kecil, angka, dan garis bawah")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	field := &entity.CustomField{
+		ID:         id,
+		TenantID:   tenantID,
+		EntityType: input.EntityType,
+		Key:        input.Key,
+		Type:       input.Type,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := applyCustomFieldInput(field, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.fieldRepo.Create(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// UpdateField mengubah nama, pilihan, dan aturan field. Kunci dan tipe tidak
+// bisa diubah karena nilai yang sudah tersimpan bergantung pada keduanya.
+// Nilai lama yang tidak lagi memenuhi aturan baru harus diperbaiki saat
+// record tersebut disimpan berikutnya.
+func (uc *CustomFieldUsecase) UpdateField(ctx context.Context, tenantID, id uuid.UUID, input CustomFieldInput) (*entity.CustomField, error) {
+	field, err := uc.fieldRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	wasUnique := field.Unique
+	if err := applyCustomFieldInput(field, input); err != nil {
+		return nil, err
+	}
+
+	if field.Unique && !wasUnique {
+		duplicate, err := uc.fieldRepo.HasDuplicateValues(ctx, tenantID, field.EntityType, field.Key)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate {
+			return nil, errors.New("custom field tidak bisa dijadikan unik karena sudah ada nilai yang sama")
+		}
+	}
+
+	field.UpdatedAt = time.Now()
+	if err := uc.fieldRepo.Update(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// DeleteField menghapus definisi field beserta nilainya di semua record.
+func (uc *CustomFieldUsecase) DeleteField(ctx context.Context, tenantID, id uuid.UUID) error {
+	return uc.fieldRepo.Delete(ctx, tenantID, id, time.Now())
+}
+
+// applyCustomFieldInput memvalidasi aturan field sesuai tipenya lalu
+// menyalinnya ke field.
+func applyCustomFieldInput(field *entity.CustomField, input CustomFieldInput) error {
+	switch field.Type {
+	case entity.CustomFieldText, entity.CustomFieldNumber, entity.CustomFieldDate,
+		entity.CustomFieldBoolean, entity.CustomFieldSelect, entity.CustomFieldMultiSelect:
+	default:
+		return fmt.Errorf("tipe custom field '%s' tidak dikenal", field.Type)
+	}
+
+	options := []string{}
+	seen := map[string]bool{}
+	for _, option := range input.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return errors.New("pilihan custom field tidak boleh kosong")
+		}
+		// Koma dipakai sebagai pemisah pilihan multi_select di file impor
+		// dan ekspor.
+		if strings.Contains(option, ",") {
+			return fmt.Errorf("pilihan '%s' tidak boleh mengandung koma", option)
+		}
+		if seen[strings.ToLower(option)] {
+			return fmt.Errorf("pilihan '%s' duplikat", option)
+		}
+		seen[strings.ToLower(option)] = true
+		options = append(options, option)
+	}
+	if field.Type.HasOptions() && len(options) == 0 {
+		return errors.New("custom field select dan multi_select wajib memiliki pilihan")
+	}
+	if !field.Type.HasOptions() && len(options) > 0 {
+		return errors.New("pilihan hanya untuk custom field select dan multi_select")
+	}
+
+	if input.Min != nil || input.Max != nil {
+		switch field.Type {
+		case entity.CustomFieldText, entity.CustomFieldMultiSelect:
+			if (input.Min != nil && *input.Min < 0) || (input.Max != nil && *input.Max < 0) {
+				return errors.New("batas min/max tidak boleh negatif")
+			}
+		case entity.CustomFieldNumber:
+		default:
+			return errors.New("batas min/max hanya untuk custom field text, number, dan multi_select")
+		}
+		if input.Min != nil && input.Max != nil && *input.Min > *input.Max {
+			return errors.New("batas min tidak boleh lebih besar dari max")
+		}
+	}
+
+	var pattern *string
+	if input.Pattern != nil && *input.Pattern != "" {
+		if field.Type != entity.CustomFieldText {
+			return errors.New("regex hanya untuk custom field text")
+		}
+		if _, err := regexp.Compile(*input.Pattern); err != nil {
+			return fmt.Errorf("regex tidak valid: %v", err)
+		}
+		pattern = input.Pattern
+	}
+
+	if input.Unique && (field.Type == entity.CustomFieldBoolean || field.Type == entity.CustomFieldMultiSelect) {
+		return errors.New("aturan unik tidak berlaku untuk custom field boolean dan multi_select")
+	}
+
+	field.Name = input.Name
+	field.Options = options
+	field.Required = input.Required
+	field.Unique = input.Unique
+	field.Min = input.Min
+	field.Max = input.Max
+	field.Pattern = pattern
+	field.SortOrder = input.SortOrder
+	return nil
+}
+
+// ------------------------------------------------------------------------
+// Validasi nilai
+
+// ValidateValues memvalidasi nilai custom field sebuah record dan
+// mengembalikan nilai yang sudah dinormalkan sesuai tipenya. recordID diisi
+// saat record sudah ada agar nilainya sendiri tidak dianggap duplikat.
+func (uc *CustomFieldUsecase) ValidateValues(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, values map[string]any, recordID *uuid.UUID) (map[string]any, error) {
+	fields, err := uc.fieldRepo.FindByEntity(ctx, tenantID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, fieldErrors := normalizeCustomFieldValues(fields, values)
+	if len(fieldErrors) == 0 {
+		if fieldErrors, err = uc.checkUniqueValues(ctx, tenantID, entityType, fields, normalized, recordID); err != nil {
+			return nil, err
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return nil, errors.New(strings.Join(fieldErrors, " "))
+	}
+	return normalized, nil
+}
+
+// checkUniqueValues memeriksa aturan unik terhadap record lain di database.
+func (uc *CustomFieldUsecase) checkUniqueValues(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, fields []*entity.CustomField, values map[string]any, recordID *uuid.UUID) ([]string, error) {
+	fieldErrors := []string{}
+	for _, f := range fields {
+		value, ok := values[f.Key]
+		if !f.Unique || !ok {
+			continue
+		}
+		exists, err := uc.fieldRepo.ValueExists(ctx, tenantID, entityType, f.Key, value, recordID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			fieldErrors = append(fieldErrors, repository.CustomFieldPrefix+f.Key+": Nilai sudah dipakai.")
+		}
+	}
+	return fieldErrors, nil
+}
+
+// normalizeCustomFieldValues memvalidasi semua nilai terhadap definisinya.
+// Nilai kosong tidak disimpan; kunci yang tidak punya definisi ditolak.
+func normalizeCustomFieldValues(fields []*entity.CustomField, values map[string]any) (map[string]any, []string) {
+	normalized := map[string]any{}
+	fieldErrors := []string{}
+
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.Key] = true
+
+		value, err := normalizeCustomFieldValue(f, values[f.Key])
+		switch {
+		case err != nil:
+			fieldErrors = append(fieldErrors, repository.CustomFieldPrefix+f.Key+": "+err.Error())
+		case value == nil && f.Required:
+			fieldErrors = append(fieldErrors, repository.CustomFieldPrefix+f.Key+": Wajib diisi.")
+		case value != nil:
+			normalized[f.Key] = value
+		}
+	}
+
+	unknown := []string{}
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, repository.CustomFieldPrefix+key+": Custom field tidak dikenal.")
+		}
+	}
+	sort.Strings(unknown)
+
+	return normalized, append(fieldErrors, unknown...)
+}
+
+var customFieldBooleans = map[string]bool{
+	"true": true, "ya": true, "y": true, "1": true,
+	"false": false, "tidak": false, "t": false, "n": false, "0": false,
+}
+
+// normalizeCustomFieldValue mengubah nilai dari JSON request maupun teks
+// (file impor, query string) menjadi nilai bertipe yang disimpan: string,
+// float64, string tanggal YYYY-MM-DD, bool, atau []string untuk
+// multi_select. Mengembalikan nil jika nilai kosong.
+func normalizeCustomFieldValue(f *entity.CustomField, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if s, ok := value.(string); ok {
+		value = strings.TrimSpace(s)
+		if value == "" {
+			return nil, nil
+		}
+	}
+
+	switch f.Type {
+	case entity.CustomFieldText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Harus berupa teks.")
+		}
+		length := float64(utf8.RuneCountInString(s))
+		if f.Min != nil && length < *f.Min {
+			return nil, fmt.Errorf("Minimal %s karakter.", formatCustomFieldNumber(*f.Min))
+		}
+		if f.Max != nil && length > *f.Max {
+			return nil, fmt.Errorf("Maksimal %s karakter.", formatCustomFieldNumber(*f.Max))
+		}
+		if f.Pattern != nil {
+			re, err := regexp.Compile(*f.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("Regex custom field tidak valid: %v.", err)
+			}
+			if !re.MatchString(s) {
+				return nil, errors.New("Format tidak sesuai.")
+			}
+		}
+		return s, nil
+
+	case entity.CustomFieldNumber:
+		var n float64
+		switch v := value.(type) {
+		case float64:
+			n = v
+		case string:
+			parsed, err := parseImportNumber(v)
+			if err != nil {
+				return nil, errors.New("Harus berupa angka.")
+			}
+			n = parsed
+		default:
+			return nil, errors.New("Harus berupa angka.")
+		}
+		if f.Min != nil && n < *f.Min {
+			return nil, fmt.Errorf("Minimal %s.", formatCustomFieldNumber(*f.Min))
+		}
+		if f.Max != nil && n > *f.Max {
+			return nil, fmt.Errorf("Maksimal %s.", formatCustomFieldNumber(*f.Max))
+		}
+		return n, nil
+
+	case entity.CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Harus berupa tanggal.")
+		}
+		t, ok := parseImportDate(s)
+		if !ok {
+			return nil, errors.New("Format tanggal tidak valid (gunakan YYYY-MM-DD atau DD/MM/YYYY).")
+		}
+		return t.Format("2006-01-02"), nil
+
+	case entity.CustomFieldBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, ok := customFieldBooleans[strings.ToLower(v)]; ok {
+				return b, nil
+			}
+		}
+		return nil, errors.New("Harus bernilai true/false (atau ya/tidak).")
+
+	case entity.CustomFieldSelect:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Harus berupa teks.")
+		}
+		return matchCustomFieldOption(f, s)
+
+	case entity.CustomFieldMultiSelect:
+		var items []string
+		switch v := value.(type) {
+		case string:
+			items = strings.Split(v, ",")
+		case []string:
+			items = v
+		case []any:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, errors.New("Harus berupa daftar teks.")
+				}
+				items = append(items, s)
+			}
+		default:
+			return nil, errors.New("Harus berupa daftar teks.")
+		}
+
+		selected := []string{}
+		seen := map[string]bool{}
+		for _, item := range items {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			option, err := matchCustomFieldOption(f, item)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[option] {
+				seen[option] = true
+				selected = append(selected, option)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil
+		}
+		count := float64(len(selected))
+		if f.Min != nil && count < *f.Min {
+			return nil, fmt.Errorf("Pilih minimal %s.", formatCustomFieldNumber(*f.Min))
+		}
+		if f.Max != nil && count > *f.Max {
+			return nil, fmt.Errorf("Pilih maksimal %s.", formatCustomFieldNumber(*f.Max))
+		}
+		return selected, nil
+	}
+
+	return nil, fmt.Errorf("Tipe custom field '%s' tidak dikenal.", f.Type)
+}
+
+// matchCustomFieldOption mencocokkan nilai dengan pilihan tanpa membedakan
+// huruf besar kecil, dan mengembalikan pilihan sesuai penulisan di definisi.
+func matchCustomFieldOption(f *entity.CustomField, value string) (string, error) {
+	for _, option := range f.Options {
+		if strings.EqualFold(option, value) {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("'%s' bukan pilihan yang valid (%s).", value, strings.Join(f.Options, ", "))
+}
+
+func formatCustomFieldNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// formatCustomFieldValue mengubah nilai tersimpan menjadi teks untuk ekspor,
+// dengan format yang bisa dibaca kembali oleh impor.
+func formatCustomFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return formatCustomFieldNumber(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatCustomFieldValue(item))
+		}
+		return strings.Join(items, ", ")
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// ------------------------------------------------------------------------
+// Filter dan sorting list
+
+// ResolveListQuery menyiapkan filter dan sorting custom field pada query list
+// entitas (lihat repository.CustomFieldPrefix): nilai filter dari query string
+// diubah sesuai tipe field. Mengembalikan error yang membungkus
+// ErrInvalidCustomFieldQuery jika field tidak dikenal atau nilainya tidak
+// valid.
+func (uc *CustomFieldUsecase) ResolveListQuery(ctx context.Context, tenantID uuid.UUID, entityType entity.CustomFieldEntity, query *util.PaginationQuery) error {
+	sortKey, sortCustom := strings.CutPrefix(query.SortBy, repository.CustomFieldPrefix)
+	filterNames := []string{}
+	for name := range query.Filters {
+		if strings.HasPrefix(name, repository.CustomFieldPrefix) {
+			filterNames = append(filterNames, name)
+		}
+	}
+	if !sortCustom && len(filterNames) == 0 {
+		return nil
+	}
+
+	fields, err := uc.fieldRepo.FindByEntity(ctx, tenantID, entityType)
+	if err != nil {
+		return err
+	}
+	byKey := map[string]*entity.CustomField{}
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+
+	if sortCustom {
+		f, ok := byKey[sortKey]
+		if !ok {
+			return fmt.Errorf("%w: custom field '%s' tidak dikenal", ErrInvalidCustomFieldQuery, sortKey)
+		}
+		if f.Type == entity.CustomFieldMultiSelect {
+			return fmt.Errorf("%w: custom field multi_select tidak bisa dipakai untuk sorting", ErrInvalidCustomFieldQuery)
+		}
+	}
+
+	for _, name := range filterNames {
+		key := strings.TrimPrefix(name, repository.CustomFieldPrefix)
+		isRange := false
+		if k, ok := strings.CutSuffix(key, repository.CustomFieldFilterMin); ok {
+			key, isRange = k, true
+		} else if k, ok := strings.CutSuffix(key, repository.CustomFieldFilterMax); ok {
+			key, isRange = k, true
+		}
+
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("%w: custom field '%s' tidak dikenal", ErrInvalidCustomFieldQuery, key)
+		}
+		if isRange && f.Type != entity.CustomFieldNumber && f.Type != entity.CustomFieldDate {
+			return fmt.Errorf("%w: filter min/max hanya untuk custom field number dan date", ErrInvalidCustomFieldQuery)
+		}
+
+		raw, _ := query.Filters[name].(string)
+		// Filter memakai aturan tipe saja; aturan min/max/regex definisi
+		// hanya berlaku saat menyimpan.
+		typeOnly := &entity.CustomField{Type: f.Type, Options: f.Options}
+		value, err := normalizeCustomFieldValue(typeOnly, raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidCustomFieldQuery, name, err.Error())
+		}
+		if value == nil {
+			delete(query.Filters, name)
+			continue
+		}
+		query.Filters[name] = value
+	}
+	return nil
+}
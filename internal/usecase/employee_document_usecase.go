@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/mailer"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/storage"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const (
+	// EmployeeDocumentMaxFileSize adalah ukuran maksimal satu file dokumen.
+	EmployeeDocumentMaxFileSize = 20 << 20
+	// DefaultDocumentReminderDays dipakai jika jumlah hari pengingat tidak
+	// dikonfigurasi.
+	DefaultDocumentReminderDays = 30
+	documentReminderBatchSize   = 500
+)
+
+// DocumentFileInput adalah file yang diunggah untuk sebuah dokumen.
+type DocumentFileInput struct {
+	FileName string
+	// ContentType kosong berarti dideteksi dari isi file.
+	ContentType string
+	Content     []byte
+}
+
+type DocumentInput struct {
+	DocumentType string
+	ExpiryDate   *time.Time
+}
+
+// EmployeeDocumentUsecase mengelola brankas dokumen karyawan. HR (pemilik
+// manage:documents) mengelola dokumen karyawan dalam data scope-nya; setiap
+// karyawan bisa melihat dan mengunduh dokumennya sendiri.
+type EmployeeDocumentUsecase struct {
+	documentRepo   repository.EmployeeDocumentRepository
+	employeeRepo   repository.EmployeeRepository
+	permissionRepo repository.PermissionRepository
+	authorization  *AuthorizationUsecase
+	storage        storage.Storage
+	mailer         mailer.Mailer
+	appURL         string
+	// reminderDays adalah jumlah hari sebelum kedaluwarsa saat pengingat
+	// dikirim.
+	reminderDays int
+}
+
+func NewEmployeeDocumentUsecase(
+	documentRepo repository.EmployeeDocumentRepository,
+	employeeRepo repository.EmployeeRepository,
+	permissionRepo repository.PermissionRepository,
+	authorization *AuthorizationUsecase,
+	s storage.Storage,
+	m mailer.Mailer,
+	appURL string,
+	reminderDays int,
+) *EmployeeDocumentUsecase {
+	if reminderDays <= 0 {
+		reminderDays = DefaultDocumentReminderDays
+	}
+	return &EmployeeDocumentUsecase{
+		documentRepo:   documentRepo,
+		employeeRepo:   employeeRepo,
+		permissionRepo: permissionRepo,
+		authorization:  authorization,
+		storage:        s,
+		mailer:         m,
+		appURL:         appURL,
+		reminderDays:   reminderDays,
+	}
+}
+
+// documentStorageKey menyusun key storage per versi. Nama file asli hanya
+// disimpan di database agar key tidak bergantung pada input pengguna.
+func documentStorageKey(tenantID, documentID, versionID uuid.UUID) string {
+	return fmt.Sprintf("tenants/%s/documents/%s/%s", tenantID, documentID, versionID)
+}
+
+// storeDocumentFile menyimpan file ke storage dan menyiapkan data versinya.
+func (uc *EmployeeDocumentUsecase) storeDocumentFile(ctx context.Context, tenantID, documentID uuid.UUID, file DocumentFileInput, uploadedBy *uuid.UUID) (*entity.EmployeeDocumentVersion, error) {
+	if len(file.Content) == 0 {
+		return nil, errors.New("file tidak boleh kosong")
+	}
+	if len(file.Content) > EmployeeDocumentMaxFileSize {
+		return nil, fmt.Errorf("ukuran file maksimal %d MB", EmployeeDocumentMaxFileSize>>20)
+	}
+
+	fileName := strings.TrimSpace(filepath.Base(file.FileName))
+	if fileName == "" || fileName == "." || len(fileName) > 255 {
+		return nil, errors.New("nama file tidak valid")
+	}
+
+	contentType := file.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(file.Content)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	checksum := sha256.Sum256(file.Content)
+	version := &entity.EmployeeDocumentVersion{
+		ID:          id,
+		DocumentID:  documentID,
+		Version:     1,
+		FilePath:    documentStorageKey(tenantID, documentID, id),
+		FileName:    fileName,
+		ContentType: contentType,
+		FileSize:    int64(len(file.Content)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := uc.storage.Put(ctx, version.FilePath, bytes.NewReader(file.Content)); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// discardDocumentFile menghapus file yang sudah telanjur disimpan ketika
+// metadata-nya gagal disimpan.
+func (uc *EmployeeDocumentUsecase) discardDocumentFile(ctx context.Context, key string) {
+	if err := uc.storage.Delete(ctx, key); err != nil {
+		log.Printf("Gagal menghapus file dokumen %s: %v", key, err)
+	}
+}
+
+// Upload menyimpan dokumen baru untuk karyawan.
+func (uc *EmployeeDocumentUsecase) Upload(ctx context.Context, tenantID, employeeID uuid.UUID, uploadedBy *uuid.UUID, input DocumentInput, file DocumentFileInput) (*entity.EmployeeDocument, error) {
+	// Sekaligus memastikan karyawan berada dalam data scope pengunggah.
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	version, err := uc.storeDocumentFile(ctx, tenantID, id, file, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &entity.EmployeeDocument{
+		ID:           id,
+		TenantID:     tenantID,
+		EmployeeID:   employeeID,
+		DocumentType: input.DocumentType,
+		FilePath:     version.FilePath,
+		FileName:     version.FileName,
+		ContentType:  version.ContentType,
+		FileSize:     version.FileSize,
+		Version:      version.Version,
+		ExpiryDate:   input.ExpiryDate,
+		UploadedBy:   uploadedBy,
+		CreatedAt:    version.CreatedAt,
+		UpdatedAt:    version.CreatedAt,
+	}
+	if err := uc.documentRepo.Create(ctx, document, version); err != nil {
+		uc.discardDocumentFile(ctx, version.FilePath)
+		return nil, err
+	}
+
+	return uc.documentRepo.FindByID(ctx, tenantID, id)
+}
+
+// UploadVersion mengganti file dokumen dengan versi baru; versi lama tetap
+// bisa diunduh. expiryDate nil berarti tanggal kedaluwarsa tidak berubah.
+func (uc *EmployeeDocumentUsecase) UploadVersion(ctx context.Context, tenantID, id uuid.UUID, uploadedBy *uuid.UUID, expiryDate *time.Time, file DocumentFileInput) (*entity.EmployeeDocument, error) {
+	document, err := uc.documentRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := uc.storeDocumentFile(ctx, tenantID, id, file, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiryDate != nil {
+		document.ExpiryDate = expiryDate
+	}
+	if err := uc.documentRepo.AddVersion(ctx, document, version); err != nil {
+		uc.discardDocumentFile(ctx, version.FilePath)
+		return nil, err
+	}
+	return document, nil
+}
+
+func (uc *EmployeeDocumentUsecase) UpdateDocument(ctx context.Context, tenantID, id uuid.UUID, input DocumentInput) (*entity.EmployeeDocument, error) {
+	document, err := uc.documentRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	document.DocumentType = input.DocumentType
+	document.ExpiryDate = input.ExpiryDate
+	document.UpdatedAt = time.Now()
+
+	if err := uc.documentRepo.Update(ctx, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// DeleteDocument menghapus dokumen (soft delete). File semua versinya tetap
+// ada di storage sebagai arsip.
+func (uc *EmployeeDocumentUsecase) DeleteDocument(ctx context.Context, tenantID, id uuid.UUID) error {
+	if _, err := uc.documentRepo.FindByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+	return uc.documentRepo.Delete(ctx, tenantID, id, time.Now())
+}
+
+func (uc *EmployeeDocumentUsecase) GetDocument(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeDocument, error) {
+	return uc.documentRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *EmployeeDocumentUsecase) ListDocuments(ctx context.Context, tenantID uuid.UUID, filter repository.EmployeeDocumentFilter, query util.PaginationQuery) ([]*entity.EmployeeDocument, util.Pagination, error) {
+	documents, err := uc.documentRepo.Find(ctx, tenantID, filter, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.documentRepo.Count(ctx, tenantID, filter)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	return documents, query.CalculatePaginationMetadata(totalItems), nil
+}
+
+// ListExpiring adalah data dashboard dokumen yang sudah atau akan kedaluwarsa
+// dalam 'days' hari, diurutkan dari yang paling dekat. days <= 0 berarti
+// memakai jumlah hari pengingat.
+func (uc *EmployeeDocumentUsecase) ListExpiring(ctx context.Context, tenantID uuid.UUID, days int, query util.PaginationQuery) ([]*entity.EmployeeDocument, util.Pagination, error) {
+	if days <= 0 {
+		days = uc.reminderDays
+	}
+	until := truncateToDate(time.Now()).AddDate(0, 0, days)
+
+	query.SortBy = "expiry_date"
+	query.SortDir = "asc"
+	return uc.ListDocuments(ctx, tenantID, repository.EmployeeDocumentFilter{ExpiringBefore: &until}, query)
+}
+
+func (uc *EmployeeDocumentUsecase) ListVersions(ctx context.Context, tenantID, id uuid.UUID) ([]*entity.EmployeeDocumentVersion, error) {
+	if _, err := uc.documentRepo.FindByID(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+	return uc.documentRepo.FindVersions(ctx, tenantID, id)
+}
+
+// OpenDocument membuka file dokumen untuk diunduh. version 0 berarti versi
+// terbaru. Pemanggil wajib menutup reader.
+func (uc *EmployeeDocumentUsecase) OpenDocument(ctx context.Context, tenantID, id uuid.UUID, version int) (*entity.EmployeeDocumentVersion, io.ReadCloser, error) {
+	document, err := uc.documentRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return uc.openVersion(ctx, document, version)
+}
+
+func (uc *EmployeeDocumentUsecase) openVersion(ctx context.Context, document *entity.EmployeeDocument, version int) (*entity.EmployeeDocumentVersion, io.ReadCloser, error) {
+	if version <= 0 {
+		version = document.Version
+	}
+
+	v, err := uc.documentRepo.FindVersion(ctx, document.TenantID, document.ID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := uc.storage.Open(ctx, v.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, rc, nil
+}
+
+// ------------------------------------------------------------------------
+// Dokumen milik sendiri
+
+func (uc *EmployeeDocumentUsecase) ListOwnDocuments(ctx context.Context, tenantID, userID uuid.UUID, query util.PaginationQuery) ([]*entity.EmployeeDocument, util.Pagination, error) {
+	return uc.ListDocuments(ctx, tenantID, repository.EmployeeDocumentFilter{UserID: &userID}, query)
+}
+
+// OpenOwnDocument sama dengan OpenDocument, tetapi hanya untuk dokumen milik
+// karyawan dengan akun login userID.
+func (uc *EmployeeDocumentUsecase) OpenOwnDocument(ctx context.Context, tenantID, userID, id uuid.UUID, version int) (*entity.EmployeeDocumentVersion, io.ReadCloser, error) {
+	document, err := uc.documentRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if document.EmployeeUserID != userID {
+		return nil, nil, errors.New("employee document not found")
+	}
+	return uc.openVersion(ctx, document, version)
+}
+
+// ------------------------------------------------------------------------
+// Pengingat kedaluwarsa
+
+// ProcessExpiryReminders mengirim email ke karyawan dan HR untuk dokumen semua
+// tenant yang kedaluwarsa dalam reminderDays hari ke depan. HR (pemilik
+// manage:documents) menerima satu ringkasan berisi dokumen dalam data
+// scope-nya. Setiap tanggal kedaluwarsa hanya diingatkan sekali.
+func (uc *EmployeeDocumentUsecase) ProcessExpiryReminders(ctx context.Context, now time.Time) (int, error) {
+	today := truncateToDate(now)
+	until := today.AddDate(0, 0, uc.reminderDays)
+	reminded := 0
+
+	for {
+		due, err := uc.documentRepo.FindReminderDue(repository.WithPlatformAccess(ctx), today, until, documentReminderBatchSize)
+		if err != nil {
+			return reminded, err
+		}
+
+		byTenant := map[uuid.UUID][]*entity.EmployeeDocument{}
+		tenantOrder := []uuid.UUID{}
+		for _, d := range due {
+			if _, ok := byTenant[d.TenantID]; !ok {
+				tenantOrder = append(tenantOrder, d.TenantID)
+			}
+			byTenant[d.TenantID] = append(byTenant[d.TenantID], d)
+		}
+
+		for _, tenantID := range tenantOrder {
+			n, err := uc.remindTenant(security.WithTenantID(ctx, tenantID), tenantID, byTenant[tenantID], now)
+			if err != nil {
+				return reminded, err
+			}
+			reminded += n
+		}
+
+		if len(due) < documentReminderBatchSize {
+			return reminded, nil
+		}
+	}
+}
+
+func (uc *EmployeeDocumentUsecase) remindTenant(ctx context.Context, tenantID uuid.UUID, documents []*entity.EmployeeDocument, now time.Time) (int, error) {
+	ids := make([]uuid.UUID, 0, len(documents))
+	for _, d := range documents {
+		ids = append(ids, d.ID)
+	}
+
+	managers, err := uc.permissionRepo.FindUsersWithPermission(ctx, tenantID, entity.PermissionManageDocuments)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, manager := range managers {
+		rule, err := uc.authorization.DataScopeFor(ctx, tenantID, manager.ID, entity.PermissionManageDocuments)
+		if err != nil {
+			return 0, err
+		}
+		if rule == nil {
+			continue
+		}
+
+		visible, err := uc.documentRepo.Find(repository.WithDataScope(ctx, rule), tenantID,
+			repository.EmployeeDocumentFilter{IDs: ids},
+			util.PaginationQuery{Page: 1, Limit: len(ids), SortBy: "expiry_date", SortDir: "asc"})
+		if err != nil {
+			return 0, err
+		}
+		if len(visible) == 0 {
+			continue
+		}
+
+		if err := uc.mailer.Send(ctx, uc.managerReminderMessage(manager, visible, now)); err != nil {
+			log.Printf("Gagal mengirim pengingat dokumen ke %s: %v", manager.Email, err)
+		}
+	}
+
+	// Dokumen yang email ke karyawannya gagal dikirim akan dicoba lagi pada
+	// putaran berikutnya.
+	sent := []uuid.UUID{}
+	for _, d := range documents {
+		if err := uc.mailer.Send(ctx, uc.employeeReminderMessage(d, now)); err != nil {
+			log.Printf("Gagal mengirim pengingat dokumen ke %s: %v", d.EmployeeEmail, err)
+			continue
+		}
+		sent = append(sent, d.ID)
+	}
+
+	if err := uc.documentRepo.MarkReminded(ctx, tenantID, sent); err != nil {
+		return 0, err
+	}
+	return len(sent), nil
+}
+
+func (uc *EmployeeDocumentUsecase) employeeReminderMessage(d *entity.EmployeeDocument, now time.Time) mailer.Message {
+	body := fmt.Sprintf("Halo %s,\n\n"+
+		"Dokumen %s Anda (%s) akan kedaluwarsa pada %s (%d hari lagi). "+
+		"Segera serahkan dokumen terbaru ke HR.\n",
+		d.EmployeeName, d.DocumentType, d.FileName, d.ExpiryDate.Format("02 Jan 2006"), *d.DaysUntilExpiry(now))
+
+	return mailer.Message{
+		To:      []string{d.EmployeeEmail},
+		Subject: "Pengingat: dokumen " + d.DocumentType + " akan kedaluwarsa",
+		Body:    body,
+	}
+}
+
+func (uc *EmployeeDocumentUsecase) managerReminderMessage(manager *entity.User, documents []*entity.EmployeeDocument, now time.Time) mailer.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Halo %s,\n\nDokumen karyawan berikut akan kedaluwarsa:\n\n", manager.Name)
+	for _, d := range documents {
+		fmt.Fprintf(&b, "- %s: %s (%s), kedaluwarsa %s (%d hari lagi)\n",
+			d.EmployeeName, d.DocumentType, d.FileName, d.ExpiryDate.Format("02 Jan 2006"), *d.DaysUntilExpiry(now))
+	}
+	fmt.Fprintf(&b, "\nDaftar lengkap: %s/documents/expiring\n", uc.appURL)
+
+	return mailer.Message{
+		To:      []string{manager.Email},
+		Subject: fmt.Sprintf("Pengingat: %d dokumen karyawan akan kedaluwarsa", len(documents)),
+		Body:    b.String(),
+	}
+}
+
+// RunExpiryReminders adalah ScheduledJob untuk ProcessExpiryReminders.
+func (uc *EmployeeDocumentUsecase) RunExpiryReminders(ctx context.Context, now time.Time) {
+	reminded, err := uc.ProcessExpiryReminders(ctx, now)
+	if err != nil {
+		log.Printf("Gagal mengirim pengingat dokumen kedaluwarsa: %v", err)
+	} else if reminded > 0 {
+		log.Printf("Pengingat dokumen kedaluwarsa: %d dokumen diingatkan", reminded)
+	}
+}
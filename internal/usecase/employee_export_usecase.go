@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const (
+	employeeExportBatchSize = 500
+	// employeeExportMaxRows membatasi ukuran file ekspor yang dibuat di memori.
+	employeeExportMaxRows = 20000
+)
+
+// EmployeeExportUsecase mengekspor data karyawan ke CSV atau XLSX dengan
+// kolom yang sama seperti file impor (judul kolom = label field, termasuk
+// custom field), sehingga file ekspor bisa diubah lalu diimpor kembali.
+type EmployeeExportUsecase struct {
+	employeeRepo repository.EmployeeRepository
+	importRepo   repository.EmployeeImportRepository
+	customFields *CustomFieldUsecase
+}
+
+func NewEmployeeExportUsecase(employeeRepo repository.EmployeeRepository, importRepo repository.EmployeeImportRepository, customFields *CustomFieldUsecase) *EmployeeExportUsecase {
+	return &EmployeeExportUsecase{
+		employeeRepo: employeeRepo,
+		importRepo:   importRepo,
+		customFields: customFields,
+	}
+}
+
+// ExportEmployees memakai filter, pencarian, dan sorting yang sama dengan list
+// karyawan, dan mengikuti data scope di context. Page dan limit query
+// diabaikan.
+func (uc *EmployeeExportUsecase) ExportEmployees(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery, format entity.ImportFileFormat) ([]byte, error) {
+	if format != entity.ImportFormatCSV && format != entity.ImportFormatXLSX {
+		return nil, errors.New("format ekspor harus csv atau xlsx")
+	}
+
+	if err := uc.customFields.ResolveListQuery(ctx, tenantID, entity.CustomFieldEntityEmployee, &query); err != nil {
+		return nil, err
+	}
+	customFields, err := uc.customFields.ListFields(ctx, tenantID, entity.CustomFieldEntityEmployee)
+	if err != nil {
+		return nil, err
+	}
+	lookups, err := uc.importRepo.FindExportLookups(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.employeeRepo.Count(ctx, tenantID, query)
+	if err != nil {
+		return nil, err
+	}
+	if total > employeeExportMaxRows {
+		return nil, errors.New("data terlalu banyak untuk diekspor sekaligus (maksimal " +
+			strconv.Itoa(employeeExportMaxRows) + " karyawan), persempit dengan filter")
+	}
+
+	fields := withCustomImportFields(customFields)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.Label
+	}
+	records := [][]string{header}
+
+	query.Limit = employeeExportBatchSize
+	for query.Page = 1; ; query.Page++ {
+		employees, err := uc.employeeRepo.Find(ctx, tenantID, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range employees {
+			values := employeeExportValues(e, lookups)
+			record := make([]string, len(fields))
+			for i, f := range fields {
+				record[i] = values[f.Key]
+			}
+			records = append(records, record)
+		}
+		if len(employees) < query.Limit {
+			break
+		}
+	}
+
+	if format == entity.ImportFormatXLSX {
+		return util.WriteXLSX(records)
+	}
+
+	var buf bytes.Buffer
+	// BOM agar Excel membaca file sebagai UTF-8.
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// employeeExportValues mengubah data karyawan menjadi nilai per field impor.
+func employeeExportValues(e *entity.Employee, lookups *repository.EmployeeExportLookups) map[string]string {
+	values := map[string]string{
+		"email":              e.Email,
+		"employee_id_number": e.EmployeeIDNumber,
+		"full_name":          e.Profile.FullName,
+		"join_date":          e.JoinDate.Format("2006-01-02"),
+	}
+
+	masters := map[string]*uuid.UUID{
+		"branch":                               e.BranchID,
+		string(entity.OrgUnitDepartment):       e.DepartmentID,
+		string(entity.OrgUnitPosition):         e.PositionID,
+		string(entity.OrgUnitJobLevel):         e.JobLevelID,
+		string(entity.OrgUnitEmploymentStatus): e.EmploymentStatusID,
+		string(entity.OrgUnitSBU):              e.SBUID,
+	}
+	for field, id := range masters {
+		if id != nil {
+			values[field] = lookups.Masters[field][*id]
+		}
+	}
+	if e.ManagerID != nil {
+		values["manager"] = lookups.EmployeeIDNumbers[*e.ManagerID]
+	}
+	if e.Profile.DateOfBirth != nil {
+		values["date_of_birth"] = e.Profile.DateOfBirth.Format("2006-01-02")
+	}
+	if e.Financial.BasicSalary != nil {
+		values["basic_salary"] = strconv.FormatFloat(*e.Financial.BasicSalary, 'f', -1, 64)
+	}
+
+	optional := map[string]*string{
+		"nik_ktp":                 e.Profile.NIKKTP,
+		"place_of_birth":          e.Profile.PlaceOfBirth,
+		"gender":                  e.Profile.Gender,
+		"marital_status":          e.Profile.MaritalStatus,
+		"religion":                e.Profile.Religion,
+		"address_ktp":             e.Profile.AddressKTP,
+		"address_domicile":        e.Profile.AddressDomicile,
+		"emergency_contact_name":  e.Profile.EmergencyContactName,
+		"emergency_contact_phone": e.Profile.EmergencyContactPhone,
+		"bank_name":               e.Financial.BankName,
+		"bank_account_number":     e.Financial.BankAccountNumber,
+		"bank_account_holder":     e.Financial.BankAccountHolder,
+		"npwp":                    e.Financial.NPWP,
+		"ptkp_status":             e.Financial.PTKPStatus,
+		"bpjs_ketenagakerjaan_no": e.Financial.BPJSKetenagakerjaanNo,
+		"bpjs_kesehatan_no":       e.Financial.BPJSKesehatanNo,
+	}
+	for field, value := range optional {
+		if value != nil {
+			values[field] = *value
+		}
+	}
+
+	for key, value := range e.CustomFields {
+		values[repository.CustomFieldPrefix+key] = formatCustomFieldValue(value)
+	}
+	return values
+}
@@ -0,0 +1,832 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+const (
+	// EmployeeImportMaxFileSize adalah ukuran maksimal file impor.
+	EmployeeImportMaxFileSize = 10 << 20
+	employeeImportMaxRows     = 5000
+	// employeeImportChunkSize adalah jumlah baris yang disimpan per transaksi.
+	employeeImportChunkSize = 100
+	// employeeImportStaleAfter adalah lama job processing tanpa progres
+	// sebelum dianggap terputus dan boleh dilanjutkan worker lain.
+	employeeImportStaleAfter  = 10 * time.Minute
+	employeeImportResumeBatch = 20
+)
+
+// EmployeeImportField adalah satu field karyawan yang bisa diisi dari kolom
+// file impor. Selain daftar ini, kolom bisa dipetakan ke custom field karyawan
+// dengan kunci "custom.<key>".
+type EmployeeImportField struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+var EmployeeImportFields = []EmployeeImportField{
+	{"email", "Email", true},
+	// Nomor induk kosong dibuat otomatis dari format nomor induk tenant.
+	{"employee_id_number", "Nomor Induk Karyawan", false},
+	{"full_name", "Nama Lengkap", true},
+	{"join_date", "Tanggal Bergabung", true},
+	{"branch", "Cabang", false},
+	{"department", "Departemen", false},
+	{"position", "Jabatan", false},
+	{"job_level", "Level Jabatan", false},
+	{"employment_status", "Status Kepegawaian", false},
+	{"sbu", "SBU", false},
+	{"manager", "Nomor Induk Atasan", false},
+	{"nik_ktp", "NIK KTP", false},
+	{"place_of_birth", "Tempat Lahir", false},
+	{"date_of_birth", "Tanggal Lahir", false},
+	{"gender", "Jenis Kelamin", false},
+	{"marital_status", "Status Pernikahan", false},
+	{"religion", "Agama", false},
+	{"address_ktp", "Alamat KTP", false},
+	{"address_domicile", "Alamat Domisili", false},
+	{"emergency_contact_name", "Nama Kontak Darurat", false},
+	{"emergency_contact_phone", "Telepon Kontak Darurat", false},
+	{"bank_name", "Nama Bank", false},
+	{"bank_account_number", "Nomor Rekening", false},
+	{"bank_account_holder", "Nama Pemilik Rekening", false},
+	{"npwp", "NPWP", false},
+	{"ptkp_status", "Status PTKP", false},
+	{"bpjs_ketenagakerjaan_no", "No BPJS Ketenagakerjaan", false},
+	{"bpjs_kesehatan_no", "No BPJS Kesehatan", false},
+	{"basic_salary", "Gaji Pokok", false},
+}
+
+// employeeImportRecord dipakai untuk validasi format baris dengan aturan yang
+// sama seperti request pembuatan karyawan.
+type employeeImportRecord struct {
+	Email                 string `field:"email" validate:"required,email,max=255"`
+	EmployeeIDNumber      string `field:"employee_id_number" validate:"omitempty,max=255"`
+	FullName              string `field:"full_name" validate:"required,min=2,max=255,no_consecutive_spaces"`
+	JoinDate              string `field:"join_date" validate:"required"`
+	Manager               string `field:"manager" validate:"omitempty,max=255"`
+	NIKKTP                string `field:"nik_ktp" validate:"omitempty,nik"`
+	PlaceOfBirth          string `field:"place_of_birth" validate:"omitempty,max=255"`
+	MaritalStatus         string `field:"marital_status" validate:"omitempty,max=50"`
+	Religion              string `field:"religion" validate:"omitempty,max=50"`
+	EmergencyContactName  string `field:"emergency_contact_name" validate:"omitempty,max=255"`
+	EmergencyContactPhone string `field:"emergency_contact_phone" validate:"omitempty,max=50"`
+	BankName              string `field:"bank_name" validate:"omitempty,max=100"`
+	BankAccountNumber     string `field:"bank_account_number" validate:"omitempty,max=100"`
+	BankAccountHolder     string `field:"bank_account_holder" validate:"omitempty,max=255"`
+	NPWP                  string `field:"npwp" validate:"omitempty,npwp"`
+	PTKPStatus            string `field:"ptkp_status" validate:"omitempty,max=10"`
+	BPJSKetenagakerjaanNo string `field:"bpjs_ketenagakerjaan_no" validate:"omitempty,bpjs_ketenagakerjaan"`
+	BPJSKesehatanNo       string `field:"bpjs_kesehatan_no" validate:"omitempty,bpjs_kesehatan"`
+}
+
+func newEmployeeImportRecord(data map[string]string) *employeeImportRecord {
+	record := &employeeImportRecord{}
+	v := reflect.ValueOf(record).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).SetString(data[v.Type().Field(i).Tag.Get("field")])
+	}
+	return record
+}
+
+type EmployeeImportInput struct {
+	FileName string
+	Content  []byte
+	// Mapping kosong berarti kolom dipetakan otomatis dari judul kolom.
+	Mapping   map[string]string
+	DryRun    bool
+	CreatedBy *uuid.UUID
+}
+
+// EmployeeImportUsecase mengimpor karyawan massal dari file CSV atau XLSX.
+// File divalidasi formatnya saat diunggah, lalu setiap baris diproses job
+// latar per chunk: nama cabang/departemen/jabatan dicocokkan ke data master,
+// lalu baris valid disimpan (atau hanya divalidasi pada dry run).
+type EmployeeImportUsecase struct {
+	importRepo    repository.EmployeeImportRepository
+	employeeLimit *EmployeeLimitUsecase
+	customFields  *CustomFieldUsecase
+	numbers       *EmployeeNumberUsecase
+	validate      *validator.Validate
+}
+
+func NewEmployeeImportUsecase(importRepo repository.EmployeeImportRepository, employeeLimit *EmployeeLimitUsecase, customFields *CustomFieldUsecase, numbers *EmployeeNumberUsecase) *EmployeeImportUsecase {
+	validate := util.NewValidator()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return f.Tag.Get("field")
+	})
+
+	return &EmployeeImportUsecase{
+		importRepo:    importRepo,
+		employeeLimit: employeeLimit,
+		customFields:  customFields,
+		numbers:       numbers,
+		validate:      validate,
+	}
+}
+
+// ------------------------------------------------------------------------
+// Unggah file
+
+// CreateImport membaca file, memvalidasi format setiap baris, menyimpan job,
+// lalu menjalankan pemrosesan di latar belakang. Progres dipantau lewat
+// GetImport.
+func (uc *EmployeeImportUsecase) CreateImport(ctx context.Context, tenantID uuid.UUID, input EmployeeImportInput) (*entity.EmployeeImport, error) {
+	if len(input.Content) > EmployeeImportMaxFileSize {
+		return nil, fmt.Errorf("ukuran file maksimal %d MB", EmployeeImportMaxFileSize>>20)
+	}
+
+	var format entity.ImportFileFormat
+	var records [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(input.FileName)) {
+	case ".csv":
+		format = entity.ImportFormatCSV
+		records, err = readImportCSV(input.Content)
+	case ".xlsx":
+		format = entity.ImportFormatXLSX
+		records, err = util.ReadXLSX(input.Content)
+	default:
+		return nil, errors.New("format file harus CSV atau XLSX")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("file tidak memiliki baris data")
+	}
+	if len(records)-1 > employeeImportMaxRows {
+		return nil, fmt.Errorf("maksimal %d baris data per file", employeeImportMaxRows)
+	}
+
+	headers := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		headers[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+
+	fields, customFields, err := uc.ImportFields(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := input.Mapping
+	if len(mapping) == 0 {
+		mapping = autoImportMapping(headers, fields)
+	}
+	if err := validateImportMapping(headers, mapping, fields); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	job := &entity.EmployeeImport{
+		ID:        id,
+		TenantID:  tenantID,
+		FileName:  filepath.Base(input.FileName),
+		Format:    format,
+		Mapping:   mapping,
+		DryRun:    input.DryRun,
+		Status:    entity.ImportPending,
+		CreatedBy: input.CreatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	rows := []*entity.EmployeeImportRow{}
+	emails := map[string]int{}
+	numbers := map[string]int{}
+	// uniqueValues mencatat baris pertama setiap nilai custom field unik.
+	uniqueValues := map[string]map[string]int{}
+	for i, record := range records[1:] {
+		rowNumber := i + 2
+		data := map[string]string{}
+		for col, header := range headers {
+			field, ok := mapping[header]
+			if !ok || col >= len(record) {
+				continue
+			}
+			if value := strings.TrimSpace(record[col]); value != "" {
+				data[field] = value
+			}
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		rowID, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+		row := &entity.EmployeeImportRow{
+			ID:        rowID,
+			ImportID:  job.ID,
+			RowNumber: rowNumber,
+			Status:    entity.ImportRowPending,
+			Data:      data,
+		}
+
+		var parsed EmployeeInput
+		parsed, _, row.Errors = uc.parseImportRow(data, customFields, nil)
+		if email := strings.ToLower(data["email"]); email != "" {
+			if first, ok := emails[email]; ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("email: Duplikat dengan baris %d.", first))
+			} else {
+				emails[email] = rowNumber
+			}
+		}
+		if number := strings.ToLower(data["employee_id_number"]); number != "" {
+			if first, ok := numbers[number]; ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("employee_id_number: Duplikat dengan baris %d.", first))
+			} else {
+				numbers[number] = rowNumber
+			}
+		}
+		for _, f := range customFields {
+			value, ok := parsed.CustomFields[f.Key]
+			if !f.Unique || !ok {
+				continue
+			}
+			if uniqueValues[f.Key] == nil {
+				uniqueValues[f.Key] = map[string]int{}
+			}
+			formatted := formatCustomFieldValue(value)
+			if first, ok := uniqueValues[f.Key][formatted]; ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s%s: Duplikat dengan baris %d.", repository.CustomFieldPrefix, f.Key, first))
+			} else {
+				uniqueValues[f.Key][formatted] = rowNumber
+			}
+		}
+		if len(row.Errors) > 0 {
+			row.Status = entity.ImportRowInvalid
+			job.ProcessedRows++
+			job.FailedRows++
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("file tidak memiliki baris data")
+	}
+	job.TotalRows = len(rows)
+
+	if err := uc.importRepo.Create(ctx, job, rows); err != nil {
+		return nil, err
+	}
+
+	// Worker memakai salinan job agar respons tidak ikut berubah saat diproses.
+	worker := *job
+	go uc.runImport(security.WithTenantID(context.Background(), tenantID), &worker)
+
+	return job, nil
+}
+
+// readImportCSV membaca file CSV dengan pemisah koma atau titik koma (format
+// bawaan Excel berbahasa Indonesia).
+func readImportCSV(content []byte) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	firstLine := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		firstLine = content[:i]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records := [][]string{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("file CSV tidak valid: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// normalizeImportHeader menyamakan judul kolom, misalnya "Nama Lengkap" dan
+// "nama_lengkap".
+func normalizeImportHeader(h string) string {
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(h)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' {
+			if underscore && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	return sb.String()
+}
+
+// ImportFields mengembalikan field yang bisa dipakai sebagai tujuan mapping:
+// EmployeeImportFields ditambah custom field karyawan milik tenant.
+func (uc *EmployeeImportUsecase) ImportFields(ctx context.Context, tenantID uuid.UUID) ([]EmployeeImportField, []*entity.CustomField, error) {
+	customFields, err := uc.customFields.ListFields(ctx, tenantID, entity.CustomFieldEntityEmployee)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return withCustomImportFields(customFields), customFields, nil
+}
+
+// withCustomImportFields menambahkan custom field ke EmployeeImportFields.
+// Urutan ini juga menjadi urutan kolom file ekspor.
+func withCustomImportFields(customFields []*entity.CustomField) []EmployeeImportField {
+	fields := append([]EmployeeImportField{}, EmployeeImportFields...)
+	for _, f := range customFields {
+		fields = append(fields, EmployeeImportField{
+			Key:      repository.CustomFieldPrefix + f.Key,
+			Label:    f.Name,
+			Required: f.Required,
+		})
+	}
+	return fields
+}
+
+// autoImportMapping mencocokkan judul kolom dengan kunci atau label field.
+// Jika label custom field sama dengan label field bawaan, field bawaan yang
+// dipakai.
+func autoImportMapping(headers []string, fields []EmployeeImportField) map[string]string {
+	byName := map[string]string{}
+	for _, f := range fields {
+		for _, name := range []string{f.Key, normalizeImportHeader(f.Label)} {
+			if _, ok := byName[name]; !ok {
+				byName[name] = f.Key
+			}
+		}
+	}
+
+	mapping := map[string]string{}
+	used := map[string]bool{}
+	for _, h := range headers {
+		field, ok := byName[normalizeImportHeader(h)]
+		if ok && !used[field] {
+			mapping[h] = field
+			used[field] = true
+		}
+	}
+	return mapping
+}
+
+func validateImportMapping(headers []string, mapping map[string]string, fields []EmployeeImportField) error {
+	exists := map[string]bool{}
+	for _, h := range headers {
+		exists[h] = true
+	}
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.Key] = true
+	}
+
+	used := map[string]string{}
+	for header, field := range mapping {
+		if !exists[header] {
+			return fmt.Errorf("kolom '%s' pada mapping tidak ada di file", header)
+		}
+		if !known[field] {
+			return fmt.Errorf("field impor '%s' tidak dikenal", field)
+		}
+		if other, ok := used[field]; ok {
+			return fmt.Errorf("field '%s' dipetakan dari dua kolom ('%s' dan '%s')", field, other, header)
+		}
+		used[field] = header
+	}
+
+	missing := []string{}
+	for _, f := range fields {
+		if _, ok := used[f.Key]; f.Required && !ok {
+			missing = append(missing, f.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("kolom wajib belum dipetakan: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ------------------------------------------------------------------------
+// Parsing dan validasi baris
+
+var importDateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006", "02-01-2006", "2-1-2006"}
+
+// parseImportDate menerima tanggal YYYY-MM-DD, DD/MM/YYYY, DD-MM-YYYY, atau
+// nomor seri tanggal Excel.
+func parseImportDate(s string) (time.Time, bool) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return util.ParseExcelSerialDate(s)
+}
+
+var indonesianNumberPattern = regexp.MustCompile(`^\d{1,3}(\.\d{3})+(,\d+)?$`)
+
+// parseImportNumber menerima angka biasa ("5000000.5") maupun format
+// Indonesia ("5.000.000,50").
+func parseImportNumber(s string) (float64, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	if indonesianNumberPattern.MatchString(s) {
+		s = strings.ReplaceAll(s, ".", "")
+	}
+	s = strings.Replace(s, ",", ".", 1)
+	return strconv.ParseFloat(s, 64)
+}
+
+var importGenders = map[string]string{
+	"male": "male", "l": "male", "laki-laki": "male", "laki laki": "male", "pria": "male",
+	"female": "female", "p": "female", "perempuan": "female", "wanita": "female",
+}
+
+func optionalImportValue(data map[string]string, field string) *string {
+	if v, ok := data[field]; ok {
+		return &v
+	}
+	return nil
+}
+
+// optionalImportIDNumber seperti optionalImportValue, untuk NIK, NPWP, dan
+// nomor BPJS yang disimpan tanpa titik, tanda hubung, dan spasi. Sel yang
+// kosong setelah dinormalisasi (misalnya hanya "-") dianggap tidak diisi.
+func optionalImportIDNumber(data map[string]string, field string) *string {
+	if v := util.NormalizeIDNumber(data[field]); v != "" {
+		return &v
+	}
+	return nil
+}
+
+// parseImportRow mengubah data baris menjadi EmployeeInput. Tanpa lookups
+// hanya format yang diperiksa; dengan lookups, nama data master dicocokkan dan
+// email, nomor induk, serta atasan diperiksa terhadap data tenant. Nilai
+// custom field divalidasi terhadap definisi customFields.
+func (uc *EmployeeImportUsecase) parseImportRow(data map[string]string, customFields []*entity.CustomField, lookups *repository.EmployeeImportLookups) (EmployeeInput, *string, []string) {
+	rowErrors := []string{}
+
+	if err := uc.validate.Struct(newEmployeeImportRecord(data)); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return EmployeeInput{}, nil, []string{err.Error()}
+		}
+		for field, message := range util.FormatValidationErrors(validationErrors) {
+			rowErrors = append(rowErrors, field+": "+message)
+		}
+		sort.Strings(rowErrors)
+	}
+
+	input := EmployeeInput{
+		Email:            strings.ToLower(data["email"]),
+		EmployeeIDNumber: data["employee_id_number"],
+		Profile: entity.EmployeeProfile{
+			FullName:              data["full_name"],
+			NIKKTP:                optionalImportIDNumber(data, "nik_ktp"),
+			PlaceOfBirth:          optionalImportValue(data, "place_of_birth"),
+			MaritalStatus:         optionalImportValue(data, "marital_status"),
+			Religion:              optionalImportValue(data, "religion"),
+			AddressKTP:            optionalImportValue(data, "address_ktp"),
+			AddressDomicile:       optionalImportValue(data, "address_domicile"),
+			EmergencyContactName:  optionalImportValue(data, "emergency_contact_name"),
+			EmergencyContactPhone: optionalImportValue(data, "emergency_contact_phone"),
+		},
+		Financial: entity.EmployeeFinancial{
+			BankName:              optionalImportValue(data, "bank_name"),
+			BankAccountNumber:     optionalImportValue(data, "bank_account_number"),
+			BankAccountHolder:     optionalImportValue(data, "bank_account_holder"),
+			NPWP:                  optionalImportIDNumber(data, "npwp"),
+			PTKPStatus:            optionalImportValue(data, "ptkp_status"),
+			BPJSKetenagakerjaanNo: optionalImportIDNumber(data, "bpjs_ketenagakerjaan_no"),
+			BPJSKesehatanNo:       optionalImportIDNumber(data, "bpjs_kesehatan_no"),
+		},
+	}
+
+	if v, ok := data["join_date"]; ok {
+		if t, ok := parseImportDate(v); ok {
+			input.JoinDate = t
+		} else {
+			rowErrors = append(rowErrors, "join_date: Format tanggal tidak valid (gunakan YYYY-MM-DD atau DD/MM/YYYY).")
+		}
+	}
+	if v, ok := data["date_of_birth"]; ok {
+		if t, ok := parseImportDate(v); ok {
+			input.Profile.DateOfBirth = &t
+		} else {
+			rowErrors = append(rowErrors, "date_of_birth: Format tanggal tidak valid (gunakan YYYY-MM-DD atau DD/MM/YYYY).")
+		}
+	}
+	if v, ok := data["gender"]; ok {
+		if gender, ok := importGenders[strings.ToLower(v)]; ok {
+			input.Profile.Gender = &gender
+		} else {
+			rowErrors = append(rowErrors, "gender: Harus male/female (atau L/P).")
+		}
+	}
+	if input.Profile.NIKKTP != nil && input.Profile.DateOfBirth != nil {
+		var gender string
+		if input.Profile.Gender != nil {
+			gender = *input.Profile.Gender
+		}
+		if nik, ok := util.ParseNIK(*input.Profile.NIKKTP); ok && !nik.MatchesBirth(*input.Profile.DateOfBirth, gender) {
+			rowErrors = append(rowErrors, "nik_ktp: Tanggal lahir atau jenis kelamin pada NIK tidak sesuai dengan data profil.")
+		}
+	}
+	if v, ok := data["basic_salary"]; ok {
+		if salary, err := parseImportNumber(v); err == nil && salary >= 0 {
+			input.Financial.BasicSalary = &salary
+		} else {
+			rowErrors = append(rowErrors, "basic_salary: Harus berupa angka positif.")
+		}
+	}
+
+	customValues := map[string]any{}
+	for field, value := range data {
+		if key, ok := strings.CutPrefix(field, repository.CustomFieldPrefix); ok {
+			customValues[key] = value
+		}
+	}
+	var customErrors []string
+	input.CustomFields, customErrors = normalizeCustomFieldValues(customFields, customValues)
+	rowErrors = append(rowErrors, customErrors...)
+
+	var managerIDNumber *string
+	if v, ok := data["manager"]; ok {
+		if strings.EqualFold(v, input.EmployeeIDNumber) {
+			rowErrors = append(rowErrors, "manager: Karyawan tidak bisa menjadi atasan dirinya sendiri.")
+		}
+		managerIDNumber = &v
+	}
+
+	if lookups == nil || len(rowErrors) > 0 {
+		return input, managerIDNumber, rowErrors
+	}
+
+	masters := []struct {
+		field string
+		label string
+		dest  **uuid.UUID
+	}{
+		{"branch", "cabang", &input.BranchID},
+		{string(entity.OrgUnitDepartment), entity.OrgUnitDepartment.Label(), &input.DepartmentID},
+		{string(entity.OrgUnitPosition), entity.OrgUnitPosition.Label(), &input.PositionID},
+		{string(entity.OrgUnitJobLevel), entity.OrgUnitJobLevel.Label(), &input.JobLevelID},
+		{string(entity.OrgUnitEmploymentStatus), entity.OrgUnitEmploymentStatus.Label(), &input.EmploymentStatusID},
+		{string(entity.OrgUnitSBU), entity.OrgUnitSBU.Label(), &input.SBUID},
+	}
+	for _, m := range masters {
+		name, ok := data[m.field]
+		if !ok {
+			continue
+		}
+		id, ok := lookups.Masters[m.field][strings.ToLower(name)]
+		if !ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("%s: %s '%s' tidak ditemukan.", m.field, m.label, name))
+			continue
+		}
+		*m.dest = &id
+	}
+
+	if lookups.Emails[input.Email] {
+		rowErrors = append(rowErrors, "email: Email sudah terdaftar.")
+	}
+	if input.EmployeeIDNumber != "" && lookups.EmployeeIDNumbers[strings.ToLower(input.EmployeeIDNumber)] {
+		rowErrors = append(rowErrors, "employee_id_number: Nomor induk karyawan sudah dipakai.")
+	}
+	if managerIDNumber != nil && !lookups.EmployeeIDNumbers[strings.ToLower(*managerIDNumber)] {
+		rowErrors = append(rowErrors, fmt.Sprintf("manager: Atasan dengan nomor induk %s tidak ditemukan (harus sudah ada atau berada di baris sebelumnya).", *managerIDNumber))
+	}
+
+	return input, managerIDNumber, rowErrors
+}
+
+// ------------------------------------------------------------------------
+// Pemrosesan latar belakang
+
+// runImport memproses job sampai selesai. Jika proses terhenti karena
+// context dibatalkan (server berhenti), job dibiarkan processing dan
+// dilanjutkan oleh ResumeImports.
+func (uc *EmployeeImportUsecase) runImport(ctx context.Context, job *entity.EmployeeImport) {
+	err := uc.processImport(ctx, job)
+	if err == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		log.Printf("Impor karyawan %s terhenti dan akan dilanjutkan: %v", job.ID, err)
+		return
+	}
+
+	log.Printf("Gagal memproses impor karyawan %s: %v", job.ID, err)
+	message := fmt.Sprintf("gagal memproses impor: %v", err)
+	job.Status = entity.ImportFailed
+	job.ErrorMessage = &message
+	if err := uc.importRepo.Finish(ctx, job, time.Now()); err != nil {
+		log.Printf("Gagal menyimpan status impor karyawan %s: %v", job.ID, err)
+	}
+}
+
+func (uc *EmployeeImportUsecase) processImport(ctx context.Context, job *entity.EmployeeImport) error {
+	now := time.Now()
+	claimed, err := uc.importRepo.Claim(ctx, job, now.Add(-employeeImportStaleAfter), now)
+	if err != nil || !claimed {
+		return err
+	}
+
+	lookups, err := uc.importRepo.FindLookups(ctx, job.TenantID)
+	if err != nil {
+		return err
+	}
+	customFields, err := uc.customFields.ListFields(ctx, job.TenantID, entity.CustomFieldEntityEmployee)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rows, err := uc.importRepo.FindPendingRows(ctx, job.ID, employeeImportChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		records := make([]*repository.EmployeeImportRecord, 0, len(rows))
+		importable := 0
+		for _, row := range rows {
+			record := &repository.EmployeeImportRecord{Row: row}
+			records = append(records, record)
+
+			input, managerIDNumber, rowErrors := uc.parseImportRow(row.Data, customFields, lookups)
+			if len(rowErrors) == 0 {
+				// Duplikat di dalam file sudah diperiksa saat unggah; di sini
+				// nilai unik dicek terhadap karyawan yang sudah ada.
+				if rowErrors, err = uc.customFields.checkUniqueValues(ctx, job.TenantID, entity.CustomFieldEntityEmployee, customFields, input.CustomFields, nil); err != nil {
+					return err
+				}
+			}
+			if len(rowErrors) == 0 && input.EmployeeIDNumber == "" {
+				if rowErrors, err = uc.generateImportNumber(ctx, job, &input, lookups); err != nil {
+					return err
+				}
+			}
+			if len(rowErrors) > 0 {
+				row.Status = entity.ImportRowInvalid
+				row.Errors = rowErrors
+				continue
+			}
+
+			// Baris berikutnya boleh merujuk karyawan ini sebagai atasan,
+			// dan tidak boleh memakai email atau nomor induk yang sama.
+			lookups.Emails[input.Email] = true
+			if input.EmployeeIDNumber != "" {
+				lookups.EmployeeIDNumbers[strings.ToLower(input.EmployeeIDNumber)] = true
+			}
+
+			if job.DryRun {
+				row.Status = entity.ImportRowValid
+				continue
+			}
+
+			user, employee, err := newEmployee(job.TenantID, input, time.Now())
+			if err != nil {
+				return err
+			}
+			record.User = user
+			record.Employee = employee
+			record.ManagerIDNumber = managerIDNumber
+			importable++
+		}
+
+		err = uc.employeeLimit.EnsureCapacity(ctx, job.TenantID, importable)
+		if err == nil {
+			err = asEmployeeLimitError(uc.importRepo.ProcessChunk(ctx, job, records, time.Now()))
+		}
+		if err != nil {
+			var limitErr *EmployeeLimitError
+			if !errors.As(err, &limitErr) {
+				return err
+			}
+			// Chunk sebelumnya sudah tersimpan; sisa baris ditandai gagal.
+			message := limitErr.Error()
+			job.Status = entity.ImportFailed
+			job.ErrorMessage = &message
+			return uc.importRepo.Finish(ctx, job, time.Now())
+		}
+	}
+
+	job.Status = entity.ImportCompleted
+	if job.DryRun {
+		if err := uc.employeeLimit.EnsureCapacity(ctx, job.TenantID, job.SuccessRows); err != nil {
+			var limitErr *EmployeeLimitError
+			if !errors.As(err, &limitErr) {
+				return err
+			}
+			message := "semua baris valid tidak bisa diimpor: " + limitErr.Error()
+			job.ErrorMessage = &message
+		}
+	}
+	return uc.importRepo.Finish(ctx, job, time.Now())
+}
+
+// generateImportNumber mengisi nomor induk baris yang tidak mencantumkannya.
+// Pada dry run nomor urut tidak dipakai; hanya diperiksa apakah nomor bisa
+// dibuat untuk baris ini.
+func (uc *EmployeeImportUsecase) generateImportNumber(ctx context.Context, job *entity.EmployeeImport, input *EmployeeInput, lookups *repository.EmployeeImportLookups) ([]string, error) {
+	var err error
+	if job.DryRun {
+		_, err = uc.numbers.Preview(ctx, job.TenantID, EmployeeNumberPreviewInput{BranchID: input.BranchID, JoinDate: input.JoinDate})
+	} else {
+		input.EmployeeIDNumber, err = uc.numbers.Generate(ctx, job.TenantID, input.BranchID, input.JoinDate, lookups.EmployeeIDNumbers)
+	}
+	if errors.Is(err, ErrEmployeeNumberUnavailable) {
+		return []string{"employee_id_number: " + err.Error()}, nil
+	}
+	return nil, err
+}
+
+// ResumeImports melanjutkan job impor semua tenant yang belum diambil worker
+// atau terputus di tengah jalan (misalnya karena server restart).
+func (uc *EmployeeImportUsecase) ResumeImports(ctx context.Context, now time.Time) {
+	jobs, err := uc.importRepo.FindResumable(repository.WithPlatformAccess(ctx), now.Add(-employeeImportStaleAfter), employeeImportResumeBatch)
+	if err != nil {
+		log.Printf("Gagal mengambil impor karyawan yang tertunda: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		uc.runImport(security.WithTenantID(ctx, job.TenantID), job)
+	}
+}
+
+// ------------------------------------------------------------------------
+// Laporan
+
+func (uc *EmployeeImportUsecase) ListImports(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.EmployeeImport, util.Pagination, error) {
+	jobs, err := uc.importRepo.Find(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.importRepo.Count(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	return jobs, query.CalculatePaginationMetadata(totalItems), nil
+}
+
+func (uc *EmployeeImportUsecase) GetImport(ctx context.Context, tenantID, id uuid.UUID) (*entity.EmployeeImport, error) {
+	return uc.importRepo.FindByID(ctx, tenantID, id)
+}
+
+// ListImportRows mengembalikan laporan validasi per baris, difilter per
+// status jika status tidak kosong.
+func (uc *EmployeeImportUsecase) ListImportRows(ctx context.Context, tenantID, id uuid.UUID, status entity.ImportRowStatus, query util.PaginationQuery) ([]*entity.EmployeeImportRow, util.Pagination, error) {
+	if _, err := uc.importRepo.FindByID(ctx, tenantID, id); err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	rows, err := uc.importRepo.FindRows(ctx, tenantID, id, status, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.importRepo.CountRows(ctx, tenantID, id, status)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	return rows, query.CalculatePaginationMetadata(totalItems), nil
+}
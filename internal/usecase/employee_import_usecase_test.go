@@ -0,0 +1,28 @@
+package usecase
+
+import "testing"
+
+func TestOptionalImportIDNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+		want *string
+	}{
+		{"kolom tidak ada", map[string]string{}, nil},
+		{"sel kosong", map[string]string{"npwp": ""}, nil},
+		{"hanya pemisah", map[string]string{"npwp": " - . "}, nil},
+		{"dinormalisasi", map[string]string{"npwp": "01.234.567.8-901.234"}, optionalString("012345678901234")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := optionalImportIDNumber(tt.data, "npwp")
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("optionalImportIDNumber = %q, want nil", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("optionalImportIDNumber = %v, want %q", got, *tt.want)
+			}
+		})
+	}
+}
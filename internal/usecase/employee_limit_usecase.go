@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const employeeLimitUpgradeHint = "Upgrade paket langganan atau beli addon 'employee_seats' untuk menambah kuota karyawan."
+
+// EmployeeLimitError dikembalikan ketika penambahan karyawan akan melebihi
+// kuota paket (plans.employee_limit + addon kursi).
+type EmployeeLimitError struct {
+	Capacity        int
+	ActiveEmployees int
+	Requested       int
+	UpgradeHint     string
+}
+
+func (e *EmployeeLimitError) Error() string {
+	return fmt.Sprintf("batas karyawan paket tercapai: %d aktif dari kuota %d, tidak bisa menambah %d karyawan",
+		e.ActiveEmployees, e.Capacity, e.Requested)
+}
+
+func newEmployeeLimitError(usage *entity.EmployeeSeatUsage, requested int) *EmployeeLimitError {
+	return &EmployeeLimitError{
+		Capacity:        usage.Capacity(),
+		ActiveEmployees: usage.ActiveEmployees,
+		Requested:       requested,
+		UpgradeHint:     employeeLimitUpgradeHint,
+	}
+}
+
+// asEmployeeLimitError mengubah repository.EmployeeSeatLimitError dari
+// transaksi penyimpanan menjadi EmployeeLimitError; error lain dikembalikan apa adanya.
+func asEmployeeLimitError(err error) error {
+	var seatErr *repository.EmployeeSeatLimitError
+	if errors.As(err, &seatErr) {
+		return newEmployeeLimitError(seatErr.Usage, seatErr.Requested)
+	}
+	return err
+}
+
+type EmployeeLimitUsecase struct {
+	seatRepo repository.EmployeeSeatRepository
+}
+
+func NewEmployeeLimitUsecase(seatRepo repository.EmployeeSeatRepository) *EmployeeLimitUsecase {
+	return &EmployeeLimitUsecase{
+		seatRepo: seatRepo,
+	}
+}
+
+func (uc *EmployeeLimitUsecase) GetUsage(ctx context.Context, tenantID uuid.UUID) (*entity.EmployeeSeatUsage, error) {
+	return uc.seatRepo.FindUsage(ctx, tenantID, time.Now())
+}
+
+// EnsureCapacity dipanggil sebelum membuat, mengimpor, atau mengaktifkan
+// kembali karyawan agar permintaan yang jelas melebihi kuota ditolak lebih
+// awal. 'additional' adalah jumlah karyawan aktif yang akan bertambah.
+// Pemeriksaan ini di luar transaksi; kuota dijamin oleh repository yang
+// menghitung ulang di dalam transaksi penyimpanan (lihat
+// repository.EmployeeSeatLimitError).
+func (uc *EmployeeLimitUsecase) EnsureCapacity(ctx context.Context, tenantID uuid.UUID, additional int) error {
+	if additional <= 0 {
+		return nil
+	}
+
+	usage, err := uc.seatRepo.FindUsage(ctx, tenantID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if usage.ActiveEmployees+additional > usage.Capacity() {
+		return newEmployeeLimitError(usage, additional)
+	}
+	return nil
+}
+
+// ReportNearLimit mengembalikan tenant dengan pemakaian >= threshold (0..1),
+// diurutkan dari yang paling penuh.
+func (uc *EmployeeLimitUsecase) ReportNearLimit(ctx context.Context, threshold float64) ([]*entity.EmployeeSeatUsage, error) {
+	usages, err := uc.seatRepo.FindAllUsages(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*entity.EmployeeSeatUsage{}
+	for _, u := range usages {
+		if u.UsageRatio() >= threshold {
+			result = append(result, u)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].UsageRatio() > result[j].UsageRatio()
+	})
+	return result, nil
+}
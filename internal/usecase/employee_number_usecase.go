@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// ErrEmployeeNumberUnavailable berarti nomor induk tidak bisa dibuat dari
+// format tenant untuk data karyawan ini, misalnya cabang kosong atau belum
+// punya kode. Karyawan tetap bisa disimpan dengan nomor induk yang diisi
+// manual.
+var ErrEmployeeNumberUnavailable = errors.New("nomor induk karyawan tidak bisa dibuat otomatis")
+
+// employeeNumberMaxAttempts membatasi berapa nomor urut yang dilewati karena
+// nomornya sudah dipakai (misalnya diisi manual sebelumnya).
+const employeeNumberMaxAttempts = 100
+
+type EmployeeNumberSchemeInput struct {
+	Template       string
+	ResetYearly    bool
+	ResetPerBranch bool
+}
+
+type EmployeeNumberPreviewInput struct {
+	// Scheme nil berarti memakai format yang tersimpan. Diisi untuk mencoba
+	// format baru sebelum disimpan.
+	Scheme   *EmployeeNumberSchemeInput
+	BranchID *uuid.UUID
+	// JoinDate kosong berarti hari ini.
+	JoinDate time.Time
+}
+
+type EmployeeNumberPreview struct {
+	Number   string
+	Sequence int64
+}
+
+// EmployeeNumberUsecase membuat nomor induk karyawan otomatis dari format
+// per tenant, dipakai saat karyawan dibuat atau diimpor tanpa nomor induk.
+type EmployeeNumberUsecase struct {
+	numberRepo repository.EmployeeNumberRepository
+	orgRepo    repository.OrganizationRepository
+}
+
+func NewEmployeeNumberUsecase(numberRepo repository.EmployeeNumberRepository, orgRepo repository.OrganizationRepository) *EmployeeNumberUsecase {
+	return &EmployeeNumberUsecase{
+		numberRepo: numberRepo,
+		orgRepo:    orgRepo,
+	}
+}
+
+// GetScheme mengembalikan format tenant, atau format bawaan jika tenant
+// belum mengaturnya.
+func (uc *EmployeeNumberUsecase) GetScheme(ctx context.Context, tenantID uuid.UUID) (*entity.EmployeeNumberScheme, error) {
+	scheme, err := uc.numberRepo.FindScheme(ctx, tenantID)
+	if errors.Is(err, repository.ErrEmployeeNumberSchemeNotFound) {
+		return entity.DefaultEmployeeNumberScheme(tenantID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
+
+// SaveScheme mengganti format tenant. Nomor urut yang sudah berjalan tidak
+// diulang.
+func (uc *EmployeeNumberUsecase) SaveScheme(ctx context.Context, tenantID uuid.UUID, input EmployeeNumberSchemeInput) (*entity.EmployeeNumberScheme, error) {
+	if _, err := parseEmployeeNumberScheme(input); err != nil {
+		return nil, err
+	}
+
+	scheme, err := uc.GetScheme(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if scheme.CreatedAt.IsZero() {
+		scheme.CreatedAt = now
+	}
+	scheme.Template = input.Template
+	scheme.ResetYearly = input.ResetYearly
+	scheme.ResetPerBranch = input.ResetPerBranch
+	scheme.UpdatedAt = now
+
+	if err := uc.numberRepo.SaveScheme(ctx, scheme); err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
+
+// Preview mengembalikan nomor induk berikutnya tanpa memakai nomor urutnya.
+// Nomor yang benar-benar didapat bisa berbeda jika ada karyawan lain yang
+// dibuat lebih dulu.
+func (uc *EmployeeNumberUsecase) Preview(ctx context.Context, tenantID uuid.UUID, input EmployeeNumberPreviewInput) (*EmployeeNumberPreview, error) {
+	schemeInput := input.Scheme
+	if schemeInput == nil {
+		scheme, err := uc.GetScheme(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		schemeInput = &EmployeeNumberSchemeInput{
+			Template:       scheme.Template,
+			ResetYearly:    scheme.ResetYearly,
+			ResetPerBranch: scheme.ResetPerBranch,
+		}
+	}
+	joinDate := input.JoinDate
+	if joinDate.IsZero() {
+		joinDate = time.Now()
+	}
+
+	format, err := uc.prepare(ctx, tenantID, *schemeInput, input.BranchID, joinDate)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.numberRepo.CurrentSequence(ctx, tenantID, format.scope)
+	if err != nil {
+		return nil, err
+	}
+	for seq := current + 1; seq <= current+employeeNumberMaxAttempts; seq++ {
+		number := format.render(seq)
+		exists, err := uc.numberRepo.NumberExists(ctx, tenantID, number)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return &EmployeeNumberPreview{Number: number, Sequence: seq}, nil
+		}
+	}
+	return nil, errors.New("tidak menemukan nomor induk karyawan yang belum dipakai")
+}
+
+// Generate mengambil nomor urut berikutnya dan merender nomor induk untuk
+// karyawan di cabang branchID yang bergabung pada joinDate. Nomor yang sudah
+// dipakai karyawan lain, atau ada di 'taken' (huruf kecil, opsional), dilewati.
+// Mengembalikan error yang membungkus ErrEmployeeNumberUnavailable jika data
+// karyawan tidak cukup untuk format tenant.
+//
+// Setiap nomor yang dilewati tetap menghabiskan satu nomor urut, begitu juga
+// nomor yang sudah diambil tetapi karyawannya batal disimpan, sehingga
+// penomoran bisa bolong; ini memang diharapkan. Nomor urut tidak dipakai
+// ulang agar dua proses yang berjalan bersamaan tidak mendapat nomor yang
+// sama. Setelah employeeNumberMaxAttempts nomor berturut-turut sudah dipakai,
+// Generate menyerah dan mengembalikan error.
+func (uc *EmployeeNumberUsecase) Generate(ctx context.Context, tenantID uuid.UUID, branchID *uuid.UUID, joinDate time.Time, taken map[string]bool) (string, error) {
+	scheme, err := uc.GetScheme(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	format, err := uc.prepare(ctx, tenantID, EmployeeNumberSchemeInput{
+		Template:       scheme.Template,
+		ResetYearly:    scheme.ResetYearly,
+		ResetPerBranch: scheme.ResetPerBranch,
+	}, branchID, joinDate)
+	if err != nil {
+		return "", err
+	}
+
+	for range employeeNumberMaxAttempts {
+		seq, err := uc.numberRepo.NextSequence(ctx, tenantID, format.scope, time.Now())
+		if err != nil {
+			return "", err
+		}
+		number := format.render(seq)
+		if taken[strings.ToLower(number)] {
+			continue
+		}
+		exists, err := uc.numberRepo.NumberExists(ctx, tenantID, number)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return number, nil
+		}
+	}
+	return "", errors.New("tidak menemukan nomor induk karyawan yang belum dipakai")
+}
+
+// prepare mengurai format dan menyiapkan kode cabang serta lingkup nomor urut
+// untuk satu karyawan.
+func (uc *EmployeeNumberUsecase) prepare(ctx context.Context, tenantID uuid.UUID, input EmployeeNumberSchemeInput, branchID *uuid.UUID, joinDate time.Time) (*employeeNumberFormat, error) {
+	tokens, err := parseEmployeeNumberScheme(input)
+	if err != nil {
+		return nil, err
+	}
+
+	format := &employeeNumberFormat{tokens: tokens, joinDate: joinDate}
+
+	scope := []string{}
+	if input.ResetYearly {
+		scope = append(scope, "Y"+strconv.Itoa(joinDate.Year()))
+	}
+
+	if hasEmployeeNumberToken(tokens, "BRANCH") || input.ResetPerBranch {
+		if branchID == nil {
+			return nil, fmt.Errorf("%w: cabang wajib diisi karena format nomor induk memakai kode cabang", ErrEmployeeNumberUnavailable)
+		}
+		branch, err := uc.orgRepo.FindBranchByID(ctx, tenantID, *branchID)
+		if err != nil {
+			return nil, err
+		}
+		if branch.Code == nil {
+			return nil, fmt.Errorf("%w: cabang '%s' belum memiliki kode", ErrEmployeeNumberUnavailable, branch.Name)
+		}
+		format.branchCode = *branch.Code
+		if input.ResetPerBranch {
+			scope = append(scope, "B"+branch.ID.String())
+		}
+	}
+
+	format.scope = strings.Join(scope, ":")
+	return format, nil
+}
+
+// ------------------------------------------------------------------------
+// Format nomor induk
+
+type employeeNumberToken struct {
+	// literal diisi untuk teks biasa; name untuk token, e.g., "SEQ".
+	literal string
+	name    string
+	width   int
+}
+
+type employeeNumberFormat struct {
+	tokens     []employeeNumberToken
+	branchCode string
+	joinDate   time.Time
+	scope      string
+}
+
+func (f *employeeNumberFormat) render(seq int64) string {
+	var sb strings.Builder
+	for _, t := range f.tokens {
+		switch t.name {
+		case "":
+			sb.WriteString(t.literal)
+		case "BRANCH":
+			sb.WriteString(f.branchCode)
+		case "YYYY":
+			sb.WriteString(f.joinDate.Format("2006"))
+		case "YY":
+			sb.WriteString(f.joinDate.Format("06"))
+		case "MM":
+			sb.WriteString(f.joinDate.Format("01"))
+		case "SEQ":
+			fmt.Fprintf(&sb, "%0*d", t.width, seq)
+		}
+	}
+	return sb.String()
+}
+
+var (
+	employeeNumberTokenPattern   = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+	employeeNumberLiteralPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]*$`)
+)
+
+// parseEmployeeNumberScheme memvalidasi template dan aturan reset-nya.
+func parseEmployeeNumberScheme(input EmployeeNumberSchemeInput) ([]employeeNumberToken, error) {
+	if input.Template == "" {
+		return nil, errors.New("format nomor induk wajib diisi")
+	}
+
+	tokens := []employeeNumberToken{}
+	addLiteral := func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if !employeeNumberLiteralPattern.MatchString(s) {
+			return fmt.Errorf("teks '%s' pada format nomor induk hanya boleh berisi huruf, angka, titik, garis miring, tanda hubung, dan garis bawah", s)
+		}
+		tokens = append(tokens, employeeNumberToken{literal: s})
+		return nil
+	}
+
+	seqCount := 0
+	last := 0
+	for _, m := range employeeNumberTokenPattern.FindAllStringSubmatchIndex(input.Template, -1) {
+		if err := addLiteral(input.Template[last:m[0]]); err != nil {
+			return nil, err
+		}
+		last = m[1]
+
+		token := employeeNumberToken{name: input.Template[m[2]:m[3]], width: 1}
+		hasWidth := m[4] >= 0
+		switch token.name {
+		case "SEQ":
+			seqCount++
+			if hasWidth {
+				width, err := strconv.Atoi(input.Template[m[4]:m[5]])
+				if err != nil || width < 1 || width > 10 {
+					return nil, errors.New("panjang {SEQ:n} harus 1 sampai 10 digit")
+				}
+				token.width = width
+			}
+		case "BRANCH", "YYYY", "YY", "MM":
+			if hasWidth {
+				return nil, fmt.Errorf("token {%s} tidak menerima panjang", token.name)
+			}
+		default:
+			return nil, fmt.Errorf("token {%s} tidak dikenal (gunakan {BRANCH}, {YYYY}, {YY}, {MM}, atau {SEQ:n})", token.name)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := addLiteral(input.Template[last:]); err != nil {
+		return nil, err
+	}
+
+	if seqCount != 1 {
+		return nil, errors.New("format nomor induk harus memuat tepat satu {SEQ} atau {SEQ:n}")
+	}
+	// Tanpa tahun atau kode cabang di nomor, nomor urut yang diulang akan
+	// menghasilkan nomor yang sama.
+	if input.ResetYearly && !hasEmployeeNumberToken(tokens, "YYYY") && !hasEmployeeNumberToken(tokens, "YY") {
+		return nil, errors.New("format harus memuat {YYYY} atau {YY} jika nomor urut diulang setiap tahun")
+	}
+	if input.ResetPerBranch && !hasEmployeeNumberToken(tokens, "BRANCH") {
+		return nil, errors.New("format harus memuat {BRANCH} jika nomor urut dihitung per cabang")
+	}
+	return tokens, nil
+}
+
+func hasEmployeeNumberToken(tokens []employeeNumberToken, name string) bool {
+	for _, t := range tokens {
+		if t.name == name {
+			return true
+		}
+	}
+	return false
+}
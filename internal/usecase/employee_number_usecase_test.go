@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEmployeeNumberScheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   EmployeeNumberSchemeInput
+		wantErr string
+	}{
+		{name: "hanya {SEQ}", input: EmployeeNumberSchemeInput{Template: "{SEQ}"}},
+		{name: "semua token", input: EmployeeNumberSchemeInput{Template: "EMP/{BRANCH}.{YYYY}{YY}{MM}-{SEQ:4}_x"}},
+		{name: "{SEQ:1}", input: EmployeeNumberSchemeInput{Template: "{SEQ:1}"}},
+		{name: "{SEQ:10}", input: EmployeeNumberSchemeInput{Template: "{SEQ:10}"}},
+		{name: "reset tahunan dengan {YYYY}", input: EmployeeNumberSchemeInput{Template: "{YYYY}{SEQ:4}", ResetYearly: true}},
+		{name: "reset tahunan dengan {YY}", input: EmployeeNumberSchemeInput{Template: "{YY}{SEQ:4}", ResetYearly: true}},
+		{name: "reset per cabang dengan {BRANCH}", input: EmployeeNumberSchemeInput{Template: "{BRANCH}{SEQ:4}", ResetPerBranch: true}},
+		{
+			name:  "reset tahunan dan per cabang",
+			input: EmployeeNumberSchemeInput{Template: "{BRANCH}-{YYYY}-{SEQ:4}", ResetYearly: true, ResetPerBranch: true},
+		},
+
+		{name: "template kosong", input: EmployeeNumberSchemeInput{}, wantErr: "wajib diisi"},
+		{name: "token tidak dikenal", input: EmployeeNumberSchemeInput{Template: "{DEPT}-{SEQ}"}, wantErr: "token {DEPT} tidak dikenal"},
+		{name: "tanpa {SEQ}", input: EmployeeNumberSchemeInput{Template: "{BRANCH}-{YYYY}"}, wantErr: "tepat satu {SEQ}"},
+		{name: "{SEQ} ganda", input: EmployeeNumberSchemeInput{Template: "{SEQ}-{SEQ:4}"}, wantErr: "tepat satu {SEQ}"},
+		{name: "{SEQ:0}", input: EmployeeNumberSchemeInput{Template: "{SEQ:0}"}, wantErr: "1 sampai 10 digit"},
+		{name: "{SEQ:11}", input: EmployeeNumberSchemeInput{Template: "{SEQ:11}"}, wantErr: "1 sampai 10 digit"},
+		{name: "panjang pada {YYYY}", input: EmployeeNumberSchemeInput{Template: "{YYYY:2}{SEQ}"}, wantErr: "token {YYYY} tidak menerima panjang"},
+		{name: "panjang pada {BRANCH}", input: EmployeeNumberSchemeInput{Template: "{BRANCH:3}{SEQ}"}, wantErr: "token {BRANCH} tidak menerima panjang"},
+		{name: "teks dengan spasi", input: EmployeeNumberSchemeInput{Template: "EMP {SEQ}"}, wantErr: "teks 'EMP '"},
+		{name: "kurung kurawal tidak lengkap", input: EmployeeNumberSchemeInput{Template: "{SEQ}-{YYYY"}, wantErr: "teks '-{YYYY'"},
+		{name: "token huruf kecil", input: EmployeeNumberSchemeInput{Template: "{seq}"}, wantErr: "teks '{seq}'"},
+		{
+			name:    "reset tahunan tanpa tahun",
+			input:   EmployeeNumberSchemeInput{Template: "{MM}{SEQ:4}", ResetYearly: true},
+			wantErr: "{YYYY} atau {YY}",
+		},
+		{
+			name:    "reset per cabang tanpa {BRANCH}",
+			input:   EmployeeNumberSchemeInput{Template: "{YYYY}{SEQ:4}", ResetPerBranch: true},
+			wantErr: "{BRANCH}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseEmployeeNumberScheme(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("error tidak diharapkan: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want mengandung %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmployeeNumberFormatRender(t *testing.T) {
+	joinDate := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		seq      int64
+		want     string
+	}{
+		{"cabang, tahun, dan nomor urut", "{BRANCH}-{YYYY}-{SEQ:4}", 7, "JKT-2025-0007"},
+		{"tahun dua digit dan bulan", "EMP{YY}{MM}{SEQ:3}", 42, "EMP2503042"},
+		{"tanpa panjang", "{SEQ}", 123, "123"},
+		{"nomor urut melebihi panjang", "{SEQ:2}", 1234, "1234"},
+		{"teks di awal dan akhir", "HR/{SEQ:5}.A", 1, "HR/00001.A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := parseEmployeeNumberScheme(EmployeeNumberSchemeInput{Template: tt.template})
+			if err != nil {
+				t.Fatalf("format tidak valid: %v", err)
+			}
+			format := &employeeNumberFormat{tokens: tokens, branchCode: "JKT", joinDate: joinDate}
+			if got := format.render(tt.seq); got != tt.want {
+				t.Errorf("render(%d) = %q, want %q", tt.seq, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type EmployeeInput struct {
+	// Email hanya dipakai saat membuat karyawan. Email akun login diubah lewat
+	// alur akun, bukan lewat data karyawan.
+	Email string
+	// EmployeeIDNumber kosong berarti nomor induk dibuat otomatis saat
+	// karyawan dibuat, atau tidak diubah saat update.
+	EmployeeIDNumber string
+	JoinDate         time.Time
+
+	BranchID           *uuid.UUID
+	DepartmentID       *uuid.UUID
+	PositionID         *uuid.UUID
+	JobLevelID         *uuid.UUID
+	EmploymentStatusID *uuid.UUID
+	SBUID              *uuid.UUID
+	ManagerID          *uuid.UUID
+
+	Profile   entity.EmployeeProfile
+	Financial entity.EmployeeFinancial
+	// CustomFields nil saat update berarti nilai custom field tidak diubah.
+	CustomFields map[string]any
+}
+
+// EmployeeUsecase mengelola data induk karyawan. Karyawan baru mendapat akun
+// login tanpa password; undangan dikirim lewat POST /invitations/bulk.
+type EmployeeUsecase struct {
+	employeeRepo  repository.EmployeeRepository
+	employeeLimit *EmployeeLimitUsecase
+	authorization *AuthorizationUsecase
+	customFields  *CustomFieldUsecase
+	numbers       *EmployeeNumberUsecase
+}
+
+func NewEmployeeUsecase(employeeRepo repository.EmployeeRepository, employeeLimit *EmployeeLimitUsecase, authorization *AuthorizationUsecase, customFields *CustomFieldUsecase, numbers *EmployeeNumberUsecase) *EmployeeUsecase {
+	return &EmployeeUsecase{
+		employeeRepo:  employeeRepo,
+		employeeLimit: employeeLimit,
+		authorization: authorization,
+		customFields:  customFields,
+		numbers:       numbers,
+	}
+}
+
+func (uc *EmployeeUsecase) CreateEmployee(ctx context.Context, tenantID uuid.UUID, input EmployeeInput) (*entity.Employee, error) {
+	if err := uc.employeeLimit.EnsureCapacity(ctx, tenantID, 1); err != nil {
+		return nil, err
+	}
+
+	customFields, err := uc.customFields.ValidateValues(ctx, tenantID, entity.CustomFieldEntityEmployee, input.CustomFields, nil)
+	if err != nil {
+		return nil, err
+	}
+	input.CustomFields = customFields
+
+	if input.EmployeeIDNumber == "" {
+		if input.EmployeeIDNumber, err = uc.numbers.Generate(ctx, tenantID, input.BranchID, input.JoinDate, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	user, employee, err := newEmployee(tenantID, input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.employeeRepo.Create(ctx, user, employee); err != nil {
+		return nil, asEmployeeLimitError(err)
+	}
+
+	// Karyawan baru bisa saja berada di luar data scope pembuatnya (mis. HR
+	// cabang menambah karyawan cabang lain), jadi dibaca ulang tanpa scope.
+	return uc.employeeRepo.FindByID(repository.WithDataScope(ctx, nil), tenantID, employee.ID)
+}
+
+// newEmployee menyiapkan akun login (tanpa password) dan data karyawan baru.
+// Dipakai oleh CreateEmployee dan impor karyawan.
+func newEmployee(tenantID uuid.UUID, input EmployeeInput, now time.Time) (*entity.User, *entity.Employee, error) {
+	userID, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+	employeeID, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	user := &entity.User{
+		ID:        userID,
+		TenantID:  tenantID,
+		Name:      input.Profile.FullName,
+		Email:     strings.ToLower(input.Email),
+		Timezone:  "UTC",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	employee := &entity.Employee{
+		ID:           employeeID,
+		UserID:       userID,
+		TenantID:     tenantID,
+		CustomFields: input.CustomFields,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	applyEmployeeInput(employee, input)
+
+	return user, employee, nil
+}
+
+// ListEmployees mengembalikan error yang membungkus ErrInvalidCustomFieldQuery
+// jika filter atau sorting custom field tidak valid.
+func (uc *EmployeeUsecase) ListEmployees(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Employee, util.Pagination, error) {
+	if err := uc.customFields.ResolveListQuery(ctx, tenantID, entity.CustomFieldEntityEmployee, &query); err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	employees, err := uc.employeeRepo.Find(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.employeeRepo.Count(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	pagination := query.CalculatePaginationMetadata(totalItems)
+
+	return employees, pagination, nil
+}
+
+func (uc *EmployeeUsecase) GetEmployee(ctx context.Context, tenantID, id uuid.UUID) (*entity.Employee, error) {
+	return uc.employeeRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *EmployeeUsecase) UpdateEmployee(ctx context.Context, tenantID, id uuid.UUID, input EmployeeInput) (*entity.Employee, error) {
+	employee, err := uc.employeeRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ManagerID != nil && *input.ManagerID == employee.ID {
+		return nil, errors.New("karyawan tidak bisa menjadi atasan dirinya sendiri")
+	}
+
+	orgChanged := !sameUUID(employee.BranchID, input.BranchID) ||
+		!sameUUID(employee.DepartmentID, input.DepartmentID) ||
+		!sameUUID(employee.SBUID, input.SBUID) ||
+		!sameUUID(employee.ManagerID, input.ManagerID)
+
+	if input.CustomFields != nil {
+		customFields, err := uc.customFields.ValidateValues(ctx, tenantID, entity.CustomFieldEntityEmployee, input.CustomFields, &employee.ID)
+		if err != nil {
+			return nil, err
+		}
+		employee.CustomFields = customFields
+	}
+
+	if input.EmployeeIDNumber == "" {
+		input.EmployeeIDNumber = employee.EmployeeIDNumber
+	}
+	applyEmployeeInput(employee, input)
+
+	if err := uc.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, err
+	}
+
+	if orgChanged {
+		// Posisi organisasi adalah acuan data scope user ini.
+		uc.authorization.InvalidateUser(employee.UserID)
+	}
+
+	return uc.employeeRepo.FindByID(repository.WithDataScope(ctx, nil), tenantID, id)
+}
+
+func (uc *EmployeeUsecase) DeleteEmployee(ctx context.Context, tenantID, id uuid.UUID) error {
+	employee, err := uc.employeeRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.employeeRepo.Delete(ctx, tenantID, id, time.Now()); err != nil {
+		return err
+	}
+
+	uc.authorization.InvalidateUser(employee.UserID)
+	return nil
+}
+
+// OrgChartFormat adalah format ekspor bagan organisasi.
+type OrgChartFormat string
+
+const (
+	OrgChartFormatDOT OrgChartFormat = "dot"
+	OrgChartFormatSVG OrgChartFormat = "svg"
+)
+
+// GetChainOfCommand mengembalikan atasan karyawan dari yang terdekat sampai
+// puncak. Karyawan acuan harus terlihat dalam data scope pemanggil.
+func (uc *EmployeeUsecase) GetChainOfCommand(ctx context.Context, tenantID, id uuid.UUID) ([]*entity.OrgChartNode, error) {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+	return uc.employeeRepo.FindChainOfCommand(ctx, tenantID, id)
+}
+
+// GetReports mengembalikan bawahan langsung (directOnly) atau seluruh bawahan
+// langsung dan tidak langsung karyawan.
+func (uc *EmployeeUsecase) GetReports(ctx context.Context, tenantID, id uuid.UUID, directOnly bool) ([]*entity.OrgChartNode, error) {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	maxDepth := 0
+	if directOnly {
+		maxDepth = 1
+	}
+	return uc.employeeRepo.FindReports(ctx, tenantID, id, maxDepth)
+}
+
+// GetOrgChart menyusun bagan organisasi karyawan aktif. Jika root diisi,
+// hanya cabang pohon mulai dari karyawan tersebut yang dikembalikan.
+func (uc *EmployeeUsecase) GetOrgChart(ctx context.Context, tenantID uuid.UUID, root *uuid.UUID) ([]*entity.OrgChartNode, error) {
+	nodes, err := uc.employeeRepo.FindHierarchy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := buildOrgChart(nodes)
+	if root == nil {
+		return roots, nil
+	}
+
+	node := findOrgChartNode(roots, *root)
+	if node == nil {
+		return nil, errors.New("employee not found")
+	}
+	return []*entity.OrgChartNode{rebaseOrgChartLevel(node, 0)}, nil
+}
+
+// ExportOrgChart merender bagan organisasi dalam format DOT atau SVG.
+func (uc *EmployeeUsecase) ExportOrgChart(ctx context.Context, tenantID uuid.UUID, root *uuid.UUID, format OrgChartFormat) ([]byte, error) {
+	roots, err := uc.GetOrgChart(ctx, tenantID, root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case OrgChartFormatDOT:
+		return renderOrgChartDOT(roots), nil
+	case OrgChartFormatSVG:
+		return renderOrgChartSVG(roots), nil
+	}
+	return nil, fmt.Errorf("format bagan organisasi tidak dikenal: %s", format)
+}
+
+func applyEmployeeInput(employee *entity.Employee, input EmployeeInput) {
+	employee.EmployeeIDNumber = input.EmployeeIDNumber
+	employee.JoinDate = input.JoinDate
+	employee.BranchID = input.BranchID
+	employee.DepartmentID = input.DepartmentID
+	employee.PositionID = input.PositionID
+	employee.JobLevelID = input.JobLevelID
+	employee.EmploymentStatusID = input.EmploymentStatusID
+	employee.SBUID = input.SBUID
+	employee.ManagerID = input.ManagerID
+	employee.Profile = input.Profile
+	employee.Financial = input.Financial
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
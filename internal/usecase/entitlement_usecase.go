@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const defaultEntitlementCacheTTL = 5 * time.Minute
+
+type entitlementCacheEntry struct {
+	entitlements []entity.Entitlement
+	features     map[string]struct{}
+	expiresAt    time.Time
+}
+
+// EntitlementUsecase menjawab pertanyaan "apakah tenant X punya fitur Y saat ini?".
+// Hasilnya di-cache per tenant di memori dan harus di-invalidate setiap kali
+// langganan atau addon tenant berubah.
+type EntitlementUsecase struct {
+	featureRepo repository.FeatureRepository
+	ttl         time.Duration
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]entitlementCacheEntry
+}
+
+func NewEntitlementUsecase(featureRepo repository.FeatureRepository) *EntitlementUsecase {
+	return &EntitlementUsecase{
+		featureRepo: featureRepo,
+		ttl:         defaultEntitlementCacheTTL,
+		cache:       make(map[uuid.UUID]entitlementCacheEntry),
+	}
+}
+
+func (uc *EntitlementUsecase) HasFeature(ctx context.Context, tenantID uuid.UUID, featureSlug string) (bool, error) {
+	entry, err := uc.load(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := entry.features[featureSlug]
+	return ok, nil
+}
+
+func (uc *EntitlementUsecase) ListEntitlements(ctx context.Context, tenantID uuid.UUID) ([]entity.Entitlement, error) {
+	entry, err := uc.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.entitlements, nil
+}
+
+// Invalidate menghapus cache entitlement milik satu tenant.
+func (uc *EntitlementUsecase) Invalidate(tenantID uuid.UUID) {
+	uc.mu.Lock()
+	delete(uc.cache, tenantID)
+	uc.mu.Unlock()
+}
+
+func (uc *EntitlementUsecase) load(ctx context.Context, tenantID uuid.UUID) (entitlementCacheEntry, error) {
+	now := time.Now()
+
+	uc.mu.RLock()
+	entry, ok := uc.cache[tenantID]
+	uc.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	entitlements, err := uc.featureRepo.FindEntitlements(ctx, tenantID, now)
+	if err != nil {
+		return entitlementCacheEntry{}, err
+	}
+
+	sort.Slice(entitlements, func(i, j int) bool {
+		return entitlements[i].FeatureSlug < entitlements[j].FeatureSlug
+	})
+
+	// Cache tidak boleh hidup lebih lama dari entitlement yang paling cepat
+	// kedaluwarsa, supaya addon/trial yang habis langsung tidak berlaku.
+	entry = entitlementCacheEntry{
+		entitlements: entitlements,
+		features:     make(map[string]struct{}, len(entitlements)),
+		expiresAt:    now.Add(uc.ttl),
+	}
+	for _, e := range entitlements {
+		entry.features[e.FeatureSlug] = struct{}{}
+		if e.ExpiresAt != nil && e.ExpiresAt.Before(entry.expiresAt) {
+			entry.expiresAt = *e.ExpiresAt
+		}
+	}
+
+	uc.mu.Lock()
+	uc.cache[tenantID] = entry
+	uc.mu.Unlock()
+
+	return entry, nil
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const (
+	defaultImpersonationDuration = 30 * time.Minute
+	maxImpersonationDuration     = time.Hour
+)
+
+// ErrImpersonationForbidden dikembalikan jika admin tidak memiliki hak akses
+// impersonasi.
+var ErrImpersonationForbidden = errors.New("admin tidak memiliki hak akses impersonasi")
+
+type StartImpersonationInput struct {
+	UserID   uuid.UUID
+	Reason   string
+	Duration time.Duration
+	ReadOnly bool
+}
+
+type ImpersonationResult struct {
+	Session *entity.ImpersonationSession
+	User    *entity.User
+	Token   string
+}
+
+// ImpersonationUsecase mengizinkan superadmin (tim support) melihat aplikasi
+// persis seperti user tenant. Setiap sesi dibatasi waktu dan seluruh request
+// selama sesi dicatat di audit log.
+type ImpersonationUsecase struct {
+	adminRepo         repository.AdminUserRepository
+	tenantRepo        repository.TenantRepository
+	userRepo          repository.UserRepository
+	roleRepo          repository.RoleRepository
+	impersonationRepo repository.ImpersonationRepository
+	auditLogRepo      repository.AuditLogRepository
+	jwtService        *security.JWTService
+}
+
+func NewImpersonationUsecase(
+	adminRepo repository.AdminUserRepository,
+	tenantRepo repository.TenantRepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	impersonationRepo repository.ImpersonationRepository,
+	auditLogRepo repository.AuditLogRepository,
+	jwtService *security.JWTService,
+) *ImpersonationUsecase {
+	return &ImpersonationUsecase{
+		adminRepo:         adminRepo,
+		tenantRepo:        tenantRepo,
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+		impersonationRepo: impersonationRepo,
+		auditLogRepo:      auditLogRepo,
+		jwtService:        jwtService,
+	}
+}
+
+// Start membuka sesi impersonasi dan membuat token tenant atas nama user.
+func (uc *ImpersonationUsecase) Start(ctx context.Context, adminID, tenantID uuid.UUID, input StartImpersonationInput) (*ImpersonationResult, error) {
+	if err := uc.ensurePermission(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	duration := input.Duration
+	if duration <= 0 {
+		duration = defaultImpersonationDuration
+	}
+	if duration > maxImpersonationDuration {
+		return nil, fmt.Errorf("durasi impersonasi maksimal %s", maxImpersonationDuration)
+	}
+
+	if _, err := uc.tenantRepo.FindByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, tenantID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := uc.roleRepo.FindByUser(ctx, tenantID, user.ID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil role user")
+	}
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = role.Name
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	session := &entity.ImpersonationSession{
+		ID:          id,
+		AdminUserID: adminID,
+		TenantID:    tenantID,
+		UserID:      user.ID,
+		Reason:      input.Reason,
+		ReadOnly:    input.ReadOnly,
+		ExpiresAt:   now.Add(duration),
+		CreatedAt:   now,
+	}
+
+	if err := uc.impersonationRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan sesi impersonasi: %w", err)
+	}
+
+	token, err := uc.jwtService.GenerateImpersonationToken(user.ID, tenantID, roleNames, user.IsEmailVerified(), security.ActorClaims{
+		Subject:   adminID.String(),
+		SessionID: session.ID,
+		ReadOnly:  session.ReadOnly,
+	}, session.ExpiresAt)
+	if err != nil {
+		return nil, errors.New("gagal membuat token")
+	}
+
+	return &ImpersonationResult{
+		Session: session,
+		User:    user,
+		Token:   token,
+	}, nil
+}
+
+// End mengakhiri sesi lebih awal; token yang sudah terbit langsung ditolak.
+func (uc *ImpersonationUsecase) End(ctx context.Context, adminID, sessionID uuid.UUID) (*entity.ImpersonationSession, error) {
+	if err := uc.ensurePermission(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.impersonationRepo.FindByID(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.impersonationRepo.End(ctx, sessionID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return uc.impersonationRepo.FindByID(ctx, sessionID)
+}
+
+// Authorize memastikan token impersonasi masih merujuk ke sesi aktif untuk
+// user dan tenant yang sama.
+func (uc *ImpersonationUsecase) Authorize(ctx context.Context, claims *security.TenantClaims) (*entity.ImpersonationSession, error) {
+	if claims.Act == nil {
+		return nil, errors.New("token bukan token impersonasi")
+	}
+
+	session, err := uc.impersonationRepo.FindByID(ctx, claims.Act.SessionID)
+	if err != nil {
+		return nil, errors.New("sesi impersonasi tidak ditemukan")
+	}
+
+	if session.UserID != claims.UserID || session.TenantID != claims.TenantID || session.AdminUserID.String() != claims.Act.Subject {
+		return nil, errors.New("token tidak cocok dengan sesi impersonasi")
+	}
+
+	if !session.IsActive(time.Now()) {
+		return nil, errors.New("sesi impersonasi sudah berakhir")
+	}
+
+	return session, nil
+}
+
+// RecordAction mencatat satu request yang dilakukan selama impersonasi.
+func (uc *ImpersonationUsecase) RecordAction(ctx context.Context, session *entity.ImpersonationSession, method, path string, statusCode int, ipAddress, userAgent string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	return uc.auditLogRepo.Create(ctx, &entity.AuditLog{
+		ID:                     id,
+		TenantID:               session.TenantID,
+		UserID:                 &session.UserID,
+		ImpersonatorAdminID:    &session.AdminUserID,
+		ImpersonationSessionID: &session.ID,
+		Method:                 method,
+		Path:                   path,
+		StatusCode:             statusCode,
+		IPAddress:              ipAddress,
+		UserAgent:              userAgent,
+		CreatedAt:              time.Now(),
+	})
+}
+
+func (uc *ImpersonationUsecase) ListAuditLogs(ctx context.Context, adminID, sessionID uuid.UUID) ([]*entity.AuditLog, error) {
+	if err := uc.ensurePermission(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.impersonationRepo.FindByID(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	return uc.auditLogRepo.FindByImpersonationSession(ctx, sessionID)
+}
+
+func (uc *ImpersonationUsecase) ensurePermission(ctx context.Context, adminID uuid.UUID) error {
+	allowed, err := uc.adminRepo.HasPermission(ctx, adminID, entity.AdminPermissionImpersonate)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrImpersonationForbidden
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/mailer"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// invitationTTL adalah masa berlaku link undangan.
+const invitationTTL = 72 * time.Hour
+
+type InviteUserInput struct {
+	Name  string
+	Email string
+	// InvitedBy adalah user tenant yang mengirim undangan.
+	InvitedBy uuid.UUID
+}
+
+type BulkInviteResult struct {
+	UserID     uuid.UUID
+	Email      string
+	Invitation *entity.UserInvitation
+	Err        error
+}
+
+type InvitationUsecase struct {
+	userRepo       repository.UserRepository
+	invitationRepo repository.UserInvitationRepository
+	tenantRepo     repository.TenantRepository
+	signer         *security.TokenSigner
+	mailer         mailer.Mailer
+	passwords      *PasswordPolicyUsecase
+	appURL         string
+}
+
+func NewInvitationUsecase(
+	userRepo repository.UserRepository,
+	invitationRepo repository.UserInvitationRepository,
+	tenantRepo repository.TenantRepository,
+	signer *security.TokenSigner,
+	m mailer.Mailer,
+	passwords *PasswordPolicyUsecase,
+	appURL string,
+) *InvitationUsecase {
+	return &InvitationUsecase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		tenantRepo:     tenantRepo,
+		signer:         signer,
+		mailer:         m,
+		passwords:      passwords,
+		appURL:         strings.TrimRight(appURL, "/"),
+	}
+}
+
+// Invite mengundang staf lewat email. Jika email belum terdaftar, user baru
+// dibuat tanpa password; password di-set saat undangan diterima.
+func (uc *InvitationUsecase) Invite(ctx context.Context, tenantID uuid.UUID, input InviteUserInput) (*entity.UserInvitation, error) {
+	var newUser *entity.User
+
+	user, err := uc.userRepo.FindByEmail(ctx, tenantID, input.Email)
+	if err == nil {
+		if user.IsEmailVerified() {
+			return nil, errors.New("user sudah aktif")
+		}
+	} else {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+
+		now := time.Now()
+		newUser = &entity.User{
+			ID:        id,
+			TenantID:  tenantID,
+			Name:      input.Name,
+			Email:     input.Email,
+			Timezone:  "UTC",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		user = newUser
+	}
+
+	invitation, err := uc.createInvitation(ctx, user, input.InvitedBy, newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.sendInvitation(ctx, invitation, user.Name); err != nil {
+		return invitation, err
+	}
+	return invitation, nil
+}
+
+// InviteUsers mengirim undangan ke banyak user sekaligus, mis. karyawan hasil
+// import. Jika userIDs kosong, semua user yang belum terverifikasi diundang.
+// Kegagalan per user dilaporkan di hasil tanpa menghentikan user lainnya.
+func (uc *InvitationUsecase) InviteUsers(ctx context.Context, tenantID, invitedBy uuid.UUID, userIDs []uuid.UUID) ([]BulkInviteResult, error) {
+	users, err := uc.userRepo.FindUnverified(ctx, tenantID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]bool, len(users))
+	results := make([]BulkInviteResult, 0, len(users))
+	for _, user := range users {
+		found[user.ID] = true
+		result := BulkInviteResult{UserID: user.ID, Email: user.Email}
+
+		result.Invitation, result.Err = uc.createInvitation(ctx, user, invitedBy, nil)
+		if result.Err == nil {
+			result.Err = uc.sendInvitation(ctx, result.Invitation, user.Name)
+		}
+		results = append(results, result)
+	}
+
+	for _, id := range userIDs {
+		if !found[id] {
+			results = append(results, BulkInviteResult{
+				UserID: id,
+				Err:    errors.New("user tidak ditemukan atau sudah terverifikasi"),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// Resend mencabut undangan lama dan mengirim undangan baru dengan masa
+// berlaku baru.
+func (uc *InvitationUsecase) Resend(ctx context.Context, tenantID, invitationID, invitedBy uuid.UUID) (*entity.UserInvitation, error) {
+	previous, err := uc.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil || previous.TenantID != tenantID {
+		return nil, errors.New("undangan tidak ditemukan")
+	}
+	if previous.AcceptedAt != nil {
+		return nil, errors.New("undangan sudah diterima")
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, tenantID, previous.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsEmailVerified() {
+		return nil, errors.New("user sudah aktif")
+	}
+
+	invitation, err := uc.createInvitation(ctx, user, invitedBy, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.sendInvitation(ctx, invitation, user.Name); err != nil {
+		return invitation, err
+	}
+	return invitation, nil
+}
+
+// GetPendingInvitation memvalidasi token dari link undangan, dipakai frontend
+// sebelum menampilkan form set password.
+func (uc *InvitationUsecase) GetPendingInvitation(ctx context.Context, tenantID uuid.UUID, token string) (*entity.UserInvitation, error) {
+	id, err := uc.signer.Verify(security.TokenPurposeInvitation, token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(ctx, id)
+	if err != nil || invitation.TenantID != tenantID {
+		return nil, security.ErrSignedTokenInvalid
+	}
+	if !invitation.IsPending(time.Now()) {
+		return nil, errors.New("undangan sudah tidak berlaku")
+	}
+
+	return invitation, nil
+}
+
+// Accept menyimpan password dan menandai email terverifikasi sekaligus.
+func (uc *InvitationUsecase) Accept(ctx context.Context, tenantID uuid.UUID, token, password string) error {
+	invitation, err := uc.GetPendingInvitation(ctx, tenantID, token)
+	if err != nil {
+		return err
+	}
+
+	// User undangan belum punya password, jadi cukup aturan komposisi yang
+	// diperiksa (tanpa riwayat).
+	policy, err := uc.passwords.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if err := uc.passwords.CheckNewPassword(ctx, policy, nil, password); err != nil {
+		return err
+	}
+
+	user := &entity.User{}
+	if err := user.HashPassword(password); err != nil {
+		return errors.New("gagal hash password")
+	}
+
+	return uc.invitationRepo.Accept(ctx, invitation, user.Password, time.Now())
+}
+
+func (uc *InvitationUsecase) createInvitation(ctx context.Context, user *entity.User, invitedBy uuid.UUID, newUser *entity.User) (*entity.UserInvitation, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	invitation := &entity.UserInvitation{
+		ID:        id,
+		TenantID:  user.TenantID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: now.Add(invitationTTL).Truncate(time.Second),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if invitedBy != uuid.Nil {
+		invitation.InvitedBy = &invitedBy
+	}
+
+	if err := uc.invitationRepo.Create(ctx, invitation, newUser); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (uc *InvitationUsecase) sendInvitation(ctx context.Context, invitation *entity.UserInvitation, name string) error {
+	tenant, err := uc.tenantRepo.FindByID(ctx, invitation.TenantID)
+	if err != nil {
+		return err
+	}
+
+	token := uc.signer.Sign(security.TokenPurposeInvitation, invitation.ID, invitation.ExpiresAt)
+	link := fmt.Sprintf("%s/invitations/accept?tenant=%s&token=%s",
+		uc.appURL, url.QueryEscape(tenant.Slug), url.QueryEscape(token))
+
+	body := fmt.Sprintf("Halo %s,\n\n"+
+		"Anda diundang untuk bergabung ke %s. Buka link berikut untuk membuat password "+
+		"dan memverifikasi email Anda:\n\n%s\n\n"+
+		"Link ini berlaku sampai %s.\n",
+		name, tenant.Name, link, invitation.ExpiresAt.Format("02 Jan 2006 15:04 MST"))
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      []string{invitation.Email},
+		Subject: "Undangan bergabung ke " + tenant.Name,
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("undangan tersimpan tetapi email gagal dikirim: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type RecordPaymentInput struct {
+	PaymentDate   time.Time
+	AmountPaid    float64
+	PaymentMethod string
+	TransactionID string
+	Notes         string
+}
+
+type InvoiceUsecase struct {
+	invoiceRepo    repository.InvoiceRepository
+	creditNoteRepo repository.CreditNoteRepository
+	tenantRepo     repository.TenantRepository
+}
+
+func NewInvoiceUsecase(invoiceRepo repository.InvoiceRepository, creditNoteRepo repository.CreditNoteRepository, tenantRepo repository.TenantRepository) *InvoiceUsecase {
+	return &InvoiceUsecase{
+		invoiceRepo:    invoiceRepo,
+		creditNoteRepo: creditNoteRepo,
+		tenantRepo:     tenantRepo,
+	}
+}
+
+func (uc *InvoiceUsecase) ListTenantInvoices(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Invoice, util.Pagination, error) {
+	invoices, err := uc.invoiceRepo.FindByTenant(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.invoiceRepo.CountByTenant(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	pagination := query.CalculatePaginationMetadata(totalItems)
+
+	return invoices, pagination, nil
+}
+
+func (uc *InvoiceUsecase) GetInvoice(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	return uc.invoiceRepo.FindByID(ctx, id)
+}
+
+// RecordPayment mencatat pembayaran manual (transfer, dsb). Metode 'credit' dan
+// 'refund' dicadangkan untuk pemakaian saldo kredit dan credit note. Nominal
+// dibatasi sisa tagihan (total - credit note - pembayaran) yang diperiksa
+// repository di dalam transaksi.
+func (uc *InvoiceUsecase) RecordPayment(ctx context.Context, invoiceID uuid.UUID, input RecordPaymentInput) (*entity.InvoicePayment, error) {
+	invoice, err := uc.invoiceRepo.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status == entity.StatusInvoiceVoid {
+		return nil, errors.New("invoice sudah dibatalkan")
+	}
+	if invoice.Status == entity.StatusInvoicePaid {
+		return nil, errors.New("invoice sudah lunas")
+	}
+	if input.PaymentMethod == entity.PaymentMethodCredit || input.PaymentMethod == entity.PaymentMethodRefund {
+		return nil, errors.New("metode pembayaran tidak valid")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	paymentDate := input.PaymentDate
+	if paymentDate.IsZero() {
+		paymentDate = now
+	}
+
+	payment := &entity.InvoicePayment{
+		ID:            id,
+		InvoiceID:     invoiceID,
+		PaymentDate:   paymentDate,
+		AmountPaid:    entity.RoundMoney(input.AmountPaid),
+		PaymentMethod: optionalString(input.PaymentMethod),
+		TransactionID: optionalString(input.TransactionID),
+		Notes:         optionalString(input.Notes),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := uc.invoiceRepo.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// ApplyCredits memakai saldo kredit tenant (proration atau credit note) untuk
+// melunasi invoice. Mengembalikan nominal kredit yang terpakai.
+func (uc *InvoiceUsecase) ApplyCredits(ctx context.Context, invoiceID uuid.UUID) (float64, error) {
+	invoice, err := uc.invoiceRepo.FindByID(ctx, invoiceID)
+	if err != nil {
+		return 0, err
+	}
+	if invoice.Status == entity.StatusInvoicePaid || invoice.Status == entity.StatusInvoiceVoid {
+		return 0, errors.New("invoice tidak memiliki sisa tagihan")
+	}
+
+	return uc.invoiceRepo.ApplyCredits(ctx, invoiceID)
+}
+
+func (uc *InvoiceUsecase) GetAvailableCredits(ctx context.Context, tenantID uuid.UUID) (float64, error) {
+	return uc.invoiceRepo.SumAvailableCredits(ctx, tenantID)
+}
+
+// GetInvoicePDF menghasilkan PDF invoice beserta ringkasan pembayaran dan
+// credit note yang pernah diterbitkan untuk invoice tersebut.
+func (uc *InvoiceUsecase) GetInvoicePDF(ctx context.Context, id uuid.UUID) (*entity.Invoice, []byte, error) {
+	invoice, err := uc.invoiceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tenant, err := uc.tenantRepo.FindByID(ctx, invoice.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paid, err := uc.invoiceRepo.SumPayments(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	creditNotes, err := uc.creditNoteRepo.FindByInvoice(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invoice, renderInvoicePDF(tenant, invoice, paid, creditNotes), nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
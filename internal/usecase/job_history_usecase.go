@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// jobChangeBatchSize adalah jumlah perubahan terjadwal yang diproses per
+// putaran job.
+const jobChangeBatchSize = 200
+
+// JobChangeInput berisi bagian posisi yang berubah. Field nil berarti sama
+// dengan posisi sebelum tanggal berlaku; mengosongkan referensi (misalnya
+// melepas atasan) dilakukan lewat update data karyawan.
+type JobChangeInput struct {
+	ChangeType    entity.JobChangeType
+	EffectiveDate time.Time
+	Reason        string
+	DecreeNumber  *string
+
+	BranchID           *uuid.UUID
+	DepartmentID       *uuid.UUID
+	PositionID         *uuid.UUID
+	JobLevelID         *uuid.UUID
+	EmploymentStatusID *uuid.UUID
+	SBUID              *uuid.UUID
+	ManagerID          *uuid.UUID
+	BasicSalary        *float64
+}
+
+// EmploymentAsOf adalah posisi karyawan pada satu tanggal beserta baris
+// riwayat yang menjadi sumbernya.
+type EmploymentAsOf struct {
+	Date    time.Time
+	History *entity.EmployeeJobHistory
+}
+
+// JobHistoryUsecase mengelola riwayat jabatan karyawan: mutasi, promosi,
+// demosi, dan perubahan status atau gaji. Perubahan dengan tanggal berlaku
+// di masa depan diterapkan oleh ApplyDueJobChanges.
+type JobHistoryUsecase struct {
+	historyRepo   repository.EmployeeJobHistoryRepository
+	employeeRepo  repository.EmployeeRepository
+	authorization *AuthorizationUsecase
+}
+
+func NewJobHistoryUsecase(historyRepo repository.EmployeeJobHistoryRepository, employeeRepo repository.EmployeeRepository, authorization *AuthorizationUsecase) *JobHistoryUsecase {
+	return &JobHistoryUsecase{
+		historyRepo:   historyRepo,
+		employeeRepo:  employeeRepo,
+		authorization: authorization,
+	}
+}
+
+// CreateJobChange mencatat perubahan jabatan. Perubahan yang berlaku hari ini
+// atau sebelumnya langsung diterapkan; sisanya dijadwalkan.
+func (uc *JobHistoryUsecase) CreateJobChange(ctx context.Context, tenantID, employeeID uuid.UUID, createdBy *uuid.UUID, input JobChangeInput) (*entity.EmployeeJobHistory, error) {
+	if input.ChangeType == entity.JobChangeHire {
+		return nil, errors.New("riwayat hire dicatat otomatis saat karyawan dibuat")
+	}
+
+	employee, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveDate := truncateToDate(input.EffectiveDate)
+	if effectiveDate.Before(truncateToDate(employee.JoinDate)) {
+		return nil, errors.New("tanggal berlaku tidak boleh sebelum tanggal bergabung")
+	}
+	if input.ManagerID != nil && *input.ManagerID == employee.ID {
+		return nil, errors.New("karyawan tidak bisa menjadi atasan dirinya sendiri")
+	}
+
+	// Posisi baru dihitung dari posisi yang berlaku tepat sebelum tanggal
+	// berlaku, termasuk perubahan lain yang sudah terjadwal.
+	baseline := employee.Employment()
+	previous, err := uc.historyRepo.FindAsOf(ctx, tenantID, employeeID, effectiveDate)
+	if err == nil {
+		baseline = previous.Employment
+	}
+
+	employment := applyJobChangeInput(baseline, input)
+	if employment.Equal(baseline) {
+		return nil, errors.New("tidak ada perubahan posisi, status, atau gaji")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	history := &entity.EmployeeJobHistory{
+		ID:            id,
+		TenantID:      tenantID,
+		EmployeeID:    employeeID,
+		ChangeType:    input.ChangeType,
+		EffectiveDate: effectiveDate,
+		Status:        entity.JobChangeScheduled,
+		Employment:    employment,
+		Reason:        &input.Reason,
+		DecreeNumber:  input.DecreeNumber,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if !effectiveDate.After(truncateToDate(now)) {
+		history.Status = entity.JobChangeApplied
+		history.AppliedAt = &now
+	}
+
+	if err := uc.historyRepo.Create(ctx, history); err != nil {
+		return nil, err
+	}
+
+	if history.Status == entity.JobChangeApplied && scopeChanged(employee.Employment(), employment) {
+		// Posisi organisasi adalah acuan data scope user ini.
+		uc.authorization.InvalidateUser(employee.UserID)
+	}
+
+	return history, nil
+}
+
+func (uc *JobHistoryUsecase) ListJobHistories(ctx context.Context, tenantID, employeeID uuid.UUID) ([]*entity.EmployeeJobHistory, error) {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+	return uc.historyRepo.FindByEmployee(ctx, tenantID, employeeID)
+}
+
+// GetEmploymentAsOf mengembalikan posisi karyawan pada tanggal tertentu.
+func (uc *JobHistoryUsecase) GetEmploymentAsOf(ctx context.Context, tenantID, employeeID uuid.UUID, date time.Time) (*EmploymentAsOf, error) {
+	employee, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	date = truncateToDate(date)
+	if date.Before(truncateToDate(employee.JoinDate)) {
+		return nil, errors.New("karyawan belum bergabung pada tanggal tersebut")
+	}
+
+	history, err := uc.historyRepo.FindAsOf(ctx, tenantID, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmploymentAsOf{Date: date, History: history}, nil
+}
+
+func (uc *JobHistoryUsecase) CancelJobChange(ctx context.Context, tenantID, employeeID, id uuid.UUID) error {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return err
+	}
+	return uc.historyRepo.Cancel(ctx, tenantID, employeeID, id, time.Now())
+}
+
+// ApplyDueJobChanges menerapkan perubahan terjadwal semua tenant yang sudah
+// jatuh tempo. Perubahan yang tidak bisa diterapkan (misalnya jabatan
+// tujuan sudah dihapus) ditandai failed beserta alasannya.
+func (uc *JobHistoryUsecase) ApplyDueJobChanges(ctx context.Context, now time.Time) (applied, failed int, err error) {
+	ctx = repository.WithPlatformAccess(ctx)
+	today := truncateToDate(now)
+
+	for {
+		due, err := uc.historyRepo.FindDue(ctx, today, jobChangeBatchSize)
+		if err != nil {
+			return applied, failed, err
+		}
+
+		tenants := map[uuid.UUID]bool{}
+		for _, h := range due {
+			if err := uc.historyRepo.Apply(ctx, h, now); err != nil {
+				if ctx.Err() != nil {
+					return applied, failed, ctx.Err()
+				}
+				if markErr := uc.historyRepo.MarkFailed(ctx, h.ID, err.Error(), now); markErr != nil {
+					return applied, failed, markErr
+				}
+				failed++
+				continue
+			}
+			applied++
+			tenants[h.TenantID] = true
+		}
+
+		for tenantID := range tenants {
+			uc.authorization.InvalidateTenant(tenantID)
+		}
+
+		if len(due) < jobChangeBatchSize {
+			return applied, failed, nil
+		}
+	}
+}
+
+// RunDueJobChanges adalah ScheduledJob untuk ApplyDueJobChanges.
+func (uc *JobHistoryUsecase) RunDueJobChanges(ctx context.Context, now time.Time) {
+	applied, failed, err := uc.ApplyDueJobChanges(ctx, now)
+	if err != nil {
+		log.Printf("Gagal menerapkan perubahan jabatan terjadwal: %v", err)
+	} else if applied > 0 || failed > 0 {
+		log.Printf("Perubahan jabatan terjadwal: %d diterapkan, %d gagal", applied, failed)
+	}
+}
+
+func applyJobChangeInput(m entity.Employment, input JobChangeInput) entity.Employment {
+	if input.BranchID != nil {
+		m.BranchID = input.BranchID
+	}
+	if input.DepartmentID != nil {
+		m.DepartmentID = input.DepartmentID
+	}
+	if input.PositionID != nil {
+		m.PositionID = input.PositionID
+	}
+	if input.JobLevelID != nil {
+		m.JobLevelID = input.JobLevelID
+	}
+	if input.EmploymentStatusID != nil {
+		m.EmploymentStatusID = input.EmploymentStatusID
+	}
+	if input.SBUID != nil {
+		m.SBUID = input.SBUID
+	}
+	if input.ManagerID != nil {
+		m.ManagerID = input.ManagerID
+	}
+	if input.BasicSalary != nil {
+		m.BasicSalary = input.BasicSalary
+	}
+	return m
+}
+
+// scopeChanged bernilai true jika perubahan menyentuh acuan data scope.
+func scopeChanged(a, b entity.Employment) bool {
+	return !sameUUID(a.BranchID, b.BranchID) ||
+		!sameUUID(a.DepartmentID, b.DepartmentID) ||
+		!sameUUID(a.SBUID, b.SBUID) ||
+		!sameUUID(a.ManagerID, b.ManagerID)
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// terminationBatchSize adalah jumlah pengakhiran terjadwal yang diproses per
+// putaran job.
+const terminationBatchSize = 200
+
+type ChecklistTemplateInput struct {
+	Name  string
+	Type  entity.ChecklistType
+	Tasks []string
+}
+
+type TerminationInput struct {
+	Type           entity.TerminationType
+	Reason         string
+	LastWorkingDay time.Time
+	// EffectiveDate kosong berarti login dinonaktifkan sehari setelah hari
+	// kerja terakhir.
+	EffectiveDate     *time.Time
+	EligibleForRehire bool
+	// TemplateID kosong berarti memakai template offboarding pertama milik
+	// tenant; tanpa template, checklist tidak dibuat.
+	TemplateID *uuid.UUID
+}
+
+// OffboardingUsecase mengelola pengakhiran hubungan kerja karyawan beserta
+// checklist offboarding dan komponen pembayaran akhirnya.
+type OffboardingUsecase struct {
+	offboardingRepo repository.OffboardingRepository
+	employeeRepo    repository.EmployeeRepository
+	authorization   *AuthorizationUsecase
+}
+
+func NewOffboardingUsecase(offboardingRepo repository.OffboardingRepository, employeeRepo repository.EmployeeRepository, authorization *AuthorizationUsecase) *OffboardingUsecase {
+	return &OffboardingUsecase{
+		offboardingRepo: offboardingRepo,
+		employeeRepo:    employeeRepo,
+		authorization:   authorization,
+	}
+}
+
+// ------------------------------------------------------------------------
+// Template checklist
+
+func normalizeChecklistTasks(tasks []string) ([]string, error) {
+	normalized := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if t = strings.TrimSpace(t); t != "" {
+			normalized = append(normalized, t)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("template checklist harus memiliki minimal satu tugas")
+	}
+	return normalized, nil
+}
+
+func (uc *OffboardingUsecase) CreateTemplate(ctx context.Context, tenantID uuid.UUID, input ChecklistTemplateInput) (*entity.ChecklistTemplate, error) {
+	tasks, err := normalizeChecklistTasks(input.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	template := &entity.ChecklistTemplate{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      input.Name,
+		Type:      input.Type,
+		Tasks:     tasks,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.offboardingRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (uc *OffboardingUsecase) UpdateTemplate(ctx context.Context, tenantID, id uuid.UUID, input ChecklistTemplateInput) (*entity.ChecklistTemplate, error) {
+	template, err := uc.offboardingRepo.FindTemplateByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := normalizeChecklistTasks(input.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = input.Name
+	template.Type = input.Type
+	template.Tasks = tasks
+	template.UpdatedAt = time.Now()
+
+	if err := uc.offboardingRepo.UpdateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (uc *OffboardingUsecase) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	return uc.offboardingRepo.DeleteTemplate(ctx, tenantID, id)
+}
+
+func (uc *OffboardingUsecase) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*entity.ChecklistTemplate, error) {
+	return uc.offboardingRepo.FindTemplateByID(ctx, tenantID, id)
+}
+
+func (uc *OffboardingUsecase) ListTemplates(ctx context.Context, tenantID uuid.UUID, checklistType entity.ChecklistType) ([]*entity.ChecklistTemplate, error) {
+	return uc.offboardingRepo.FindTemplates(ctx, tenantID, checklistType)
+}
+
+// ------------------------------------------------------------------------
+// Pengakhiran hubungan kerja
+
+// Terminate mencatat pengakhiran hubungan kerja. Jika tanggal efektif sudah
+// tiba, login karyawan langsung dinonaktifkan; jika belum, dinonaktifkan
+// oleh ProcessDueTerminations.
+func (uc *OffboardingUsecase) Terminate(ctx context.Context, tenantID, employeeID uuid.UUID, createdBy *uuid.UUID, input TerminationInput) (*entity.EmployeeTermination, error) {
+	employee, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastWorkingDay := truncateToDate(input.LastWorkingDay)
+	if lastWorkingDay.Before(truncateToDate(employee.JoinDate)) {
+		return nil, errors.New("hari kerja terakhir tidak boleh sebelum tanggal bergabung")
+	}
+
+	effectiveDate := lastWorkingDay.AddDate(0, 0, 1)
+	if input.EffectiveDate != nil {
+		effectiveDate = truncateToDate(*input.EffectiveDate)
+		if effectiveDate.Before(lastWorkingDay) {
+			return nil, errors.New("tanggal nonaktif login tidak boleh sebelum hari kerja terakhir")
+		}
+	}
+
+	var template *entity.ChecklistTemplate
+	if input.TemplateID != nil {
+		template, err = uc.offboardingRepo.FindTemplateByID(ctx, tenantID, *input.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if template.Type != entity.ChecklistOffboarding {
+			return nil, errors.New("template checklist bukan template offboarding")
+		}
+	} else {
+		templates, err := uc.offboardingRepo.FindTemplates(ctx, tenantID, entity.ChecklistOffboarding)
+		if err != nil {
+			return nil, err
+		}
+		if len(templates) > 0 {
+			template = templates[0]
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	termination := &entity.EmployeeTermination{
+		ID:                id,
+		TenantID:          tenantID,
+		EmployeeID:        employeeID,
+		Type:              input.Type,
+		Reason:            input.Reason,
+		LastWorkingDay:    lastWorkingDay,
+		EffectiveDate:     effectiveDate,
+		EligibleForRehire: input.EligibleForRehire,
+		Status:            entity.TerminationScheduled,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if !effectiveDate.After(truncateToDate(now)) {
+		termination.Status = entity.TerminationCompleted
+		termination.CompletedAt = &now
+	}
+
+	if err := uc.offboardingRepo.Terminate(ctx, termination, template); err != nil {
+		return nil, err
+	}
+
+	if termination.Status == entity.TerminationCompleted {
+		uc.authorization.InvalidateUser(employee.UserID)
+	}
+
+	return termination, nil
+}
+
+func (uc *OffboardingUsecase) GetTermination(ctx context.Context, tenantID, employeeID uuid.UUID) (*entity.EmployeeTermination, error) {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+	return uc.offboardingRepo.FindByEmployee(ctx, tenantID, employeeID)
+}
+
+func (uc *OffboardingUsecase) CancelTermination(ctx context.Context, tenantID, employeeID uuid.UUID) error {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return err
+	}
+	return uc.offboardingRepo.Cancel(ctx, tenantID, employeeID, time.Now())
+}
+
+// UpdateChecklistTask menandai satu tugas checklist karyawan selesai atau
+// belum.
+func (uc *OffboardingUsecase) UpdateChecklistTask(ctx context.Context, tenantID, employeeID, checklistID uuid.UUID, index int, done bool, by *uuid.UUID) (*entity.EmployeeChecklist, error) {
+	if _, err := uc.employeeRepo.FindByID(ctx, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+	return uc.offboardingRepo.UpdateChecklistTask(ctx, tenantID, employeeID, checklistID, index, done, by, time.Now())
+}
+
+// ProcessDueTerminations menonaktifkan login karyawan semua tenant yang
+// tanggal efektif pengakhirannya sudah tiba.
+func (uc *OffboardingUsecase) ProcessDueTerminations(ctx context.Context, now time.Time) (int, error) {
+	ctx = repository.WithPlatformAccess(ctx)
+	today := truncateToDate(now)
+	completed := 0
+
+	for {
+		due, err := uc.offboardingRepo.FindDue(ctx, today, terminationBatchSize)
+		if err != nil {
+			return completed, err
+		}
+
+		tenants := map[uuid.UUID]bool{}
+		for _, t := range due {
+			if err := uc.offboardingRepo.Complete(ctx, t, now); err != nil {
+				return completed, err
+			}
+			completed++
+			tenants[t.TenantID] = true
+		}
+
+		for tenantID := range tenants {
+			uc.authorization.InvalidateTenant(tenantID)
+		}
+
+		if len(due) < terminationBatchSize {
+			return completed, nil
+		}
+	}
+}
+
+// RunDueTerminations adalah ScheduledJob untuk ProcessDueTerminations.
+func (uc *OffboardingUsecase) RunDueTerminations(ctx context.Context, now time.Time) {
+	completed, err := uc.ProcessDueTerminations(ctx, now)
+	if err != nil {
+		log.Printf("Gagal memproses pengakhiran hubungan kerja terjadwal: %v", err)
+	} else if completed > 0 {
+		log.Printf("Pengakhiran hubungan kerja terjadwal: %d login karyawan dinonaktifkan", completed)
+	}
+}
+
+// ------------------------------------------------------------------------
+// Komponen pembayaran akhir
+
+func (uc *OffboardingUsecase) ListFinalPayItems(ctx context.Context, tenantID uuid.UUID, status entity.FinalPayItemStatus) ([]*entity.FinalPayItem, error) {
+	return uc.offboardingRepo.FindFinalPayItems(ctx, tenantID, status)
+}
+
+// SettleFinalPayItem menandai komponen pembayaran akhir sudah diperhitungkan
+// payroll.
+func (uc *OffboardingUsecase) SettleFinalPayItem(ctx context.Context, tenantID, id uuid.UUID) error {
+	return uc.offboardingRepo.SettleFinalPayItem(ctx, tenantID, id, time.Now())
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+)
+
+// buildOrgChart menyusun daftar node datar menjadi pohon. Karyawan yang
+// atasannya tidak ada di daftar (tanpa atasan, atasan sudah resign, atau di
+// luar data scope) menjadi akar. Node yang terjebak di siklus lama juga
+// dijadikan akar agar tetap tampil.
+func buildOrgChart(nodes []*entity.OrgChartNode) []*entity.OrgChartNode {
+	byID := make(map[uuid.UUID]*entity.OrgChartNode, len(nodes))
+	for _, n := range nodes {
+		n.Reports = nil
+		byID[n.EmployeeID] = n
+	}
+
+	children := make(map[uuid.UUID][]*entity.OrgChartNode, len(nodes))
+	roots := []*entity.OrgChartNode{}
+	for _, n := range nodes {
+		if n.ManagerID != nil {
+			if _, ok := byID[*n.ManagerID]; ok && *n.ManagerID != n.EmployeeID {
+				children[*n.ManagerID] = append(children[*n.ManagerID], n)
+				continue
+			}
+		}
+		roots = append(roots, n)
+	}
+
+	visited := make(map[uuid.UUID]bool, len(nodes))
+	var attach func(n *entity.OrgChartNode, level int)
+	attach = func(n *entity.OrgChartNode, level int) {
+		visited[n.EmployeeID] = true
+		n.Level = level
+		for _, c := range children[n.EmployeeID] {
+			if visited[c.EmployeeID] {
+				continue
+			}
+			n.Reports = append(n.Reports, c)
+			attach(c, level+1)
+		}
+	}
+
+	for _, r := range roots {
+		attach(r, 0)
+	}
+	for _, n := range nodes {
+		if !visited[n.EmployeeID] {
+			roots = append(roots, n)
+			attach(n, 0)
+		}
+	}
+	return roots
+}
+
+// findOrgChartNode mencari node dengan ID tertentu di dalam pohon.
+func findOrgChartNode(roots []*entity.OrgChartNode, id uuid.UUID) *entity.OrgChartNode {
+	for _, n := range roots {
+		if n.EmployeeID == id {
+			return n
+		}
+		if found := findOrgChartNode(n.Reports, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// rebaseOrgChartLevel menghitung ulang Level agar node menjadi akar.
+func rebaseOrgChartLevel(n *entity.OrgChartNode, level int) *entity.OrgChartNode {
+	n.Level = level
+	for _, r := range n.Reports {
+		rebaseOrgChartLevel(r, level+1)
+	}
+	return n
+}
+
+func orgChartSubtitle(n *entity.OrgChartNode) string {
+	if n.PositionName != nil {
+		return *n.PositionName
+	}
+	if n.DepartmentName != nil {
+		return *n.DepartmentName
+	}
+	return n.EmployeeIDNumber
+}
+
+// renderOrgChartDOT menghasilkan bagan organisasi dalam format Graphviz DOT.
+func renderOrgChartDOT(roots []*entity.OrgChartNode) []byte {
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=rounded, fontname=\"Helvetica\"];\n")
+
+	var walk func(n *entity.OrgChartNode)
+	walk = func(n *entity.OrgChartNode) {
+		label := dotEscape(n.FullName) + `\n` + dotEscape(orgChartSubtitle(n))
+		fmt.Fprintf(&b, "  %q [label=\"%s\"];\n", n.EmployeeID.String(), label)
+		for _, r := range n.Reports {
+			fmt.Fprintf(&b, "  %q -> %q;\n", n.EmployeeID.String(), r.EmployeeID.String())
+			walk(r)
+		}
+	}
+	for _, r := range roots {
+		walk(r)
+	}
+
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+func dotEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// Ukuran kotak dan jarak antarkotak pada bagan SVG, dalam piksel.
+const (
+	orgChartBoxWidth  = 180
+	orgChartBoxHeight = 56
+	orgChartGapX      = 20
+	orgChartGapY      = 60
+	orgChartMargin    = 20
+)
+
+// renderOrgChartSVG menghasilkan bagan organisasi sebagai SVG. Setiap daun
+// mendapat satu kolom; atasan diletakkan di tengah kolom bawahannya.
+func renderOrgChartSVG(roots []*entity.OrgChartNode) []byte {
+	type placed struct {
+		x, y float64
+	}
+	pos := map[uuid.UUID]placed{}
+	slot := 0
+	maxLevel := 0
+
+	var layout func(n *entity.OrgChartNode, level int) float64
+	layout = func(n *entity.OrgChartNode, level int) float64 {
+		if level > maxLevel {
+			maxLevel = level
+		}
+		y := float64(orgChartMargin + level*(orgChartBoxHeight+orgChartGapY))
+		if len(n.Reports) == 0 {
+			x := float64(orgChartMargin + slot*(orgChartBoxWidth+orgChartGapX))
+			slot++
+			pos[n.EmployeeID] = placed{x, y}
+			return x
+		}
+		first := layout(n.Reports[0], level+1)
+		last := first
+		for _, r := range n.Reports[1:] {
+			last = layout(r, level+1)
+		}
+		x := (first + last) / 2
+		pos[n.EmployeeID] = placed{x, y}
+		return x
+	}
+	for _, r := range roots {
+		layout(r, 0)
+	}
+
+	width := orgChartMargin*2 + slot*(orgChartBoxWidth+orgChartGapX) - orgChartGapX
+	height := orgChartMargin*2 + (maxLevel+1)*(orgChartBoxHeight+orgChartGapY) - orgChartGapY
+	if slot == 0 {
+		width, height = orgChartMargin*2, orgChartMargin*2
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		width, height, width, height)
+
+	var draw func(n *entity.OrgChartNode)
+	draw = func(n *entity.OrgChartNode) {
+		p := pos[n.EmployeeID]
+		for _, r := range n.Reports {
+			c := pos[r.EmployeeID]
+			x1 := p.x + orgChartBoxWidth/2
+			y1 := p.y + orgChartBoxHeight
+			x2 := c.x + orgChartBoxWidth/2
+			midY := y1 + orgChartGapY/2
+			fmt.Fprintf(&b, `  <path d="M%.1f %.1f V%.1f H%.1f V%.1f" fill="none" stroke="#888"/>`+"\n",
+				x1, y1, midY, x2, c.y)
+			draw(r)
+		}
+
+		fmt.Fprintf(&b, `  <rect x="%.1f" y="%.1f" width="%d" height="%d" rx="6" fill="#fff" stroke="#333"/>`+"\n",
+			p.x, p.y, orgChartBoxWidth, orgChartBoxHeight)
+		cx := p.x + orgChartBoxWidth/2
+		fmt.Fprintf(&b, `  <text x="%.1f" y="%.1f" text-anchor="middle" font-size="13" font-weight="bold">%s</text>`+"\n",
+			cx, p.y+24, html.EscapeString(truncateText(n.FullName, 24)))
+		fmt.Fprintf(&b, `  <text x="%.1f" y="%.1f" text-anchor="middle" font-size="11" fill="#555">%s</text>`+"\n",
+			cx, p.y+42, html.EscapeString(truncateText(orgChartSubtitle(n), 28)))
+	}
+	for _, r := range roots {
+		draw(r)
+	}
+
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
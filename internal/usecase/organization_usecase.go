@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+// defaultAttendanceRadiusMeters sama dengan default kolom
+// branches.attendance_radius_meters.
+const defaultAttendanceRadiusMeters = 100
+
+type BranchInput struct {
+	Name      string
+	Code      *string
+	Address   *string
+	Latitude  *float64
+	Longitude *float64
+	// AttendanceRadiusMeters nil berarti memakai radius bawaan (saat dibuat)
+	// atau radius yang sudah ada (saat diperbarui).
+	AttendanceRadiusMeters *int
+}
+
+type OrgUnitInput struct {
+	Name string
+	// Description hanya disimpan untuk SBU.
+	Description *string
+}
+
+// OrganizationUsecase mengelola data master organisasi yang dirujuk data
+// karyawan: cabang, departemen, jabatan, level jabatan, SBU, dan status
+// kepegawaian.
+type OrganizationUsecase struct {
+	orgRepo       repository.OrganizationRepository
+	authorization *AuthorizationUsecase
+}
+
+func NewOrganizationUsecase(orgRepo repository.OrganizationRepository, authorization *AuthorizationUsecase) *OrganizationUsecase {
+	return &OrganizationUsecase{
+		orgRepo:       orgRepo,
+		authorization: authorization,
+	}
+}
+
+func (uc *OrganizationUsecase) CreateBranch(ctx context.Context, tenantID uuid.UUID, input BranchInput) (*entity.Branch, error) {
+	if err := validateBranchLocation(input); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	branch := &entity.Branch{
+		ID:                     id,
+		TenantID:               tenantID,
+		Name:                   input.Name,
+		Code:                   input.Code,
+		Address:                input.Address,
+		Latitude:               input.Latitude,
+		Longitude:              input.Longitude,
+		AttendanceRadiusMeters: defaultAttendanceRadiusMeters,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+	if input.AttendanceRadiusMeters != nil {
+		branch.AttendanceRadiusMeters = *input.AttendanceRadiusMeters
+	}
+
+	if err := uc.orgRepo.CreateBranch(ctx, branch); err != nil {
+		return nil, err
+	}
+
+	return uc.orgRepo.FindBranchByID(ctx, tenantID, id)
+}
+
+func (uc *OrganizationUsecase) ListBranches(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Branch, util.Pagination, error) {
+	branches, err := uc.orgRepo.FindBranches(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.orgRepo.CountBranches(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	pagination := query.CalculatePaginationMetadata(totalItems)
+
+	return branches, pagination, nil
+}
+
+func (uc *OrganizationUsecase) GetBranch(ctx context.Context, tenantID, id uuid.UUID) (*entity.Branch, error) {
+	return uc.orgRepo.FindBranchByID(ctx, tenantID, id)
+}
+
+func (uc *OrganizationUsecase) UpdateBranch(ctx context.Context, tenantID, id uuid.UUID, input BranchInput) (*entity.Branch, error) {
+	if err := validateBranchLocation(input); err != nil {
+		return nil, err
+	}
+
+	branch, err := uc.orgRepo.FindBranchByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	branch.Name = input.Name
+	branch.Code = input.Code
+	branch.Address = input.Address
+	branch.Latitude = input.Latitude
+	branch.Longitude = input.Longitude
+	if input.AttendanceRadiusMeters != nil {
+		branch.AttendanceRadiusMeters = *input.AttendanceRadiusMeters
+	}
+
+	if err := uc.orgRepo.UpdateBranch(ctx, branch); err != nil {
+		return nil, err
+	}
+
+	return uc.orgRepo.FindBranchByID(ctx, tenantID, id)
+}
+
+// DeleteBranch menolak penghapusan cabang yang masih punya karyawan aktif,
+// kecuali reassignTo diisi. Mengembalikan jumlah karyawan yang dipindahkan.
+func (uc *OrganizationUsecase) DeleteBranch(ctx context.Context, tenantID, id uuid.UUID, reassignTo *uuid.UUID) (int64, error) {
+	moved, err := uc.orgRepo.DeleteBranch(ctx, tenantID, id, reassignTo, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if moved > 0 {
+		// Cabang adalah acuan data scope "branch".
+		uc.authorization.InvalidateTenant(tenantID)
+	}
+	return moved, nil
+}
+
+func (uc *OrganizationUsecase) CreateUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, input OrgUnitInput) (*entity.OrgUnit, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	unit := &entity.OrgUnit{
+		ID:        id,
+		TenantID:  tenantID,
+		Kind:      kind,
+		Name:      input.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if kind.HasDescription() {
+		unit.Description = input.Description
+	}
+
+	if err := uc.orgRepo.CreateUnit(ctx, unit); err != nil {
+		return nil, err
+	}
+
+	return uc.orgRepo.FindUnitByID(ctx, kind, tenantID, id)
+}
+
+func (uc *OrganizationUsecase) ListUnits(ctx context.Context, kind entity.OrgUnitKind, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.OrgUnit, util.Pagination, error) {
+	units, err := uc.orgRepo.FindUnits(ctx, kind, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.orgRepo.CountUnits(ctx, kind, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	pagination := query.CalculatePaginationMetadata(totalItems)
+
+	return units, pagination, nil
+}
+
+func (uc *OrganizationUsecase) GetUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID) (*entity.OrgUnit, error) {
+	return uc.orgRepo.FindUnitByID(ctx, kind, tenantID, id)
+}
+
+func (uc *OrganizationUsecase) UpdateUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID, input OrgUnitInput) (*entity.OrgUnit, error) {
+	unit, err := uc.orgRepo.FindUnitByID(ctx, kind, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	unit.Name = input.Name
+	if kind.HasDescription() {
+		unit.Description = input.Description
+	}
+
+	if err := uc.orgRepo.UpdateUnit(ctx, unit); err != nil {
+		return nil, err
+	}
+
+	return uc.orgRepo.FindUnitByID(ctx, kind, tenantID, id)
+}
+
+// DeleteUnit berperilaku sama dengan DeleteBranch.
+func (uc *OrganizationUsecase) DeleteUnit(ctx context.Context, kind entity.OrgUnitKind, tenantID, id uuid.UUID, reassignTo *uuid.UUID) (int64, error) {
+	moved, err := uc.orgRepo.DeleteUnit(ctx, kind, tenantID, id, reassignTo, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if moved > 0 && (kind == entity.OrgUnitDepartment || kind == entity.OrgUnitSBU) {
+		// Departemen dan SBU adalah acuan data scope.
+		uc.authorization.InvalidateTenant(tenantID)
+	}
+	return moved, nil
+}
+
+// validateBranchLocation memastikan koordinat diisi berpasangan dan berada
+// dalam rentang yang valid.
+func validateBranchLocation(input BranchInput) error {
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		return errors.New("latitude dan longitude harus diisi bersamaan")
+	}
+	if input.Latitude != nil && (*input.Latitude < -90 || *input.Latitude > 90) {
+		return errors.New("latitude harus di antara -90 dan 90")
+	}
+	if input.Longitude != nil && (*input.Longitude < -180 || *input.Longitude > 180) {
+		return errors.New("longitude harus di antara -180 dan 180")
+	}
+	if input.AttendanceRadiusMeters != nil && *input.AttendanceRadiusMeters < 0 {
+		return errors.New("radius absensi tidak boleh negatif")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type PasswordPolicyInput struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	ExpiryDays       int
+	HistoryCount     int
+	BlockCommon      bool
+}
+
+// PasswordPolicyUsecase mengelola kebijakan password per tenant dan menjadi
+// satu-satunya jalur untuk menyimpan password baru staf, sehingga aturan
+// komposisi dan riwayat password selalu diperiksa.
+type PasswordPolicyUsecase struct {
+	policyRepo repository.PasswordPolicyRepository
+	userRepo   repository.UserRepository
+}
+
+func NewPasswordPolicyUsecase(policyRepo repository.PasswordPolicyRepository, userRepo repository.UserRepository) *PasswordPolicyUsecase {
+	return &PasswordPolicyUsecase{
+		policyRepo: policyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// GetPolicy mengembalikan kebijakan tenant, atau kebijakan bawaan jika
+// tenant belum mengaturnya.
+func (uc *PasswordPolicyUsecase) GetPolicy(ctx context.Context, tenantID uuid.UUID) (*entity.PasswordPolicy, error) {
+	policy, err := uc.policyRepo.FindByTenant(ctx, tenantID)
+	if errors.Is(err, repository.ErrPasswordPolicyNotFound) {
+		return entity.DefaultPasswordPolicy(tenantID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetRules mengembalikan aturan komposisi password tenant untuk validator
+// "password_policy".
+func (uc *PasswordPolicyUsecase) GetRules(ctx context.Context, tenantID uuid.UUID) (util.PasswordRules, error) {
+	policy, err := uc.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return util.PasswordRules{}, err
+	}
+	return passwordRules(policy), nil
+}
+
+func (uc *PasswordPolicyUsecase) SavePolicy(ctx context.Context, tenantID uuid.UUID, input PasswordPolicyInput) (*entity.PasswordPolicy, error) {
+	policy, err := uc.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if policy.CreatedAt.IsZero() {
+		policy.CreatedAt = now
+	}
+	policy.MinLength = input.MinLength
+	policy.RequireUppercase = input.RequireUppercase
+	policy.RequireLowercase = input.RequireLowercase
+	policy.RequireDigit = input.RequireDigit
+	policy.RequireSymbol = input.RequireSymbol
+	policy.ExpiryDays = input.ExpiryDays
+	policy.HistoryCount = input.HistoryCount
+	policy.BlockCommon = input.BlockCommon
+	policy.UpdatedAt = now
+
+	if err := uc.policyRepo.Save(ctx, policy); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan kebijakan password: %w", err)
+	}
+	return policy, nil
+}
+
+// CheckNewPassword memeriksa password baru terhadap kebijakan tenant. Jika
+// user diisi, password juga tidak boleh sama dengan password saat ini atau
+// HistoryCount password sebelumnya.
+func (uc *PasswordPolicyUsecase) CheckNewPassword(ctx context.Context, policy *entity.PasswordPolicy, user *entity.User, password string) error {
+	if violations := passwordRules(policy).Violations(password); len(violations) > 0 {
+		return fmt.Errorf("password %s", strings.Join(violations, ", "))
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	if user.Password != "" && user.CheckPassword(password) {
+		return errors.New("password baru tidak boleh sama dengan password saat ini")
+	}
+
+	if policy.HistoryCount > 0 {
+		hashes, err := uc.userRepo.FindPasswordHistory(ctx, user.ID, policy.HistoryCount)
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			old := entity.User{Password: hash}
+			if old.CheckPassword(password) {
+				return fmt.Errorf("password tidak boleh sama dengan %d password sebelumnya", policy.HistoryCount)
+			}
+		}
+	}
+	return nil
+}
+
+// SetPassword memeriksa lalu menyimpan password baru user beserta riwayatnya.
+func (uc *PasswordPolicyUsecase) SetPassword(ctx context.Context, policy *entity.PasswordPolicy, user *entity.User, password string) error {
+	if err := uc.CheckNewPassword(ctx, policy, user, password); err != nil {
+		return err
+	}
+
+	if err := user.HashPassword(password); err != nil {
+		return errors.New("gagal hash password")
+	}
+
+	return uc.userRepo.UpdatePassword(ctx, user, policy.HistoryCount)
+}
+
+func passwordRules(policy *entity.PasswordPolicy) util.PasswordRules {
+	return util.PasswordRules{
+		MinLength:        policy.MinLength,
+		RequireUppercase: policy.RequireUppercase,
+		RequireLowercase: policy.RequireLowercase,
+		RequireDigit:     policy.RequireDigit,
+		RequireSymbol:    policy.RequireSymbol,
+		BlockCommon:      policy.BlockCommon,
+	}
+}
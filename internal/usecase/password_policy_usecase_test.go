@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordHistoryRepo adalah UserRepository palsu yang hanya menyediakan
+// riwayat password.
+type passwordHistoryRepo struct {
+	repository.UserRepository
+	hashes []string
+	// limit adalah limit pemanggilan terakhir; -1 jika tidak pernah dipanggil.
+	limit int
+}
+
+func (r *passwordHistoryRepo) FindPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	r.limit = limit
+	if limit < len(r.hashes) {
+		return r.hashes[:limit], nil
+	}
+	return r.hashes, nil
+}
+
+// testPasswordHash memakai cost minimum agar test tidak lambat;
+// CheckPassword menerima hash dengan cost berapa pun.
+func testPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("gagal hash password: %v", err)
+	}
+	return string(hash)
+}
+
+func TestPasswordPolicyCheckNewPassword(t *testing.T) {
+	current := "Sekarang-Aman-01"
+	previous := []string{"Lama-Sekali-03", "Lama-Sekali-02", "Lama-Sekali-01"}
+	history := make([]string, len(previous))
+	for i, p := range previous {
+		history[i] = testPasswordHash(t, p)
+	}
+	user := &entity.User{ID: uuid.New(), Password: testPasswordHash(t, current)}
+
+	policy := func(historyCount int) *entity.PasswordPolicy {
+		p := entity.DefaultPasswordPolicy(uuid.New())
+		p.RequireDigit = true
+		p.HistoryCount = historyCount
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		policy    *entity.PasswordPolicy
+		user      *entity.User
+		password  string
+		wantErr   string
+		wantLimit int
+	}{
+		{"password baru valid", policy(3), user, "Benar-Baru-99", "", 3},
+		{"tanpa user tidak memeriksa riwayat", policy(3), nil, "Benar-Baru-99", "", -1},
+		{"melanggar komposisi", policy(3), user, "pendek", "password minimal 10 karakter, wajib mengandung angka", -1},
+		{"password umum", policy(3), user, "bismillah2024", "password terlalu umum atau pernah bocor", -1},
+		{"sama dengan password saat ini", policy(3), user, current, "tidak boleh sama dengan password saat ini", -1},
+		{"sama dengan riwayat terbaru", policy(3), user, previous[0], "tidak boleh sama dengan 3 password sebelumnya", 3},
+		{"sama dengan riwayat terakhir yang dijaga", policy(3), user, previous[2], "tidak boleh sama dengan 3 password sebelumnya", 3},
+		{"riwayat di luar HistoryCount boleh dipakai", policy(2), user, previous[2], "", 2},
+		{"HistoryCount 0 tidak memeriksa riwayat", policy(0), user, previous[0], "", -1},
+		{"user tanpa password (undangan)", policy(3), &entity.User{ID: uuid.New()}, "Benar-Baru-99", "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &passwordHistoryRepo{hashes: history, limit: -1}
+			uc := NewPasswordPolicyUsecase(nil, repo)
+
+			err := uc.CheckNewPassword(context.Background(), tt.policy, tt.user, tt.password)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("error tidak diharapkan: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("err = %v, want mengandung %q", err, tt.wantErr)
+			}
+			if repo.limit != tt.wantLimit {
+				t.Errorf("FindPasswordHistory limit = %d, want %d", repo.limit, tt.wantLimit)
+			}
+		})
+	}
+}
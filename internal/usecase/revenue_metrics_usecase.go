@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// ErrRevenueReportForbidden dikembalikan jika admin tidak memiliki hak akses
+// laporan revenue global.
+var ErrRevenueReportForbidden = errors.New("admin tidak memiliki hak akses laporan revenue")
+
+type RevenueMetricsUsecase struct {
+	metricsRepo repository.RevenueMetricsRepository
+	adminRepo   repository.AdminUserRepository
+}
+
+func NewRevenueMetricsUsecase(metricsRepo repository.RevenueMetricsRepository, adminRepo repository.AdminUserRepository) *RevenueMetricsUsecase {
+	return &RevenueMetricsUsecase{
+		metricsRepo: metricsRepo,
+		adminRepo:   adminRepo,
+	}
+}
+
+// GetMetrics menghitung metrik SaaS untuk rentang [from, to).
+// Pergerakan MRR dihitung dengan membandingkan MRR per tenant pada 'from' dan 'to':
+// tenant baru = new, naik = expansion, turun = contraction, hilang = churn.
+func (uc *RevenueMetricsUsecase) GetMetrics(ctx context.Context, adminID uuid.UUID, from, to time.Time) (*entity.RevenueMetrics, error) {
+	if err := uc.ensurePermission(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, errors.New("tanggal awal harus sebelum tanggal akhir")
+	}
+
+	start, err := uc.metricsRepo.FindMRRByTenant(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := uc.metricsRepo.FindMRRByTenant(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &entity.RevenueMetrics{
+		From: from,
+		To:   to,
+	}
+
+	startByTenant := sumMRRByTenant(start)
+	endByTenant := sumMRRByTenant(end)
+
+	for tenantID, mrr := range endByTenant {
+		metrics.MRR += mrr
+
+		before := startByTenant[tenantID]
+		switch {
+		case before == 0:
+			metrics.NewMRR += mrr
+		case mrr > before:
+			metrics.ExpansionMRR += mrr - before
+		case mrr < before:
+			metrics.ContractionMRR += before - mrr
+		}
+	}
+	for tenantID, mrr := range startByTenant {
+		if _, ok := endByTenant[tenantID]; !ok {
+			metrics.ChurnedMRR += mrr
+		}
+	}
+
+	metrics.MRR = entity.RoundMoney(metrics.MRR)
+	metrics.ARR = entity.RoundMoney(metrics.MRR * 12)
+	metrics.NewMRR = entity.RoundMoney(metrics.NewMRR)
+	metrics.ExpansionMRR = entity.RoundMoney(metrics.ExpansionMRR)
+	metrics.ContractionMRR = entity.RoundMoney(metrics.ContractionMRR)
+	metrics.ChurnedMRR = entity.RoundMoney(metrics.ChurnedMRR)
+	metrics.NetNewMRR = entity.RoundMoney(metrics.NewMRR + metrics.ExpansionMRR - metrics.ContractionMRR - metrics.ChurnedMRR)
+
+	if metrics.CollectedRevenue, err = uc.metricsRepo.SumCollectedRevenue(ctx, from, to); err != nil {
+		return nil, err
+	}
+	if metrics.CreditNotesIssued, metrics.Refunds, err = uc.metricsRepo.SumCreditNotes(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	if metrics.TrialsStarted, metrics.TrialsConverted, err = uc.metricsRepo.CountTrialConversions(ctx, from, to); err != nil {
+		return nil, err
+	}
+	if metrics.TrialsStarted > 0 {
+		metrics.TrialConversionRate = entity.RoundMoney(float64(metrics.TrialsConverted) / float64(metrics.TrialsStarted) * 100)
+	}
+
+	if metrics.TenantsByStatus, err = uc.metricsRepo.CountTenantsByStatus(ctx); err != nil {
+		return nil, err
+	}
+
+	if metrics.TenantsByPlan, err = uc.metricsRepo.CountTenantsByPlan(ctx, to); err != nil {
+		return nil, err
+	}
+	mrrByPlan := map[uuid.UUID]float64{}
+	for _, m := range end {
+		mrrByPlan[m.PlanID] += m.MRR
+	}
+	for i := range metrics.TenantsByPlan {
+		metrics.TenantsByPlan[i].MRR = entity.RoundMoney(mrrByPlan[metrics.TenantsByPlan[i].PlanID])
+	}
+
+	return metrics, nil
+}
+
+// GetReceivablesAging mengelompokkan piutang yang belum lunas per 'asOf'
+// ke bucket 30/60/90 hari berdasarkan tanggal jatuh tempo.
+func (uc *RevenueMetricsUsecase) GetReceivablesAging(ctx context.Context, adminID uuid.UUID, asOf time.Time) (*entity.ReceivablesAging, error) {
+	if err := uc.ensurePermission(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	invoices, err := uc.metricsRepo.FindOutstandingInvoices(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	aging := &entity.ReceivablesAging{
+		AsOf:     asOf,
+		Totals:   make(map[string]float64, len(entity.AgingBuckets)),
+		Invoices: invoices,
+	}
+	for _, bucket := range entity.AgingBuckets {
+		aging.Totals[bucket] = 0
+	}
+	for _, invoice := range invoices {
+		bucket := entity.AgingBucket(invoice.DaysPastDue)
+		aging.Totals[bucket] = entity.RoundMoney(aging.Totals[bucket] + invoice.Outstanding)
+	}
+
+	return aging, nil
+}
+
+func sumMRRByTenant(rows []repository.TenantMRR) map[uuid.UUID]float64 {
+	result := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		result[row.TenantID] += row.MRR
+	}
+	return result
+}
+
+func (uc *RevenueMetricsUsecase) ensurePermission(ctx context.Context, adminID uuid.UUID) error {
+	allowed, err := uc.adminRepo.HasPermission(ctx, adminID, entity.AdminPermissionViewGlobalRevenue)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrRevenueReportForbidden
+	}
+	return nil
+}
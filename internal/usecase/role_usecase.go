@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+	"github.com/maskholilaziz/hris-go/pkg/util"
+)
+
+type RoleInput struct {
+	Name        string
+	Description *string
+	// Permissions cukup diisi Name dan DataScope (default tenant).
+	Permissions []entity.PermissionGrant
+}
+
+// UserAccess merangkum akses seorang user: role, hak akses langsung, dan
+// hasil gabungannya.
+type UserAccess struct {
+	UserID               uuid.UUID
+	Roles                []*entity.Role
+	DirectPermissions    []*entity.Permission
+	EffectivePermissions []string
+	DataScopes           map[string][]entity.DataScope
+}
+
+type RoleUsecase struct {
+	roleRepo       repository.RoleRepository
+	permissionRepo repository.PermissionRepository
+	userRepo       repository.UserRepository
+	invitations    *InvitationUsecase
+	authorization  *AuthorizationUsecase
+}
+
+func NewRoleUsecase(
+	roleRepo repository.RoleRepository,
+	permissionRepo repository.PermissionRepository,
+	userRepo repository.UserRepository,
+	invitations *InvitationUsecase,
+	authorization *AuthorizationUsecase,
+) *RoleUsecase {
+	return &RoleUsecase{
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+		userRepo:       userRepo,
+		invitations:    invitations,
+		authorization:  authorization,
+	}
+}
+
+func (uc *RoleUsecase) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	return uc.permissionRepo.FindAll(ctx)
+}
+
+func (uc *RoleUsecase) CreateRole(ctx context.Context, tenantID uuid.UUID, input RoleInput) (*entity.Role, error) {
+	grants, err := uc.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	role := &entity.Role{
+		ID:          id,
+		TenantID:    tenantID,
+		Name:        input.Name,
+		Description: input.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := uc.roleRepo.Create(ctx, role, grants); err != nil {
+		return nil, err
+	}
+
+	return uc.roleRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *RoleUsecase) ListRoles(ctx context.Context, tenantID uuid.UUID, query util.PaginationQuery) ([]*entity.Role, util.Pagination, error) {
+	roles, err := uc.roleRepo.Find(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	totalItems, err := uc.roleRepo.Count(ctx, tenantID, query)
+	if err != nil {
+		return nil, util.Pagination{}, err
+	}
+
+	pagination := query.CalculatePaginationMetadata(totalItems)
+
+	return roles, pagination, nil
+}
+
+func (uc *RoleUsecase) GetRole(ctx context.Context, tenantID, id uuid.UUID) (*entity.Role, error) {
+	return uc.roleRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *RoleUsecase) UpdateRole(ctx context.Context, tenantID, id uuid.UUID, input RoleInput) (*entity.Role, error) {
+	role, err := uc.roleRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := uc.resolvePermissions(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.Name == entity.AdministratorRoleName && input.Name != role.Name {
+		return nil, errors.New("role Administrator tidak boleh diganti namanya")
+	}
+
+	role.Name = input.Name
+	role.Description = input.Description
+
+	if err := uc.roleRepo.Update(ctx, role, grants); err != nil {
+		return nil, err
+	}
+	uc.authorization.InvalidateTenant(tenantID)
+
+	return uc.roleRepo.FindByID(ctx, tenantID, id)
+}
+
+func (uc *RoleUsecase) DeleteRole(ctx context.Context, tenantID, id uuid.UUID) error {
+	role, err := uc.roleRepo.FindByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if role.Name == entity.AdministratorRoleName {
+		return errors.New("role Administrator tidak boleh dihapus")
+	}
+
+	if err := uc.roleRepo.Delete(ctx, tenantID, id); err != nil {
+		return err
+	}
+	uc.authorization.InvalidateTenant(tenantID)
+	return nil
+}
+
+func (uc *RoleUsecase) GetUserAccess(ctx context.Context, tenantID, userID uuid.UUID) (*UserAccess, error) {
+	if _, err := uc.userRepo.FindByID(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := uc.roleRepo.FindByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	direct, err := uc.permissionRepo.FindDirectByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := uc.authorization.EffectivePermissions(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := uc.authorization.EffectiveDataScopes(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserAccess{
+		UserID:               userID,
+		Roles:                roles,
+		DirectPermissions:    direct,
+		EffectivePermissions: effective,
+		DataScopes:           scopes,
+	}, nil
+}
+
+// SyncUserRoles mengganti seluruh role milik user.
+func (uc *RoleUsecase) SyncUserRoles(ctx context.Context, tenantID, userID uuid.UUID, roleIDs []uuid.UUID) (*UserAccess, error) {
+	if _, err := uc.userRepo.FindByID(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	for _, roleID := range roleIDs {
+		if _, err := uc.roleRepo.FindByID(ctx, tenantID, roleID); err != nil {
+			return nil, fmt.Errorf("role %s tidak ditemukan", roleID)
+		}
+	}
+
+	if err := uc.roleRepo.SyncUserRoles(ctx, tenantID, userID, roleIDs); err != nil {
+		return nil, err
+	}
+	uc.authorization.InvalidateUser(userID)
+
+	return uc.GetUserAccess(ctx, tenantID, userID)
+}
+
+// SyncUserPermissions mengganti seluruh hak akses yang diberikan langsung ke user.
+func (uc *RoleUsecase) SyncUserPermissions(ctx context.Context, tenantID, userID uuid.UUID, permissions []entity.PermissionGrant) (*UserAccess, error) {
+	if _, err := uc.userRepo.FindByID(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	grants, err := uc.resolvePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.permissionRepo.SyncUserPermissions(ctx, userID, grants); err != nil {
+		return nil, err
+	}
+	uc.authorization.InvalidateUser(userID)
+
+	return uc.GetUserAccess(ctx, tenantID, userID)
+}
+
+// InviteAdministrator dipakai superadmin untuk onboarding tenant: role
+// Administrator (semua hak akses) dibuat jika belum ada, lalu admin pertama
+// tenant diundang dan diberi role tersebut.
+func (uc *RoleUsecase) InviteAdministrator(ctx context.Context, tenantID uuid.UUID, name, email string) (*entity.UserInvitation, error) {
+	role, err := uc.ensureAdministratorRole(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, inviteErr := uc.invitations.Invite(ctx, tenantID, InviteUserInput{Name: name, Email: email})
+	if invitation == nil {
+		return nil, inviteErr
+	}
+
+	roles, err := uc.roleRepo.FindByUser(ctx, tenantID, invitation.UserID)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := []uuid.UUID{role.ID}
+	for _, existing := range roles {
+		if existing.ID != role.ID {
+			roleIDs = append(roleIDs, existing.ID)
+		}
+	}
+
+	if err := uc.roleRepo.SyncUserRoles(ctx, tenantID, invitation.UserID, roleIDs); err != nil {
+		return nil, err
+	}
+	uc.authorization.InvalidateUser(invitation.UserID)
+
+	// inviteErr di sini hanya bisa berupa kegagalan kirim email; undangan tetap
+	// tersimpan dan bisa dikirim ulang.
+	return invitation, inviteErr
+}
+
+func (uc *RoleUsecase) ensureAdministratorRole(ctx context.Context, tenantID uuid.UUID) (*entity.Role, error) {
+	permissions, err := uc.permissionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]entity.PermissionGrant, len(permissions))
+	for i, p := range permissions {
+		grants[i] = entity.PermissionGrant{Name: p.Name, DataScope: entity.DataScopeTenant}
+	}
+	input := RoleInput{Name: entity.AdministratorRoleName, Permissions: grants}
+
+	role, err := uc.roleRepo.FindByName(ctx, tenantID, entity.AdministratorRoleName)
+	if err != nil {
+		return uc.CreateRole(ctx, tenantID, input)
+	}
+
+	// Sinkronkan ulang supaya permission baru di katalog ikut dimiliki Administrator.
+	return uc.UpdateRole(ctx, tenantID, role.ID, input)
+}
+
+// resolvePermissions memvalidasi nama hak akses terhadap katalog dan data
+// scope-nya, lalu mengisi PermissionID. Data scope kosong berarti tenant.
+func (uc *RoleUsecase) resolvePermissions(ctx context.Context, grants []entity.PermissionGrant) ([]entity.PermissionGrant, error) {
+	if len(grants) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(grants))
+	for i, g := range grants {
+		names[i] = g.Name
+	}
+
+	permissions, err := uc.permissionRepo.FindByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]uuid.UUID, len(permissions))
+	for _, p := range permissions {
+		known[p.Name] = p.ID
+	}
+
+	unknown := []string{}
+	resolved := make([]entity.PermissionGrant, 0, len(grants))
+	seen := make(map[string]bool, len(grants))
+	for _, g := range grants {
+		if seen[g.Name] {
+			return nil, fmt.Errorf("hak akses %s diisi lebih dari sekali", g.Name)
+		}
+		seen[g.Name] = true
+
+		id, ok := known[g.Name]
+		if !ok {
+			unknown = append(unknown, g.Name)
+			continue
+		}
+
+		if g.DataScope == "" {
+			g.DataScope = entity.DataScopeTenant
+		}
+		if !g.DataScope.IsValid() {
+			return nil, fmt.Errorf("data scope %s untuk hak akses %s tidak valid", g.DataScope, g.Name)
+		}
+
+		g.PermissionID = id
+		resolved = append(resolved, g)
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("hak akses tidak dikenal: %v", unknown)
+	}
+	return resolved, nil
+}
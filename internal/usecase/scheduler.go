@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// ScheduledJob adalah pekerjaan latar yang dijalankan RunScheduler. Job
+// sendiri yang mencatat hasil dan error-nya ke log.
+type ScheduledJob func(ctx context.Context, now time.Time)
+
+// RunScheduler menjalankan semua job saat start dan setiap interval sampai
+// ctx dibatalkan. Job dijalankan berurutan, bukan paralel.
+func RunScheduler(ctx context.Context, interval time.Duration, jobs ...ScheduledJob) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				return
+			}
+			job(ctx, time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,751 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/scim"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const (
+	scimTokenKind = "scim"
+	// scimTokenTouchInterval membatasi update last_used_at agar tidak
+	// menulis ke database di setiap request.
+	scimTokenTouchInterval = time.Minute
+)
+
+// SCIMUsecase menangani provisioning SCIM 2.0. User SCIM dipetakan ke users +
+// employees (satu karyawan per user), Group dipetakan ke role tenant.
+// Menonaktifkan user mengisi login_disabled_at dan resign_date.
+type SCIMUsecase struct {
+	scimRepo      repository.SCIMRepository
+	roleRepo      repository.RoleRepository
+	employeeLimit *EmployeeLimitUsecase
+	authorization *AuthorizationUsecase
+}
+
+func NewSCIMUsecase(
+	scimRepo repository.SCIMRepository,
+	roleRepo repository.RoleRepository,
+	employeeLimit *EmployeeLimitUsecase,
+	authorization *AuthorizationUsecase,
+) *SCIMUsecase {
+	return &SCIMUsecase{
+		scimRepo:      scimRepo,
+		roleRepo:      roleRepo,
+		employeeLimit: employeeLimit,
+		authorization: authorization,
+	}
+}
+
+// ------------------------------------------------------------------------
+// Token
+// ------------------------------------------------------------------------
+
+// CreateToken membuat token SCIM baru. Plaintext hanya dikembalikan sekali.
+func (uc *SCIMUsecase) CreateToken(ctx context.Context, tenantID uuid.UUID, name string) (*entity.SCIMToken, string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, "", fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	secret, err := security.NewOpaqueToken(scimTokenKind)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &entity.SCIMToken{
+		ID:          id,
+		TenantID:    tenantID,
+		Name:        name,
+		TokenPrefix: secret.Prefix,
+		TokenHash:   secret.Hash,
+		CreatedAt:   time.Now(),
+	}
+	if err := uc.scimRepo.CreateToken(ctx, token); err != nil {
+		return nil, "", fmt.Errorf("gagal menyimpan token SCIM: %w", err)
+	}
+
+	return token, secret.Plaintext, nil
+}
+
+func (uc *SCIMUsecase) ListTokens(ctx context.Context, tenantID uuid.UUID) ([]*entity.SCIMToken, error) {
+	return uc.scimRepo.FindTokens(ctx, tenantID)
+}
+
+func (uc *SCIMUsecase) RevokeToken(ctx context.Context, tenantID, id uuid.UUID) error {
+	return uc.scimRepo.RevokeToken(ctx, tenantID, id, time.Now())
+}
+
+// Authenticate memvalidasi bearer token SCIM milik tenant.
+func (uc *SCIMUsecase) Authenticate(ctx context.Context, tenantID uuid.UUID, plaintext string) (*entity.SCIMToken, error) {
+	if !strings.HasPrefix(plaintext, scimTokenKind+"_") {
+		return nil, errors.New("token SCIM tidak valid")
+	}
+
+	token, err := uc.scimRepo.FindActiveTokenByHash(ctx, tenantID, security.HashOpaqueToken(plaintext))
+	if err != nil {
+		return nil, errors.New("token SCIM tidak valid")
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > scimTokenTouchInterval {
+		// Gagal mencatat pemakaian tidak boleh menggagalkan request.
+		_ = uc.scimRepo.TouchToken(ctx, token.ID, now)
+	}
+	return token, nil
+}
+
+// ------------------------------------------------------------------------
+// Users
+// ------------------------------------------------------------------------
+
+func (uc *SCIMUsecase) ListUsers(ctx context.Context, tenantID uuid.UUID, filter string, startIndex, count int) ([]*entity.SCIMUser, int64, error) {
+	parsed, err := scim.ParseFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := uc.scimRepo.CountUsers(ctx, tenantID, parsed)
+	if err != nil {
+		return nil, 0, scimRepoError(err)
+	}
+
+	users, err := uc.scimRepo.FindUsers(ctx, tenantID, repository.SCIMListQuery{Filter: parsed, StartIndex: startIndex, Count: count})
+	if err != nil {
+		return nil, 0, scimRepoError(err)
+	}
+	return users, total, nil
+}
+
+func (uc *SCIMUsecase) GetUser(ctx context.Context, tenantID, id uuid.UUID) (*entity.SCIMUser, error) {
+	su, err := uc.scimRepo.FindUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, scimRepoError(err)
+	}
+	return su, nil
+}
+
+// CreateUser membuat akun user beserta data karyawannya. Karyawan aktif
+// memakai kuota paket. User SCIM tidak memiliki password sehingga login
+// dilakukan lewat SSO.
+func (uc *SCIMUsecase) CreateUser(ctx context.Context, tenantID uuid.UUID, res *scim.User) (*entity.SCIMUser, error) {
+	email, err := scimUserEmail(res)
+	if err != nil {
+		return nil, err
+	}
+
+	active := res.Active == nil || bool(*res.Active)
+	if active {
+		if err := uc.employeeLimit.EnsureCapacity(ctx, tenantID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	userID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+	employeeID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	today := truncateToDate(now)
+
+	employeeNumber := scimEmployeeNumber(res)
+	if employeeNumber == "" {
+		// IdP tidak selalu mengirim employeeNumber; pakai nomor sementara yang
+		// unik dan bisa diganti admin.
+		employeeNumber = "SCIM-" + strings.ToUpper(strings.ReplaceAll(employeeID.String(), "-", "")[20:])
+	}
+
+	su := &entity.SCIMUser{
+		User: entity.User{
+			ID:              userID,
+			TenantID:        tenantID,
+			Name:            scimFullName(res, email),
+			Email:           email,
+			EmailVerifiedAt: &now,
+			Timezone:        "UTC",
+			SCIMExternalID:  optionalString(strings.TrimSpace(res.ExternalID)),
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		},
+		EmployeeID:     &employeeID,
+		EmployeeNumber: &employeeNumber,
+		JoinDate:       &today,
+		Groups:         []entity.SCIMGroupRef{},
+	}
+	if !active {
+		su.User.LoginDisabledAt = &now
+		su.ResignDate = &today
+	}
+
+	if err := uc.scimRepo.CreateUser(ctx, su); err != nil {
+		return nil, scimRepoError(err)
+	}
+
+	return uc.GetUser(ctx, tenantID, userID)
+}
+
+// ReplaceUser menangani PUT: atribut yang dikelola diganti dengan isi request.
+func (uc *SCIMUsecase) ReplaceUser(ctx context.Context, tenantID, id uuid.UUID, res *scim.User) (*entity.SCIMUser, error) {
+	su, err := uc.GetUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := scimUserEmail(res)
+	if err != nil {
+		return nil, err
+	}
+
+	su.User.Email = email
+	su.User.Name = scimFullName(res, email)
+	su.User.SCIMExternalID = optionalString(strings.TrimSpace(res.ExternalID))
+	if number := scimEmployeeNumber(res); number != "" && su.EmployeeID != nil {
+		su.EmployeeNumber = &number
+	}
+
+	if res.Active != nil {
+		if err := uc.setActive(ctx, su, bool(*res.Active)); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.saveUser(ctx, su)
+}
+
+// PatchUser menerapkan operasi PATCH. Atribut yang tidak dikelola aplikasi
+// (mis. phoneNumbers, title) diabaikan agar sinkronisasi IdP tidak gagal.
+func (uc *SCIMUsecase) PatchUser(ctx context.Context, tenantID, id uuid.UUID, req *scim.PatchRequest) (*entity.SCIMUser, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	su, err := uc.GetUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &scimUserPatch{user: su}
+	for _, op := range req.Operations {
+		if err := patch.apply(op); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.active != nil {
+		if err := uc.setActive(ctx, su, *patch.active); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.saveUser(ctx, su)
+}
+
+// DeleteUser menonaktifkan user, mengisi resign_date, lalu menghapusnya.
+func (uc *SCIMUsecase) DeleteUser(ctx context.Context, tenantID, id uuid.UUID) error {
+	if err := uc.scimRepo.DeleteUser(ctx, tenantID, id, time.Now()); err != nil {
+		return scimRepoError(err)
+	}
+	uc.authorization.InvalidateUser(id)
+	return nil
+}
+
+func (uc *SCIMUsecase) saveUser(ctx context.Context, su *entity.SCIMUser) (*entity.SCIMUser, error) {
+	if err := uc.scimRepo.UpdateUser(ctx, su); err != nil {
+		return nil, scimRepoError(err)
+	}
+	uc.authorization.InvalidateUser(su.User.ID)
+
+	return uc.GetUser(ctx, su.User.TenantID, su.User.ID)
+}
+
+// setActive menonaktifkan (login_disabled_at + resign_date hari ini) atau
+// mengaktifkan kembali user. Reaktivasi karyawan yang sudah keluar memakai
+// kuota paket.
+func (uc *SCIMUsecase) setActive(ctx context.Context, su *entity.SCIMUser, active bool) error {
+	now := time.Now()
+	today := truncateToDate(now)
+
+	if !active {
+		if su.User.LoginDisabledAt == nil {
+			su.User.LoginDisabledAt = &now
+		}
+		if su.EmployeeID != nil && su.ResignDate == nil {
+			su.ResignDate = &today
+		}
+		return nil
+	}
+
+	if su.EmployeeID != nil && su.ResignDate != nil {
+		if su.ResignDate.Before(today) {
+			if err := uc.employeeLimit.EnsureCapacity(ctx, su.User.TenantID, 1); err != nil {
+				return err
+			}
+		}
+		su.ResignDate = nil
+	}
+	su.User.LoginDisabledAt = nil
+	return nil
+}
+
+// scimUserPatch mengumpulkan perubahan dari operasi PATCH. Status aktif
+// diterapkan terakhir karena membutuhkan pengecekan kuota.
+type scimUserPatch struct {
+	user   *entity.SCIMUser
+	active *bool
+}
+
+func (p *scimUserPatch) apply(op scim.PatchOperation) error {
+	path, err := scim.ParsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+	if path.Attribute != "" {
+		return p.set(op.Op, path.Attribute, op.Value)
+	}
+
+	// Tanpa path, value berisi objek atribut. Objek bertingkat (name dan
+	// extension enterprise) diratakan satu tingkat.
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &attrs); err != nil {
+		return scim.InvalidValue("value harus berupa objek jika path kosong")
+	}
+
+	for key, raw := range attrs {
+		prefix := scim.NormalizeAttribute(key) + "."
+		if strings.EqualFold(key, scim.SchemaEnterpriseUser) {
+			prefix = ""
+		} else if !strings.EqualFold(key, "name") {
+			if err := p.set(op.Op, scim.NormalizeAttribute(key), raw); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &nested); err != nil {
+			return scim.InvalidValue(key + " harus berupa objek")
+		}
+		for sub, v := range nested {
+			if err := p.set(op.Op, prefix+strings.ToLower(sub), v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *scimUserPatch) set(op, attribute string, raw json.RawMessage) error {
+	u := p.user
+
+	if op == "remove" {
+		if attribute == "externalid" {
+			u.User.SCIMExternalID = nil
+		}
+		return nil
+	}
+
+	switch attribute {
+	case "active":
+		active, err := scim.ParseBool(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		p.active = &active
+
+	case "username", "emails.value":
+		value, err := scim.ParseString(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		email, err := normalizeSCIMEmail(value)
+		if err != nil {
+			return err
+		}
+		u.User.Email = email
+
+	case "emails":
+		var emails []scim.MultiValue
+		if err := json.Unmarshal(raw, &emails); err != nil {
+			return scim.InvalidValue("emails harus berupa array")
+		}
+		email, err := normalizeSCIMEmail((&scim.User{Emails: emails}).Email())
+		if err != nil {
+			return err
+		}
+		u.User.Email = email
+
+	case "displayname", "name.formatted":
+		value, err := scim.ParseString(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			u.User.Name = value
+		}
+
+	case "name":
+		var name scim.Name
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return scim.InvalidValue("name harus berupa objek")
+		}
+		if full := (&scim.User{Name: &name}).FullName(); full != "" {
+			u.User.Name = full
+		}
+
+	case "name.givenname", "name.familyname":
+		value, err := scim.ParseString(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		// Nama disimpan sebagai satu kolom: kata pertama dianggap givenName,
+		// sisanya familyName.
+		given, family, _ := strings.Cut(u.User.Name, " ")
+		if attribute == "name.givenname" {
+			given = value
+		} else {
+			family = value
+		}
+		if full := strings.TrimSpace(strings.TrimSpace(given) + " " + strings.TrimSpace(family)); full != "" {
+			u.User.Name = full
+		}
+
+	case "externalid":
+		value, err := scim.ParseString(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		u.User.SCIMExternalID = optionalString(strings.TrimSpace(value))
+
+	case "employeenumber":
+		value, err := scim.ParseString(raw)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		if value = strings.TrimSpace(value); value != "" && u.EmployeeID != nil {
+			u.EmployeeNumber = &value
+		}
+	}
+	return nil
+}
+
+// ------------------------------------------------------------------------
+// Groups
+// ------------------------------------------------------------------------
+
+func (uc *SCIMUsecase) ListGroups(ctx context.Context, tenantID uuid.UUID, filter string, startIndex, count int) ([]*entity.SCIMGroup, int64, error) {
+	parsed, err := scim.ParseFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := uc.scimRepo.CountGroups(ctx, tenantID, parsed)
+	if err != nil {
+		return nil, 0, scimRepoError(err)
+	}
+
+	groups, err := uc.scimRepo.FindGroups(ctx, tenantID, repository.SCIMListQuery{Filter: parsed, StartIndex: startIndex, Count: count})
+	if err != nil {
+		return nil, 0, scimRepoError(err)
+	}
+	return groups, total, nil
+}
+
+func (uc *SCIMUsecase) GetGroup(ctx context.Context, tenantID, id uuid.UUID) (*entity.SCIMGroup, error) {
+	g, err := uc.scimRepo.FindGroup(ctx, tenantID, id)
+	if err != nil {
+		return nil, scimRepoError(err)
+	}
+	return g, nil
+}
+
+// CreateGroup membuat role baru tanpa hak akses. Hak akses diatur admin
+// tenant lewat menu role.
+func (uc *SCIMUsecase) CreateGroup(ctx context.Context, tenantID uuid.UUID, res *scim.Group) (*entity.SCIMGroup, error) {
+	name := strings.TrimSpace(res.DisplayName)
+	if name == "" {
+		return nil, scim.InvalidValue("displayName wajib diisi")
+	}
+
+	members, err := scimMemberRefs(res.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	now := time.Now()
+	g := &entity.SCIMGroup{
+		Role: entity.Role{
+			ID:        id,
+			TenantID:  tenantID,
+			Name:      name,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Members: members,
+	}
+	if err := uc.scimRepo.CreateGroup(ctx, g); err != nil {
+		return nil, scimRepoError(err)
+	}
+	uc.authorization.InvalidateTenant(tenantID)
+
+	return uc.GetGroup(ctx, tenantID, id)
+}
+
+// ReplaceGroup menangani PUT: nama dan seluruh anggota diganti.
+func (uc *SCIMUsecase) ReplaceGroup(ctx context.Context, tenantID, id uuid.UUID, res *scim.Group) (*entity.SCIMGroup, error) {
+	g, err := uc.GetGroup(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := scimMemberRefs(res.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := renameSCIMGroup(g, res.DisplayName); err != nil {
+		return nil, err
+	}
+	g.Members = members
+
+	return uc.saveGroup(ctx, g)
+}
+
+func (uc *SCIMUsecase) PatchGroup(ctx context.Context, tenantID, id uuid.UUID, req *scim.PatchRequest) (*entity.SCIMGroup, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	g, err := uc.GetGroup(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		if err := applySCIMGroupPatch(g, op); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.saveGroup(ctx, g)
+}
+
+func (uc *SCIMUsecase) DeleteGroup(ctx context.Context, tenantID, id uuid.UUID) error {
+	g, err := uc.GetGroup(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if g.Role.Name == entity.AdministratorRoleName {
+		return scim.NewError(http.StatusBadRequest, "mutability", "role Administrator tidak boleh dihapus")
+	}
+
+	if err := uc.roleRepo.Delete(ctx, tenantID, id); err != nil {
+		return err
+	}
+	uc.authorization.InvalidateTenant(tenantID)
+	return nil
+}
+
+func (uc *SCIMUsecase) saveGroup(ctx context.Context, g *entity.SCIMGroup) (*entity.SCIMGroup, error) {
+	if err := uc.scimRepo.UpdateGroup(ctx, g); err != nil {
+		return nil, scimRepoError(err)
+	}
+	uc.authorization.InvalidateTenant(g.Role.TenantID)
+
+	return uc.GetGroup(ctx, g.Role.TenantID, g.Role.ID)
+}
+
+func applySCIMGroupPatch(g *entity.SCIMGroup, op scim.PatchOperation) error {
+	path, err := scim.ParsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch path.Attribute {
+	case "":
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return scim.InvalidValue("value harus berupa objek jika path kosong")
+		}
+		for key, raw := range attrs {
+			if err := applySCIMGroupPatch(g, scim.PatchOperation{Op: op.Op, Path: key, Value: raw}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "displayname":
+		if op.Op == "remove" {
+			return scim.InvalidValue("displayName tidak boleh dihapus")
+		}
+		name, err := scim.ParseString(op.Value)
+		if err != nil {
+			return scim.InvalidValue(err.Error())
+		}
+		return renameSCIMGroup(g, name)
+
+	case "members", "members.value":
+		// members[value eq "id"] menunjuk satu anggota.
+		if path.FilterAttribute != "" {
+			if path.FilterAttribute != "value" || op.Op != "remove" {
+				return scim.NewError(http.StatusBadRequest, scim.ErrorTypeInvalidPath, "path members hanya mendukung remove dengan filter value")
+			}
+			ref, err := scimMemberRef(path.FilterValue)
+			if err != nil {
+				return err
+			}
+			g.Members = withoutMembers(g.Members, []entity.SCIMGroupRef{ref})
+			return nil
+		}
+
+		var refs []entity.SCIMGroupRef
+		if len(op.Value) > 0 {
+			var values []scim.MultiValue
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return scim.InvalidValue("members harus berupa array")
+			}
+			refs, err = scimMemberRefs(values)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch op.Op {
+		case "add":
+			g.Members = append(withoutMembers(g.Members, refs), refs...)
+		case "replace":
+			g.Members = refs
+		case "remove":
+			if len(op.Value) == 0 {
+				g.Members = []entity.SCIMGroupRef{}
+			} else {
+				g.Members = withoutMembers(g.Members, refs)
+			}
+		}
+		return nil
+	}
+
+	// Atribut lain (mis. externalId) tidak disimpan untuk group.
+	return nil
+}
+
+func renameSCIMGroup(g *entity.SCIMGroup, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return scim.InvalidValue("displayName wajib diisi")
+	}
+	if g.Role.Name == entity.AdministratorRoleName && name != g.Role.Name {
+		return scim.NewError(http.StatusBadRequest, "mutability", "role Administrator tidak boleh diganti namanya")
+	}
+	g.Role.Name = name
+	return nil
+}
+
+func withoutMembers(members, remove []entity.SCIMGroupRef) []entity.SCIMGroupRef {
+	removed := make(map[uuid.UUID]bool, len(remove))
+	for _, r := range remove {
+		removed[r.ID] = true
+	}
+
+	result := []entity.SCIMGroupRef{}
+	for _, m := range members {
+		if !removed[m.ID] {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// ------------------------------------------------------------------------
+// Helper
+// ------------------------------------------------------------------------
+
+// scimRepoError mengubah error repository menjadi error SCIM dengan status
+// HTTP yang sesuai.
+func scimRepoError(err error) error {
+	err = asEmployeeLimitError(err)
+	switch {
+	case errors.Is(err, repository.ErrSCIMNotFound):
+		return scim.NotFound(err.Error())
+	case errors.Is(err, repository.ErrSCIMConflict):
+		return scim.NewError(http.StatusConflict, scim.ErrorTypeUniqueness, err.Error())
+	case errors.Is(err, repository.ErrSCIMFilterUnsupported):
+		return scim.NewError(http.StatusBadRequest, scim.ErrorTypeInvalidFilter, err.Error())
+	}
+	return err
+}
+
+func scimUserEmail(res *scim.User) (string, error) {
+	// userName dipakai sebagai email login; jika bukan email, pakai emails.
+	if email, err := normalizeSCIMEmail(res.UserName); err == nil {
+		return email, nil
+	}
+	return normalizeSCIMEmail(res.Email())
+}
+
+func normalizeSCIMEmail(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		return "", scim.InvalidValue("userName atau emails harus berisi alamat email yang valid")
+	}
+	return value, nil
+}
+
+func scimFullName(res *scim.User, email string) string {
+	if name := res.FullName(); name != "" {
+		return name
+	}
+	return strings.SplitN(email, "@", 2)[0]
+}
+
+func scimEmployeeNumber(res *scim.User) string {
+	if res.Enterprise == nil {
+		return ""
+	}
+	return strings.TrimSpace(res.Enterprise.EmployeeNumber)
+}
+
+func scimMemberRefs(values []scim.MultiValue) ([]entity.SCIMGroupRef, error) {
+	refs := make([]entity.SCIMGroupRef, 0, len(values))
+	for _, v := range values {
+		ref, err := scimMemberRef(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+func scimMemberRef(value string) (entity.SCIMGroupRef, error) {
+	id, err := uuid.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return entity.SCIMGroupRef{}, scim.InvalidValue("ID anggota tidak valid: " + value)
+	}
+	return entity.SCIMGroupRef{ID: id}, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+const ssoLoginRequestTTL = 10 * time.Minute
+
+type SSOConfigInput struct {
+	Protocol              entity.SSOProtocol
+	Enabled               bool
+	JITProvisioning       bool
+	PasswordLoginDisabled bool
+
+	OIDCIssuer   string
+	OIDCClientID string
+	// OIDCClientSecret kosong berarti secret lama dipertahankan.
+	OIDCClientSecret string
+
+	SAMLIDPMetadataURL string
+	SAMLIDPMetadataXML string
+}
+
+// SSOUsecase mengelola konfigurasi IdP tenant dan alur login SSO. User
+// dicocokkan berdasarkan email; jika belum ada dan JIT aktif, akun dibuat
+// otomatis tanpa password (hanya bisa login lewat SSO atau undangan).
+type SSOUsecase struct {
+	ssoRepo    repository.SSORepository
+	userRepo   repository.UserRepository
+	roleRepo   repository.RoleRepository
+	jwtService *security.JWTService
+	oidc       *sso.OIDC
+	apiURL     string
+}
+
+func NewSSOUsecase(
+	ssoRepo repository.SSORepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	jwtService *security.JWTService,
+	oidc *sso.OIDC,
+	apiURL string,
+) *SSOUsecase {
+	return &SSOUsecase{
+		ssoRepo:    ssoRepo,
+		userRepo:   userRepo,
+		roleRepo:   roleRepo,
+		jwtService: jwtService,
+		oidc:       oidc,
+		apiURL:     strings.TrimRight(apiURL, "/"),
+	}
+}
+
+func (uc *SSOUsecase) GetConfig(ctx context.Context, tenantID uuid.UUID) (*entity.SSOConfig, error) {
+	return uc.ssoRepo.FindConfig(ctx, tenantID)
+}
+
+// SaveConfig memvalidasi konfigurasi IdP (discovery OIDC atau metadata SAML)
+// sebelum disimpan, supaya tenant tidak terkunci karena konfigurasi salah.
+func (uc *SSOUsecase) SaveConfig(ctx context.Context, tenantID uuid.UUID, input SSOConfigInput) (*entity.SSOConfig, error) {
+	now := time.Now()
+
+	config, err := uc.ssoRepo.FindConfig(ctx, tenantID)
+	if err != nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+		}
+		config = &entity.SSOConfig{ID: id, TenantID: tenantID, CreatedAt: now}
+	}
+
+	config.Protocol = input.Protocol
+	config.Enabled = input.Enabled
+	config.JITProvisioning = input.JITProvisioning
+	config.PasswordLoginDisabled = input.PasswordLoginDisabled
+	config.UpdatedAt = now
+
+	switch input.Protocol {
+	case entity.SSOProtocolOIDC:
+		if input.OIDCIssuer == "" || input.OIDCClientID == "" {
+			return nil, errors.New("issuer dan client_id OIDC wajib diisi")
+		}
+		if input.OIDCClientSecret != "" {
+			config.OIDCClientSecret = input.OIDCClientSecret
+		}
+		if config.OIDCClientSecret == "" {
+			return nil, errors.New("client_secret OIDC wajib diisi")
+		}
+		if err := uc.oidc.Discover(input.OIDCIssuer); err != nil {
+			return nil, err
+		}
+
+		config.OIDCIssuer = input.OIDCIssuer
+		config.OIDCClientID = input.OIDCClientID
+		config.SAMLIDPMetadataURL = ""
+		config.SAMLIDPMetadataXML = ""
+
+	case entity.SSOProtocolSAML:
+		metadata := input.SAMLIDPMetadataXML
+		if input.SAMLIDPMetadataURL != "" {
+			metadata, err = sso.FetchSAMLMetadata(ctx, input.SAMLIDPMetadataURL)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if metadata == "" {
+			return nil, errors.New("metadata IdP SAML (URL atau XML) wajib diisi")
+		}
+		if _, err := sso.ParseSAMLMetadata(metadata); err != nil {
+			return nil, err
+		}
+
+		config.SAMLIDPMetadataURL = input.SAMLIDPMetadataURL
+		config.SAMLIDPMetadataXML = metadata
+		config.OIDCIssuer = ""
+		config.OIDCClientID = ""
+		config.OIDCClientSecret = ""
+
+	default:
+		return nil, errors.New("protocol SSO tidak valid")
+	}
+
+	if err := uc.ssoRepo.SaveConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan konfigurasi SSO: %w", err)
+	}
+
+	return config, nil
+}
+
+// BeginLogin mengembalikan URL IdP tujuan redirect browser.
+func (uc *SSOUsecase) BeginLogin(ctx context.Context, tenant *entity.Tenant) (string, error) {
+	config, err := uc.enabledConfig(ctx, tenant.ID)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	req := &entity.SSOLoginRequest{
+		ID:        sso.RandomToken(),
+		TenantID:  tenant.ID,
+		Protocol:  config.Protocol,
+		ExpiresAt: now.Add(ssoLoginRequestTTL),
+		CreatedAt: now,
+	}
+
+	var redirectURL string
+	switch config.Protocol {
+	case entity.SSOProtocolOIDC:
+		req.CodeVerifier = sso.RandomToken()
+		req.Nonce = sso.RandomToken()
+		redirectURL, err = uc.oidc.AuthCodeURL(ctx, uc.oidcParams(tenant, config), req.ID, req.Nonce, req.CodeVerifier)
+	case entity.SSOProtocolSAML:
+		redirectURL, req.SAMLRequestID, err = sso.SAMLAuthnRedirect(uc.samlParams(tenant, config), req.ID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.ssoRepo.CreateLoginRequest(ctx, req); err != nil {
+		return "", fmt.Errorf("gagal menyimpan sesi login SSO: %w", err)
+	}
+
+	return redirectURL, nil
+}
+
+// CompleteOIDC memproses callback OIDC dan mengembalikan token tenant.
+func (uc *SSOUsecase) CompleteOIDC(ctx context.Context, tenant *entity.Tenant, state, code string) (string, error) {
+	config, err := uc.enabledConfig(ctx, tenant.ID)
+	if err != nil {
+		return "", err
+	}
+	if config.Protocol != entity.SSOProtocolOIDC {
+		return "", errors.New("tenant tidak memakai OIDC")
+	}
+
+	req, err := uc.ssoRepo.ConsumeLoginRequest(ctx, tenant.ID, state, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	identity, err := uc.oidc.Exchange(ctx, uc.oidcParams(tenant, config), code, req.CodeVerifier, req.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.login(ctx, config, identity)
+}
+
+// CompleteSAML memproses SAMLResponse dari ACS dan mengembalikan token tenant.
+// Login yang dimulai IdP (tanpa RelayState dari kita) ditolak.
+func (uc *SSOUsecase) CompleteSAML(ctx context.Context, tenant *entity.Tenant, relayState, samlResponse string) (string, error) {
+	config, err := uc.enabledConfig(ctx, tenant.ID)
+	if err != nil {
+		return "", err
+	}
+	if config.Protocol != entity.SSOProtocolSAML {
+		return "", errors.New("tenant tidak memakai SAML")
+	}
+
+	req, err := uc.ssoRepo.ConsumeLoginRequest(ctx, tenant.ID, relayState, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	identity, err := sso.ParseSAMLResponse(uc.samlParams(tenant, config), samlResponse, req.SAMLRequestID)
+	if err != nil {
+		return "", err
+	}
+
+	return uc.login(ctx, config, identity)
+}
+
+// SAMLMetadata mengembalikan metadata SP tenant untuk didaftarkan di IdP.
+func (uc *SSOUsecase) SAMLMetadata(tenant *entity.Tenant) ([]byte, error) {
+	return sso.SAMLMetadata(uc.samlParams(tenant, nil))
+}
+
+func (uc *SSOUsecase) login(ctx context.Context, config *entity.SSOConfig, identity *sso.Identity) (string, error) {
+	user, err := uc.userRepo.FindByEmail(ctx, config.TenantID, identity.Email)
+	if err != nil {
+		if !config.JITProvisioning {
+			return "", errors.New("user dengan email " + identity.Email + " tidak terdaftar di tenant ini")
+		}
+
+		user, err = uc.provisionUser(ctx, config.TenantID, identity)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if user.IsLoginDisabled() {
+		return "", errors.New("akun sudah dinonaktifkan")
+	}
+
+	roles, err := uc.roleRepo.FindByUser(ctx, user.TenantID, user.ID)
+	if err != nil {
+		return "", errors.New("gagal mengambil role user")
+	}
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = role.Name
+	}
+
+	// Email sudah diverifikasi oleh IdP, sehingga token selalu verified.
+	token, err := uc.jwtService.GenerateTenantToken(user.ID, user.TenantID, roleNames, true)
+	if err != nil {
+		return "", errors.New("gagal membuat token")
+	}
+	return token, nil
+}
+
+func (uc *SSOUsecase) provisionUser(ctx context.Context, tenantID uuid.UUID, identity *sso.Identity) (*entity.User, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat UUID: %w", err)
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		ID:              id,
+		TenantID:        tenantID,
+		Name:            name,
+		Email:           identity.Email,
+		EmailVerifiedAt: &now,
+		Timezone:        "UTC",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("gagal membuat user SSO: %w", err)
+	}
+	return user, nil
+}
+
+func (uc *SSOUsecase) enabledConfig(ctx context.Context, tenantID uuid.UUID) (*entity.SSOConfig, error) {
+	config, err := uc.ssoRepo.FindConfig(ctx, tenantID)
+	if err != nil || !config.Enabled {
+		return nil, errors.New("SSO belum diaktifkan untuk tenant ini")
+	}
+	return config, nil
+}
+
+func (uc *SSOUsecase) oidcParams(tenant *entity.Tenant, config *entity.SSOConfig) sso.OIDCParams {
+	return sso.OIDCParams{
+		Issuer:       config.OIDCIssuer,
+		ClientID:     config.OIDCClientID,
+		ClientSecret: config.OIDCClientSecret,
+		RedirectURL:  uc.apiURL + "/sso/" + tenant.Slug + "/oidc/callback",
+	}
+}
+
+func (uc *SSOUsecase) samlParams(tenant *entity.Tenant, config *entity.SSOConfig) sso.SAMLParams {
+	params := sso.SAMLParams{
+		EntityID: uc.apiURL + "/sso/" + tenant.Slug + "/saml/metadata",
+		AcsURL:   uc.apiURL + "/sso/" + tenant.Slug + "/saml/acs",
+	}
+	if config != nil {
+		params.IDPMetadataXML = config.SAMLIDPMetadataXML
+	}
+	return params
+}
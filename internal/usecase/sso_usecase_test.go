@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/sso/mockidp"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// memorySSORepo menyimpan konfigurasi dan login request di memori dengan
+// aturan yang sama seperti postgresSSORepo: login request hanya bisa dipakai
+// sekali, oleh tenant pemiliknya, dan sebelum kedaluwarsa.
+type memorySSORepo struct {
+	configs  map[uuid.UUID]*entity.SSOConfig
+	requests map[string]*entity.SSOLoginRequest
+}
+
+func (r *memorySSORepo) FindConfig(ctx context.Context, tenantID uuid.UUID) (*entity.SSOConfig, error) {
+	config, ok := r.configs[tenantID]
+	if !ok {
+		return nil, errors.New("sso config not found")
+	}
+	return config, nil
+}
+
+func (r *memorySSORepo) SaveConfig(ctx context.Context, config *entity.SSOConfig) error {
+	r.configs[config.TenantID] = config
+	return nil
+}
+
+func (r *memorySSORepo) CreateLoginRequest(ctx context.Context, req *entity.SSOLoginRequest) error {
+	r.requests[req.ID] = req
+	return nil
+}
+
+func (r *memorySSORepo) ConsumeLoginRequest(ctx context.Context, tenantID uuid.UUID, id string, at time.Time) (*entity.SSOLoginRequest, error) {
+	req, ok := r.requests[id]
+	if !ok || req.TenantID != tenantID || req.ConsumedAt != nil || !req.ExpiresAt.After(at) {
+		return nil, errors.New("sesi login SSO tidak ditemukan atau sudah kedaluwarsa")
+	}
+	req.ConsumedAt = &at
+	return req, nil
+}
+
+// ssoUserRepo hanya mengenal satu user; method lain tidak dipakai alur SSO.
+type ssoUserRepo struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (r *ssoUserRepo) FindByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*entity.User, error) {
+	if tenantID != r.user.TenantID || email != r.user.Email {
+		return nil, errors.New("user not found")
+	}
+	return r.user, nil
+}
+
+type ssoRoleRepo struct {
+	repository.RoleRepository
+}
+
+func (ssoRoleRepo) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*entity.Role, error) {
+	return []*entity.Role{{Name: "hr_admin"}}, nil
+}
+
+type ssoFlow struct {
+	uc     *SSOUsecase
+	repo   *memorySSORepo
+	jwt    *security.JWTService
+	tenant *entity.Tenant
+	other  *entity.Tenant
+	user   *entity.User
+}
+
+// newSSOFlow menyiapkan mock IdP, API (hanya metadata SP), dan dua tenant
+// yang sama-sama memakai IdP tersebut dengan protokol 'protocol'.
+func newSSOFlow(t *testing.T, protocol entity.SSOProtocol) *ssoFlow {
+	t.Helper()
+
+	idpServer := httptest.NewUnstartedServer(nil)
+	idp, err := mockidp.New(mockidp.Config{
+		Issuer:       "http://" + idpServer.Listener.Addr().String(),
+		ClientID:     "hris",
+		ClientSecret: "secret",
+		Email:        "budi@example.com",
+		Name:         "Budi",
+	})
+	if err != nil {
+		t.Fatalf("gagal membuat mock IdP: %v", err)
+	}
+	idpServer.Config.Handler = idp.Handler()
+	idpServer.Start()
+	t.Cleanup(idpServer.Close)
+
+	f := &ssoFlow{
+		repo: &memorySSORepo{
+			configs:  map[uuid.UUID]*entity.SSOConfig{},
+			requests: map[string]*entity.SSOLoginRequest{},
+		},
+		jwt:    security.NewJWTService("test-secret"),
+		tenant: &entity.Tenant{ID: uuid.New(), Slug: "acme"},
+		other:  &entity.Tenant{ID: uuid.New(), Slug: "lain"},
+	}
+	f.user = &entity.User{ID: uuid.New(), TenantID: f.tenant.ID, Email: "budi@example.com"}
+
+	// API tiruan hanya melayani metadata SP, yang diambil mock IdP saat
+	// menerima AuthnRequest.
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, tenant := range []*entity.Tenant{f.tenant, f.other} {
+			if r.URL.Path == "/sso/"+tenant.Slug+"/saml/metadata" {
+				metadata, err := f.uc.SAMLMetadata(tenant)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				w.Write(metadata)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(api.Close)
+
+	f.uc = NewSSOUsecase(f.repo, &ssoUserRepo{user: f.user}, ssoRoleRepo{}, f.jwt, sso.NewOIDC(), api.URL)
+
+	var idpMetadata string
+	if protocol == entity.SSOProtocolSAML {
+		idpMetadata, err = sso.FetchSAMLMetadata(context.Background(), idpServer.URL+"/saml/metadata")
+		if err != nil {
+			t.Fatalf("gagal mengambil metadata IdP: %v", err)
+		}
+	}
+	for _, tenant := range []*entity.Tenant{f.tenant, f.other} {
+		f.repo.configs[tenant.ID] = &entity.SSOConfig{
+			TenantID:           tenant.ID,
+			Protocol:           protocol,
+			Enabled:            true,
+			OIDCIssuer:         idpServer.URL,
+			OIDCClientID:       "hris",
+			OIDCClientSecret:   "secret",
+			SAMLIDPMetadataXML: idpMetadata,
+		}
+	}
+	return f
+}
+
+var ssoNoRedirectClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// visitIDP membuka URL login seperti browser dan mengembalikan isi respons
+// IdP (callback OIDC di Location, atau form auto-POST SAML di body).
+func visitIDP(t *testing.T, loginURL string) *http.Response {
+	t.Helper()
+	resp, err := ssoNoRedirectClient.Get(loginURL)
+	if err != nil {
+		t.Fatalf("GET IdP gagal: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// beginOIDC memulai login dan mengembalikan state serta code dari callback.
+func (f *ssoFlow) beginOIDC(t *testing.T, tenant *entity.Tenant) (string, string) {
+	t.Helper()
+	loginURL, err := f.uc.BeginLogin(context.Background(), tenant)
+	if err != nil {
+		t.Fatalf("BeginLogin gagal: %v", err)
+	}
+	location, err := visitIDP(t, loginURL).Location()
+	if err != nil {
+		t.Fatalf("IdP tidak redirect ke callback: %v", err)
+	}
+	return location.Query().Get("state"), location.Query().Get("code")
+}
+
+var (
+	ssoSAMLResponseInput = regexp.MustCompile(`name="SAMLResponse" value="([^"]+)"`)
+	ssoRelayStateInput   = regexp.MustCompile(`name="RelayState" value="([^"]*)"`)
+)
+
+// beginSAML memulai login dan mengembalikan RelayState serta SAMLResponse
+// dari form yang dikirim IdP ke ACS.
+func (f *ssoFlow) beginSAML(t *testing.T, tenant *entity.Tenant) (string, string) {
+	t.Helper()
+	loginURL, err := f.uc.BeginLogin(context.Background(), tenant)
+	if err != nil {
+		t.Fatalf("BeginLogin gagal: %v", err)
+	}
+	body, err := io.ReadAll(visitIDP(t, loginURL).Body)
+	if err != nil {
+		t.Fatalf("gagal membaca respons IdP: %v", err)
+	}
+	response := ssoSAMLResponseInput.FindSubmatch(body)
+	relayState := ssoRelayStateInput.FindSubmatch(body)
+	if response == nil || relayState == nil {
+		t.Fatalf("respons IdP tanpa SAMLResponse/RelayState: %s", body)
+	}
+	return html.UnescapeString(string(relayState[1])), html.UnescapeString(string(response[1]))
+}
+
+func (f *ssoFlow) assertToken(t *testing.T, token string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("login SSO gagal: %v", err)
+	}
+	claims, err := f.jwt.ValidateTenantToken(token)
+	if err != nil {
+		t.Fatalf("token tidak valid: %v", err)
+	}
+	if claims.UserID != f.user.ID || claims.TenantID != f.tenant.ID || !claims.EmailVerified {
+		t.Errorf("klaim token = %+v, want user %s di tenant %s", claims, f.user.ID, f.tenant.ID)
+	}
+}
+
+func TestSSOUsecaseOIDCLogin(t *testing.T) {
+	f := newSSOFlow(t, entity.SSOProtocolOIDC)
+
+	state, code := f.beginOIDC(t, f.tenant)
+	token, err := f.uc.CompleteOIDC(context.Background(), f.tenant, state, code)
+	f.assertToken(t, token, err)
+}
+
+func TestSSOUsecaseOIDCRejectsWrongState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("state tidak dikenal", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolOIDC)
+		state, code := f.beginOIDC(t, f.tenant)
+
+		if _, err := f.uc.CompleteOIDC(ctx, f.tenant, sso.RandomToken(), code); err == nil {
+			t.Fatal("callback dengan state palsu diterima")
+		}
+		// Code tidak ditukar saat state ditolak, sehingga login asli tetap
+		// bisa diselesaikan.
+		token, err := f.uc.CompleteOIDC(ctx, f.tenant, state, code)
+		f.assertToken(t, token, err)
+	})
+
+	t.Run("state dipakai ulang", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolOIDC)
+		state, code := f.beginOIDC(t, f.tenant)
+
+		token, err := f.uc.CompleteOIDC(ctx, f.tenant, state, code)
+		f.assertToken(t, token, err)
+
+		_, code2 := f.beginOIDC(t, f.tenant)
+		if _, err := f.uc.CompleteOIDC(ctx, f.tenant, state, code2); err == nil {
+			t.Error("state yang sudah terpakai diterima lagi")
+		}
+	})
+
+	t.Run("state milik tenant lain", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolOIDC)
+		state, code := f.beginOIDC(t, f.other)
+
+		if _, err := f.uc.CompleteOIDC(ctx, f.tenant, state, code); err == nil {
+			t.Error("state tenant lain diterima")
+		}
+	})
+}
+
+func TestSSOUsecaseSAMLLogin(t *testing.T) {
+	f := newSSOFlow(t, entity.SSOProtocolSAML)
+
+	relayState, response := f.beginSAML(t, f.tenant)
+	token, err := f.uc.CompleteSAML(context.Background(), f.tenant, relayState, response)
+	f.assertToken(t, token, err)
+}
+
+func TestSSOUsecaseSAMLRejects(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RelayState tidak dikenal", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolSAML)
+		_, response := f.beginSAML(t, f.tenant)
+
+		if _, err := f.uc.CompleteSAML(ctx, f.tenant, sso.RandomToken(), response); err == nil {
+			t.Error("SAMLResponse dengan RelayState palsu diterima")
+		}
+	})
+
+	t.Run("response untuk login lain", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolSAML)
+		relayState, _ := f.beginSAML(t, f.tenant)
+		_, otherResponse := f.beginSAML(t, f.tenant)
+
+		_, err := f.uc.CompleteSAML(ctx, f.tenant, relayState, otherResponse)
+		if err == nil || !strings.Contains(err.Error(), "InResponseTo") {
+			t.Errorf("err = %v, want InResponseTo ditolak", err)
+		}
+	})
+
+	t.Run("response untuk tenant lain", func(t *testing.T) {
+		f := newSSOFlow(t, entity.SSOProtocolSAML)
+		_, otherResponse := f.beginSAML(t, f.other)
+		relayState, _ := f.beginSAML(t, f.tenant)
+
+		if _, err := f.uc.CompleteSAML(ctx, f.tenant, relayState, otherResponse); err == nil {
+			t.Error("SAMLResponse untuk SP tenant lain diterima")
+		}
+	})
+}
@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+type SubscribeInput struct {
+	PlanID      uuid.UUID  `json:"plan_id"`
+	Status      string     `json:"status"`
+	TrialEndsAt *time.Time `json:"trial_ends_at"`
+}
+
+type GrantAddonInput struct {
+	FeatureSlug        string     `json:"feature_slug"`
+	ExtraEmployeeSeats int        `json:"extra_employee_seats"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+type ChangePlanInput struct {
+	PlanID uuid.UUID `json:"plan_id"`
+}
+
+// PlanChangePreview adalah rincian biaya yang ditampilkan sebelum tenant
+// mengonfirmasi perpindahan paket.
+type PlanChangePreview struct {
+	CurrentSubscription *entity.Subscription
+	CurrentPlan         *entity.Plan
+	NewPlan             *entity.Plan
+	Proration           entity.Proration
+}
+
+type PlanChangeResult struct {
+	Subscription *entity.Subscription
+	Proration    entity.Proration
+	Invoice      *entity.Invoice
+	Credit       *entity.BillingCredit
+}
+
+const defaultTrialDays = 14
+
+// invoiceDueDays adalah jatuh tempo invoice penyesuaian sejak tanggal terbit.
+const invoiceDueDays = 14
+
+type SubscriptionUsecase struct {
+	tenantRepo       repository.TenantRepository
+	planRepo         repository.PlanRepository
+	subscriptionRepo repository.SubscriptionRepository
+	featureRepo      repository.FeatureRepository
+	addonRepo        repository.TenantAddonRepository
+	entitlements     *EntitlementUsecase
+}
+
+func NewSubscriptionUsecase(
+	tenantRepo repository.TenantRepository,
+	planRepo repository.PlanRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	featureRepo repository.FeatureRepository,
+	addonRepo repository.TenantAddonRepository,
+	entitlements *EntitlementUsecase,
+) *SubscriptionUsecase {
+	return &SubscriptionUsecase{
+		tenantRepo:       tenantRepo,
+		planRepo:         planRepo,
+		subscriptionRepo: subscriptionRepo,
+		featureRepo:      featureRepo,
+		addonRepo:        addonRepo,
+		entitlements:     entitlements,
+	}
+}
+
+func (uc *SubscriptionUsecase) GetCurrentSubscription(ctx context.Context, tenantID uuid.UUID) (*entity.Subscription, error) {
+	return uc.subscriptionRepo.FindCurrentByTenant(ctx, tenantID)
+}
+
+// Subscribe mengganti langganan tenant ke paket baru. Langganan lama ditutup
+// (canceled + ends_at) sehingga riwayat langganan tetap tersimpan; penutupan
+// dan langganan baru disimpan dalam satu transaksi.
+func (uc *SubscriptionUsecase) Subscribe(ctx context.Context, tenantID uuid.UUID, input SubscribeInput) (*entity.Subscription, error) {
+	if _, err := uc.tenantRepo.FindByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.planRepo.FindByID(ctx, input.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive {
+		return nil, errors.New("paket tidak aktif")
+	}
+
+	status := entity.StatusSubscription(input.Status)
+	if status == "" {
+		status = entity.StatusSubscriptionActive
+	}
+	if status != entity.StatusSubscriptionActive && status != entity.StatusSubscriptionTrialing {
+		return nil, errors.New("status langganan tidak valid")
+	}
+
+	now := time.Now()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.New("gagal membuat UUID")
+	}
+
+	periodEnd := entity.AddBillingCycle(now, plan.BillingCycle)
+	subscription := &entity.Subscription{
+		ID:                 id,
+		TenantID:           tenantID,
+		PlanID:             plan.ID,
+		Status:             status,
+		StartedAt:          now,
+		CurrentPeriodStart: &now,
+		CurrentPeriodEnd:   &periodEnd,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	// trial_ends_at selalu diisi untuk langganan trial, karena kolom ini yang
+	// menandai sebuah langganan pernah berstatus trial (dipakai metrik konversi).
+	if status == entity.StatusSubscriptionTrialing {
+		trialEndsAt := now.AddDate(0, 0, defaultTrialDays)
+		if input.TrialEndsAt != nil {
+			trialEndsAt = *input.TrialEndsAt
+		}
+		subscription.TrialEndsAt = &trialEndsAt
+	}
+
+	if err := uc.subscriptionRepo.Replace(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	uc.entitlements.Invalidate(tenantID)
+	return subscription, nil
+}
+
+func (uc *SubscriptionUsecase) CancelSubscription(ctx context.Context, tenantID uuid.UUID) error {
+	current, err := uc.subscriptionRepo.FindCurrentByTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	current.Status = entity.StatusSubscriptionCanceled
+	current.EndsAt = &now
+	if err := uc.subscriptionRepo.Update(ctx, current); err != nil {
+		return err
+	}
+
+	uc.entitlements.Invalidate(tenantID)
+	return nil
+}
+
+func (uc *SubscriptionUsecase) GrantAddon(ctx context.Context, tenantID uuid.UUID, input GrantAddonInput) (*entity.TenantAddon, error) {
+	if _, err := uc.tenantRepo.FindByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	feature, err := uc.featureRepo.FindBySlug(ctx, input.FeatureSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !feature.IsAddon {
+		return nil, errors.New("fitur ini bukan addon")
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.New("gagal membuat UUID")
+	}
+
+	now := time.Now()
+	addon := &entity.TenantAddon{
+		ID:                 id,
+		TenantID:           tenantID,
+		FeatureID:          feature.ID,
+		ExtraEmployeeSeats: input.ExtraEmployeeSeats,
+		ExpiresAt:          input.ExpiresAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := uc.addonRepo.Upsert(ctx, addon); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan addon: %w", err)
+	}
+
+	uc.entitlements.Invalidate(tenantID)
+	return addon, nil
+}
+
+func (uc *SubscriptionUsecase) RevokeAddon(ctx context.Context, tenantID uuid.UUID, featureSlug string) error {
+	feature, err := uc.featureRepo.FindBySlug(ctx, featureSlug)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.addonRepo.Delete(ctx, tenantID, feature.ID); err != nil {
+		return err
+	}
+
+	uc.entitlements.Invalidate(tenantID)
+	return nil
+}
+
+func (uc *SubscriptionUsecase) PreviewPlanChange(ctx context.Context, tenantID uuid.UUID, input ChangePlanInput) (*PlanChangePreview, error) {
+	return uc.buildPlanChange(ctx, tenantID, input.PlanID, time.Now())
+}
+
+// ChangePlan melakukan upgrade/downgrade di tengah periode. Selisih positif
+// diterbitkan sebagai invoice penyesuaian, selisih negatif dicatat sebagai
+// kredit tenant untuk tagihan berikutnya.
+func (uc *SubscriptionUsecase) ChangePlan(ctx context.Context, tenantID uuid.UUID, input ChangePlanInput) (*PlanChangeResult, error) {
+	now := time.Now()
+
+	preview, err := uc.buildPlanChange(ctx, tenantID, input.PlanID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	current := preview.CurrentSubscription
+	proration := preview.Proration
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.New("gagal membuat UUID")
+	}
+
+	next := &entity.Subscription{
+		ID:                 id,
+		TenantID:           tenantID,
+		PlanID:             preview.NewPlan.ID,
+		Status:             current.Status,
+		StartedAt:          now,
+		TrialEndsAt:        current.TrialEndsAt,
+		CurrentPeriodStart: &proration.NewPeriodStart,
+		CurrentPeriodEnd:   &proration.NewPeriodEnd,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	current.Status = entity.StatusSubscriptionCanceled
+	current.EndsAt = &now
+
+	result := &PlanChangeResult{
+		Subscription: next,
+		Proration:    proration,
+	}
+
+	// Selama trial Net selalu 0 (lihat entity.CalculateProration), jadi
+	// perpindahan paket tidak menghasilkan invoice maupun kredit.
+	switch {
+	case proration.Net > 0:
+		result.Invoice, err = newPlanChangeInvoice(next, preview, now)
+	case proration.Net < 0:
+		result.Credit, err = newPlanChangeCredit(tenantID, preview, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.subscriptionRepo.ChangePlan(ctx, current, next, result.Invoice, result.Credit); err != nil {
+		return nil, err
+	}
+
+	uc.entitlements.Invalidate(tenantID)
+	return result, nil
+}
+
+func (uc *SubscriptionUsecase) buildPlanChange(ctx context.Context, tenantID, newPlanID uuid.UUID, at time.Time) (*PlanChangePreview, error) {
+	current, err := uc.subscriptionRepo.FindCurrentByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, errors.New("tenant belum memiliki langganan aktif")
+	}
+
+	if current.PlanID == newPlanID {
+		return nil, errors.New("tenant sudah berlangganan paket ini")
+	}
+
+	currentPlan, err := uc.planRepo.FindByID(ctx, current.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	newPlan, err := uc.planRepo.FindByID(ctx, newPlanID)
+	if err != nil {
+		return nil, err
+	}
+	if !newPlan.IsActive {
+		return nil, errors.New("paket tidak aktif")
+	}
+
+	periodStart := current.StartedAt
+	if current.CurrentPeriodStart != nil {
+		periodStart = *current.CurrentPeriodStart
+	}
+	periodEnd := entity.AddBillingCycle(periodStart, currentPlan.BillingCycle)
+	if current.CurrentPeriodEnd != nil {
+		periodEnd = *current.CurrentPeriodEnd
+	}
+
+	proration := entity.CalculateProration(entity.ProrationInput{
+		CurrentPlan: currentPlan,
+		NewPlan:     newPlan,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		ChangeAt:    at,
+		IsTrial:     current.Status == entity.StatusSubscriptionTrialing,
+	})
+
+	return &PlanChangePreview{
+		CurrentSubscription: current,
+		CurrentPlan:         currentPlan,
+		NewPlan:             newPlan,
+		Proration:           proration,
+	}, nil
+}
+
+func newPlanChangeInvoice(next *entity.Subscription, preview *PlanChangePreview, now time.Time) (*entity.Invoice, error) {
+	invoiceID, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.New("gagal membuat UUID")
+	}
+
+	proration := preview.Proration
+	period := fmt.Sprintf("%s s/d %s", proration.NewPeriodStart.Format("2006-01-02"), proration.NewPeriodEnd.Format("2006-01-02"))
+
+	items := []entity.InvoiceItem{
+		{
+			Description: fmt.Sprintf("Paket %s (%s)", preview.NewPlan.Name, period),
+			Quantity:    1,
+			UnitPrice:   proration.Charge,
+			TotalPrice:  proration.Charge,
+		},
+	}
+	if proration.Credit > 0 {
+		items = append(items, entity.InvoiceItem{
+			Description: fmt.Sprintf("Kredit sisa waktu paket %s", preview.CurrentPlan.Name),
+			Quantity:    1,
+			UnitPrice:   -proration.Credit,
+			TotalPrice:  -proration.Credit,
+		})
+	}
+
+	for i := range items {
+		itemID, err := uuid.NewV7()
+		if err != nil {
+			return nil, errors.New("gagal membuat UUID")
+		}
+		items[i].ID = itemID
+		items[i].InvoiceID = invoiceID
+	}
+
+	issueDate := now.Truncate(24 * time.Hour)
+	return &entity.Invoice{
+		ID:             invoiceID,
+		TenantID:       next.TenantID,
+		SubscriptionID: next.ID,
+		IssueDate:      issueDate,
+		DueDate:        issueDate.AddDate(0, 0, invoiceDueDays),
+		TotalAmount:    proration.Net,
+		Status:         entity.StatusInvoiceUnpaid,
+		Items:          items,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func newPlanChangeCredit(tenantID uuid.UUID, preview *PlanChangePreview, now time.Time) (*entity.BillingCredit, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.New("gagal membuat UUID")
+	}
+
+	amount := -preview.Proration.Net
+	return &entity.BillingCredit{
+		ID:              id,
+		TenantID:        tenantID,
+		Source:          entity.BillingCreditSourceProration,
+		Description:     fmt.Sprintf("Prorata perpindahan paket %s ke %s", preview.CurrentPlan.Name, preview.NewPlan.Name),
+		Amount:          amount,
+		RemainingAmount: amount,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
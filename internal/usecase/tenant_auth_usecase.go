@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maskholilaziz/hris-go/internal/entity"
+	"github.com/maskholilaziz/hris-go/internal/infrastructure/security"
+	"github.com/maskholilaziz/hris-go/internal/repository"
+)
+
+// ErrPasswordExpired dikembalikan Login jika password benar tetapi sudah
+// melewati masa berlaku kebijakan tenant. User harus mengganti password lewat
+// ChangeExpiredPassword.
+var ErrPasswordExpired = errors.New("password sudah kedaluwarsa, silakan ganti password")
+
+type TenantAuthUsecase struct {
+	userRepo   repository.UserRepository
+	roleRepo   repository.RoleRepository
+	ssoRepo    repository.SSORepository
+	jwtService *security.JWTService
+
+	authorization *AuthorizationUsecase
+	passwords     *PasswordPolicyUsecase
+}
+
+func NewTenantAuthUsecase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	ssoRepo repository.SSORepository,
+	jwtService *security.JWTService,
+	authorization *AuthorizationUsecase,
+	passwords *PasswordPolicyUsecase,
+) *TenantAuthUsecase {
+	return &TenantAuthUsecase{
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		ssoRepo:       ssoRepo,
+		jwtService:    jwtService,
+		authorization: authorization,
+		passwords:     passwords,
+	}
+}
+
+// Login mengautentikasi staf pada tenant yang sudah di-resolve. User dengan
+// email yang sama di tenant lain tidak akan ditemukan. Tenant yang mewajibkan
+// SSO menolak login dengan password.
+func (uc *TenantAuthUsecase) Login(ctx context.Context, tenantID uuid.UUID, email, password string) (string, error) {
+	user, err := uc.authenticate(ctx, tenantID, email, password)
+	if err != nil {
+		return "", err
+	}
+
+	policy, err := uc.passwords.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	if policy.IsExpired(user.PasswordChangedAt, time.Now()) {
+		return "", ErrPasswordExpired
+	}
+
+	return uc.issueToken(ctx, user)
+}
+
+// ChangePassword mengganti password user yang sedang login.
+func (uc *TenantAuthUsecase) ChangePassword(ctx context.Context, tenantID, userID uuid.UUID, currentPassword, newPassword string) error {
+	user, err := uc.userRepo.FindByID(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CheckPassword(currentPassword) {
+		return errors.New("password saat ini salah")
+	}
+
+	policy, err := uc.passwords.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return uc.passwords.SetPassword(ctx, policy, user, newPassword)
+}
+
+// ChangeExpiredPassword dipakai user yang ditolak Login karena password
+// kedaluwarsa: kredensial lama diperiksa seperti Login, password diganti,
+// lalu token baru diterbitkan.
+func (uc *TenantAuthUsecase) ChangeExpiredPassword(ctx context.Context, tenantID uuid.UUID, email, currentPassword, newPassword string) (string, error) {
+	user, err := uc.authenticate(ctx, tenantID, email, currentPassword)
+	if err != nil {
+		return "", err
+	}
+
+	policy, err := uc.passwords.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	if err := uc.passwords.SetPassword(ctx, policy, user, newPassword); err != nil {
+		return "", err
+	}
+
+	return uc.issueToken(ctx, user)
+}
+
+// authenticate memeriksa email dan password. Hash dengan cost bcrypt lama
+// di-hash ulang selagi plaintext-nya tersedia.
+func (uc *TenantAuthUsecase) authenticate(ctx context.Context, tenantID uuid.UUID, email, password string) (*entity.User, error) {
+	if config, err := uc.ssoRepo.FindConfig(ctx, tenantID); err == nil && config.BlocksPasswordLogin() {
+		return nil, errors.New("login dengan password dinonaktifkan, silakan login lewat SSO")
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, tenantID, email)
+	if err != nil {
+		return nil, errors.New("email atau password salah")
+	}
+
+	if !user.CheckPassword(password) {
+		return nil, errors.New("email atau password salah")
+	}
+
+	if user.IsLoginDisabled() {
+		return nil, errors.New("akun sudah dinonaktifkan")
+	}
+
+	if user.NeedsRehash() {
+		// Gagal upgrade hash tidak boleh menggagalkan login; dicoba lagi
+		// pada login berikutnya.
+		if err := user.HashPassword(password); err == nil {
+			_ = uc.userRepo.UpdatePasswordHash(ctx, tenantID, user.ID, user.Password)
+		}
+	}
+
+	return user, nil
+}
+
+func (uc *TenantAuthUsecase) issueToken(ctx context.Context, user *entity.User) (string, error) {
+	roles, err := uc.roleNames(ctx, user)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := uc.jwtService.GenerateTenantToken(user.ID, user.TenantID, roles, user.IsEmailVerified())
+	if err != nil {
+		return "", errors.New("gagal membuat token")
+	}
+
+	return token, nil
+}
+
+// Me mengembalikan user yang sedang login beserta nama role dan hak akses
+// efektifnya.
+func (uc *TenantAuthUsecase) Me(ctx context.Context, tenantID, userID uuid.UUID) (*entity.User, []string, []string, error) {
+	user, err := uc.userRepo.FindByID(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	roles, err := uc.roleNames(ctx, user)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	permissions, err := uc.authorization.EffectivePermissions(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return user, roles, permissions, nil
+}
+
+func (uc *TenantAuthUsecase) roleNames(ctx context.Context, user *entity.User) ([]string, error) {
+	roles, err := uc.roleRepo.FindByUser(ctx, user.TenantID, user.ID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil role user")
+	}
+
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.Name
+	}
+	return names, nil
+}
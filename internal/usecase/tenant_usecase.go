@@ -67,6 +67,10 @@ func (uc *TenantUsecase) GetTenantByID(ctx context.Context, id uuid.UUID) (*enti
 	return uc.tenantRepo.FindByID(ctx, id)
 }
 
+func (uc *TenantUsecase) GetTenantBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	return uc.tenantRepo.FindBySlug(ctx, slug)
+}
+
 func (uc *TenantUsecase) UpdateTenant(ctx context.Context, id uuid.UUID, input UpdateTenantInput) (*entity.Tenant, error) {
 	tenant, err := uc.tenantRepo.FindByID(ctx, id)
 	if err != nil {
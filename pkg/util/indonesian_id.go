@@ -0,0 +1,203 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NormalizeIDNumber membuang spasi, titik, dan tanda hubung dari nomor
+// identitas, sehingga "12.345.678.9-012.345" menjadi "123456789012345".
+func NormalizeIDNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isDigits(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// nikProvinceCodes adalah kode provinsi Kemendagri yang bisa muncul di dua
+// digit pertama NIK.
+var nikProvinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true, "21": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true,
+}
+
+// NIK adalah hasil penguraian Nomor Induk Kependudukan (16 digit):
+// kode provinsi, kabupaten/kota, dan kecamatan (masing-masing 2 digit),
+// tanggal lahir DDMMYY (tanggal ditambah 40 untuk perempuan), dan nomor urut
+// 4 digit.
+type NIK struct {
+	ProvinceCode string
+	RegencyCode  string
+	DistrictCode string
+	BirthDay     int
+	BirthMonth   int
+	// BirthYear hanya dua digit terakhir tahun lahir.
+	BirthYear int
+	Female    bool
+	Serial    string
+}
+
+// ParseNIK menguraikan NIK setelah dinormalisasi dengan NormalizeIDNumber.
+// Mengembalikan false jika panjang, kode wilayah, tanggal lahir, atau nomor
+// urutnya tidak valid.
+func ParseNIK(s string) (NIK, bool) {
+	s = NormalizeIDNumber(s)
+	if !isDigits(s, 16) {
+		return NIK{}, false
+	}
+
+	n := NIK{
+		ProvinceCode: s[0:2],
+		RegencyCode:  s[2:4],
+		DistrictCode: s[4:6],
+		BirthDay:     atoi2(s[6:8]),
+		BirthMonth:   atoi2(s[8:10]),
+		BirthYear:    atoi2(s[10:12]),
+		Serial:       s[12:16],
+	}
+	if !nikProvinceCodes[n.ProvinceCode] || n.RegencyCode == "00" || n.DistrictCode == "00" || n.Serial == "0000" {
+		return NIK{}, false
+	}
+	if n.BirthDay > 40 {
+		n.Female = true
+		n.BirthDay -= 40
+	}
+	if n.BirthMonth < 1 || n.BirthMonth > 12 || n.BirthDay < 1 {
+		return NIK{}, false
+	}
+	// Tahun 2000 dipakai karena kabisat, sehingga 29 Februari tetap diterima
+	// untuk tahun berapa pun.
+	if n.BirthDay > time.Date(2000, time.Month(n.BirthMonth)+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return NIK{}, false
+	}
+	return n, true
+}
+
+func atoi2(s string) int {
+	return int(s[0]-'0')*10 + int(s[1]-'0')
+}
+
+// MatchesBirth memeriksa apakah tanggal lahir di NIK sama dengan dateOfBirth
+// dan kode perempuan sesuai dengan gender ("male" atau "female"). Gender
+// kosong tidak diperiksa.
+func (n NIK) MatchesBirth(dateOfBirth time.Time, gender string) bool {
+	if dateOfBirth.Day() != n.BirthDay || int(dateOfBirth.Month()) != n.BirthMonth || dateOfBirth.Year()%100 != n.BirthYear {
+		return false
+	}
+	switch gender {
+	case "male":
+		return !n.Female
+	case "female":
+		return n.Female
+	}
+	return true
+}
+
+// IsValidNPWP menerima NPWP 15 digit dan NPWP 16 digit, yaitu NIK untuk wajib
+// pajak orang pribadi atau "0" diikuti NPWP 15 digit untuk wajib pajak
+// lainnya. Titik, tanda hubung, dan spasi diabaikan.
+func IsValidNPWP(s string) bool {
+	s = NormalizeIDNumber(s)
+	switch {
+	case isDigits(s, 15):
+		return strings.Trim(s, "0") != ""
+	case isDigits(s, 16):
+		if s[0] == '0' {
+			return strings.Trim(s, "0") != ""
+		}
+		_, ok := ParseNIK(s)
+		return ok
+	}
+	return false
+}
+
+// IsValidBPJSKetenagakerjaan memeriksa nomor peserta (KPJ) BPJS
+// Ketenagakerjaan, yaitu 11 digit.
+func IsValidBPJSKetenagakerjaan(s string) bool {
+	return isDigits(NormalizeIDNumber(s), 11)
+}
+
+// IsValidBPJSKesehatan memeriksa nomor kartu JKN BPJS Kesehatan, yaitu 13
+// digit.
+func IsValidBPJSKesehatan(s string) bool {
+	return isDigits(NormalizeIDNumber(s), 13)
+}
+
+// validateNIKBirth adalah validator "nik_birth=<FieldTanggalLahir> <FieldGender>"
+// yang membandingkan NIK dengan field tanggal lahir (string YYYY-MM-DD atau
+// time.Time) dan gender di struct yang sama. NIK yang tidak valid dan
+// tanggal lahir yang kosong dilewati; keduanya diperiksa aturan lain.
+func validateNIKBirth(fl validator.FieldLevel) bool {
+	nik, ok := ParseNIK(fl.Field().String())
+	if !ok {
+		return true
+	}
+
+	params := strings.Fields(fl.Param())
+	if len(params) == 0 {
+		return true
+	}
+	parent := reflect.Indirect(fl.Parent())
+
+	var dateOfBirth time.Time
+	switch v := fieldValue(parent, params[0]).(type) {
+	case time.Time:
+		dateOfBirth = v
+	case string:
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return true
+		}
+		dateOfBirth = t
+	default:
+		return true
+	}
+
+	var gender string
+	if len(params) > 1 {
+		gender, _ = fieldValue(parent, params[1]).(string)
+	}
+	return nik.MatchesBirth(dateOfBirth, gender)
+}
+
+// fieldValue mengambil nilai field bernama 'name' dari struct, dengan pointer
+// nil dianggap kosong (nil).
+func fieldValue(parent reflect.Value, name string) any {
+	if parent.Kind() != reflect.Struct {
+		return nil
+	}
+	f := parent.FieldByName(name)
+	for f.IsValid() && f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			return nil
+		}
+		f = f.Elem()
+	}
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	return f.Interface()
+}
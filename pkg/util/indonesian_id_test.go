@@ -0,0 +1,175 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNIK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   NIK
+		wantOK bool
+	}{
+		{
+			name:  "laki-laki",
+			input: "3171011205900001",
+			want: NIK{
+				ProvinceCode: "31", RegencyCode: "71", DistrictCode: "01",
+				BirthDay: 12, BirthMonth: 5, BirthYear: 90, Serial: "0001",
+			},
+			wantOK: true,
+		},
+		{
+			name:  "perempuan, tanggal ditambah 40",
+			input: "3171015205900001",
+			want: NIK{
+				ProvinceCode: "31", RegencyCode: "71", DistrictCode: "01",
+				BirthDay: 12, BirthMonth: 5, BirthYear: 90, Female: true, Serial: "0001",
+			},
+			wantOK: true,
+		},
+		{
+			name:  "perempuan tanggal 31",
+			input: "3171017112000002",
+			want: NIK{
+				ProvinceCode: "31", RegencyCode: "71", DistrictCode: "01",
+				BirthDay: 31, BirthMonth: 12, BirthYear: 0, Female: true, Serial: "0002",
+			},
+			wantOK: true,
+		},
+		{
+			name:  "dengan titik dan spasi",
+			input: "31.71.01 120590.0001",
+			want: NIK{
+				ProvinceCode: "31", RegencyCode: "71", DistrictCode: "01",
+				BirthDay: 12, BirthMonth: 5, BirthYear: 90, Serial: "0001",
+			},
+			wantOK: true,
+		},
+		{name: "29 Februari diterima", input: "3171012902010001", wantOK: true, want: NIK{
+			ProvinceCode: "31", RegencyCode: "71", DistrictCode: "01",
+			BirthDay: 29, BirthMonth: 2, BirthYear: 1, Serial: "0001",
+		}},
+		{name: "kode provinsi 00", input: "0071011205900001"},
+		{name: "kode provinsi tidak terdaftar", input: "2071011205900001"},
+		{name: "kode provinsi 99", input: "9971011205900001"},
+		{name: "kode kabupaten 00", input: "3100011205900001"},
+		{name: "kode kecamatan 00", input: "3171001205900001"},
+		{name: "nomor urut 0000", input: "3171011205900000"},
+		{name: "30 Februari", input: "3171013002900001"},
+		{name: "31 April", input: "3171013104900001"},
+		{name: "31 April perempuan", input: "3171017104900001"},
+		{name: "tanggal 00", input: "3171010005900001"},
+		{name: "tanggal 40 (perempuan tanggal 0)", input: "3171014005900001"},
+		{name: "tanggal 32", input: "3171013205900001"},
+		{name: "tanggal 72", input: "3171017205900001"},
+		{name: "bulan 00", input: "3171011200900001"},
+		{name: "bulan 13", input: "3171011213900001"},
+		{name: "15 digit", input: "317101120590001"},
+		{name: "17 digit", input: "31710112059000011"},
+		{name: "mengandung huruf", input: "31710112059000A1"},
+		{name: "kosong", input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseNIK(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseNIK(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseNIK(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNIKMatchesBirth(t *testing.T) {
+	male, _ := ParseNIK("3171011205900001")
+	female, _ := ParseNIK("3171015205900001")
+	birth := time.Date(1990, time.May, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		nik         NIK
+		dateOfBirth time.Time
+		gender      string
+		want        bool
+	}{
+		{"laki-laki cocok", male, birth, "male", true},
+		{"perempuan cocok", female, birth, "female", true},
+		{"gender kosong tidak diperiksa", female, birth, "", true},
+		{"kode perempuan tapi gender male", female, birth, "male", false},
+		{"kode laki-laki tapi gender female", male, birth, "female", false},
+		{"tanggal berbeda", male, birth.AddDate(0, 0, 1), "male", false},
+		{"bulan berbeda", male, birth.AddDate(0, 1, 0), "male", false},
+		{"tahun berbeda", male, birth.AddDate(1, 0, 0), "male", false},
+		{"abad berbeda dengan dua digit sama", male, birth.AddDate(100, 0, 0), "male", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.nik.MatchesBirth(tt.dateOfBirth, tt.gender); got != tt.want {
+				t.Errorf("MatchesBirth(%s, %q) = %v, want %v", tt.dateOfBirth.Format("2006-01-02"), tt.gender, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidNPWP(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"15 digit", "012345678901234", true},
+		{"15 digit berformat", "01.234.567.8-901.234", true},
+		{"15 digit nol semua", "000000000000000", false},
+		{"16 digit dari NIK", "3171011205900001", true},
+		{"16 digit dari NIK tidak valid", "3171013002900001", false},
+		{"16 digit diawali 0", "0012345678901234", true},
+		{"16 digit nol semua", "0000000000000000", false},
+		{"14 digit", "01234567890123", false},
+		{"17 digit", "01234567890123456", false},
+		{"mengandung huruf", "01234567890123A", false},
+		{"kosong", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidNPWP(tt.input); got != tt.want {
+				t.Errorf("IsValidNPWP(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidBPJS(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) bool
+		input    string
+		want     bool
+	}{
+		{"ketenagakerjaan 11 digit", IsValidBPJSKetenagakerjaan, "12345678901", true},
+		{"ketenagakerjaan berformat", IsValidBPJSKetenagakerjaan, "1234-5678-901", true},
+		{"ketenagakerjaan 10 digit", IsValidBPJSKetenagakerjaan, "1234567890", false},
+		{"ketenagakerjaan 13 digit", IsValidBPJSKetenagakerjaan, "1234567890123", false},
+		{"ketenagakerjaan huruf", IsValidBPJSKetenagakerjaan, "1234567890A", false},
+		{"kesehatan 13 digit", IsValidBPJSKesehatan, "0001234567890", true},
+		{"kesehatan berformat", IsValidBPJSKesehatan, "0001 2345 67890", true},
+		{"kesehatan 11 digit", IsValidBPJSKesehatan, "12345678901", false},
+		{"kesehatan 14 digit", IsValidBPJSKesehatan, "12345678901234", false},
+		{"kesehatan kosong", IsValidBPJSKesehatan, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validate(tt.input); got != tt.want {
+				t.Errorf("validasi %q = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package util
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// maxPasswordBytes adalah batas input bcrypt; byte setelahnya diabaikan
+// sehingga password yang lebih panjang ditolak.
+const maxPasswordBytes = 72
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = parseCommonPasswords(commonPasswordList)
+
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
+
+// IsCommonPassword memeriksa password terhadap daftar password umum/bocor
+// bawaan, termasuk variasi dengan tambahan angka atau simbol di akhir.
+// Password tanpa huruf sama sekali (mis. hanya angka) juga dianggap umum.
+func IsCommonPassword(password string) bool {
+	p := strings.ToLower(strings.TrimSpace(password))
+	if _, ok := commonPasswords[p]; ok {
+		return true
+	}
+
+	base := strings.TrimRightFunc(p, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if base == "" {
+		return true
+	}
+	_, ok := commonPasswords[base]
+	return ok
+}
+
+// PasswordRules adalah aturan komposisi password yang diperiksa validator
+// "password_policy". Kebijakan tenant (entity.PasswordPolicy) diterjemahkan
+// ke sini oleh usecase.
+type PasswordRules struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	BlockCommon      bool
+}
+
+// DefaultPasswordRules dipakai jika context tidak membawa aturan tenant,
+// misalnya saat registrasi superadmin.
+var DefaultPasswordRules = PasswordRules{MinLength: 10, BlockCommon: true}
+
+// Violations mengembalikan daftar aturan yang dilanggar password, kosong
+// jika password valid.
+func (r PasswordRules) Violations(password string) []string {
+	violations := []string{}
+
+	if n := len([]rune(password)); n < r.MinLength {
+		violations = append(violations, fmt.Sprintf("minimal %d karakter", r.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("maksimal %d byte", maxPasswordBytes))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		case !unicode.IsLetter(c) && !unicode.IsSpace(c):
+			symbol = true
+		}
+	}
+
+	if r.RequireUppercase && !upper {
+		violations = append(violations, "wajib mengandung huruf besar")
+	}
+	if r.RequireLowercase && !lower {
+		violations = append(violations, "wajib mengandung huruf kecil")
+	}
+	if r.RequireDigit && !digit {
+		violations = append(violations, "wajib mengandung angka")
+	}
+	if r.RequireSymbol && !symbol {
+		violations = append(violations, "wajib mengandung simbol")
+	}
+	if r.BlockCommon && IsCommonPassword(password) {
+		violations = append(violations, "terlalu umum atau pernah bocor")
+	}
+
+	return violations
+}
+
+type passwordRulesContextKey struct{}
+
+// WithPasswordRules menyimpan aturan password tenant ke context agar bisa
+// dibaca validator "password_policy" lewat validate.StructCtx.
+func WithPasswordRules(ctx context.Context, rules PasswordRules) context.Context {
+	return context.WithValue(ctx, passwordRulesContextKey{}, rules)
+}
+
+// PasswordRulesFromContext mengambil aturan password dari context, atau
+// DefaultPasswordRules jika belum ada.
+func PasswordRulesFromContext(ctx context.Context) PasswordRules {
+	if rules, ok := ctx.Value(passwordRulesContextKey{}).(PasswordRules); ok {
+		return rules
+	}
+	return DefaultPasswordRules
+}
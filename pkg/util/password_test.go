@@ -0,0 +1,111 @@
+package util
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPasswordRulesViolations(t *testing.T) {
+	all := PasswordRules{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BlockCommon:      true,
+	}
+
+	tests := []struct {
+		name     string
+		rules    PasswordRules
+		password string
+		want     []string
+	}{
+		{"memenuhi semua aturan", all, "Kopi-Tubruk-42", []string{}},
+		{"tanpa aturan", PasswordRules{}, "", []string{}},
+		{"terlalu pendek", PasswordRules{MinLength: 10}, "Kopi-42", []string{"minimal 10 karakter"}},
+		{"panjang tepat batas", PasswordRules{MinLength: 10}, "abcdefghij", []string{}},
+		{"panjang dihitung per karakter", PasswordRules{MinLength: 5}, "ééééé", []string{}},
+		{"lebih dari 72 byte", PasswordRules{}, strings.Repeat("a", 73), []string{"maksimal 72 byte"}},
+		{"tepat 72 byte", PasswordRules{}, strings.Repeat("a", 72), []string{}},
+		{"karakter multibyte dihitung per byte", PasswordRules{}, strings.Repeat("é", 37), []string{"maksimal 72 byte"}},
+		{"tanpa huruf besar", all, "kopi-tubruk-42", []string{"wajib mengandung huruf besar"}},
+		{"tanpa huruf kecil", all, "KOPI-TUBRUK-42", []string{"wajib mengandung huruf kecil"}},
+		{"tanpa angka", all, "Kopi-Tubruk-Enak", []string{"wajib mengandung angka"}},
+		{"tanpa simbol", all, "KopiTubruk4242", []string{"wajib mengandung simbol"}},
+		{"spasi bukan simbol", all, "Kopi Tubruk 42", []string{"wajib mengandung simbol"}},
+		{"huruf non-latin bukan simbol", PasswordRules{RequireSymbol: true}, "Kopiñ", []string{"wajib mengandung simbol"}},
+		{"password umum", PasswordRules{BlockCommon: true}, "Password", []string{"terlalu umum atau pernah bocor"}},
+		{"password umum diizinkan jika tidak diblok", PasswordRules{}, "password", []string{}},
+		{
+			"beberapa pelanggaran berurutan",
+			all,
+			"qwerty",
+			[]string{
+				"minimal 12 karakter",
+				"wajib mengandung huruf besar",
+				"wajib mengandung angka",
+				"wajib mengandung simbol",
+				"terlalu umum atau pernah bocor",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Violations(tt.password)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Violations(%q) = %q, want %q", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommonPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"ada di daftar", "password", true},
+		{"tidak case-sensitive", "BisMillah", true},
+		{"spasi di tepi diabaikan", "  qwerty  ", true},
+		{"angka di akhir", "bismillah123", true},
+		{"angka dan simbol di akhir", "Indonesia2024!", true},
+		{"hanya angka", "98765432109876", true},
+		{"hanya simbol", "!!!!!!!!", true},
+		{"kosong", "", true},
+		{"angka di awal tidak dibuang", "123bismillah", false},
+		{"kata umum di tengah", "bismillahku", false},
+		{"tidak ada di daftar", "Kopi-Tubruk-42", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCommonPassword(tt.password); got != tt.want {
+				t.Errorf("IsCommonPassword(%q) = %v, want %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommonPasswords(t *testing.T) {
+	got := parseCommonPasswords("# komentar\n\nRahasia\n  sandi  \r\n#bukan\n")
+	want := map[string]struct{}{"rahasia": {}, "sandi": {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCommonPasswords = %v, want %v", got, want)
+	}
+}
+
+func TestPasswordRulesFromContext(t *testing.T) {
+	if got := PasswordRulesFromContext(context.Background()); got != DefaultPasswordRules {
+		t.Errorf("tanpa aturan di context = %+v, want DefaultPasswordRules", got)
+	}
+
+	rules := PasswordRules{MinLength: 16, RequireSymbol: true}
+	if got := PasswordRulesFromContext(WithPasswordRules(context.Background(), rules)); got != rules {
+		t.Errorf("aturan dari context = %+v, want %+v", got, rules)
+	}
+}
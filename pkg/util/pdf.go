@@ -0,0 +1,128 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDFDocument adalah generator PDF teks sederhana (font Courier, ukuran A4)
+// untuk dokumen seperti invoice dan credit note. Karena font-nya monospace,
+// kolom bisa dirapikan cukup dengan padding spasi (lihat PadRight/PadLeft).
+type PDFDocument struct {
+	pages        [][]string
+	linesPerPage int
+}
+
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfFontSize   = 10
+	pdfLeading    = 14
+)
+
+func NewPDFDocument() *PDFDocument {
+	return &PDFDocument{
+		pages:        [][]string{{}},
+		linesPerPage: (pdfPageHeight - 2*pdfMargin) / pdfLeading,
+	}
+}
+
+// AddLine menambah satu baris teks. Halaman baru dibuat otomatis jika penuh.
+func (d *PDFDocument) AddLine(text string) {
+	last := len(d.pages) - 1
+	if len(d.pages[last]) >= d.linesPerPage {
+		d.pages = append(d.pages, []string{})
+		last++
+	}
+	d.pages[last] = append(d.pages[last], text)
+}
+
+func (d *PDFDocument) AddLines(lines ...string) {
+	for _, line := range lines {
+		d.AddLine(line)
+	}
+}
+
+// Bytes menghasilkan isi file PDF lengkap.
+func (d *PDFDocument) Bytes() []byte {
+	var buf bytes.Buffer
+	offsets := []int{}
+
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objek 1: Catalog, 2: Pages, 3: Font. Setiap halaman memakai 2 objek
+	// (Page + content stream) mulai dari nomor 4.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+i*2)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range d.pages {
+		contentRef := 4 + i*2 + 1
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, contentRef))
+
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+// pdfEscape meng-escape karakter khusus string PDF dan mengubah teks UTF-8
+// ke Latin-1 (WinAnsi). Karakter di luar Latin-1 diganti '?'.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func PadRight(s string, width int) string {
+	if len([]rune(s)) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len([]rune(s)))
+}
+
+func PadLeft(s string, width int) string {
+	if len([]rune(s)) >= width {
+		return s
+	}
+	return strings.Repeat(" ", width-len([]rune(s))) + s
+}
@@ -51,6 +51,17 @@ func ErrorResponse(w http.ResponseWriter, statusCode int, message, err string) {
 	})
 }
 
+// ErrorResponseWithData sama seperti ErrorResponse, tapi ikut mengirim detail
+// tambahan (misalnya kuota paket) di field 'data'.
+func ErrorResponseWithData(w http.ResponseWriter, statusCode int, message, err string, data any) {
+	writeJSON(w, statusCode, JSONResponse{
+		Success: false,
+		Message: message,
+		Error:   err,
+		Data:    data,
+	})
+}
+
 func ValidationErrorResponse(w http.ResponseWriter, validationErrors any) {
 	writeJSON(w, http.StatusUnprocessableEntity, JSONResponse{
 		Success: false,
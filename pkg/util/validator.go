@@ -1,6 +1,7 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -16,6 +17,30 @@ func NewValidator() *validator.Validate {
 		return !strings.Contains(s, "  ")
 	})
 
+	// password_policy memeriksa password terhadap aturan tenant yang disimpan
+	// di context (util.WithPasswordRules). Pakai validate.StructCtx; tanpa
+	// context, DefaultPasswordRules yang berlaku.
+	validate.RegisterValidationCtx("password_policy", func(ctx context.Context, fl validator.FieldLevel) bool {
+		return len(PasswordRulesFromContext(ctx).Violations(fl.Field().String())) == 0
+	})
+
+	// Nomor identitas Indonesia. Titik, tanda hubung, dan spasi diabaikan
+	// (lihat NormalizeIDNumber).
+	validate.RegisterValidation("nik", func(fl validator.FieldLevel) bool {
+		_, ok := ParseNIK(fl.Field().String())
+		return ok
+	})
+	validate.RegisterValidation("nik_birth", validateNIKBirth)
+	validate.RegisterValidation("npwp", func(fl validator.FieldLevel) bool {
+		return IsValidNPWP(fl.Field().String())
+	})
+	validate.RegisterValidation("bpjs_ketenagakerjaan", func(fl validator.FieldLevel) bool {
+		return IsValidBPJSKetenagakerjaan(fl.Field().String())
+	})
+	validate.RegisterValidation("bpjs_kesehatan", func(fl validator.FieldLevel) bool {
+		return IsValidBPJSKesehatan(fl.Field().String())
+	})
+
 	return validate
 }
 
@@ -37,6 +62,18 @@ func FormatValidationErrors(errs validator.ValidationErrors) map[string]string {
 			errorMessages[fieldName] = fmt.Sprintf("Maksimal %s karakter.", err.Param())
 		case "no_consecutive_spaces":
 			errorMessages[fieldName] = "Tidak boleh mengandung spasi berturut-turut."
+		case "password_policy":
+			errorMessages[fieldName] = "Password tidak memenuhi kebijakan password tenant."
+		case "nik":
+			errorMessages[fieldName] = "NIK harus 16 digit dengan kode wilayah dan tanggal lahir yang valid."
+		case "nik_birth":
+			errorMessages[fieldName] = "Tanggal lahir atau jenis kelamin pada NIK tidak sesuai dengan data profil."
+		case "npwp":
+			errorMessages[fieldName] = "NPWP harus 15 digit, atau 16 digit (NIK atau 0 diikuti NPWP 15 digit)."
+		case "bpjs_ketenagakerjaan":
+			errorMessages[fieldName] = "Nomor BPJS Ketenagakerjaan harus 11 digit."
+		case "bpjs_kesehatan":
+			errorMessages[fieldName] = "Nomor BPJS Kesehatan harus 13 digit."
 		default:
 			errorMessages[fieldName] = fmt.Sprintf("Input tidak valid (%s).", err.Tag())
 		}